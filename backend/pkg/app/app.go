@@ -11,32 +11,58 @@ type Application struct {
 }
 
 type Commands struct {
-	AddTranslation    command.AddTranslationHandler
-	UpdateTranslation command.UpdateTranslationHandler
-	DeleteTranslation command.DeleteTranslationHandler
+	AddTranslation             command.AddTranslationHandler
+	UpdateTranslation          command.UpdateTranslationHandler
+	DeleteTranslation          command.DeleteTranslationHandler
+	ReviewTranslation          command.ReviewTranslationHandler
+	RestoreTranslationRevision command.RestoreTranslationRevisionHandler
+	ImportTranslations         command.ImportTranslationsHandler
+	ImportDictionary           command.ImportDictionaryHandler
+	BulkTagTranslations        command.BulkTagTranslationsHandler
+	BulkMoveTranslations       command.BulkMoveTranslationsHandler
+	BulkDeleteTranslations     command.BulkDeleteTranslationsHandler
 
 	AddTag    command.AddTagHandler
 	UpdateTag command.UpdateTagHandler
 	DeleteTag command.DeleteTagHandler
+	MergeTags command.MergeTagsHandler
 
 	AddUser    command.AddUserHandler
 	UpdateUser command.UpdateUserHandler
 	DeleteUser command.DeleteUserHandler
+	UnlockUser command.UnlockUserHandler
 
 	AddLang    command.AddLangHandler
 	UpdateLang command.UpdateLangHandler
 	DeleteLang command.DeleteLangHandler
 
-	UpdateProfile command.UpdateProfileHandler
+	UpdateProfile    command.UpdateProfileHandler
+	EnrollTwoFactor  command.EnrollTwoFactorHandler
+	ConfirmTwoFactor command.ConfirmTwoFactorHandler
+	ResetTwoFactor   command.ResetTwoFactorHandler
+
+	RevokeSession     command.RevokeSessionHandler
+	RevokeAllSessions command.RevokeAllSessionsHandler
+
+	CreateToken command.CreateTokenHandler
+	RevokeToken command.RevokeTokenHandler
+
+	RestoreTrashItem  command.RestoreTrashItemHandler
+	PurgeTrash        command.PurgeTrashHandler
+	PurgeExpiredTrash command.PurgeExpiredTrashHandler
 }
 
 type Queries struct {
-	SingleTranslation  query.SingleTranslationHandler
-	SearchTranslations query.SearchTranslationsHandler
-	RandomTranslations query.RandomTranslationsHandler
+	SingleTranslation    query.SingleTranslationHandler
+	SearchTranslations   query.SearchTranslationsHandler
+	RandomTranslations   query.RandomTranslationsHandler
+	DueReviews           query.DueReviewsHandler
+	FuzzyTranslations    query.FuzzyTranslationsHandler
+	TranslationRevisions query.TranslationRevisionsHandler
 
 	SingleTag query.SingleTagHandler
 	AllTags   query.AllTagsHandler
+	TagTree   query.TagTreeHandler
 
 	AllUsers   query.AllUsersHandler
 	SingleUser query.SingleUserHandler
@@ -45,4 +71,13 @@ type Queries struct {
 	AllLangs   query.AllLangsHandler
 
 	AllRoles query.AllRolesHandler
+
+	AllTrashItems query.AllTrashItemsHandler
+
+	AllSessions query.AllSessionsHandler
+
+	AllTokens query.AllTokensHandler
+
+	DictionaryStats  query.DictionaryStatsHandler
+	ExportDictionary query.ExportDictionaryHandler
 }
@@ -7,6 +7,8 @@ import (
 type AddLang struct {
 	Name     string
 	AuthorID string
+	Metadata lang.Metadata
+	Fields   []lang.Field // Fields schema of the lang translations custom fields
 }
 
 type AddLangHandler struct {
@@ -20,11 +22,15 @@ func NewAddLangHandler(langRepo lang.Repository) AddLangHandler {
 }
 
 func (h AddLangHandler) Handle(cmd AddLang) (string, error) {
-	ln, err := lang.NewLang(cmd.Name, cmd.AuthorID)
+	ln, err := lang.NewLang(cmd.Name, cmd.AuthorID, cmd.Metadata)
 	if err != nil {
 		return "", err
 	}
 
+	if err = ln.ApplyFields(cmd.Fields); err != nil {
+		return "", err
+	}
+
 	if err := h.langRepo.Create(ln); err != nil {
 		return "", err
 	}
@@ -33,6 +33,18 @@ func TestAddLangHandler_Handle_NegativeCases(t *testing.T) {
 			}},
 			assert.Error,
 		},
+		{
+			"Error on unknown lang code",
+			func() fields {
+				return fields{langRepo: &lang.MockRepository{}}
+			},
+			args{cmd: AddLang{
+				Name:     "en",
+				AuthorID: "testAuthor",
+				Metadata: lang.NewMetadata("english", "", lang.NoTranscription),
+			}},
+			assert.Error,
+		},
 		{
 			"Error on lang saving",
 			func() fields {
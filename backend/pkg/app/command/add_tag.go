@@ -7,6 +7,7 @@ import (
 // AddTag create new tag cmd
 type AddTag struct {
 	Name     string
+	ParentID string // ParentID is empty for root tags
 	AuthorID string
 }
 
@@ -28,6 +29,10 @@ func (h AddTagHandler) Handle(cmd AddTag) (string, error) {
 		return "", err
 	}
 
+	if err = moveTag(h.tagRepo, tg, cmd.ParentID); err != nil {
+		return "", err
+	}
+
 	if err = h.tagRepo.Create(tg); err != nil {
 		return "", err
 	}
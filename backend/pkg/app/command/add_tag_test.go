@@ -53,6 +53,23 @@ func TestAddTagHandler_Handle_NegativeCases(t *testing.T) {
 				return true
 			},
 		},
+		{
+			"Error on missing parent",
+			func() fields {
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("Get", "parentID", "testAuthor").Return(nil, errors.New("testError"))
+				return fields{tagRepo: &tagRepo}
+			},
+			args{cmd: AddTag{
+				Name:     "testTag",
+				ParentID: "parentID",
+				AuthorID: "testAuthor",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "can not get parent tag parentID: testError", err.Error(), i)
+				return true
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -87,3 +104,15 @@ func TestAddTagHandler_Handle_PositiveCase(t *testing.T) {
 	assert.Equal(t, cmd.Name, data["name"])
 	assert.Equal(t, cmd.AuthorID, data["authorID"])
 }
+
+func TestAddTagHandler_Handle_NestedTag(t *testing.T) {
+	tagRepo := tag.MockRepository{}
+	tagRepo.On("Get", "parentID", "testAuthor").Return(tag.UnmarshalFromDB("parentID", "parent", "testAuthor", ""), nil)
+	tagRepo.On("Create", mock.AnythingOfType("*tag.Tag")).Return(nil)
+
+	_, err := NewAddTagHandler(&tagRepo).Handle(AddTag{Name: "testTag", ParentID: "parentID", AuthorID: "testAuthor"})
+	assert.Nil(t, err)
+
+	createdTag := tagRepo.Calls[1].Arguments[0].(*tag.Tag)
+	assert.Equal(t, "parentID", createdTag.ParentID())
+}
@@ -9,12 +9,18 @@ import (
 // AddTranslation create new translation cmd
 type AddTranslation struct {
 	Transcription string
-	Target        string
 	Source        string
-	Example       string
-	TagIDs        []string
+	Senses        []TranslationSense
 	AuthorID      string
 	LangID        string
+	Fields        map[string]string // Fields custom field values validated against the lang schema
+}
+
+// TranslationSense one of translation meanings passed with translation cmd
+type TranslationSense struct {
+	Target  string
+	Example string
+	TagIDs  []string
 }
 
 // AddTranslationHandler create new translation cmd handler
@@ -32,33 +38,71 @@ func NewAddTranslationHandler(translationRep translation.Repository, tagRepo tag
 
 // Handle performs translation creation cmd
 func (h AddTranslationHandler) Handle(cmd AddTranslation) (string, error) {
+	tr, err := h.newTranslation(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	err = h.translationRepo.Create(tr)
+
+	if err != nil {
+		return "", err
+	}
+
+	return tr.ID(), nil
+}
+
+// newTranslation validates cmd and builds new translation entity without saving it
+func (h AddTranslationHandler) newTranslation(cmd AddTranslation) (*translation.Translation, error) {
 	if err := h.validator.validate(translationData{
-		TagIDs:   cmd.TagIDs,
+		TagIDs:   sensesTagIDs(cmd.Senses),
 		LangID:   cmd.LangID,
 		AuthorID: cmd.AuthorID,
 		Source:   cmd.Source,
+		Fields:   cmd.Fields,
 	}); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	tr, err := translation.NewTranslation(
 		cmd.Source,
 		cmd.Transcription,
-		cmd.Target,
+		toDomainSenses(cmd.Senses),
 		cmd.AuthorID,
-		cmd.Example,
-		cmd.TagIDs,
 		cmd.LangID,
 	)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	err = h.translationRepo.Create(tr)
+	if err = tr.ApplyFields(cmd.Fields); err != nil {
+		return nil, err
+	}
 
-	if err != nil {
-		return "", err
+	return tr, nil
+}
+
+func toDomainSenses(senses []TranslationSense) []translation.Sense {
+	domainSenses := make([]translation.Sense, 0, len(senses))
+	for _, sense := range senses {
+		domainSenses = append(domainSenses, translation.NewSense(sense.Target, sense.Example, sense.TagIDs))
 	}
+	return domainSenses
+}
 
-	return tr.ID(), nil
+func sensesTagIDs(senses []TranslationSense) []string {
+	var tagIDs []string
+	seen := map[string]struct{}{}
+
+	for _, sense := range senses {
+		for _, tagID := range sense.TagIDs {
+			if _, ok := seen[tagID]; ok {
+				continue
+			}
+			seen[tagID] = struct{}{}
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+
+	return tagIDs
 }
@@ -29,7 +29,7 @@ func TestAddTranslationHandler_Handle_NegativeCases(t *testing.T) {
 					validator: newFailValidator(),
 				}
 			},
-			args{cmd: AddTranslation{TagIDs: []string{"tag1"}, AuthorID: "testAuthor"}},
+			args{cmd: AddTranslation{Senses: []TranslationSense{{TagIDs: []string{"tag1"}}}, AuthorID: "testAuthor"}},
 			assert.Error,
 		},
 		{
@@ -39,7 +39,7 @@ func TestAddTranslationHandler_Handle_NegativeCases(t *testing.T) {
 					validator: newSuccessValidator(),
 				}
 			},
-			args{cmd: AddTranslation{Source: "text", Target: "test", AuthorID: "testAuthor"}},
+			args{cmd: AddTranslation{Source: "text", Senses: []TranslationSense{{Target: "test"}}, AuthorID: "testAuthor"}},
 			assert.Error,
 		},
 		{
@@ -52,7 +52,7 @@ func TestAddTranslationHandler_Handle_NegativeCases(t *testing.T) {
 					validator:       newSuccessValidator(),
 				}
 			},
-			args{cmd: AddTranslation{Source: "text", Target: "test", AuthorID: "testAuthor", LangID: "testLang"}},
+			args{cmd: AddTranslation{Source: "text", Senses: []TranslationSense{{Target: "test"}}, AuthorID: "testAuthor", LangID: "testLang"}},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Equal(t, "testErr", err.Error(), i)
 				return true
@@ -88,10 +88,8 @@ func TestAddTranslationHandler_Handle_PositiveCase(t *testing.T) {
 
 	cmd := AddTranslation{
 		Transcription: "transcription",
-		Target:        "target",
 		Source:        source,
-		Example:       "example",
-		TagIDs:        tags,
+		Senses:        []TranslationSense{{Target: "target", Example: "example", TagIDs: tags}, {Target: "target2"}},
 		AuthorID:      authorID,
 		LangID:        langID,
 	}
@@ -103,10 +101,22 @@ func TestAddTranslationHandler_Handle_PositiveCase(t *testing.T) {
 	data := createdTranslation.ToMap()
 
 	assert.Equal(t, id, createdTranslation.ID())
-	assert.Equal(t, cmd.Target, data["target"])
 	assert.Equal(t, cmd.Transcription, data["transcription"])
 	assert.Equal(t, cmd.Source, data["source"])
-	assert.Equal(t, cmd.TagIDs, data["tagIDs"])
+	assert.Equal(t, []map[string]interface{}{
+		{"target": "target", "example": "example", "tagIDs": tags},
+		{"target": "target2", "example": "", "tagIDs": []string(nil)},
+	}, data["senses"])
 	assert.Equal(t, cmd.AuthorID, data["authorID"])
 	assert.Equal(t, cmd.LangID, data["langID"])
 }
+
+func Test_sensesTagIDs(t *testing.T) {
+	senses := []TranslationSense{
+		{Target: "first", TagIDs: []string{"tag1", "tag2"}},
+		{Target: "second"},
+		{Target: "third", TagIDs: []string{"tag2", "tag3"}},
+	}
+
+	assert.Equal(t, []string{"tag1", "tag2", "tag3"}, sensesTagIDs(senses))
+}
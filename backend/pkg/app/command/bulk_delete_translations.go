@@ -0,0 +1,35 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+)
+
+// BulkDeleteTranslations deletes multiple translations cmd
+type BulkDeleteTranslations struct {
+	IDs      []string
+	AuthorID string
+}
+
+// BulkDeleteTranslationsHandler bulk delete translations cmd handler
+type BulkDeleteTranslationsHandler struct {
+	translationRepo translation.Repository
+	trashRepo       trash.Repository
+}
+
+func NewBulkDeleteTranslationsHandler(translationRepo translation.Repository, trashRepo trash.Repository) BulkDeleteTranslationsHandler {
+	return BulkDeleteTranslationsHandler{
+		translationRepo: translationRepo,
+		trashRepo:       trashRepo,
+	}
+}
+
+// Handle moves translations to trash like DeleteTranslation cmd, all translations moved to trash are deleted at once
+func (h BulkDeleteTranslationsHandler) Handle(cmd BulkDeleteTranslations) (BulkResult, error) {
+	records, result, err := loadBulkTranslations(h.translationRepo, cmd.IDs, cmd.AuthorID)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	return bulkDeleter{translationRepo: h.translationRepo, trashRepo: h.trashRepo}.delete(records, result, cmd.AuthorID), nil
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestBulkDeleteTranslationsHandler_Handle(t *testing.T) {
+	tr1, err := translation.NewTranslation("source1", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+	assert.Nil(t, err)
+	tr2, err := translation.NewTranslation("source2", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		fieldsFn func() (translation.Repository, trash.Repository)
+		want     []BulkItemResult
+	}{
+		{
+			"Translations are moved to trash and deleted",
+			func() (translation.Repository, trash.Repository) {
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("Get", "id1", "testAuthor").Return(tr1, nil)
+				translationRepo.On("Get", "id2", "testAuthor").Return(tr2, nil)
+				translationRepo.On("Get", "missing", "testAuthor").Return(nil, translation.ErrNotFound)
+				translationRepo.On("DeleteMany", []*translation.Translation{tr2}).Return(nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.MatchedBy(func(item *trash.Item) bool { return item.Translation() == tr1 })).Return(errors.New("testErr"))
+				trashRepo.On("Create", mock.MatchedBy(func(item *trash.Item) bool { return item.Translation() == tr2 })).Return(nil)
+				return translationRepo, trashRepo
+			},
+			[]BulkItemResult{{ID: "id1", Err: errors.New("testErr")}, {ID: "id2"}, {ID: "missing", Err: translation.ErrNotFound}},
+		},
+		{
+			"Error on deleting, trash items are removed",
+			func() (translation.Repository, trash.Repository) {
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("Get", "id1", "testAuthor").Return(tr1, nil)
+				translationRepo.On("Get", "id2", "testAuthor").Return(tr2, nil)
+				translationRepo.On("DeleteMany", []*translation.Translation{tr1, tr2}).Return(errors.New("testErr"))
+				translationRepo.On("Get", tr1.ID(), "testAuthor").Return(tr1, nil)
+				translationRepo.On("Get", tr2.ID(), "testAuthor").Return(tr2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil).Twice()
+				trashRepo.On("Delete", mock.AnythingOfType("string"), "testAuthor").Return(nil).Twice()
+				return translationRepo, trashRepo
+			},
+			[]BulkItemResult{{ID: "id1", Err: errors.Join(errors.New("testErr"))}, {ID: "id2", Err: errors.Join(errors.New("testErr"))}},
+		},
+		{
+			"Partial deleting, trash items of deleted translations are kept",
+			func() (translation.Repository, trash.Repository) {
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("Get", "id1", "testAuthor").Return(tr1, nil)
+				translationRepo.On("Get", "id2", "testAuthor").Return(tr2, nil)
+				translationRepo.On("DeleteMany", []*translation.Translation{tr1, tr2}).Return(errors.New("testErr"))
+				translationRepo.On("Get", tr1.ID(), "testAuthor").Return(nil, translation.ErrNotFound)
+				translationRepo.On("Get", tr2.ID(), "testAuthor").Return(tr2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil).Twice()
+				trashRepo.On("Delete", mock.AnythingOfType("string"), "testAuthor").Return(nil).Once()
+				return translationRepo, trashRepo
+			},
+			[]BulkItemResult{{ID: "id1"}, {ID: "id2", Err: errors.Join(errors.New("testErr"))}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]string, 0, len(tt.want))
+			for _, item := range tt.want {
+				ids = append(ids, item.ID)
+			}
+
+			h := NewBulkDeleteTranslationsHandler(tt.fieldsFn())
+			result, err := h.Handle(BulkDeleteTranslations{IDs: ids, AuthorID: "testAuthor"})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, result.Items)
+		})
+	}
+}
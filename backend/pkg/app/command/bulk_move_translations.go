@@ -0,0 +1,44 @@
+package command
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+)
+
+// BulkMoveTranslations moves multiple translations to another lang cmd
+type BulkMoveTranslations struct {
+	IDs      []string
+	LangID   string
+	AuthorID string
+}
+
+// BulkMoveTranslationsHandler bulk move translations cmd handler
+type BulkMoveTranslationsHandler struct {
+	updater  bulkUpdater
+	langRepo lang.Repository
+}
+
+func NewBulkMoveTranslationsHandler(
+	translationRepo translation.Repository,
+	revisionRepo translation.RevisionRepository,
+	langRepo lang.Repository,
+) BulkMoveTranslationsHandler {
+	return BulkMoveTranslationsHandler{
+		updater:  bulkUpdater{translationRepo: translationRepo, revisionRepo: revisionRepo},
+		langRepo: langRepo,
+	}
+}
+
+// Handle moves translations to the lang, translations with source already existing in the lang fail with translation.ErrSourceAlreadyExists,
+// translations with custom field values not matching the lang schema fail too
+func (h BulkMoveTranslationsHandler) Handle(cmd BulkMoveTranslations) (BulkResult, error) {
+	ln, err := h.langRepo.Get(cmd.LangID, cmd.AuthorID)
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("can not get lang with id: %s: %w", cmd.LangID, err)
+	}
+
+	return h.updater.update(cmd.IDs, cmd.AuthorID, func(tr *translation.Translation) error {
+		return moveToLang(tr, ln)
+	})
+}
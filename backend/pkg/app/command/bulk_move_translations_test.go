@@ -0,0 +1,84 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestBulkMoveTranslationsHandler_Handle(t *testing.T) {
+	t.Run("Error on getting lang", func(t *testing.T) {
+		langRepo := lang.NewMockRepository(t)
+		langRepo.On("Get", "DE", "testAuthor").Return(nil, lang.ErrNotFound)
+		h := BulkMoveTranslationsHandler{langRepo: langRepo}
+		_, err := h.Handle(BulkMoveTranslations{IDs: []string{"id1"}, LangID: "DE", AuthorID: "testAuthor"})
+		assert.ErrorIs(t, err, lang.ErrNotFound)
+	})
+
+	t.Run("Translations are moved, conflicts are reported", func(t *testing.T) {
+		tr1, err := translation.NewTranslation("source1", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+		assert.Nil(t, err)
+		tr2, err := translation.NewTranslation("source2", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+		assert.Nil(t, err)
+		tr3, err := translation.NewTranslation("source3", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "DE")
+		assert.Nil(t, err)
+
+		translationRepo := translation.NewMockRepository(t)
+		translationRepo.On("Get", "id1", "testAuthor").Return(tr1, nil)
+		translationRepo.On("Get", "id2", "testAuthor").Return(tr2, nil)
+		translationRepo.On("Get", "id3", "testAuthor").Return(tr3, nil)
+		translationRepo.On("UpdateMany", []*translation.Translation{tr1, tr2}).Return([]error{translation.ErrSourceAlreadyExists, nil})
+
+		revisionRepo := translation.NewMockRevisionRepository(t)
+		revisionRepo.On("Create", mock.MatchedBy(func(revision *translation.Revision) bool {
+			return revision.TranslationID() == tr2.ID() && revision.ToMap()["langID"] == "EN"
+		})).Return(nil).Once()
+
+		langRepo := lang.NewMockRepository(t)
+		langRepo.On("Get", "DE", "testAuthor").Return(lang.UnmarshalFromDB("DE", "German", "testAuthor", lang.Metadata{}, nil), nil)
+
+		h := BulkMoveTranslationsHandler{
+			updater:  bulkUpdater{translationRepo: translationRepo, revisionRepo: revisionRepo},
+			langRepo: langRepo,
+		}
+
+		result, err := h.Handle(BulkMoveTranslations{IDs: []string{"id1", "id2", "id3"}, LangID: "DE", AuthorID: "testAuthor"})
+		assert.Nil(t, err)
+		assert.Equal(t, []BulkItemResult{
+			{ID: "id1", Err: translation.ErrSourceAlreadyExists},
+			{ID: "id2"},
+			{ID: "id3"},
+		}, result.Items)
+		assert.Equal(t, "DE", tr2.LangID())
+	})
+}
+
+func TestBulkMoveTranslationsHandler_Handle_FieldsNotMatchLangSchema(t *testing.T) {
+	matched, err := translation.NewTranslation("Katze", "", []translation.Sense{translation.NewSense("cat", "", nil)}, "testAuthor", "EN")
+	assert.Nil(t, err)
+	assert.Nil(t, matched.ApplyFields(map[string]string{"gender": "f"}))
+	notMatched, err := translation.NewTranslation("Hund", "", []translation.Sense{translation.NewSense("dog", "", nil)}, "testAuthor", "EN")
+	assert.Nil(t, err)
+	assert.Nil(t, notMatched.ApplyFields(map[string]string{"reading": "hund"}))
+
+	translationRepo := translation.NewMockRepository(t)
+	translationRepo.On("Get", "id1", "testAuthor").Return(matched, nil)
+	translationRepo.On("Get", "id2", "testAuthor").Return(notMatched, nil)
+	translationRepo.On("UpdateMany", []*translation.Translation{matched}).Return([]error{nil})
+	revisionRepo := translation.NewMockRevisionRepository(t)
+	revisionRepo.On("Create", mock.AnythingOfType("*translation.Revision")).Return(nil).Once()
+	langRepo := lang.NewMockRepository(t)
+	langRepo.On("Get", "DE", "testAuthor").Return(lang.UnmarshalFromDB("DE", "German", "testAuthor", lang.Metadata{}, []lang.Field{
+		lang.NewField("gender", lang.EnumField, []string{"m", "f", "n"}),
+	}), nil)
+
+	h := NewBulkMoveTranslationsHandler(translationRepo, revisionRepo, langRepo)
+	result, err := h.Handle(BulkMoveTranslations{IDs: []string{"id1", "id2"}, LangID: "DE", AuthorID: "testAuthor"})
+	assert.Nil(t, err)
+	assert.Nil(t, result.Items[0].Err)
+	assert.EqualError(t, result.Items[1].Err, "field values do not match lang German schema: field reading is not defined for lang German")
+	assert.Equal(t, "DE", matched.LangID())
+	assert.Equal(t, "EN", notMatched.LangID())
+}
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+)
+
+// BulkTagTranslations adds and removes tags of all senses of multiple translations cmd
+type BulkTagTranslations struct {
+	IDs          []string
+	AddTagIDs    []string
+	RemoveTagIDs []string
+	AuthorID     string
+}
+
+// BulkTagTranslationsHandler bulk tag translations cmd handler
+type BulkTagTranslationsHandler struct {
+	updater   bulkUpdater
+	validator validator
+}
+
+func NewBulkTagTranslationsHandler(
+	translationRepo translation.Repository,
+	revisionRepo translation.RevisionRepository,
+	tagRepo tag.Repository,
+	langRepo lang.Repository,
+) BulkTagTranslationsHandler {
+	return BulkTagTranslationsHandler{
+		updater:   bulkUpdater{translationRepo: translationRepo, revisionRepo: revisionRepo},
+		validator: newValidator(tagRepo, langRepo),
+	}
+}
+
+// Handle adds tags to translations and then removes tags from them, translations exceeding tags limit fail
+func (h BulkTagTranslationsHandler) Handle(cmd BulkTagTranslations) (BulkResult, error) {
+	if len(cmd.AddTagIDs) == 0 && len(cmd.RemoveTagIDs) == 0 {
+		return BulkResult{}, errors.New("at least one tag should be added or removed")
+	}
+
+	if err := h.validator.validateTags(translationData{TagIDs: cmd.AddTagIDs, AuthorID: cmd.AuthorID}); err != nil {
+		return BulkResult{}, err
+	}
+
+	return h.updater.update(cmd.IDs, cmd.AuthorID, func(tr *translation.Translation) error {
+		if len(cmd.AddTagIDs) > 0 {
+			if err := tr.AddTags(cmd.AddTagIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(cmd.RemoveTagIDs) > 0 {
+			return tr.RemoveTags(cmd.RemoveTagIDs)
+		}
+
+		return nil
+	})
+}
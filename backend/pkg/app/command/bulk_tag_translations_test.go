@@ -0,0 +1,83 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestBulkTagTranslationsHandler_Handle_NegativeCases(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator
+		cmd       BulkTagTranslations
+	}{
+		{"No tags passed", newSuccessValidator(), BulkTagTranslations{IDs: []string{"id1"}, AuthorID: "testAuthor"}},
+		{"Error on validation", newFailValidator(), BulkTagTranslations{IDs: []string{"id1"}, AddTagIDs: []string{"tag1"}, AuthorID: "testAuthor"}},
+		{"No translations passed", newSuccessValidator(), BulkTagTranslations{IDs: []string{""}, AddTagIDs: []string{"tag1"}, AuthorID: "testAuthor"}},
+		{"Empty author", newSuccessValidator(), BulkTagTranslations{IDs: []string{"id1"}, RemoveTagIDs: []string{"tag1"}}},
+		{"Too many translations", newSuccessValidator(), BulkTagTranslations{IDs: make([]string, MaxBulkItems+1), AddTagIDs: []string{"tag1"}, AuthorID: "testAuthor"}},
+	}
+	for i := range tests[4].cmd.IDs {
+		tests[4].cmd.IDs[i] = fmt.Sprintf("id%d", i)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := BulkTagTranslationsHandler{validator: tt.validator}
+			_, err := h.Handle(tt.cmd)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBulkTagTranslationsHandler_Handle(t *testing.T) {
+	tr1, err := translation.NewTranslation("source1", "", []translation.Sense{translation.NewSense("target", "", []string{"tag1"})}, "testAuthor", "EN")
+	assert.Nil(t, err)
+	tr2, err := translation.NewTranslation("source2", "", []translation.Sense{translation.NewSense("target", "", []string{"tag2"})}, "testAuthor", "EN")
+	assert.Nil(t, err)
+	tr3, err := translation.NewTranslation("source3", "", []translation.Sense{translation.NewSense("target", "", []string{"tag1", "tag3", "tag4", "tag5", "tag6"})}, "testAuthor", "EN")
+	assert.Nil(t, err)
+	tr4, err := translation.NewTranslation("source4", "", []translation.Sense{translation.NewSense("target", "", []string{"tag1", "tag2"})}, "testAuthor", "EN")
+	assert.Nil(t, err)
+
+	translationRepo := translation.NewMockRepository(t)
+	translationRepo.On("Get", "id1", "testAuthor").Return(tr1, nil)
+	translationRepo.On("Get", "id2", "testAuthor").Return(tr2, nil)
+	translationRepo.On("Get", "id3", "testAuthor").Return(tr3, nil)
+	translationRepo.On("Get", "id4", "testAuthor").Return(tr4, nil)
+	translationRepo.On("Get", "missing", "testAuthor").Return(nil, translation.ErrNotFound)
+	translationRepo.On("UpdateMany", []*translation.Translation{tr1, tr4}).Return([]error{nil, errors.New("testErr")})
+
+	revisionRepo := translation.NewMockRevisionRepository(t)
+	revisionRepo.On("Create", mock.MatchedBy(func(revision *translation.Revision) bool {
+		return revision.TranslationID() == tr1.ID()
+	})).Return(nil).Once()
+
+	h := BulkTagTranslationsHandler{
+		updater:   bulkUpdater{translationRepo: translationRepo, revisionRepo: revisionRepo},
+		validator: newSuccessValidator(),
+	}
+
+	result, err := h.Handle(BulkTagTranslations{
+		IDs:          []string{"id1", "id2", "id1", "id3", "id4", "missing"},
+		AddTagIDs:    []string{"tag2"},
+		RemoveTagIDs: []string{"tag1"},
+		AuthorID:     "testAuthor",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(result.Items))
+	assert.Equal(t, 2, result.Succeeded())
+
+	assert.Equal(t, BulkItemResult{ID: "id1"}, result.Items[0])
+	assert.Equal(t, BulkItemResult{ID: "id2"}, result.Items[1], "not changed translation is not saved")
+	assert.Equal(t, "id3", result.Items[2].ID)
+	assert.Error(t, result.Items[2].Err, "tags limit is exceeded")
+	assert.Equal(t, "id4", result.Items[3].ID)
+	assert.Equal(t, "testErr", result.Items[3].Err.Error())
+	assert.Equal(t, BulkItemResult{ID: "missing", Err: translation.ErrNotFound}, result.Items[4])
+
+	assert.Equal(t, []string{"tag2"}, tr1.Senses()[0].TagIDs())
+}
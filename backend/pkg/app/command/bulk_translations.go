@@ -0,0 +1,210 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+)
+
+// MaxBulkItems limits amount of translations changed by single bulk cmd
+const MaxBulkItems = 1000
+
+// BulkResult contains results of all translations passed to bulk cmd in the same order, repeated IDs are reported once
+type BulkResult struct {
+	Items []BulkItemResult
+}
+
+// BulkItemResult result of bulk cmd for a single translation, nil Err means the translation is processed
+type BulkItemResult struct {
+	ID  string
+	Err error
+}
+
+// Succeeded returns amount of processed translations
+func (r BulkResult) Succeeded() int {
+	count := 0
+	for _, item := range r.Items {
+		if item.Err == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// bulkUpdater applies the change to every translation, saves changed ones at once and records their revisions
+type bulkUpdater struct {
+	translationRepo translation.Repository
+	revisionRepo    translation.RevisionRepository
+}
+
+// bulkChange applies a change to the translation, an error fails only this translation
+type bulkChange func(tr *translation.Translation) error
+
+func (u bulkUpdater) update(ids []string, authorID string, change bulkChange) (BulkResult, error) {
+	records, result, err := loadBulkTranslations(u.translationRepo, ids, authorID)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	return u.apply(records, result, change), nil
+}
+
+// apply changes loaded translations, nil records are skipped as they are already failed in the result
+func (u bulkUpdater) apply(records []*translation.Translation, result BulkResult, change bulkChange) BulkResult {
+	var changed []*translation.Translation
+	var revisions []*translation.Revision
+	var positions []int // positions of changed translations in the result
+
+	for i, tr := range records {
+		if tr == nil {
+			continue
+		}
+
+		before := *tr
+		if err := change(tr); err != nil {
+			result.Items[i].Err = err
+			continue
+		}
+
+		revision, ok := translation.NewRevision(&before, tr)
+		if !ok {
+			continue
+		}
+
+		changed = append(changed, tr)
+		revisions = append(revisions, revision)
+		positions = append(positions, i)
+	}
+
+	if len(changed) == 0 {
+		return result
+	}
+
+	errs := u.translationRepo.UpdateMany(changed)
+	for j, position := range positions {
+		if j < len(errs) && errs[j] != nil {
+			result.Items[position].Err = errs[j]
+			continue
+		}
+
+		result.Items[position].Err = u.revisionRepo.Create(revisions[j])
+	}
+
+	return result
+}
+
+// moveToLang moves translation to the lang, custom field values have to match the lang schema
+func moveToLang(tr *translation.Translation, ln *lang.Lang) error {
+	if err := ln.ValidateFieldValues(tr.Fields()); err != nil {
+		return fmt.Errorf("field values do not match lang %s schema: %w", ln.Name(), err)
+	}
+
+	return tr.MoveToLang(ln.ID())
+}
+
+// bulkDeleter moves translations to trash and deletes all trashed ones at once
+type bulkDeleter struct {
+	translationRepo translation.Repository
+	trashRepo       trash.Repository
+}
+
+// delete moves loaded translations to trash like DeleteTranslation cmd, nil records are skipped as they are already failed in the result
+func (d bulkDeleter) delete(records []*translation.Translation, result BulkResult, authorID string) BulkResult {
+	var deleted []*translation.Translation
+	var items []*trash.Item
+	var positions []int // positions of deleted translations in the result
+
+	for i, tr := range records {
+		if tr == nil {
+			continue
+		}
+
+		item, err := trash.NewTranslationItem(tr)
+		if err == nil {
+			err = d.trashRepo.Create(item)
+		}
+
+		if err != nil {
+			result.Items[i].Err = err
+			continue
+		}
+
+		deleted = append(deleted, tr)
+		items = append(items, item)
+		positions = append(positions, i)
+	}
+
+	if len(deleted) == 0 {
+		return result
+	}
+
+	if err := d.translationRepo.DeleteMany(deleted); err != nil {
+		for j, position := range positions {
+			result.Items[position].Err = d.rollback(deleted[j], items[j], authorID, err)
+		}
+	}
+
+	return result
+}
+
+// rollback removes trash item of the translation which is not deleted, store could delete a part of translations
+// before the failure, so trash items of already deleted translations are kept to let them be restored
+func (d bulkDeleter) rollback(tr *translation.Translation, item *trash.Item, authorID string, deleteErr error) error {
+	_, err := d.translationRepo.Get(tr.ID(), authorID)
+	if errors.Is(err, translation.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Join(deleteErr, err)
+	}
+
+	return errors.Join(deleteErr, d.trashRepo.Delete(item.ID(), authorID))
+}
+
+// loadBulkTranslations returns author translations in order of unique passed IDs, not found translations are nil with failed results
+func loadBulkTranslations(translationRepo translation.Repository, ids []string, authorID string) ([]*translation.Translation, BulkResult, error) {
+	if authorID == "" {
+		return nil, BulkResult{}, errors.New("authorID can not be empty")
+	}
+
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return nil, BulkResult{}, errors.New("at least one translation should be passed")
+	}
+
+	if len(ids) > MaxBulkItems {
+		return nil, BulkResult{}, fmt.Errorf("max amount of translations changed at once is %d, %d passed", MaxBulkItems, len(ids))
+	}
+
+	records := make([]*translation.Translation, len(ids))
+	result := BulkResult{Items: make([]BulkItemResult, len(ids))}
+
+	for i, id := range ids {
+		result.Items[i].ID = id
+
+		tr, err := translationRepo.Get(id, authorID)
+		if err != nil {
+			result.Items[i].Err = err
+			continue
+		}
+		records[i] = tr
+	}
+
+	return records, result, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	unique := make([]string, 0, len(ids))
+	seen := map[string]struct{}{}
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
@@ -0,0 +1,36 @@
+package command
+
+import "github.com/macyan13/webdict/backend/pkg/app/domain/user"
+
+// ConfirmTwoFactor enables two-factor authentication with the first code from authenticator app
+type ConfirmTwoFactor struct {
+	UserID string
+	Code   string
+}
+
+type ConfirmTwoFactorHandler struct {
+	userRepo user.Repository
+}
+
+func NewConfirmTwoFactorHandler(userRepo user.Repository) ConfirmTwoFactorHandler {
+	return ConfirmTwoFactorHandler{userRepo: userRepo}
+}
+
+// Handle returns one-time recovery codes, they are stored as hashes only, so they can not be shown again
+func (h ConfirmTwoFactorHandler) Handle(cmd ConfirmTwoFactor) ([]string, error) {
+	usr, err := h.userRepo.Get(cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := usr.ConfirmTwoFactor(cmd.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.userRepo.Update(usr); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConfirmTwoFactorHandler_Handle(t *testing.T) {
+	usr, err := user.NewUser("test", "test@test.com", "testPasswd", user.Author)
+	assert.Nil(t, err)
+	secret, err := usr.EnrollTwoFactor()
+	assert.Nil(t, err)
+
+	userRepo := user.NewMockRepository(t)
+	userRepo.On("Get", usr.ID()).Return(usr, nil)
+	userRepo.On("Update", usr).Once().Return(nil)
+
+	h := NewConfirmTwoFactorHandler(userRepo)
+	_, err = h.Handle(ConfirmTwoFactor{UserID: usr.ID(), Code: "000000x"})
+	assert.Equal(t, user.ErrInvalidTwoFactorCode, err)
+	assert.False(t, usr.TwoFactorEnabled())
+
+	code, err := user.GenerateTOTPCode(secret, time.Now())
+	assert.Nil(t, err)
+
+	codes, err := h.Handle(ConfirmTwoFactor{UserID: usr.ID(), Code: code})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, codes)
+	assert.True(t, usr.TwoFactorEnabled())
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"time"
+)
+
+// CreateToken creates personal access token of the user, zero ExpiresAt means the token never expires
+type CreateToken struct {
+	UserID    string
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// CreatedToken the secret is not stored, so it can be shown to the user only once
+type CreatedToken struct {
+	ID     string
+	Secret string
+}
+
+type CreateTokenHandler struct {
+	tokenRepo token.Repository
+}
+
+func NewCreateTokenHandler(tokenRepo token.Repository) CreateTokenHandler {
+	return CreateTokenHandler{tokenRepo: tokenRepo}
+}
+
+func (h CreateTokenHandler) Handle(cmd CreateToken) (CreatedToken, error) {
+	scopes := make([]token.Scope, 0, len(cmd.Scopes))
+	var err error
+	for _, value := range cmd.Scopes {
+		scope, parseErr := token.ParseScope(value)
+		if parseErr != nil {
+			err = errors.Join(err, parseErr)
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+
+	if err != nil {
+		return CreatedToken{}, err
+	}
+
+	t, secret, err := token.NewToken(cmd.UserID, cmd.Name, scopes, cmd.ExpiresAt)
+	if err != nil {
+		return CreatedToken{}, err
+	}
+
+	if err = h.tokenRepo.Create(t); err != nil {
+		return CreatedToken{}, err
+	}
+
+	return CreatedToken{ID: t.ID(), Secret: secret}, nil
+}
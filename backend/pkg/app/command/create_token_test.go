@@ -0,0 +1,36 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+)
+
+func TestCreateTokenHandler_Handle(t *testing.T) {
+	tokenRepo := token.NewMockRepository(t)
+	tokenRepo.On("Create", mock.AnythingOfType("*token.Token")).Once().Return(errors.New("testErr"))
+	tokenRepo.On("Create", mock.AnythingOfType("*token.Token")).Return(nil)
+
+	h := NewCreateTokenHandler(tokenRepo)
+	_, err := h.Handle(CreateToken{UserID: "testUser", Name: "cron", Scopes: []string{"users:read", "tags:delete"}})
+	assert.True(t, strings.Contains(err.Error(), "scope users:read has unknown resource"))
+	assert.True(t, strings.Contains(err.Error(), "scope tags:delete has unknown access"))
+
+	_, err = h.Handle(CreateToken{UserID: "testUser", Name: "cron"})
+	assert.Equal(t, "at least one scope is required", err.Error())
+
+	_, err = h.Handle(CreateToken{UserID: "testUser", Name: "cron", Scopes: []string{"read"}})
+	assert.Equal(t, "testErr", err.Error())
+
+	created, err := h.Handle(CreateToken{UserID: "testUser", Name: "cron", Scopes: []string{"Translations:Write"}})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.True(t, strings.HasPrefix(created.Secret, token.SecretPrefix))
+
+	saved := tokenRepo.Calls[len(tokenRepo.Calls)-1].Arguments.Get(0).(*token.Token)
+	assert.Equal(t, []token.Scope{"translations:write"}, saved.Scopes())
+	assert.True(t, saved.Allows(token.Translations, token.Write))
+}
@@ -1,41 +1,161 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+)
+
+// DeleteLangMode defines what happens with translations of the deleted lang
+type DeleteLangMode string
+
+const (
+	DeleteLangRestrict DeleteLangMode = ""        // DeleteLangRestrict refuses deletion of the lang used by translations
+	DeleteLangMove     DeleteLangMode = "move"    // DeleteLangMove moves translations to the target lang before the deletion
+	DeleteLangCascade  DeleteLangMode = "cascade" // DeleteLangCascade moves translations to trash together with the lang
 )
 
 type DeleteLang struct {
-	ID       string
-	AuthorID string
+	ID           string
+	AuthorID     string
+	Mode         DeleteLangMode
+	TargetLangID string // TargetLangID is required for DeleteLangMove mode only
+}
+
+// DeleteLangResult contains amounts of moved and deleted translations,
+// the lang is kept when any translation is not processed, e.g. the target lang already has translation with the same source
+// or does not define the translation custom fields
+type DeleteLangResult struct {
+	Moved       int
+	Deleted     int
+	Conflicts   []BulkItemResult
+	LangDeleted bool
 }
 
 type DeleteLangHandler struct {
 	langRepo        lang.Repository
 	translationRepo translation.Repository
+	revisionRepo    translation.RevisionRepository
+	trashRepo       trash.Repository
 }
 
-func NewDeleteLangHandler(langRepo lang.Repository, translationRepo translation.Repository) DeleteLangHandler {
-	return DeleteLangHandler{langRepo: langRepo, translationRepo: translationRepo}
+func NewDeleteLangHandler(
+	langRepo lang.Repository,
+	translationRepo translation.Repository,
+	revisionRepo translation.RevisionRepository,
+	trashRepo trash.Repository,
+) DeleteLangHandler {
+	return DeleteLangHandler{langRepo: langRepo, translationRepo: translationRepo, revisionRepo: revisionRepo, trashRepo: trashRepo}
 }
 
-func (h *DeleteLangHandler) Handle(cmd DeleteLang) error {
+// Handle moves or deletes lang translations according to the cmd mode and moves the lang to trash when all of them are processed
+func (h *DeleteLangHandler) Handle(cmd DeleteLang) (DeleteLangResult, error) {
 	if err := h.validate(cmd); err != nil {
-		return err
+		return DeleteLangResult{}, err
+	}
+
+	ln, err := h.langRepo.Get(cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return DeleteLangResult{}, err
+	}
+
+	result, err := h.processTranslations(cmd)
+	if err != nil || len(result.Conflicts) != 0 {
+		return result, err
 	}
-	return h.langRepo.Delete(cmd.ID, cmd.AuthorID)
+
+	item, err := trash.NewLangItem(ln)
+	if err != nil {
+		return result, err
+	}
+
+	if err = h.trashRepo.Create(item); err != nil {
+		return result, err
+	}
+
+	if err = h.langRepo.Delete(cmd.ID, cmd.AuthorID); err != nil {
+		return result, errors.Join(err, h.trashRepo.Delete(item.ID(), cmd.AuthorID))
+	}
+
+	result.LangDeleted = true
+	return result, nil
 }
 
-func (h *DeleteLangHandler) validate(cmd DeleteLang) error {
-	exist, err := h.translationRepo.ExistByLang(cmd.ID, cmd.AuthorID)
+// processTranslations moves or deletes all lang translations, translations which are not processed are reported as conflicts
+func (h *DeleteLangHandler) processTranslations(cmd DeleteLang) (DeleteLangResult, error) {
+	if cmd.Mode == DeleteLangRestrict {
+		return DeleteLangResult{}, nil
+	}
 
+	records, err := h.translationRepo.GetByLang(cmd.ID, cmd.AuthorID)
 	if err != nil {
-		return err
+		return DeleteLangResult{}, err
+	}
+
+	if len(records) == 0 {
+		return DeleteLangResult{}, nil
+	}
+
+	bulk := BulkResult{Items: make([]BulkItemResult, len(records))}
+	for i, tr := range records {
+		bulk.Items[i].ID = tr.ID()
+	}
+
+	var result DeleteLangResult
+	if cmd.Mode == DeleteLangMove {
+		target, err := h.langRepo.Get(cmd.TargetLangID, cmd.AuthorID)
+		if err != nil {
+			return DeleteLangResult{}, err
+		}
+
+		bulk = bulkUpdater{translationRepo: h.translationRepo, revisionRepo: h.revisionRepo}.apply(records, bulk, func(tr *translation.Translation) error {
+			return moveToLang(tr, target)
+		})
+		result.Moved = bulk.Succeeded()
+	} else {
+		bulk = bulkDeleter{translationRepo: h.translationRepo, trashRepo: h.trashRepo}.delete(records, bulk, cmd.AuthorID)
+		result.Deleted = bulk.Succeeded()
 	}
 
-	if exist {
-		return fmt.Errorf("can not remove lang:%s as some translations use it", cmd.ID)
+	for _, item := range bulk.Items {
+		if item.Err != nil {
+			result.Conflicts = append(result.Conflicts, item)
+		}
+	}
+
+	return result, nil
+}
+
+func (h *DeleteLangHandler) validate(cmd DeleteLang) error {
+	switch cmd.Mode {
+	case DeleteLangRestrict:
+		exist, err := h.translationRepo.ExistByLang(cmd.ID, cmd.AuthorID)
+
+		if err != nil {
+			return err
+		}
+
+		if exist {
+			return fmt.Errorf("can not remove lang:%s as some translations use it", cmd.ID)
+		}
+	case DeleteLangMove:
+		if cmd.TargetLangID == "" || cmd.TargetLangID == cmd.ID {
+			return fmt.Errorf("target lang should be passed and differ from the deleted one")
+		}
+
+		exist, err := h.langRepo.Exist(cmd.TargetLangID, cmd.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		if !exist {
+			return fmt.Errorf("target lang %s does not exist", cmd.TargetLangID)
+		}
+	case DeleteLangCascade:
+	default:
+		return fmt.Errorf("unknown delete lang mode %s", cmd.Mode)
 	}
 
 	return nil
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
@@ -13,6 +15,8 @@ func TestDeleteLangHandler_Handle(t *testing.T) {
 	type fields struct {
 		langRepo        lang.Repository
 		translationRepo translation.Repository
+		revisionRepo    translation.RevisionRepository
+		trashRepo       trash.Repository
 	}
 	type args struct {
 		cmd DeleteLang
@@ -31,6 +35,7 @@ func TestDeleteLangHandler_Handle(t *testing.T) {
 				return fields{
 					langRepo:        &lang.MockRepository{},
 					translationRepo: &translationRepo,
+					trashRepo:       &trash.MockRepository{},
 				}
 			},
 			args{cmd: DeleteLang{
@@ -47,6 +52,7 @@ func TestDeleteLangHandler_Handle(t *testing.T) {
 				return fields{
 					langRepo:        &lang.MockRepository{},
 					translationRepo: &translationRepo,
+					trashRepo:       &trash.MockRepository{},
 				}
 			},
 			args{cmd: DeleteLang{
@@ -59,15 +65,40 @@ func TestDeleteLangHandler_Handle(t *testing.T) {
 			},
 		},
 		{
-			"LangID repo returns error",
+			"Lang repo returns error on get",
 			func() fields {
 				translationRepo := translation.MockRepository{}
 				translationRepo.On("ExistByLang", "testId", "testAuthorID").Return(false, nil)
 				langRepo := lang.MockRepository{}
-				langRepo.On("Delete", "testId", "testAuthorID").Return(errors.New("testError"))
+				langRepo.On("Get", "testId", "testAuthorID").Return(nil, lang.ErrNotFound)
+				return fields{
+					langRepo:        &langRepo,
+					translationRepo: &translationRepo,
+					trashRepo:       &trash.MockRepository{},
+				}
+			},
+			args{cmd: DeleteLang{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, lang.ErrNotFound, i)
+				return true
+			},
+		},
+		{
+			"Trash repo returns error",
+			func() fields {
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("ExistByLang", "testId", "testAuthorID").Return(false, nil)
+				langRepo := lang.MockRepository{}
+				langRepo.On("Get", "testId", "testAuthorID").Return(lang.UnmarshalFromDB("testId", "EN", "testAuthorID", lang.Metadata{}, nil), nil)
+				trashRepo := trash.MockRepository{}
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(errors.New("testError"))
 				return fields{
 					langRepo:        &langRepo,
 					translationRepo: &translationRepo,
+					trashRepo:       &trashRepo,
 				}
 			},
 			args{cmd: DeleteLang{
@@ -76,16 +107,48 @@ func TestDeleteLangHandler_Handle(t *testing.T) {
 			}},
 			assert.Error,
 		},
+		{
+			"Lang repo returns error on delete, trash item is removed",
+			func() fields {
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("ExistByLang", "testId", "testAuthorID").Return(false, nil)
+				langRepo := lang.MockRepository{}
+				langRepo.On("Get", "testId", "testAuthorID").Return(lang.UnmarshalFromDB("testId", "EN", "testAuthorID", lang.Metadata{}, nil), nil)
+				langRepo.On("Delete", "testId", "testAuthorID").Return(errors.New("testError"))
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil)
+				trashRepo.On("Delete", mock.AnythingOfType("string"), "testAuthorID").Return(nil)
+				return fields{
+					langRepo:        &langRepo,
+					translationRepo: &translationRepo,
+					trashRepo:       trashRepo,
+				}
+			},
+			args{cmd: DeleteLang{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testError", err.Error(), i)
+				return true
+			},
+		},
 		{
 			"Positive",
 			func() fields {
 				translationRepo := translation.MockRepository{}
 				translationRepo.On("ExistByLang", "testId", "testAuthorID").Return(false, nil)
 				langRepo := lang.MockRepository{}
+				langRepo.On("Get", "testId", "testAuthorID").Return(lang.UnmarshalFromDB("testId", "EN", "testAuthorID", lang.Metadata{}, nil), nil)
 				langRepo.On("Delete", "testId", "testAuthorID").Return(nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.MatchedBy(func(item *trash.Item) bool {
+					return item.Kind() == trash.LangKind && item.EntityID() == "testId"
+				})).Return(nil)
 				return fields{
 					langRepo:        &langRepo,
 					translationRepo: &translationRepo,
+					trashRepo:       trashRepo,
 				}
 			},
 			args{cmd: DeleteLang{
@@ -94,6 +157,49 @@ func TestDeleteLangHandler_Handle(t *testing.T) {
 			}},
 			assert.NoError,
 		},
+		{
+			"Error on unknown mode",
+			func() fields {
+				return fields{langRepo: &lang.MockRepository{}, translationRepo: &translation.MockRepository{}, trashRepo: &trash.MockRepository{}}
+			},
+			args{cmd: DeleteLang{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+				Mode:     "archive",
+			}},
+			assert.Error,
+		},
+		{
+			"Error on moving to the deleted lang",
+			func() fields {
+				return fields{langRepo: &lang.MockRepository{}, translationRepo: &translation.MockRepository{}, trashRepo: &trash.MockRepository{}}
+			},
+			args{cmd: DeleteLang{
+				ID:           "testId",
+				AuthorID:     "testAuthorID",
+				Mode:         DeleteLangMove,
+				TargetLangID: "testId",
+			}},
+			assert.Error,
+		},
+		{
+			"Error on moving to not existing lang",
+			func() fields {
+				langRepo := lang.MockRepository{}
+				langRepo.On("Exist", "targetId", "testAuthorID").Return(false, nil)
+				return fields{langRepo: &langRepo, translationRepo: &translation.MockRepository{}, trashRepo: &trash.MockRepository{}}
+			},
+			args{cmd: DeleteLang{
+				ID:           "testId",
+				AuthorID:     "testAuthorID",
+				Mode:         DeleteLangMove,
+				TargetLangID: "targetId",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "target lang targetId does not exist", err.Error(), i)
+				return true
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -101,8 +207,68 @@ func TestDeleteLangHandler_Handle(t *testing.T) {
 			h := NewDeleteLangHandler(
 				f.langRepo,
 				f.translationRepo,
+				f.revisionRepo,
+				f.trashRepo,
 			)
-			tt.wantErr(t, h.Handle(tt.args.cmd), fmt.Sprintf("Handle(%v)", tt.args.cmd))
+			_, err := h.Handle(tt.args.cmd)
+			tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.cmd))
 		})
 	}
 }
+
+func TestDeleteLangHandler_HandleMove(t *testing.T) {
+	moved := createTranslationWithSource(t, "go", "testId")
+	conflicted := createTranslationWithSource(t, "run", "testId")
+	notMatched := createTranslationWithSource(t, "walk", "testId")
+	assert.Nil(t, notMatched.ApplyFields(map[string]string{"gender": "m"}))
+
+	langRepo := lang.MockRepository{}
+	langRepo.On("Exist", "targetId", "testAuthorID").Return(true, nil)
+	langRepo.On("Get", "targetId", "testAuthorID").Return(lang.UnmarshalFromDB("targetId", "DE", "testAuthorID", lang.Metadata{}, nil), nil)
+	langRepo.On("Get", "testId", "testAuthorID").Return(lang.UnmarshalFromDB("testId", "EN", "testAuthorID", lang.Metadata{}, nil), nil)
+	translationRepo := translation.MockRepository{}
+	translationRepo.On("GetByLang", "testId", "testAuthorID").Return([]*translation.Translation{moved, conflicted, notMatched}, nil)
+	translationRepo.On("UpdateMany", mock.AnythingOfType("[]*translation.Translation")).Return([]error{nil, translation.ErrSourceAlreadyExists})
+	revisionRepo := translation.MockRevisionRepository{}
+	revisionRepo.On("Create", mock.AnythingOfType("*translation.Revision")).Return(nil)
+
+	h := NewDeleteLangHandler(&langRepo, &translationRepo, &revisionRepo, &trash.MockRepository{})
+	result, err := h.Handle(DeleteLang{ID: "testId", AuthorID: "testAuthorID", Mode: DeleteLangMove, TargetLangID: "targetId"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Moved)
+	assert.False(t, result.LangDeleted)
+	assert.Equal(t, 2, len(result.Conflicts))
+	assert.Equal(t, BulkItemResult{ID: conflicted.ID(), Err: translation.ErrSourceAlreadyExists}, result.Conflicts[0])
+	assert.Equal(t, notMatched.ID(), result.Conflicts[1].ID)
+	assert.EqualError(t, result.Conflicts[1].Err, "field values do not match lang DE schema: field gender is not defined for lang DE")
+	assert.Equal(t, "targetId", moved.LangID())
+	assert.Equal(t, "testId", notMatched.LangID())
+	langRepo.AssertNotCalled(t, "Delete", "testId", "testAuthorID")
+}
+
+func TestDeleteLangHandler_HandleCascade(t *testing.T) {
+	records := []*translation.Translation{createTranslationWithSource(t, "go", "testId"), createTranslationWithSource(t, "run", "testId")}
+
+	langRepo := lang.MockRepository{}
+	langRepo.On("Get", "testId", "testAuthorID").Return(lang.UnmarshalFromDB("testId", "EN", "testAuthorID", lang.Metadata{}, nil), nil)
+	langRepo.On("Delete", "testId", "testAuthorID").Return(nil)
+	translationRepo := translation.MockRepository{}
+	translationRepo.On("GetByLang", "testId", "testAuthorID").Return(records, nil)
+	translationRepo.On("DeleteMany", records).Return(nil)
+	trashRepo := trash.MockRepository{}
+	trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil)
+
+	h := NewDeleteLangHandler(&langRepo, &translationRepo, &translation.MockRevisionRepository{}, &trashRepo)
+	result, err := h.Handle(DeleteLang{ID: "testId", AuthorID: "testAuthorID", Mode: DeleteLangCascade})
+
+	assert.Nil(t, err)
+	assert.Equal(t, DeleteLangResult{Deleted: 2, LangDeleted: true}, result)
+	trashRepo.AssertNumberOfCalls(t, "Create", 3)
+}
+
+func createTranslationWithSource(t *testing.T, source, langID string) *translation.Translation {
+	tr, err := translation.NewTranslation(source, "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthorID", langID)
+	assert.Nil(t, err)
+	return tr
+}
@@ -1,45 +1,86 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
 )
 
 // DeleteTag delete tag cmd
 type DeleteTag struct {
 	ID       string
 	AuthorID string
+	Detach   bool // Detach removes the tag from translations tagged by it instead of refusing the deletion
 }
 
 // DeleteTagHandler Delete tag cmd handler
 type DeleteTagHandler struct {
 	tagRepo         tag.Repository
 	translationRepo translation.Repository
+	trashRepo       trash.Repository
 }
 
-func NewDeleteTagHandler(tagRepo tag.Repository, translationRepo translation.Repository) DeleteTagHandler {
-	return DeleteTagHandler{tagRepo: tagRepo, translationRepo: translationRepo}
+func NewDeleteTagHandler(tagRepo tag.Repository, translationRepo translation.Repository, trashRepo trash.Repository) DeleteTagHandler {
+	return DeleteTagHandler{tagRepo: tagRepo, translationRepo: translationRepo, trashRepo: trashRepo}
 }
 
-// Handle performs tag deletion cmd
+// Handle performs tag deletion cmd, the tag is moved to trash
+// and detached from its translations when it is requested
 func (h *DeleteTagHandler) Handle(cmd DeleteTag) error {
 	if err := h.validate(cmd); err != nil {
 		return err
 	}
-	return h.tagRepo.Delete(cmd.ID, cmd.AuthorID)
+
+	tg, err := h.tagRepo.Get(cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	item, err := trash.NewTagItem(tg)
+	if err != nil {
+		return err
+	}
+
+	if err = h.trashRepo.Create(item); err != nil {
+		return err
+	}
+
+	if cmd.Detach {
+		if _, err = h.translationRepo.RemoveTag(cmd.ID, cmd.AuthorID); err != nil {
+			return errors.Join(err, h.trashRepo.Delete(item.ID(), cmd.AuthorID))
+		}
+	}
+
+	if err = h.tagRepo.Delete(cmd.ID, cmd.AuthorID); err != nil {
+		return errors.Join(err, h.trashRepo.Delete(item.ID(), cmd.AuthorID))
+	}
+
+	return nil
 }
 
-// Validate checks that there is not translation tagged by the tag to be deleted
+// Validate checks that there is not translation tagged by the tag to be deleted unless it is detached and no tag nested into it
 func (h *DeleteTagHandler) validate(cmd DeleteTag) error {
-	exist, err := h.translationRepo.ExistByTag(cmd.ID, cmd.AuthorID)
+	if !cmd.Detach {
+		exist, err := h.translationRepo.ExistByTag(cmd.ID, cmd.AuthorID)
+
+		if err != nil {
+			return err
+		}
+
+		if exist {
+			return fmt.Errorf("can not remove tag:%s as some translation is tagged by it", cmd.ID)
+		}
+	}
 
+	exist, err := h.tagRepo.ExistByParent(cmd.ID, cmd.AuthorID)
 	if err != nil {
 		return err
 	}
 
 	if exist {
-		return fmt.Errorf("can not remove tag:%s as some translation is tagged by it", cmd.ID)
+		return fmt.Errorf("can not remove tag:%s as some tag is nested into it", cmd.ID)
 	}
 
 	return nil
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
@@ -13,6 +15,7 @@ func TestDeleteTagHandler_Handle(t *testing.T) {
 	type fields struct {
 		tagRepo         tag.Repository
 		translationRepo translation.Repository
+		trashRepo       trash.Repository
 	}
 	type args struct {
 		cmd DeleteTag
@@ -31,6 +34,7 @@ func TestDeleteTagHandler_Handle(t *testing.T) {
 				return fields{
 					tagRepo:         &tag.MockRepository{},
 					translationRepo: &translationRepo,
+					trashRepo:       &trash.MockRepository{},
 				}
 			},
 			args{cmd: DeleteTag{
@@ -50,6 +54,7 @@ func TestDeleteTagHandler_Handle(t *testing.T) {
 				return fields{
 					tagRepo:         &tag.MockRepository{},
 					translationRepo: &translationRepo,
+					trashRepo:       &trash.MockRepository{},
 				}
 			},
 			args{cmd: DeleteTag{
@@ -62,15 +67,69 @@ func TestDeleteTagHandler_Handle(t *testing.T) {
 			},
 		},
 		{
-			"Case 3: tag repo returns error",
+			"Case 3: tag repo returns error on get",
 			func() fields {
 				translationRepo := translation.MockRepository{}
 				translationRepo.On("ExistByTag", "testId", "testAuthorID").Return(false, nil)
 				tagRepo := tag.MockRepository{}
+				tagRepo.On("ExistByParent", "testId", "testAuthorID").Return(false, nil)
+				tagRepo.On("Get", "testId", "testAuthorID").Return(nil, errors.New("testError"))
+				return fields{
+					tagRepo:         &tagRepo,
+					translationRepo: &translationRepo,
+					trashRepo:       &trash.MockRepository{},
+				}
+			},
+			args{cmd: DeleteTag{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testError", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Case 4: trash repo returns error",
+			func() fields {
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("ExistByTag", "testId", "testAuthorID").Return(false, nil)
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("ExistByParent", "testId", "testAuthorID").Return(false, nil)
+				tagRepo.On("Get", "testId", "testAuthorID").Return(tag.UnmarshalFromDB("testId", "tag", "testAuthorID", ""), nil)
+				trashRepo := trash.MockRepository{}
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(errors.New("testError"))
+				return fields{
+					tagRepo:         &tagRepo,
+					translationRepo: &translationRepo,
+					trashRepo:       &trashRepo,
+				}
+			},
+			args{cmd: DeleteTag{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testError", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Case 5: tag repo returns error on delete, trash item is removed",
+			func() fields {
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("ExistByTag", "testId", "testAuthorID").Return(false, nil)
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("ExistByParent", "testId", "testAuthorID").Return(false, nil)
+				tagRepo.On("Get", "testId", "testAuthorID").Return(tag.UnmarshalFromDB("testId", "tag", "testAuthorID", ""), nil)
 				tagRepo.On("Delete", "testId", "testAuthorID").Return(errors.New("testError"))
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil)
+				trashRepo.On("Delete", mock.AnythingOfType("string"), "testAuthorID").Return(nil)
 				return fields{
 					tagRepo:         &tagRepo,
 					translationRepo: &translationRepo,
+					trashRepo:       trashRepo,
 				}
 			},
 			args{cmd: DeleteTag{
@@ -83,15 +142,22 @@ func TestDeleteTagHandler_Handle(t *testing.T) {
 			},
 		},
 		{
-			"Case 4: Positive",
+			"Case 6: Positive",
 			func() fields {
 				translationRepo := translation.MockRepository{}
 				translationRepo.On("ExistByTag", "testId", "testAuthorID").Return(false, nil)
 				tagRepo := tag.MockRepository{}
+				tagRepo.On("ExistByParent", "testId", "testAuthorID").Return(false, nil)
+				tagRepo.On("Get", "testId", "testAuthorID").Return(tag.UnmarshalFromDB("testId", "tag", "testAuthorID", ""), nil)
 				tagRepo.On("Delete", "testId", "testAuthorID").Return(nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.MatchedBy(func(item *trash.Item) bool {
+					return item.Kind() == trash.TagKind && item.EntityID() == "testId"
+				})).Return(nil)
 				return fields{
 					tagRepo:         &tagRepo,
 					translationRepo: &translationRepo,
+					trashRepo:       trashRepo,
 				}
 			},
 			args{cmd: DeleteTag{
@@ -103,6 +169,82 @@ func TestDeleteTagHandler_Handle(t *testing.T) {
 				return true
 			},
 		},
+		{
+			"Case 7: tag with nested tags",
+			func() fields {
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("ExistByTag", "testId", "testAuthorID").Return(false, nil)
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("ExistByParent", "testId", "testAuthorID").Return(true, nil)
+				return fields{
+					tagRepo:         &tagRepo,
+					translationRepo: &translationRepo,
+					trashRepo:       &trash.MockRepository{},
+				}
+			},
+			args{cmd: DeleteTag{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "can not remove tag:testId as some tag is nested into it", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Case 8: tag is detached from translations",
+			func() fields {
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("ExistByParent", "testId", "testAuthorID").Return(false, nil)
+				tagRepo.On("Get", "testId", "testAuthorID").Return(tag.UnmarshalFromDB("testId", "tag", "testAuthorID", ""), nil)
+				tagRepo.On("Delete", "testId", "testAuthorID").Return(nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("RemoveTag", "testId", "testAuthorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil)
+				return fields{
+					tagRepo:         tagRepo,
+					translationRepo: translationRepo,
+					trashRepo:       trashRepo,
+				}
+			},
+			args{cmd: DeleteTag{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+				Detach:   true,
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Nil(t, err, i)
+				return true
+			},
+		},
+		{
+			"Case 9: error on detaching, trash item is removed",
+			func() fields {
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("ExistByParent", "testId", "testAuthorID").Return(false, nil)
+				tagRepo.On("Get", "testId", "testAuthorID").Return(tag.UnmarshalFromDB("testId", "tag", "testAuthorID", ""), nil)
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("RemoveTag", "testId", "testAuthorID").Return(0, errors.New("testError"))
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil)
+				trashRepo.On("Delete", mock.AnythingOfType("string"), "testAuthorID").Return(nil)
+				return fields{
+					tagRepo:         &tagRepo,
+					translationRepo: &translationRepo,
+					trashRepo:       trashRepo,
+				}
+			},
+			args{cmd: DeleteTag{
+				ID:       "testId",
+				AuthorID: "testAuthorID",
+				Detach:   true,
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testError", err.Error(), i)
+				return true
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -110,6 +252,7 @@ func TestDeleteTagHandler_Handle(t *testing.T) {
 			h := NewDeleteTagHandler(
 				fields.tagRepo,
 				fields.translationRepo,
+				fields.trashRepo,
 			)
 			tt.wantErr(t, h.Handle(tt.args.cmd), fmt.Sprintf("Handle(%v)", tt.args.cmd))
 		})
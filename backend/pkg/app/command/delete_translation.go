@@ -1,7 +1,9 @@
 package command
 
 import (
+	"errors"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
 )
 
 // DeleteTranslation cmd
@@ -13,15 +15,35 @@ type DeleteTranslation struct {
 // DeleteTranslationHandler delete translation cmd handler
 type DeleteTranslationHandler struct {
 	translationRepo translation.Repository
+	trashRepo       trash.Repository
 }
 
-func NewDeleteTranslationHandler(translationRepo translation.Repository) DeleteTranslationHandler {
+func NewDeleteTranslationHandler(translationRepo translation.Repository, trashRepo trash.Repository) DeleteTranslationHandler {
 	return DeleteTranslationHandler{
 		translationRepo: translationRepo,
+		trashRepo:       trashRepo,
 	}
 }
 
-// Handle performs translation deletion
+// Handle moves translation to trash, its revisions are kept until the trash item is purged
 func (h DeleteTranslationHandler) Handle(cmd DeleteTranslation) error {
-	return h.translationRepo.Delete(cmd.ID, cmd.AuthorID)
+	tr, err := h.translationRepo.Get(cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	item, err := trash.NewTranslationItem(tr)
+	if err != nil {
+		return err
+	}
+
+	if err = h.trashRepo.Create(item); err != nil {
+		return err
+	}
+
+	if err = h.translationRepo.Delete(cmd.ID, cmd.AuthorID); err != nil {
+		return errors.Join(err, h.trashRepo.Delete(item.ID(), cmd.AuthorID))
+	}
+
+	return nil
 }
@@ -4,13 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
 func TestDeleteTranslationHandler_Handle(t *testing.T) {
 	type fields struct {
 		translationRepo translation.Repository
+		trashRepo       trash.Repository
 	}
 	type args struct {
 		cmd DeleteTranslation
@@ -22,11 +25,51 @@ func TestDeleteTranslationHandler_Handle(t *testing.T) {
 		wantErr  assert.ErrorAssertionFunc
 	}{
 		{
-			"Case 1: error during translation removing",
+			"Case 1: translation not found",
 			func() fields {
 				repo := translation.MockRepository{}
+				repo.On("Get", "testID", "testAuthor").Return(nil, translation.ErrNotFound)
+				return fields{translationRepo: &repo, trashRepo: trash.NewMockRepository(t)}
+			},
+			args{cmd: DeleteTranslation{
+				ID:       "testID",
+				AuthorID: "testAuthor",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, translation.ErrNotFound, i)
+				return true
+			},
+		},
+		{
+			"Case 2: error during moving to trash",
+			func() fields {
+				tr, _ := translation.NewTranslation("source", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+				repo := translation.MockRepository{}
+				repo.On("Get", "testID", "testAuthor").Return(tr, nil)
+				trashRepo := trash.MockRepository{}
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(errors.New("testErr"))
+				return fields{translationRepo: &repo, trashRepo: &trashRepo}
+			},
+			args{cmd: DeleteTranslation{
+				ID:       "testID",
+				AuthorID: "testAuthor",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testErr", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Case 3: error during translation removing, trash item is removed",
+			func() fields {
+				tr, _ := translation.NewTranslation("source", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+				repo := translation.MockRepository{}
+				repo.On("Get", "testID", "testAuthor").Return(tr, nil)
 				repo.On("Delete", "testID", "testAuthor").Return(errors.New("testErr"))
-				return fields{translationRepo: &repo}
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.AnythingOfType("*trash.Item")).Return(nil)
+				trashRepo.On("Delete", mock.AnythingOfType("string"), "testAuthor").Return(nil)
+				return fields{translationRepo: &repo, trashRepo: trashRepo}
 			},
 			args{cmd: DeleteTranslation{
 				ID:       "testID",
@@ -38,11 +81,17 @@ func TestDeleteTranslationHandler_Handle(t *testing.T) {
 			},
 		},
 		{
-			"Case 2: positive case",
+			"Case 4: positive case",
 			func() fields {
+				tr, _ := translation.NewTranslation("source", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
 				repo := translation.MockRepository{}
+				repo.On("Get", "testID", "testAuthor").Return(tr, nil)
 				repo.On("Delete", "testID", "testAuthor").Return(nil)
-				return fields{translationRepo: &repo}
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Create", mock.MatchedBy(func(item *trash.Item) bool {
+					return item.Kind() == trash.TranslationKind && item.Translation() == tr
+				})).Return(nil)
+				return fields{translationRepo: &repo, trashRepo: trashRepo}
 			},
 			args{cmd: DeleteTranslation{
 				ID:       "testID",
@@ -57,7 +106,7 @@ func TestDeleteTranslationHandler_Handle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fields := tt.fieldsFn()
-			h := NewDeleteTranslationHandler(fields.translationRepo)
+			h := NewDeleteTranslationHandler(fields.translationRepo, fields.trashRepo)
 			tt.wantErr(t, h.Handle(tt.args.cmd), fmt.Sprintf("Handle(%v)", tt.args.cmd))
 		})
 	}
@@ -3,8 +3,11 @@ package command
 import (
 	"errors"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 )
 
@@ -17,10 +20,35 @@ type DeleteUserHandler struct {
 	langRepo        lang.Repository
 	tagRepo         tag.Repository
 	translationRepo translation.Repository
+	revisionRepo    translation.RevisionRepository
+	trashRepo       trash.Repository
+	sessionRepo     session.Repository
+	tokenRepo       token.Repository
+	attemptRepo     user.AttemptRepository
 }
 
-func NewDeleteUserHandler(userRepo user.Repository, langRepo lang.Repository, tagRepo tag.Repository, translationRepo translation.Repository) DeleteUserHandler {
-	return DeleteUserHandler{userRepo: userRepo, langRepo: langRepo, tagRepo: tagRepo, translationRepo: translationRepo}
+func NewDeleteUserHandler(
+	userRepo user.Repository,
+	langRepo lang.Repository,
+	tagRepo tag.Repository,
+	translationRepo translation.Repository,
+	revisionRepo translation.RevisionRepository,
+	trashRepo trash.Repository,
+	sessionRepo session.Repository,
+	tokenRepo token.Repository,
+	attemptRepo user.AttemptRepository,
+) DeleteUserHandler {
+	return DeleteUserHandler{
+		userRepo:        userRepo,
+		langRepo:        langRepo,
+		tagRepo:         tagRepo,
+		translationRepo: translationRepo,
+		revisionRepo:    revisionRepo,
+		trashRepo:       trashRepo,
+		sessionRepo:     sessionRepo,
+		tokenRepo:       tokenRepo,
+		attemptRepo:     attemptRepo,
+	}
 }
 
 // Handle removes user and all related content, no transaction support so far
@@ -38,5 +66,23 @@ func (h *DeleteUserHandler) Handle(cmd DeleteUser) (int, error) {
 	translationCount, err4 := h.translationRepo.DeleteByAuthorID(cmd.AuthorID)
 	err = errors.Join(err, err4)
 
-	return userCount + tagCount + LangCount + translationCount, err
+	// revisions are the translation history, so they are not counted as separate user content
+	_, err5 := h.revisionRepo.DeleteByAuthorID(cmd.AuthorID)
+	err = errors.Join(err, err5)
+
+	trashCount, err6 := h.trashRepo.DeleteByAuthorID(cmd.AuthorID)
+	err = errors.Join(err, err6)
+
+	// sessions are revoked to sign the user out on all devices, they are not counted as user content
+	_, err7 := h.sessionRepo.DeleteByUserID(cmd.AuthorID, "")
+	err = errors.Join(err, err7)
+
+	// personal access tokens are revoked as well, they are not counted as user content
+	_, err8 := h.tokenRepo.DeleteByUserID(cmd.AuthorID)
+	err = errors.Join(err, err8)
+
+	// failed sign-in attempts are removed, so the account lockout does not outlive the user
+	err = errors.Join(err, h.attemptRepo.DeleteAttempts(user.AccountAttemptsKey(cmd.AuthorID)))
+
+	return userCount + tagCount + LangCount + translationCount + trashCount, err
 }
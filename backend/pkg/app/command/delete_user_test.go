@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -17,6 +20,11 @@ func TestDeleteUserHandler_Handle(t *testing.T) {
 		langRepo        lang.Repository
 		tagRepo         tag.Repository
 		translationRepo translation.Repository
+		revisionRepo    translation.RevisionRepository
+		trashRepo       trash.Repository
+		sessionRepo     session.Repository
+		tokenRepo       token.Repository
+		attemptRepo     user.AttemptRepository
 	}
 	type args struct {
 		cmd DeleteUser
@@ -39,15 +47,30 @@ func TestDeleteUserHandler_Handle(t *testing.T) {
 				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
 				translationRepo := translation.NewMockRepository(t)
 				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
 				return fields{
 					userRepo:        userRepo,
 					langRepo:        langRepo,
 					tagRepo:         tagRepo,
 					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
 				}
 			},
 			args{cmd: DeleteUser{AuthorID: "authorID"}},
-			3,
+			4,
 			assert.Error,
 		},
 		{
@@ -61,15 +84,30 @@ func TestDeleteUserHandler_Handle(t *testing.T) {
 				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
 				translationRepo := translation.NewMockRepository(t)
 				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
 				return fields{
 					userRepo:        userRepo,
 					langRepo:        langRepo,
 					tagRepo:         tagRepo,
 					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
 				}
 			},
 			args{cmd: DeleteUser{AuthorID: "authorID"}},
-			3,
+			4,
 			assert.Error,
 		},
 		{
@@ -83,15 +121,30 @@ func TestDeleteUserHandler_Handle(t *testing.T) {
 				langRepo.On("DeleteByAuthorID", "authorID").Return(0, errors.New("test"))
 				translationRepo := translation.NewMockRepository(t)
 				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
 				return fields{
 					userRepo:        userRepo,
 					langRepo:        langRepo,
 					tagRepo:         tagRepo,
 					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
 				}
 			},
 			args{cmd: DeleteUser{AuthorID: "authorID"}},
-			3,
+			4,
 			assert.Error,
 		},
 		{
@@ -105,19 +158,34 @@ func TestDeleteUserHandler_Handle(t *testing.T) {
 				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
 				translationRepo := translation.NewMockRepository(t)
 				translationRepo.On("DeleteByAuthorID", "authorID").Return(0, errors.New("test"))
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
 				return fields{
 					userRepo:        userRepo,
 					langRepo:        langRepo,
 					tagRepo:         tagRepo,
 					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
 				}
 			},
 			args{cmd: DeleteUser{AuthorID: "authorID"}},
-			3,
+			4,
 			assert.Error,
 		},
 		{
-			"Everything removed without errors",
+			"Error on revisions delete",
 			func() fields {
 				userRepo := user.NewMockRepository(t)
 				userRepo.On("Delete", "authorID").Return(1, nil)
@@ -127,15 +195,215 @@ func TestDeleteUserHandler_Handle(t *testing.T) {
 				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
 				translationRepo := translation.NewMockRepository(t)
 				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(0, errors.New("test"))
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
 				return fields{
 					userRepo:        userRepo,
 					langRepo:        langRepo,
 					tagRepo:         tagRepo,
 					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
+				}
+			},
+			args{cmd: DeleteUser{AuthorID: "authorID"}},
+			5,
+			assert.Error,
+		},
+		{
+			"Error on trash delete",
+			func() fields {
+				userRepo := user.NewMockRepository(t)
+				userRepo.On("Delete", "authorID").Return(1, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(0, errors.New("test"))
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
+				return fields{
+					userRepo:        userRepo,
+					langRepo:        langRepo,
+					tagRepo:         tagRepo,
+					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
 				}
 			},
 			args{cmd: DeleteUser{AuthorID: "authorID"}},
 			4,
+			assert.Error,
+		},
+		{
+			"Error on sessions delete",
+			func() fields {
+				userRepo := user.NewMockRepository(t)
+				userRepo.On("Delete", "authorID").Return(1, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(0, errors.New("test"))
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
+				return fields{
+					userRepo:        userRepo,
+					langRepo:        langRepo,
+					tagRepo:         tagRepo,
+					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
+				}
+			},
+			args{cmd: DeleteUser{AuthorID: "authorID"}},
+			5,
+			assert.Error,
+		},
+		{
+			"Error on token delete",
+			func() fields {
+				userRepo := user.NewMockRepository(t)
+				userRepo.On("Delete", "authorID").Return(1, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(0, errors.New("test"))
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
+				return fields{
+					userRepo:        userRepo,
+					langRepo:        langRepo,
+					tagRepo:         tagRepo,
+					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
+				}
+			},
+			args{cmd: DeleteUser{AuthorID: "authorID"}},
+			5,
+			assert.Error,
+		},
+		{
+			"Error on attempts delete",
+			func() fields {
+				userRepo := user.NewMockRepository(t)
+				userRepo.On("Delete", "authorID").Return(1, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(errors.New("test"))
+				return fields{
+					userRepo:        userRepo,
+					langRepo:        langRepo,
+					tagRepo:         tagRepo,
+					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
+				}
+			},
+			args{cmd: DeleteUser{AuthorID: "authorID"}},
+			5,
+			assert.Error,
+		},
+		{
+			"Everything removed without errors",
+			func() fields {
+				userRepo := user.NewMockRepository(t)
+				userRepo.On("Delete", "authorID").Return(1, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByAuthorID", "authorID").Return(2, nil)
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("DeleteByAuthorID", "authorID").Return(1, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("DeleteByUserID", "authorID", "").Return(1, nil)
+				tokenRepo := token.NewMockRepository(t)
+				tokenRepo.On("DeleteByUserID", "authorID").Return(1, nil)
+				attemptRepo := user.NewMockAttemptRepository(t)
+				attemptRepo.On("DeleteAttempts", "account:authorID").Return(nil)
+				return fields{
+					userRepo:        userRepo,
+					langRepo:        langRepo,
+					tagRepo:         tagRepo,
+					translationRepo: translationRepo,
+					revisionRepo:    revisionRepo,
+					trashRepo:       trashRepo,
+					sessionRepo:     sessionRepo,
+					tokenRepo:       tokenRepo,
+					attemptRepo:     attemptRepo,
+				}
+			},
+			args{cmd: DeleteUser{AuthorID: "authorID"}},
+			5,
 			assert.NoError,
 		},
 	}
@@ -147,6 +415,11 @@ func TestDeleteUserHandler_Handle(t *testing.T) {
 				langRepo:        f.langRepo,
 				tagRepo:         f.tagRepo,
 				translationRepo: f.translationRepo,
+				revisionRepo:    f.revisionRepo,
+				trashRepo:       f.trashRepo,
+				sessionRepo:     f.sessionRepo,
+				tokenRepo:       f.tokenRepo,
+				attemptRepo:     f.attemptRepo,
 			}
 			got, err := h.Handle(tt.args.cmd)
 			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.cmd)) {
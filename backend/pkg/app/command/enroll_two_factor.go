@@ -0,0 +1,54 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
+)
+
+const twoFactorIssuer = "webdict"
+
+// EnrollTwoFactor starts two-factor authentication enrollment, it is enabled after the first code is confirmed with ConfirmTwoFactor
+type EnrollTwoFactor struct {
+	UserID          string
+	CurrentPassword string // CurrentPassword is required as stolen access token should not be enough to bind a new second factor
+}
+
+// TwoFactorEnrollment secret to be added to authenticator app manually or by scanning QR code of the URI
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type EnrollTwoFactorHandler struct {
+	userRepo user.Repository
+	cipher   Cipher
+}
+
+func NewEnrollTwoFactorHandler(userRepo user.Repository, cipher Cipher) EnrollTwoFactorHandler {
+	return EnrollTwoFactorHandler{userRepo: userRepo, cipher: cipher}
+}
+
+func (h EnrollTwoFactorHandler) Handle(cmd EnrollTwoFactor) (TwoFactorEnrollment, error) {
+	usr, err := h.userRepo.Get(cmd.UserID)
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	if !h.cipher.ComparePasswords(usr.Password(), cmd.CurrentPassword) {
+		return TwoFactorEnrollment{}, errors.New("current password is not valid")
+	}
+
+	secret, err := usr.EnrollTwoFactor()
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	if err = h.userRepo.Update(usr); err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	return TwoFactorEnrollment{
+		Secret: secret,
+		URI:    user.TwoFactorURI(twoFactorIssuer, usr.Email(), secret),
+	}, nil
+}
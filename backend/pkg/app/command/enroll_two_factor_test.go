@@ -0,0 +1,41 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEnrollTwoFactorHandler_Handle(t *testing.T) {
+	usr, err := user.NewUser("test", "test@test.com", "testPasswd", user.Author)
+	assert.Nil(t, err)
+
+	userRepo := user.NewMockRepository(t)
+	userRepo.On("Get", "notExist").Return(nil, user.ErrNotFound)
+	userRepo.On("Get", usr.ID()).Return(usr, nil)
+	userRepo.On("Update", usr).Once().Return(errors.New("testErr"))
+	userRepo.On("Update", usr).Return(nil)
+	cipher := MockCipher{}
+	cipher.On("ComparePasswords", usr.Password(), "wrongPasswd").Return(false)
+	cipher.On("ComparePasswords", usr.Password(), "testPasswd").Return(true)
+
+	h := NewEnrollTwoFactorHandler(userRepo, &cipher)
+	_, err = h.Handle(EnrollTwoFactor{UserID: "notExist", CurrentPassword: "testPasswd"})
+	assert.Equal(t, user.ErrNotFound, err)
+
+	_, err = h.Handle(EnrollTwoFactor{UserID: usr.ID(), CurrentPassword: "wrongPasswd"})
+	assert.Equal(t, "current password is not valid", err.Error())
+	assert.Empty(t, usr.ToMap()["twoFactor"].(map[string]interface{})["secret"])
+
+	_, err = h.Handle(EnrollTwoFactor{UserID: usr.ID(), CurrentPassword: "testPasswd"})
+	assert.Equal(t, "testErr", err.Error())
+
+	enrollment, err := h.Handle(EnrollTwoFactor{UserID: usr.ID(), CurrentPassword: "testPasswd"})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/webdict:test@test.com?"))
+	assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+	assert.False(t, usr.TwoFactorEnabled())
+}
@@ -0,0 +1,423 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"strings"
+	"time"
+)
+
+// ImportMode defines how imported translations are applied to the existing ones with the same source
+type ImportMode string
+
+const (
+	ImportModeMerge   ImportMode = "merge"   // ImportModeMerge adds imported senses with new targets and missing custom field values to the existing translation
+	ImportModeReplace ImportMode = "replace" // ImportModeReplace overrides transcription, senses and custom field values of the existing translation
+)
+
+// ImportDictionary restore previously exported dictionary cmd, langs and tags are referenced by IDs of the exported dictionary
+type ImportDictionary struct {
+	AuthorID     string
+	Mode         ImportMode
+	Langs        []DictionaryEntity
+	Tags         []DictionaryEntity
+	Translations []DictionaryTranslation
+}
+
+// DictionaryEntity exported lang or tag, matched with the existing ones by name
+type DictionaryEntity struct {
+	ID       string
+	Name     string
+	ParentID string        // ParentID dictionary ID of the tag the current one is nested into, nesting is applied to created tags only
+	Metadata lang.Metadata // Metadata of the lang, applied to created langs only
+	Fields   []lang.Field  // Fields schema of the lang translations custom fields, applied to created langs only
+}
+
+// DictionaryTranslation exported translation keeping its timestamps and review state
+type DictionaryTranslation struct {
+	ID            string
+	LangID        string
+	Source        string
+	Transcription string
+	Senses        []TranslationSense
+	Fields        map[string]string // Fields custom field values validated against the schema of the lang the translation is imported to
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Review        DictionaryReview
+}
+
+// DictionaryReview exported review state of translation
+type DictionaryReview struct {
+	Ease        float64
+	Interval    int
+	Repetitions int
+	Lapses      int
+	DueAt       time.Time
+	ReviewedAt  time.Time
+}
+
+// ImportDictionaryResult contains amount of created and updated translations and errors of the failed ones,
+// Line of the failed translation is its 1-based position in the imported dictionary
+type ImportDictionaryResult struct {
+	Created      int
+	Merged       int
+	Replaced     int
+	Unchanged    int
+	CreatedLangs []string
+	CreatedTags  []string
+	Failed       []ImportRowError
+}
+
+// ImportDictionaryHandler import dictionary cmd handler
+type ImportDictionaryHandler struct {
+	translationRepo translation.Repository
+	revisionRepo    translation.RevisionRepository
+	tagRepo         tag.Repository
+	langRepo        lang.Repository
+}
+
+func NewImportDictionaryHandler(
+	translationRepo translation.Repository,
+	revisionRepo translation.RevisionRepository,
+	tagRepo tag.Repository,
+	langRepo lang.Repository,
+) ImportDictionaryHandler {
+	return ImportDictionaryHandler{
+		translationRepo: translationRepo,
+		revisionRepo:    revisionRepo,
+		tagRepo:         tagRepo,
+		langRepo:        langRepo,
+	}
+}
+
+// Handle remaps langs and tags of the dictionary to the existing ones by name creating missing ones,
+// then imports translations one by one, a failed translation does not stop the import
+func (h ImportDictionaryHandler) Handle(cmd ImportDictionary) (ImportDictionaryResult, error) {
+	if cmd.AuthorID == "" {
+		return ImportDictionaryResult{}, errors.New("authorID can not be empty")
+	}
+
+	if cmd.Mode != ImportModeMerge && cmd.Mode != ImportModeReplace {
+		return ImportDictionaryResult{}, fmt.Errorf("unsupported import mode %s", cmd.Mode)
+	}
+
+	result := ImportDictionaryResult{}
+
+	langs, err := h.remapLangs(cmd, &result)
+	if err != nil {
+		return ImportDictionaryResult{}, err
+	}
+
+	tagIDs, err := h.remapTags(cmd, &result)
+	if err != nil {
+		return ImportDictionaryResult{}, err
+	}
+
+	for i, item := range cmd.Translations {
+		if err = h.importTranslation(cmd, item, langs, tagIDs, &result); err != nil {
+			result.Failed = append(result.Failed, ImportRowError{Line: i + 1, Source: item.Source, Err: err})
+		}
+	}
+
+	return result, nil
+}
+
+// remapLangs returns the existing langs by IDs of the dictionary ones, all missing langs are validated before creation
+func (h ImportDictionaryHandler) remapLangs(cmd ImportDictionary, result *ImportDictionaryResult) (map[string]*lang.Lang, error) {
+	langs := map[string]*lang.Lang{}
+	byName := map[string]*lang.Lang{}
+	var missing []*lang.Lang
+
+	for _, entity := range cmd.Langs {
+		name := strings.TrimSpace(entity.Name)
+		if ln, ok := byName[name]; ok {
+			langs[entity.ID] = ln
+			continue
+		}
+
+		ln, err := h.langRepo.GetByName(name, cmd.AuthorID)
+		if err == nil {
+			langs[entity.ID] = ln
+			byName[name] = ln
+			continue
+		}
+
+		if err != lang.ErrNotFound {
+			return nil, err
+		}
+
+		if ln, err = lang.NewLang(name, cmd.AuthorID, entity.Metadata); err != nil {
+			return nil, fmt.Errorf("invalid lang %s: %w", name, err)
+		}
+
+		if err = ln.ApplyFields(entity.Fields); err != nil {
+			return nil, fmt.Errorf("invalid lang %s: %w", name, err)
+		}
+
+		langs[entity.ID] = ln
+		byName[name] = ln
+		missing = append(missing, ln)
+	}
+
+	for _, ln := range missing {
+		if err := h.langRepo.Create(ln); err != nil {
+			return nil, err
+		}
+		result.CreatedLangs = append(result.CreatedLangs, ln.ToMap()["name"].(string))
+	}
+
+	return langs, nil
+}
+
+// remapTags returns IDs of the existing tags by IDs of the dictionary ones, all missing tags are validated before creation
+// and nested into their parents after it
+func (h ImportDictionaryHandler) remapTags(cmd ImportDictionary, result *ImportDictionaryResult) (map[string]string, error) {
+	ids := map[string]string{}
+	byName := map[string]string{}
+	var missing []*tag.Tag
+	var parents []string // parents dictionary IDs of parents of missing tags
+
+	for _, entity := range cmd.Tags {
+		name := strings.TrimSpace(entity.Name)
+		if id, ok := byName[name]; ok {
+			ids[entity.ID] = id
+			continue
+		}
+
+		tg, err := h.tagRepo.GetByName(name, cmd.AuthorID)
+		if err == nil {
+			ids[entity.ID] = tg.ID()
+			byName[name] = tg.ID()
+			continue
+		}
+
+		if err != tag.ErrNotFound {
+			return nil, err
+		}
+
+		if tg, err = tag.NewTag(name, cmd.AuthorID); err != nil {
+			return nil, fmt.Errorf("invalid tag %s: %w", name, err)
+		}
+
+		ids[entity.ID] = tg.ID()
+		byName[name] = tg.ID()
+		missing = append(missing, tg)
+		parents = append(parents, entity.ParentID)
+	}
+
+	for _, parentID := range parents {
+		if _, ok := ids[parentID]; parentID != "" && !ok {
+			return nil, fmt.Errorf("parent tag %s is not found in the dictionary", parentID)
+		}
+	}
+
+	for _, tg := range missing {
+		if err := h.tagRepo.Create(tg); err != nil {
+			return nil, err
+		}
+		result.CreatedTags = append(result.CreatedTags, tg.ToMap()["name"].(string))
+	}
+
+	for i, tg := range missing {
+		if parents[i] == "" {
+			continue
+		}
+
+		if err := moveTag(h.tagRepo, tg, ids[parents[i]]); err != nil {
+			return nil, fmt.Errorf("can not nest tag %s: %w", tg.ToMap()["name"], err)
+		}
+
+		if err := h.tagRepo.Update(tg); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+func (h ImportDictionaryHandler) importTranslation(
+	cmd ImportDictionary,
+	item DictionaryTranslation,
+	langs map[string]*lang.Lang,
+	tagIDs map[string]string,
+	result *ImportDictionaryResult,
+) error {
+	ln, ok := langs[item.LangID]
+	if !ok {
+		return fmt.Errorf("lang %s is not found in the dictionary", item.LangID)
+	}
+
+	if err := ln.ValidateFieldValues(item.Fields); err != nil {
+		return err
+	}
+
+	langID := ln.ID()
+
+	senses, err := h.remapSenses(item.Senses, tagIDs)
+	if err != nil {
+		return err
+	}
+
+	existing, err := h.translationRepo.GetBySource(item.Source, langID, cmd.AuthorID)
+	if err == translation.ErrNotFound {
+		if err = h.create(cmd, item, langID, senses); err != nil {
+			return err
+		}
+		result.Created++
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	changed, err := h.update(cmd.Mode, existing, item, senses)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !changed:
+		result.Unchanged++
+	case cmd.Mode == ImportModeMerge:
+		result.Merged++
+	default:
+		result.Replaced++
+	}
+
+	return nil
+}
+
+func (h ImportDictionaryHandler) remapSenses(senses []TranslationSense, tagIDs map[string]string) ([]TranslationSense, error) {
+	remapped := make([]TranslationSense, 0, len(senses))
+	for _, sense := range senses {
+		ids := make([]string, 0, len(sense.TagIDs))
+		for _, id := range sense.TagIDs {
+			tagID, ok := tagIDs[id]
+			if !ok {
+				return nil, fmt.Errorf("tag %s is not found in the dictionary", id)
+			}
+			ids = append(ids, tagID)
+		}
+		remapped = append(remapped, TranslationSense{Target: sense.Target, Example: sense.Example, TagIDs: ids})
+	}
+
+	return remapped, nil
+}
+
+func (h ImportDictionaryHandler) create(cmd ImportDictionary, item DictionaryTranslation, langID string, senses []TranslationSense) error {
+	review := translation.NewReview(
+		item.Review.Ease,
+		item.Review.Interval,
+		item.Review.Repetitions,
+		item.Review.Lapses,
+		item.Review.DueAt,
+		item.Review.ReviewedAt,
+	)
+
+	tr, err := translation.NewImportedTranslation(
+		item.Source,
+		item.Transcription,
+		toDomainSenses(senses),
+		cmd.AuthorID,
+		langID,
+		item.Fields,
+		item.CreatedAt,
+		item.UpdatedAt,
+		review,
+	)
+	if err != nil {
+		return err
+	}
+
+	return h.translationRepo.Create(tr)
+}
+
+// update applies imported translation to the existing one according to the mode and records the revision,
+// returns false if the existing translation is not changed
+func (h ImportDictionaryHandler) update(mode ImportMode, tr *translation.Translation, item DictionaryTranslation, senses []TranslationSense) (bool, error) {
+	before := *tr
+	data := tr.ToMap()
+	transcription := item.Transcription
+	domainSenses := toDomainSenses(senses)
+	fields := item.Fields
+
+	if mode == ImportModeMerge {
+		if current := data["transcription"].(string); current != "" {
+			transcription = current
+		}
+		domainSenses = mergeSenses(tr.Senses(), domainSenses)
+		fields = mergeFields(tr.Fields(), item.Fields)
+	}
+
+	if err := tr.ApplyChanges(data["source"].(string), transcription, domainSenses, tr.LangID()); err != nil {
+		return false, err
+	}
+
+	if err := tr.ApplyFields(fields); err != nil {
+		return false, err
+	}
+
+	revision, changed := translation.NewRevision(&before, tr)
+	if !changed {
+		return false, nil
+	}
+
+	if err := h.translationRepo.Update(tr); err != nil {
+		return false, err
+	}
+
+	return true, h.revisionRepo.Create(revision)
+}
+
+// mergeSenses adds imported senses with new targets to the existing ones, tags of the senses with the same target are united
+func mergeSenses(existing, imported []translation.Sense) []translation.Sense {
+	merged := make([]translation.Sense, 0, len(existing)+len(imported))
+	merged = append(merged, existing...)
+
+	for _, sense := range imported {
+		i := 0
+		for i < len(merged) && merged[i].Target() != sense.Target() {
+			i++
+		}
+
+		if i == len(merged) {
+			merged = append(merged, sense)
+			continue
+		}
+
+		tagIDs := append([]string{}, merged[i].TagIDs()...)
+		for _, tagID := range sense.TagIDs() {
+			if !containsString(tagIDs, tagID) {
+				tagIDs = append(tagIDs, tagID)
+			}
+		}
+		merged[i] = translation.NewSense(merged[i].Target(), merged[i].Example(), tagIDs)
+	}
+
+	return merged
+}
+
+// mergeFields adds imported custom field values to the existing ones, the existing values are kept
+func mergeFields(existing, imported map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(imported))
+	for name, value := range imported {
+		merged[name] = value
+	}
+	for name, value := range existing {
+		merged[name] = value
+	}
+	return merged
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
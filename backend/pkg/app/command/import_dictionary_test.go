@@ -0,0 +1,257 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestImportDictionaryHandler_Handle_NegativeCases(t *testing.T) {
+	h := NewImportDictionaryHandler(&translation.MockRepository{}, &translation.MockRevisionRepository{}, &tag.MockRepository{}, &lang.MockRepository{})
+
+	_, err := h.Handle(ImportDictionary{Mode: ImportModeMerge})
+	assert.Error(t, err)
+
+	_, err = h.Handle(ImportDictionary{AuthorID: "testAuthor", Mode: "append"})
+	assert.Error(t, err)
+
+	langRepo := lang.MockRepository{}
+	langRepo.On("GetByName", "", "testAuthor").Return(nil, lang.ErrNotFound).Once()
+	h = NewImportDictionaryHandler(&translation.MockRepository{}, &translation.MockRevisionRepository{}, &tag.MockRepository{}, &langRepo)
+
+	_, err = h.Handle(ImportDictionary{AuthorID: "testAuthor", Mode: ImportModeMerge, Langs: []DictionaryEntity{{ID: "lang1"}}})
+	assert.Error(t, err)
+	langRepo.AssertExpectations(t)
+}
+
+func TestImportDictionaryHandler_Handle(t *testing.T) {
+	authorID := "testAuthor"
+	createdAt := time.Now().Add(-time.Hour)
+
+	newTranslation := func() *translation.Translation {
+		return translation.UnmarshalFromDB(
+			"existing",
+			"go",
+			"",
+			[]translation.Sense{translation.NewSense("gehen", "", []string{"tagVerb"})},
+			authorID,
+			createdAt,
+			createdAt,
+			"langEN",
+			translation.Review{},
+			nil,
+		)
+	}
+
+	tests := []struct {
+		name         string
+		mode         ImportMode
+		wantMerged   int
+		wantReplaced int
+		wantTargets  []string
+		wantTagsFn   func(a1TagID string) []string
+	}{
+		{
+			"Merge senses with the existing translation",
+			ImportModeMerge,
+			1,
+			0,
+			[]string{"gehen", "laufen"},
+			func(a1TagID string) []string {
+				return []string{"tagVerb", a1TagID}
+			},
+		},
+		{
+			"Replace senses of the existing translation",
+			ImportModeReplace,
+			0,
+			1,
+			[]string{"gehen", "laufen"},
+			func(a1TagID string) []string {
+				return []string{a1TagID}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := newTranslation()
+			var created *translation.Translation
+			var createdTag *tag.Tag
+
+			translationRepo := translation.MockRepository{}
+			translationRepo.On("GetBySource", "go", "langEN", authorID).Return(existing, nil).Once()
+			translationRepo.On("GetBySource", "Hund", "langEN", authorID).Return(nil, translation.ErrNotFound).Once()
+			translationRepo.On("Create", mock.AnythingOfType("*translation.Translation")).Run(func(args mock.Arguments) {
+				created = args.Get(0).(*translation.Translation)
+			}).Return(nil).Once()
+			translationRepo.On("Update", existing).Return(nil).Once()
+			revisionRepo := translation.MockRevisionRepository{}
+			revisionRepo.On("Create", mock.AnythingOfType("*translation.Revision")).Return(nil).Once()
+			tagRepo := tag.MockRepository{}
+			tagRepo.On("GetByName", "verb", authorID).Return(tag.UnmarshalFromDB("tagVerb", "verb", authorID, ""), nil).Once()
+			tagRepo.On("GetByName", "A1", authorID).Return(nil, tag.ErrNotFound).Once()
+			tagRepo.On("Create", mock.AnythingOfType("*tag.Tag")).Run(func(args mock.Arguments) {
+				createdTag = args.Get(0).(*tag.Tag)
+			}).Return(nil).Once()
+			langRepo := lang.MockRepository{}
+			langRepo.On("GetByName", "EN", authorID).Return(lang.UnmarshalFromDB("langEN", "EN", authorID, lang.Metadata{}, nil), nil).Once()
+
+			h := NewImportDictionaryHandler(&translationRepo, &revisionRepo, &tagRepo, &langRepo)
+			got, err := h.Handle(ImportDictionary{
+				AuthorID: authorID,
+				Mode:     tt.mode,
+				Langs:    []DictionaryEntity{{ID: "exportedEN", Name: "EN"}},
+				Tags:     []DictionaryEntity{{ID: "exportedVerb", Name: "verb"}, {ID: "exportedA1", Name: "A1"}},
+				Translations: []DictionaryTranslation{
+					{
+						LangID: "exportedEN",
+						Source: "go",
+						Senses: []TranslationSense{
+							{Target: "gehen", Example: "let's go", TagIDs: []string{"exportedA1"}},
+							{Target: "laufen"},
+						},
+					},
+					{
+						LangID:    "exportedEN",
+						Source:    "Hund",
+						Senses:    []TranslationSense{{Target: "dog", TagIDs: []string{"exportedVerb"}}},
+						CreatedAt: createdAt,
+						Review:    DictionaryReview{Ease: 2.2, Interval: 3, Repetitions: 2, DueAt: createdAt, ReviewedAt: createdAt},
+					},
+					{LangID: "exportedDE", Source: "Katze", Senses: []TranslationSense{{Target: "cat"}}},
+					{LangID: "exportedEN", Source: "Maus", Senses: []TranslationSense{{Target: "mouse", TagIDs: []string{"exportedB2"}}}},
+				},
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, 1, got.Created)
+			assert.Equal(t, tt.wantMerged, got.Merged)
+			assert.Equal(t, tt.wantReplaced, got.Replaced)
+			assert.Equal(t, []string{"A1"}, got.CreatedTags)
+			assert.Nil(t, got.CreatedLangs)
+			assert.Equal(t, 2, len(got.Failed))
+			assert.Equal(t, 3, got.Failed[0].Line)
+			assert.Equal(t, 4, got.Failed[1].Line)
+
+			senses := existing.Senses()
+			assert.Equal(t, len(tt.wantTargets), len(senses))
+			for i, target := range tt.wantTargets {
+				assert.Equal(t, target, senses[i].Target())
+			}
+			assert.Equal(t, tt.wantTagsFn(createdTag.ID()), senses[0].TagIDs())
+
+			assert.Equal(t, createdAt, created.ToMap()["createdAt"])
+			assert.Equal(t, createdAt, created.ToMap()["updatedAt"])
+			assert.Equal(t, []string{"tagVerb"}, created.TagIDs())
+			assert.Equal(t, "langEN", created.LangID())
+			review := created.Review()
+			assert.Equal(t, createdAt, review.DueAt())
+
+			translationRepo.AssertExpectations(t)
+			revisionRepo.AssertExpectations(t)
+			tagRepo.AssertExpectations(t)
+			langRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportDictionaryHandler_Handle_Unchanged(t *testing.T) {
+	authorID := "testAuthor"
+	existing := translation.UnmarshalFromDB(
+		"existing",
+		"go",
+		"",
+		[]translation.Sense{translation.NewSense("gehen", "", nil)},
+		authorID,
+		time.Now(),
+		time.Now(),
+		"langEN",
+		translation.Review{},
+		nil,
+	)
+
+	translationRepo := translation.MockRepository{}
+	translationRepo.On("GetBySource", "go", "langEN", authorID).Return(existing, nil).Once()
+	langRepo := lang.MockRepository{}
+	langRepo.On("GetByName", "EN", authorID).Return(lang.UnmarshalFromDB("langEN", "EN", authorID, lang.Metadata{}, nil), nil).Once()
+
+	h := NewImportDictionaryHandler(&translationRepo, &translation.MockRevisionRepository{}, &tag.MockRepository{}, &langRepo)
+	got, err := h.Handle(ImportDictionary{
+		AuthorID:     authorID,
+		Mode:         ImportModeMerge,
+		Langs:        []DictionaryEntity{{ID: "exportedEN", Name: "EN"}},
+		Translations: []DictionaryTranslation{{LangID: "exportedEN", Source: "go", Senses: []TranslationSense{{Target: "gehen"}}}},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, got.Unchanged)
+	assert.Equal(t, 0, got.Merged)
+	translationRepo.AssertExpectations(t)
+}
+
+func TestImportDictionaryHandler_Handle_Fields(t *testing.T) {
+	authorID := "testAuthor"
+	existing := translation.UnmarshalFromDB(
+		"existing",
+		"go",
+		"",
+		[]translation.Sense{translation.NewSense("gehen", "", nil)},
+		authorID,
+		time.Now(),
+		time.Now(),
+		"langEN",
+		translation.Review{},
+		map[string]string{"irregular": "true"},
+	)
+	var created *translation.Translation
+	var createdLang *lang.Lang
+
+	translationRepo := translation.MockRepository{}
+	translationRepo.On("GetBySource", "go", "langEN", authorID).Return(existing, nil).Once()
+	translationRepo.On("GetBySource", "Hund", mock.AnythingOfType("string"), authorID).Return(nil, translation.ErrNotFound).Once()
+	translationRepo.On("Create", mock.AnythingOfType("*translation.Translation")).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*translation.Translation)
+	}).Return(nil).Once()
+	translationRepo.On("Update", existing).Return(nil).Once()
+	revisionRepo := translation.MockRevisionRepository{}
+	revisionRepo.On("Create", mock.AnythingOfType("*translation.Revision")).Return(nil).Once()
+	langRepo := lang.MockRepository{}
+	langRepo.On("GetByName", "EN", authorID).Return(lang.UnmarshalFromDB("langEN", "EN", authorID, lang.Metadata{}, []lang.Field{
+		lang.NewField("irregular", lang.BooleanField, nil),
+		lang.NewField("past", lang.TextField, nil),
+	}), nil).Once()
+	langRepo.On("GetByName", "DE", authorID).Return(nil, lang.ErrNotFound).Once()
+	langRepo.On("Create", mock.AnythingOfType("*lang.Lang")).Run(func(args mock.Arguments) {
+		createdLang = args.Get(0).(*lang.Lang)
+	}).Return(nil).Once()
+
+	h := NewImportDictionaryHandler(&translationRepo, &revisionRepo, &tag.MockRepository{}, &langRepo)
+	got, err := h.Handle(ImportDictionary{
+		AuthorID: authorID,
+		Mode:     ImportModeMerge,
+		Langs: []DictionaryEntity{
+			{ID: "exportedEN", Name: "EN"},
+			{ID: "exportedDE", Name: "DE", Fields: []lang.Field{lang.NewField("gender", lang.EnumField, []string{"m", "f", "n"})}},
+		},
+		Translations: []DictionaryTranslation{
+			{LangID: "exportedEN", Source: "go", Senses: []TranslationSense{{Target: "gehen"}}, Fields: map[string]string{"irregular": "false", "past": "went"}},
+			{LangID: "exportedDE", Source: "Hund", Senses: []TranslationSense{{Target: "dog"}}, Fields: map[string]string{"gender": "m"}},
+			{LangID: "exportedDE", Source: "Katze", Senses: []TranslationSense{{Target: "cat"}}, Fields: map[string]string{"plural": "Katzen"}},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, got.Created)
+	assert.Equal(t, 1, got.Merged)
+	assert.Equal(t, 1, len(got.Failed))
+	assert.Equal(t, 3, got.Failed[0].Line)
+	assert.Equal(t, []lang.Field{lang.NewField("gender", lang.EnumField, []string{"m", "f", "n"})}, createdLang.Fields())
+	assert.Equal(t, createdLang.ID(), created.LangID())
+	assert.Equal(t, map[string]string{"gender": "m"}, created.Fields())
+	assert.Equal(t, map[string]string{"irregular": "true", "past": "went"}, existing.Fields())
+}
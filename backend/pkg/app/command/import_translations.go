@@ -0,0 +1,292 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"strings"
+)
+
+// maxImportRows limits amount of rows imported by single cmd
+const maxImportRows = 5000
+
+// ImportTranslations import translations from rows of an external word list cmd
+type ImportTranslations struct {
+	AuthorID      string
+	LangID        string // LangID is used for rows without lang name
+	Rows          []ImportRow
+	CreateMissing bool // CreateMissing creates tags and langs which are not found by name
+	DryRun        bool // DryRun validates all rows without saving anything
+}
+
+// ImportRow single translation of the imported list, tags and lang are referenced by names
+type ImportRow struct {
+	Line          int
+	Source        string
+	Transcription string
+	Target        string
+	Example       string
+	Tags          []string
+	Lang          string
+}
+
+// ImportResult contains amount of imported rows and errors of the failed ones,
+// on dry run it describes what would be done
+type ImportResult struct {
+	Imported     int
+	CreatedTags  []string
+	CreatedLangs []string
+	Failed       []ImportRowError
+}
+
+// ImportRowError describes why the row was not imported
+type ImportRowError struct {
+	Line   int
+	Source string
+	Err    error
+}
+
+// ImportTranslationsHandler import translations cmd handler, every row is validated as AddTranslation cmd
+type ImportTranslationsHandler struct {
+	addTranslation  AddTranslationHandler
+	translationRepo translation.Repository
+	tagRepo         tag.Repository
+	langRepo        lang.Repository
+}
+
+func NewImportTranslationsHandler(translationRepo translation.Repository, tagRepo tag.Repository, langRepo lang.Repository) ImportTranslationsHandler {
+	return ImportTranslationsHandler{
+		addTranslation:  NewAddTranslationHandler(translationRepo, tagRepo, langRepo),
+		translationRepo: translationRepo,
+		tagRepo:         tagRepo,
+		langRepo:        langRepo,
+	}
+}
+
+// importState keeps names resolved during the import, pending IDs belong to not saved on dry run tags and langs
+type importState struct {
+	result       ImportResult
+	tagIDs       map[string]string
+	langIDs      map[string]string
+	missingTags  map[string]struct{}
+	missingLangs map[string]struct{}
+	pending      map[string]struct{}
+	sources      map[string]struct{}
+}
+
+// Handle performs import of all cmd rows, a failed row does not stop the import
+func (h ImportTranslationsHandler) Handle(cmd ImportTranslations) (ImportResult, error) {
+	if cmd.AuthorID == "" {
+		return ImportResult{}, errors.New("authorID can not be empty")
+	}
+
+	if len(cmd.Rows) > maxImportRows {
+		return ImportResult{}, fmt.Errorf("max amount of imported rows is %d, %d passed", maxImportRows, len(cmd.Rows))
+	}
+
+	state := importState{
+		tagIDs:       map[string]string{},
+		langIDs:      map[string]string{},
+		missingTags:  map[string]struct{}{},
+		missingLangs: map[string]struct{}{},
+		pending:      map[string]struct{}{},
+		sources:      map[string]struct{}{},
+	}
+
+	for _, row := range cmd.Rows {
+		if err := h.importRow(cmd, row, &state); err != nil {
+			state.result.Failed = append(state.result.Failed, ImportRowError{Line: row.Line, Source: row.Source, Err: err})
+			continue
+		}
+		state.result.Imported++
+	}
+
+	return state.result, nil
+}
+
+func (h ImportTranslationsHandler) importRow(cmd ImportTranslations, row ImportRow, state *importState) error {
+	langID, err := h.resolveLang(cmd, row, state)
+	if err != nil {
+		return err
+	}
+
+	tagIDs, err := h.resolveTags(cmd, row, state)
+	if err != nil {
+		return err
+	}
+
+	addCmd := AddTranslation{
+		Source:        strings.TrimSpace(row.Source),
+		Transcription: strings.TrimSpace(row.Transcription),
+		Senses:        []TranslationSense{{Target: strings.TrimSpace(row.Target), Example: strings.TrimSpace(row.Example), TagIDs: tagIDs}},
+		AuthorID:      cmd.AuthorID,
+		LangID:        langID,
+	}
+
+	sourceKey := langID + "\x00" + addCmd.Source
+	if _, ok := state.sources[sourceKey]; ok {
+		return translation.ErrSourceAlreadyExists
+	}
+
+	if cmd.DryRun {
+		err = h.validateRow(addCmd, state)
+	} else {
+		_, err = h.addTranslation.Handle(addCmd)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	state.sources[sourceKey] = struct{}{}
+	return nil
+}
+
+// validateRow performs the same checks as saving of the row on dry run,
+// store checks are skipped for rows referencing tags or langs which would be created
+func (h ImportTranslationsHandler) validateRow(cmd AddTranslation, state *importState) error {
+	if h.hasPending(cmd, state) {
+		_, err := translation.NewTranslation(cmd.Source, cmd.Transcription, toDomainSenses(cmd.Senses), cmd.AuthorID, cmd.LangID)
+		return err
+	}
+
+	if _, err := h.addTranslation.newTranslation(cmd); err != nil {
+		return err
+	}
+
+	exist, err := h.translationRepo.ExistBySource(cmd.Source, cmd.LangID, cmd.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		return translation.ErrSourceAlreadyExists
+	}
+
+	return nil
+}
+
+func (h ImportTranslationsHandler) hasPending(cmd AddTranslation, state *importState) bool {
+	if _, ok := state.pending[cmd.LangID]; ok {
+		return true
+	}
+
+	for _, tagID := range sensesTagIDs(cmd.Senses) {
+		if _, ok := state.pending[tagID]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveLang returns ID of the row lang found by name, rows without lang name use cmd lang
+func (h ImportTranslationsHandler) resolveLang(cmd ImportTranslations, row ImportRow, state *importState) (string, error) {
+	name := strings.TrimSpace(row.Lang)
+	if name == "" {
+		if cmd.LangID == "" {
+			return "", errors.New("lang is not set")
+		}
+		return cmd.LangID, nil
+	}
+
+	if id, ok := state.langIDs[name]; ok {
+		return id, nil
+	}
+
+	if _, ok := state.missingLangs[name]; ok {
+		return "", fmt.Errorf("lang %s is not found", name)
+	}
+
+	ln, err := h.langRepo.GetByName(name, cmd.AuthorID)
+	if err == nil {
+		state.langIDs[name] = ln.ID()
+		return ln.ID(), nil
+	}
+
+	if err != lang.ErrNotFound {
+		return "", err
+	}
+
+	if !cmd.CreateMissing {
+		state.missingLangs[name] = struct{}{}
+		return "", fmt.Errorf("lang %s is not found", name)
+	}
+
+	if ln, err = lang.NewLang(name, cmd.AuthorID, lang.Metadata{}); err != nil {
+		return "", err
+	}
+
+	if cmd.DryRun {
+		state.pending[ln.ID()] = struct{}{}
+	} else if err = h.langRepo.Create(ln); err != nil {
+		return "", err
+	}
+
+	state.langIDs[name] = ln.ID()
+	state.result.CreatedLangs = append(state.result.CreatedLangs, name)
+	return ln.ID(), nil
+}
+
+// resolveTags returns IDs of the row tags found by names, empty and repeated names are skipped
+func (h ImportTranslationsHandler) resolveTags(cmd ImportTranslations, row ImportRow, state *importState) ([]string, error) {
+	var tagIDs []string
+	seen := map[string]struct{}{}
+
+	for _, name := range row.Tags {
+		name = strings.TrimSpace(name)
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		id, err := h.resolveTag(cmd, name, state)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, id)
+	}
+
+	return tagIDs, nil
+}
+
+func (h ImportTranslationsHandler) resolveTag(cmd ImportTranslations, name string, state *importState) (string, error) {
+	if id, ok := state.tagIDs[name]; ok {
+		return id, nil
+	}
+
+	if _, ok := state.missingTags[name]; ok {
+		return "", fmt.Errorf("tag %s is not found", name)
+	}
+
+	tg, err := h.tagRepo.GetByName(name, cmd.AuthorID)
+	if err == nil {
+		state.tagIDs[name] = tg.ID()
+		return tg.ID(), nil
+	}
+
+	if err != tag.ErrNotFound {
+		return "", err
+	}
+
+	if !cmd.CreateMissing {
+		state.missingTags[name] = struct{}{}
+		return "", fmt.Errorf("tag %s is not found", name)
+	}
+
+	if tg, err = tag.NewTag(name, cmd.AuthorID); err != nil {
+		return "", err
+	}
+
+	if cmd.DryRun {
+		state.pending[tg.ID()] = struct{}{}
+	} else if err = h.tagRepo.Create(tg); err != nil {
+		return "", err
+	}
+
+	state.tagIDs[name] = tg.ID()
+	state.result.CreatedTags = append(state.result.CreatedTags, name)
+	return tg.ID(), nil
+}
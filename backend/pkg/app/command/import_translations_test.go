@@ -0,0 +1,127 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+)
+
+func TestImportTranslationsHandler_Handle_NegativeCases(t *testing.T) {
+	h := NewImportTranslationsHandler(&translation.MockRepository{}, &tag.MockRepository{}, &lang.MockRepository{})
+
+	_, err := h.Handle(ImportTranslations{Rows: []ImportRow{{Source: "test", Target: "test"}}})
+	assert.Error(t, err)
+
+	_, err = h.Handle(ImportTranslations{AuthorID: "testAuthor", Rows: make([]ImportRow, maxImportRows+1)})
+	assert.Error(t, err)
+}
+
+func TestImportTranslationsHandler_Handle(t *testing.T) {
+	authorID := "testAuthor"
+	existingLang := lang.UnmarshalFromDB("langEN", "EN", authorID, lang.Metadata{}, nil)
+
+	translationRepo := translation.MockRepository{}
+	translationRepo.On("Create", mock.AnythingOfType("*translation.Translation")).Return(nil).Times(3)
+	translationRepo.On("Create", mock.AnythingOfType("*translation.Translation")).Return(translation.ErrSourceAlreadyExists).Once()
+	tagRepo := tag.MockRepository{}
+	tagRepo.On("GetByName", "verb", authorID).Return(nil, tag.ErrNotFound).Once()
+	tagRepo.On("Create", mock.AnythingOfType("*tag.Tag")).Return(nil).Once()
+	tagRepo.On("AllExist", mock.Anything, authorID).Return(true, nil)
+	langRepo := lang.MockRepository{}
+	langRepo.On("GetByName", "EN", authorID).Return(existingLang, nil).Once()
+	langRepo.On("GetByName", "DE", authorID).Return(nil, lang.ErrNotFound).Once()
+	langRepo.On("Create", mock.AnythingOfType("*lang.Lang")).Return(nil).Once()
+	langRepo.On("Exist", mock.Anything, authorID).Return(true, nil)
+
+	h := NewImportTranslationsHandler(&translationRepo, &tagRepo, &langRepo)
+	got, err := h.Handle(ImportTranslations{
+		AuthorID:      authorID,
+		CreateMissing: true,
+		Rows: []ImportRow{
+			{Line: 1, Source: "go", Target: "gehen", Tags: []string{"verb", " verb ", ""}, Lang: "EN"},
+			{Line: 2, Source: "go ", Target: "gehen", Lang: "EN"},
+			{Line: 3, Source: "Hund", Target: "dog", Lang: "DE"},
+			{Line: 4, Source: strings.Repeat("a", 256), Target: "test", Lang: "EN"},
+			{Line: 5, Source: "run", Target: "laufen", Tags: []string{"verb"}, Lang: "EN"},
+			{Line: 6, Source: "existing", Target: "test", Lang: "EN"},
+			{Line: 7, Source: "no lang", Target: "test"},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, got.Imported)
+	assert.Equal(t, []string{"verb"}, got.CreatedTags)
+	assert.Equal(t, []string{"DE"}, got.CreatedLangs)
+	assert.Equal(t, 4, len(got.Failed))
+	assert.Equal(t, 2, got.Failed[0].Line)
+	assert.Equal(t, translation.ErrSourceAlreadyExists, got.Failed[0].Err)
+	assert.Equal(t, 4, got.Failed[1].Line)
+	assert.Equal(t, 6, got.Failed[2].Line)
+	assert.Equal(t, translation.ErrSourceAlreadyExists, got.Failed[2].Err)
+	assert.Equal(t, 7, got.Failed[3].Line)
+	translationRepo.AssertExpectations(t)
+	tagRepo.AssertExpectations(t)
+	langRepo.AssertExpectations(t)
+}
+
+func TestImportTranslationsHandler_Handle_DryRun(t *testing.T) {
+	authorID := "testAuthor"
+
+	translationRepo := translation.MockRepository{}
+	translationRepo.On("ExistBySource", "go", "langEN", authorID).Return(false, nil).Once()
+	translationRepo.On("ExistBySource", "existing", "langEN", authorID).Return(true, nil).Once()
+	tagRepo := tag.MockRepository{}
+	tagRepo.On("GetByName", "verb", authorID).Return(nil, tag.ErrNotFound).Once()
+	langRepo := lang.MockRepository{}
+	langRepo.On("Exist", "langEN", authorID).Return(true, nil)
+
+	h := NewImportTranslationsHandler(&translationRepo, &tagRepo, &langRepo)
+	got, err := h.Handle(ImportTranslations{
+		AuthorID:      authorID,
+		LangID:        "langEN",
+		CreateMissing: true,
+		DryRun:        true,
+		Rows: []ImportRow{
+			{Line: 1, Source: "go", Target: "gehen"},
+			{Line: 2, Source: "run", Target: "laufen", Tags: []string{"verb"}},
+			{Line: 3, Source: "run", Target: "rennen"},
+			{Line: 4, Source: "existing", Target: "test"},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, got.Imported)
+	assert.Equal(t, []string{"verb"}, got.CreatedTags)
+	assert.Equal(t, []ImportRowError{
+		{Line: 3, Source: "run", Err: translation.ErrSourceAlreadyExists},
+		{Line: 4, Source: "existing", Err: translation.ErrSourceAlreadyExists},
+	}, got.Failed)
+	translationRepo.AssertExpectations(t)
+	tagRepo.AssertExpectations(t)
+	langRepo.AssertExpectations(t)
+}
+
+func TestImportTranslationsHandler_Handle_MissingTag(t *testing.T) {
+	tagRepo := tag.MockRepository{}
+	tagRepo.On("GetByName", "verb", "testAuthor").Return(nil, tag.ErrNotFound).Once()
+
+	h := NewImportTranslationsHandler(&translation.MockRepository{}, &tagRepo, &lang.MockRepository{})
+	got, err := h.Handle(ImportTranslations{
+		AuthorID: "testAuthor",
+		LangID:   "langEN",
+		Rows: []ImportRow{
+			{Line: 1, Source: "go", Target: "gehen", Tags: []string{"verb"}},
+			{Line: 2, Source: "run", Target: "laufen", Tags: []string{"verb"}},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, got.Imported)
+	assert.Equal(t, 2, len(got.Failed))
+	assert.Equal(t, "tag verb is not found", got.Failed[1].Err.Error())
+	tagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+)
+
+// MergeTags merge source tag into target one cmd
+type MergeTags struct {
+	SourceID string
+	TargetID string
+	AuthorID string
+}
+
+// MergeTagsHandler merge tags cmd handler
+type MergeTagsHandler struct {
+	tagRepo         tag.Repository
+	translationRepo translation.Repository
+}
+
+func NewMergeTagsHandler(tagRepo tag.Repository, translationRepo translation.Repository) MergeTagsHandler {
+	return MergeTagsHandler{tagRepo: tagRepo, translationRepo: translationRepo}
+}
+
+// Handle retags all translations tagged by the source tag with the target one, nests source tag children into the target
+// and removes the source tag, returns amount of retagged translations
+func (h MergeTagsHandler) Handle(cmd MergeTags) (int, error) {
+	if cmd.SourceID == cmd.TargetID {
+		return 0, fmt.Errorf("tag can not be merged into itself")
+	}
+
+	source, err := h.tagRepo.Get(cmd.SourceID, cmd.AuthorID)
+	if err != nil {
+		return 0, fmt.Errorf("can not get source tag %s: %w", cmd.SourceID, err)
+	}
+
+	target, err := h.tagRepo.Get(cmd.TargetID, cmd.AuthorID)
+	if err != nil {
+		return 0, fmt.Errorf("can not get target tag %s: %w", cmd.TargetID, err)
+	}
+
+	ancestorIDs, err := tagAncestorIDs(h.tagRepo, target)
+	if err != nil {
+		return 0, err
+	}
+
+	if containsString(ancestorIDs, source.ID()) {
+		return 0, fmt.Errorf("tag can not be merged into the tag nested into it: %w", tag.ErrCycle)
+	}
+
+	count, err := h.translationRepo.ReplaceTag(source.ID(), target.ID(), cmd.AuthorID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = h.tagRepo.MoveChildren(source.ID(), target.ID(), cmd.AuthorID); err != nil {
+		return count, err
+	}
+
+	return count, h.tagRepo.Delete(source.ID(), cmd.AuthorID)
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMergeTagsHandler_Handle(t *testing.T) {
+	type fields struct {
+		tagRepo         tag.Repository
+		translationRepo translation.Repository
+	}
+	type args struct {
+		cmd MergeTags
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     int
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on merging tag into itself",
+			func() fields {
+				return fields{tagRepo: &tag.MockRepository{}, translationRepo: &translation.MockRepository{}}
+			},
+			args{cmd: MergeTags{SourceID: "source", TargetID: "source", AuthorID: "testAuthor"}},
+			0,
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "tag can not be merged into itself", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Error on missing target tag",
+			func() fields {
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("Get", "source", "testAuthor").Return(tag.UnmarshalFromDB("source", "verbs", "testAuthor", ""), nil)
+				tagRepo.On("Get", "target", "testAuthor").Return(nil, tag.ErrNotFound)
+				return fields{tagRepo: &tagRepo, translationRepo: &translation.MockRepository{}}
+			},
+			args{cmd: MergeTags{SourceID: "source", TargetID: "target", AuthorID: "testAuthor"}},
+			0,
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, tag.ErrNotFound, i)
+				return true
+			},
+		},
+		{
+			"Error on merging tag into its descendant",
+			func() fields {
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("Get", "source", "testAuthor").Return(tag.UnmarshalFromDB("source", "verbs", "testAuthor", ""), nil)
+				tagRepo.On("Get", "target", "testAuthor").Return(tag.UnmarshalFromDB("target", "verb", "testAuthor", "middle"), nil)
+				tagRepo.On("Get", "middle", "testAuthor").Return(tag.UnmarshalFromDB("middle", "middle", "testAuthor", "source"), nil)
+				return fields{tagRepo: &tagRepo, translationRepo: &translation.MockRepository{}}
+			},
+			args{cmd: MergeTags{SourceID: "source", TargetID: "target", AuthorID: "testAuthor"}},
+			0,
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, tag.ErrCycle, i)
+				return true
+			},
+		},
+		{
+			"Error on retagging translations",
+			func() fields {
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("Get", "source", "testAuthor").Return(tag.UnmarshalFromDB("source", "verbs", "testAuthor", ""), nil)
+				tagRepo.On("Get", "target", "testAuthor").Return(tag.UnmarshalFromDB("target", "verb", "testAuthor", ""), nil)
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("ReplaceTag", "source", "target", "testAuthor").Return(0, errors.New("testError"))
+				return fields{tagRepo: &tagRepo, translationRepo: &translationRepo}
+			},
+			args{cmd: MergeTags{SourceID: "source", TargetID: "target", AuthorID: "testAuthor"}},
+			0,
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testError", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Positive case",
+			func() fields {
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("Get", "source", "testAuthor").Return(tag.UnmarshalFromDB("source", "verbs", "testAuthor", ""), nil)
+				tagRepo.On("Get", "target", "testAuthor").Return(tag.UnmarshalFromDB("target", "verb", "testAuthor", ""), nil)
+				tagRepo.On("MoveChildren", "source", "target", "testAuthor").Return(nil)
+				tagRepo.On("Delete", "source", "testAuthor").Return(nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("ReplaceTag", "source", "target", "testAuthor").Return(3, nil)
+				return fields{tagRepo: tagRepo, translationRepo: translationRepo}
+			},
+			args{cmd: MergeTags{SourceID: "source", TargetID: "target", AuthorID: "testAuthor"}},
+			3,
+			assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := NewMergeTagsHandler(f.tagRepo, f.translationRepo)
+			got, err := h.Handle(tt.args.cmd)
+			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.cmd)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "Handle(%v)", tt.args.cmd)
+		})
+	}
+}
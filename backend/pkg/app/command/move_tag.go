@@ -0,0 +1,41 @@
+package command
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+)
+
+// moveTag nests the tag into the parent with passed ID, empty parentID makes the tag a root one
+func moveTag(tagRepo tag.Repository, tg *tag.Tag, parentID string) error {
+	if parentID == "" {
+		return tg.MoveTo(nil, nil)
+	}
+
+	parent, err := tagRepo.Get(parentID, tg.AuthorID())
+	if err != nil {
+		return fmt.Errorf("can not get parent tag %s: %w", parentID, err)
+	}
+
+	ancestorIDs, err := tagAncestorIDs(tagRepo, parent)
+	if err != nil {
+		return err
+	}
+
+	return tg.MoveTo(parent, ancestorIDs)
+}
+
+// tagAncestorIDs returns IDs of all tags the tag is nested into starting from its parent
+func tagAncestorIDs(tagRepo tag.Repository, tg *tag.Tag) ([]string, error) {
+	var ancestorIDs []string
+	for ancestorID := tg.ParentID(); ancestorID != "" && !containsString(ancestorIDs, ancestorID); {
+		ancestorIDs = append(ancestorIDs, ancestorID)
+
+		ancestor, err := tagRepo.Get(ancestorID, tg.AuthorID())
+		if err != nil {
+			return nil, fmt.Errorf("can not get ancestor tag %s: %w", ancestorID, err)
+		}
+		ancestorID = ancestor.ParentID()
+	}
+
+	return ancestorIDs, nil
+}
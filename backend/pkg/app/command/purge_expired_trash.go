@@ -0,0 +1,35 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"time"
+)
+
+// PurgeExpiredTrash permanently removes trash items of all authors deleted before passed time
+type PurgeExpiredTrash struct {
+	DeletedBefore time.Time
+}
+
+// PurgeExpiredTrashHandler purge expired trash cmd handler
+type PurgeExpiredTrashHandler struct {
+	trashRepo trash.Repository
+	purger    trashPurger
+}
+
+func NewPurgeExpiredTrashHandler(trashRepo trash.Repository, revisionRepo translation.RevisionRepository) PurgeExpiredTrashHandler {
+	return PurgeExpiredTrashHandler{
+		trashRepo: trashRepo,
+		purger:    newTrashPurger(trashRepo, revisionRepo),
+	}
+}
+
+// Handle returns amount of purged trash items
+func (h PurgeExpiredTrashHandler) Handle(cmd PurgeExpiredTrash) (int, error) {
+	items, err := h.trashRepo.GetDeletedBefore(cmd.DeletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return h.purger.purge(items)
+}
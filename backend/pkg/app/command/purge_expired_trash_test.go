@@ -0,0 +1,64 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPurgeExpiredTrashHandler_Handle(t *testing.T) {
+	deletedBefore := time.Now()
+	type fields struct {
+		trashRepo    trash.Repository
+		revisionRepo translation.RevisionRepository
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		want     int
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on getting expired items",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("GetDeletedBefore", deletedBefore).Return(nil, errors.New("testErr"))
+				return fields{trashRepo: trashRepo, revisionRepo: translation.NewMockRevisionRepository(t)}
+			},
+			0,
+			assert.Error,
+		},
+		{
+			"Expired items of different authors are purged",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("GetDeletedBefore", deletedBefore).Return([]*trash.Item{
+					trash.UnmarshalFromDB("item1", trash.LangKind, "author1", deletedBefore, nil, nil, lang.UnmarshalFromDB("lang1", "EN", "author1", lang.Metadata{}, nil)),
+					trash.UnmarshalFromDB("item2", trash.LangKind, "author2", deletedBefore, nil, nil, lang.UnmarshalFromDB("lang2", "EN", "author2", lang.Metadata{}, nil)),
+				}, nil)
+				trashRepo.On("Delete", "item1", "author1").Return(nil)
+				trashRepo.On("Delete", "item2", "author2").Return(nil)
+				return fields{trashRepo: trashRepo, revisionRepo: translation.NewMockRevisionRepository(t)}
+			},
+			2,
+			assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := NewPurgeExpiredTrashHandler(f.trashRepo, f.revisionRepo)
+			cmd := PurgeExpiredTrash{DeletedBefore: deletedBefore}
+			got, err := h.Handle(cmd)
+			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", cmd)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "Handle(%v)", cmd)
+		})
+	}
+}
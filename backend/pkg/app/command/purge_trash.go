@@ -0,0 +1,81 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+)
+
+// PurgeTrash permanently removes trash item, all trash items of the author are removed when ID is empty
+type PurgeTrash struct {
+	ID       string
+	AuthorID string
+}
+
+// PurgeTrashHandler purge trash cmd handler
+type PurgeTrashHandler struct {
+	trashRepo trash.Repository
+	purger    trashPurger
+}
+
+func NewPurgeTrashHandler(trashRepo trash.Repository, revisionRepo translation.RevisionRepository) PurgeTrashHandler {
+	return PurgeTrashHandler{
+		trashRepo: trashRepo,
+		purger:    newTrashPurger(trashRepo, revisionRepo),
+	}
+}
+
+// Handle returns amount of purged trash items
+func (h PurgeTrashHandler) Handle(cmd PurgeTrash) (int, error) {
+	if cmd.ID == "" {
+		items, err := h.trashRepo.GetAll(cmd.AuthorID)
+		if err != nil {
+			return 0, err
+		}
+		return h.purger.purge(items)
+	}
+
+	item, err := h.trashRepo.Get(cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return 0, err
+	}
+
+	return h.purger.purge([]*trash.Item{item})
+}
+
+// trashPurger removes trash items together with the data which was kept to restore them
+type trashPurger struct {
+	trashRepo    trash.Repository
+	revisionRepo translation.RevisionRepository
+}
+
+func newTrashPurger(trashRepo trash.Repository, revisionRepo translation.RevisionRepository) trashPurger {
+	return trashPurger{
+		trashRepo:    trashRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+// purge tries to remove all passed items, no transaction support so far
+func (p trashPurger) purge(items []*trash.Item) (int, error) {
+	var err error
+	count := 0
+
+	for _, item := range items {
+		if item.Kind() == trash.TranslationKind {
+			if revisionErr := p.revisionRepo.DeleteByTranslationID(item.EntityID(), item.AuthorID()); revisionErr != nil {
+				err = errors.Join(err, revisionErr)
+				continue
+			}
+		}
+
+		if deleteErr := p.trashRepo.Delete(item.ID(), item.AuthorID()); deleteErr != nil {
+			err = errors.Join(err, deleteErr)
+			continue
+		}
+
+		count++
+	}
+
+	return count, err
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPurgeTrashHandler_Handle(t *testing.T) {
+	type fields struct {
+		trashRepo    trash.Repository
+		revisionRepo translation.RevisionRepository
+	}
+	type args struct {
+		cmd PurgeTrash
+	}
+
+	tr := translation.UnmarshalFromDB("trID", "source", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", time.Now(), time.Now(), "EN", translation.Review{}, nil)
+	trItem := trash.UnmarshalFromDB("item1", trash.TranslationKind, "testAuthor", time.Now(), tr, nil, nil)
+	tagItem := trash.UnmarshalFromDB("item2", trash.TagKind, "testAuthor", time.Now(), nil, tag.UnmarshalFromDB("tagID", "tag", "testAuthor", ""), nil)
+
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     int
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Trash item not found",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "item1", "testAuthor").Return(nil, trash.ErrNotFound)
+				return fields{trashRepo: trashRepo, revisionRepo: translation.NewMockRevisionRepository(t)}
+			},
+			args{cmd: PurgeTrash{ID: "item1", AuthorID: "testAuthor"}},
+			0,
+			assert.Error,
+		},
+		{
+			"Single translation item is purged together with revisions",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "item1", "testAuthor").Return(trItem, nil)
+				trashRepo.On("Delete", "item1", "testAuthor").Return(nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByTranslationID", "trID", "testAuthor").Return(nil)
+				return fields{trashRepo: trashRepo, revisionRepo: revisionRepo}
+			},
+			args{cmd: PurgeTrash{ID: "item1", AuthorID: "testAuthor"}},
+			1,
+			assert.NoError,
+		},
+		{
+			"Error on revisions removing keeps trash item",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("GetAll", "testAuthor").Return([]*trash.Item{trItem, tagItem}, nil)
+				trashRepo.On("Delete", "item2", "testAuthor").Return(nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByTranslationID", "trID", "testAuthor").Return(errors.New("testErr"))
+				return fields{trashRepo: trashRepo, revisionRepo: revisionRepo}
+			},
+			args{cmd: PurgeTrash{AuthorID: "testAuthor"}},
+			1,
+			assert.Error,
+		},
+		{
+			"All author items are purged",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("GetAll", "testAuthor").Return([]*trash.Item{trItem, tagItem}, nil)
+				trashRepo.On("Delete", "item1", "testAuthor").Return(nil)
+				trashRepo.On("Delete", "item2", "testAuthor").Return(nil)
+				revisionRepo := translation.NewMockRevisionRepository(t)
+				revisionRepo.On("DeleteByTranslationID", "trID", "testAuthor").Return(nil)
+				return fields{trashRepo: trashRepo, revisionRepo: revisionRepo}
+			},
+			args{cmd: PurgeTrash{AuthorID: "testAuthor"}},
+			2,
+			assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := NewPurgeTrashHandler(f.trashRepo, f.revisionRepo)
+			got, err := h.Handle(tt.args.cmd)
+			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.cmd)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "Handle(%v)", tt.args.cmd)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package command
+
+import "github.com/macyan13/webdict/backend/pkg/app/domain/user"
+
+// ResetTwoFactor disables two-factor authentication of the user who lost access to authenticator app and recovery codes
+type ResetTwoFactor struct {
+	ID string
+}
+
+type ResetTwoFactorHandler struct {
+	userRepo user.Repository
+}
+
+func NewResetTwoFactorHandler(userRepo user.Repository) ResetTwoFactorHandler {
+	return ResetTwoFactorHandler{userRepo: userRepo}
+}
+
+func (h ResetTwoFactorHandler) Handle(cmd ResetTwoFactor) error {
+	usr, err := h.userRepo.Get(cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	usr.ResetTwoFactor()
+	return h.userRepo.Update(usr)
+}
@@ -0,0 +1,29 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestResetTwoFactorHandler_Handle(t *testing.T) {
+	usr, err := user.NewUser("test", "test@test.com", "testPasswd", user.Author)
+	assert.Nil(t, err)
+	secret, err := usr.EnrollTwoFactor()
+	assert.Nil(t, err)
+	code, err := user.GenerateTOTPCode(secret, time.Now())
+	assert.Nil(t, err)
+	_, err = usr.ConfirmTwoFactor(code)
+	assert.Nil(t, err)
+
+	userRepo := user.NewMockRepository(t)
+	userRepo.On("Get", "notExist").Return(nil, user.ErrNotFound)
+	userRepo.On("Get", usr.ID()).Return(usr, nil)
+	userRepo.On("Update", usr).Return(nil)
+
+	h := NewResetTwoFactorHandler(userRepo)
+	assert.Equal(t, user.ErrNotFound, h.Handle(ResetTwoFactor{ID: "notExist"}))
+	assert.Nil(t, h.Handle(ResetTwoFactor{ID: usr.ID()}))
+	assert.False(t, usr.TwoFactorEnabled())
+}
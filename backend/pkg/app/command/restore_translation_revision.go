@@ -0,0 +1,73 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+)
+
+// RestoreTranslationRevision roll back translation to the state kept in revision cmd
+type RestoreTranslationRevision struct {
+	ID         string
+	RevisionID string
+	AuthorID   string
+}
+
+// RestoreTranslationRevisionHandler roll back translation to revision cmd handler
+type RestoreTranslationRevisionHandler struct {
+	translationRepo translation.Repository
+	revisionRepo    translation.RevisionRepository
+	validator       validator
+}
+
+func NewRestoreTranslationRevisionHandler(
+	translationRepo translation.Repository,
+	revisionRepo translation.RevisionRepository,
+	tagRepo tag.Repository,
+	langRepo lang.Repository,
+) RestoreTranslationRevisionHandler {
+	return RestoreTranslationRevisionHandler{
+		translationRepo: translationRepo,
+		revisionRepo:    revisionRepo,
+		validator:       newValidator(tagRepo, langRepo),
+	}
+}
+
+// Handle restores translation state kept in revision, the restore itself is recorded as a new revision so it can be rolled back too
+func (h RestoreTranslationRevisionHandler) Handle(cmd RestoreTranslationRevision) error {
+	revision, err := h.revisionRepo.Get(cmd.RevisionID, cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	tr, err := h.translationRepo.Get(cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	before := *tr
+
+	if err = revision.Restore(tr); err != nil {
+		return err
+	}
+
+	if err = h.validator.validate(translationData{
+		TagIDs:   tr.TagIDs(),
+		LangID:   tr.LangID(),
+		AuthorID: cmd.AuthorID,
+		Fields:   tr.Fields(),
+	}); err != nil {
+		return err
+	}
+
+	if err = h.translationRepo.Update(tr); err != nil {
+		return err
+	}
+
+	restoreRevision, changed := translation.NewRevision(&before, tr)
+	if !changed {
+		return nil
+	}
+
+	return h.revisionRepo.Create(restoreRevision)
+}
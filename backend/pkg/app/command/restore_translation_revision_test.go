@@ -0,0 +1,115 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestRestoreTranslationRevisionHandler_Handle_NegativeCases(t *testing.T) {
+	type fields struct {
+		translationRepo translation.Repository
+		revisionRepo    translation.RevisionRepository
+		validator       validator
+	}
+	type args struct {
+		cmd RestoreTranslationRevision
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on getting revision",
+			func() fields {
+				revisionRepo := translation.MockRevisionRepository{}
+				revisionRepo.On("Get", "revID", "testID", "testAuthor").Return(nil, translation.ErrRevisionNotFound)
+				return fields{revisionRepo: &revisionRepo}
+			},
+			args{cmd: RestoreTranslationRevision{ID: "testID", RevisionID: "revID", AuthorID: "testAuthor"}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, translation.ErrRevisionNotFound, i)
+			},
+		},
+		{
+			"Error on getting translation",
+			func() fields {
+				revisionRepo := translation.MockRevisionRepository{}
+				revisionRepo.On("Get", "revID", "testID", "testAuthor").Return(&translation.Revision{}, nil)
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("Get", "testID", "testAuthor").Return(nil, errors.New("testErr"))
+				return fields{revisionRepo: &revisionRepo, translationRepo: &translationRepo}
+			},
+			args{cmd: RestoreTranslationRevision{ID: "testID", RevisionID: "revID", AuthorID: "testAuthor"}},
+			assert.Error,
+		},
+		{
+			"Error on validation",
+			func() fields {
+				tr, revision := createTranslationWithRevision(t)
+				revisionRepo := translation.MockRevisionRepository{}
+				revisionRepo.On("Get", "revID", "testID", "testAuthor").Return(revision, nil)
+				translationRepo := translation.MockRepository{}
+				translationRepo.On("Get", "testID", "testAuthor").Return(tr, nil)
+				return fields{revisionRepo: &revisionRepo, translationRepo: &translationRepo, validator: newFailValidator()}
+			},
+			args{cmd: RestoreTranslationRevision{ID: "testID", RevisionID: "revID", AuthorID: "testAuthor"}},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := RestoreTranslationRevisionHandler{
+				translationRepo: f.translationRepo,
+				revisionRepo:    f.revisionRepo,
+				validator:       f.validator,
+			}
+			tt.wantErr(t, h.Handle(tt.args.cmd), fmt.Sprintf("Handle(%v)", tt.args.cmd))
+		})
+	}
+}
+
+func TestRestoreTranslationRevisionHandler_Handle_PositiveCase(t *testing.T) {
+	tr, revision := createTranslationWithRevision(t)
+
+	revisionRepo := translation.MockRevisionRepository{}
+	revisionRepo.On("Get", "revID", tr.ID(), "testAuthor").Return(revision, nil)
+	revisionRepo.On("Create", mock.AnythingOfType("*translation.Revision")).Return(nil)
+	translationRepo := translation.MockRepository{}
+	translationRepo.On("Get", tr.ID(), "testAuthor").Return(tr, nil)
+	translationRepo.On("Update", mock.AnythingOfType("*translation.Translation")).Return(nil)
+
+	h := RestoreTranslationRevisionHandler{
+		translationRepo: &translationRepo,
+		revisionRepo:    &revisionRepo,
+		validator:       newSuccessValidator(),
+	}
+
+	assert.Nil(t, h.Handle(RestoreTranslationRevision{ID: tr.ID(), RevisionID: "revID", AuthorID: "testAuthor"}))
+
+	restored := translationRepo.Calls[1].Arguments[0].(*translation.Translation).ToMap()
+	assert.Equal(t, "source", restored["source"])
+	assert.Equal(t, "EN", restored["langID"])
+
+	restoreRevision := revisionRepo.Calls[1].Arguments[0].(*translation.Revision)
+	assert.Contains(t, restoreRevision.Changes(), translation.NewFieldChange("source", "changed", "source"))
+}
+
+func createTranslationWithRevision(t *testing.T) (*translation.Translation, *translation.Revision) {
+	tr, err := translation.NewTranslation("source", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+	assert.Nil(t, err)
+
+	before := *tr
+	assert.Nil(t, tr.ApplyChanges("changed", "", []translation.Sense{translation.NewSense("target", "", nil)}, "DE"))
+
+	revision, changed := translation.NewRevision(&before, tr)
+	assert.True(t, changed)
+
+	return tr, revision
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+)
+
+// RestoreTrashItem brings deleted entity back from trash cmd
+type RestoreTrashItem struct {
+	ID       string
+	AuthorID string
+}
+
+// RestoreTrashItemHandler restore trash item cmd handler
+type RestoreTrashItemHandler struct {
+	trashRepo       trash.Repository
+	translationRepo translation.Repository
+	tagRepo         tag.Repository
+	langRepo        lang.Repository
+	validator       validator
+}
+
+func NewRestoreTrashItemHandler(
+	trashRepo trash.Repository,
+	translationRepo translation.Repository,
+	tagRepo tag.Repository,
+	langRepo lang.Repository,
+) RestoreTrashItemHandler {
+	return RestoreTrashItemHandler{
+		trashRepo:       trashRepo,
+		translationRepo: translationRepo,
+		tagRepo:         tagRepo,
+		langRepo:        langRepo,
+		validator:       newValidator(tagRepo, langRepo),
+	}
+}
+
+// Handle saves the deleted entity back to its store and removes it from trash,
+// restoring fails when the entity conflicts with the existing one or refers to the deleted tags or lang
+func (h RestoreTrashItemHandler) Handle(cmd RestoreTrashItem) error {
+	item, err := h.trashRepo.Get(cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	switch item.Kind() {
+	case trash.TranslationKind:
+		err = h.restoreTranslation(item.Translation())
+	case trash.TagKind:
+		err = h.restoreTag(item.Tag())
+	case trash.LangKind:
+		err = h.langRepo.Create(item.Lang())
+	default:
+		err = fmt.Errorf("unknown trash item kind: %s", item.Kind())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return h.trashRepo.Delete(item.ID(), cmd.AuthorID)
+}
+
+func (h RestoreTrashItemHandler) restoreTag(tg *tag.Tag) error {
+	if tg.ParentID() != "" {
+		if err := h.validator.validateTags(translationData{TagIDs: []string{tg.ParentID()}, AuthorID: tg.AuthorID()}); err != nil {
+			return fmt.Errorf("can not restore tag nested into deleted one: %v", err)
+		}
+	}
+
+	return h.tagRepo.Create(tg)
+}
+
+func (h RestoreTrashItemHandler) restoreTranslation(tr *translation.Translation) error {
+	if err := h.validator.validate(translationData{
+		TagIDs:   tr.TagIDs(),
+		LangID:   tr.LangID(),
+		AuthorID: tr.AuthorID(),
+	}); err != nil {
+		return err
+	}
+
+	return h.translationRepo.Create(tr)
+}
@@ -0,0 +1,157 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRestoreTrashItemHandler_Handle(t *testing.T) {
+	type fields struct {
+		trashRepo       trash.Repository
+		translationRepo translation.Repository
+		tagRepo         tag.Repository
+		langRepo        lang.Repository
+	}
+	type args struct {
+		cmd RestoreTrashItem
+	}
+
+	tr := translation.UnmarshalFromDB("trID", "source", "", []translation.Sense{translation.NewSense("target", "", []string{"tag1"})}, "testAuthor", time.Now(), time.Now(), "EN", translation.Review{}, nil)
+	trItem := trash.UnmarshalFromDB("itemID", trash.TranslationKind, "testAuthor", time.Now(), tr, nil, nil)
+	tg := tag.UnmarshalFromDB("tagID", "tag", "testAuthor", "")
+	tagItem := trash.UnmarshalFromDB("itemID", trash.TagKind, "testAuthor", time.Now(), nil, tg, nil)
+	ln := lang.UnmarshalFromDB("langID", "EN", "testAuthor", lang.Metadata{}, nil)
+	langItem := trash.UnmarshalFromDB("itemID", trash.LangKind, "testAuthor", time.Now(), nil, nil, ln)
+
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Trash item not found",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "itemID", "testAuthor").Return(nil, trash.ErrNotFound)
+				return fields{trashRepo: trashRepo}
+			},
+			args{cmd: RestoreTrashItem{ID: "itemID", AuthorID: "testAuthor"}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, trash.ErrNotFound, i)
+				return true
+			},
+		},
+		{
+			"Translation tags are not found",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "itemID", "testAuthor").Return(trItem, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("AllExist", []string{"tag1"}, "testAuthor").Return(false, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("Exist", "EN", "testAuthor").Return(true, nil)
+				return fields{trashRepo: trashRepo, tagRepo: tagRepo, langRepo: langRepo}
+			},
+			args{cmd: RestoreTrashItem{ID: "itemID", AuthorID: "testAuthor"}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "some of passed tags: [tag1] are not found", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Translation source already exists",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "itemID", "testAuthor").Return(trItem, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("AllExist", []string{"tag1"}, "testAuthor").Return(true, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("Exist", "EN", "testAuthor").Return(true, nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("Create", tr).Return(translation.ErrSourceAlreadyExists)
+				return fields{trashRepo: trashRepo, translationRepo: translationRepo, tagRepo: tagRepo, langRepo: langRepo}
+			},
+			args{cmd: RestoreTrashItem{ID: "itemID", AuthorID: "testAuthor"}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, translation.ErrSourceAlreadyExists, i)
+				return true
+			},
+		},
+		{
+			"Translation is restored",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "itemID", "testAuthor").Return(trItem, nil)
+				trashRepo.On("Delete", "itemID", "testAuthor").Return(nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("AllExist", []string{"tag1"}, "testAuthor").Return(true, nil)
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("Exist", "EN", "testAuthor").Return(true, nil)
+				translationRepo := translation.NewMockRepository(t)
+				translationRepo.On("Create", tr).Return(nil)
+				return fields{trashRepo: trashRepo, translationRepo: translationRepo, tagRepo: tagRepo, langRepo: langRepo}
+			},
+			args{cmd: RestoreTrashItem{ID: "itemID", AuthorID: "testAuthor"}},
+			assert.NoError,
+		},
+		{
+			"Tag name already exists",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "itemID", "testAuthor").Return(tagItem, nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("Create", tg).Return(tag.ErrTagAlreadyExists)
+				return fields{trashRepo: trashRepo, tagRepo: tagRepo}
+			},
+			args{cmd: RestoreTrashItem{ID: "itemID", AuthorID: "testAuthor"}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, tag.ErrTagAlreadyExists, i)
+				return true
+			},
+		},
+		{
+			"Tag is restored",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "itemID", "testAuthor").Return(tagItem, nil)
+				trashRepo.On("Delete", "itemID", "testAuthor").Return(nil)
+				tagRepo := tag.NewMockRepository(t)
+				tagRepo.On("Create", tg).Return(nil)
+				return fields{trashRepo: trashRepo, tagRepo: tagRepo}
+			},
+			args{cmd: RestoreTrashItem{ID: "itemID", AuthorID: "testAuthor"}},
+			assert.NoError,
+		},
+		{
+			"Lang is restored, error on trash item removing",
+			func() fields {
+				trashRepo := trash.NewMockRepository(t)
+				trashRepo.On("Get", "itemID", "testAuthor").Return(langItem, nil)
+				trashRepo.On("Delete", "itemID", "testAuthor").Return(errors.New("testErr"))
+				langRepo := lang.NewMockRepository(t)
+				langRepo.On("Create", ln).Return(nil)
+				return fields{trashRepo: trashRepo, langRepo: langRepo}
+			},
+			args{cmd: RestoreTrashItem{ID: "itemID", AuthorID: "testAuthor"}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testErr", err.Error(), i)
+				return true
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := NewRestoreTrashItemHandler(f.trashRepo, f.translationRepo, f.tagRepo, f.langRepo)
+			tt.wantErr(t, h.Handle(tt.args.cmd), fmt.Sprintf("Handle(%v)", tt.args.cmd))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+)
+
+// ReviewTranslation grade the answer for translation review cmd
+type ReviewTranslation struct {
+	ID       string
+	AuthorID string
+	Grade    int
+}
+
+// ReviewTranslationHandler grade translation review cmd handler
+type ReviewTranslationHandler struct {
+	translationRepo translation.Repository
+}
+
+func NewReviewTranslationHandler(translationRepo translation.Repository) ReviewTranslationHandler {
+	return ReviewTranslationHandler{
+		translationRepo: translationRepo,
+	}
+}
+
+// Handle applies the answer grade to translation and saves the next review schedule
+func (h ReviewTranslationHandler) Handle(cmd ReviewTranslation) error {
+	tr, err := h.translationRepo.Get(cmd.ID, cmd.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	if err = tr.Grade(cmd.Grade); err != nil {
+		return err
+	}
+
+	return h.translationRepo.Update(tr)
+}
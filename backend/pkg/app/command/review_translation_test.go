@@ -0,0 +1,86 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestReviewTranslationHandler_Handle_NegativeCases(t *testing.T) {
+	type fields struct {
+		translationRepo translation.Repository
+	}
+	type args struct {
+		cmd ReviewTranslation
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on getting translation",
+			func() fields {
+				repo := translation.MockRepository{}
+				repo.On("Get", "testID", "testAuthor").Return(nil, errors.New("testErr"))
+				return fields{translationRepo: &repo}
+			},
+			args{cmd: ReviewTranslation{ID: "testID", AuthorID: "testAuthor", Grade: 4}},
+			assert.Error,
+		},
+		{
+			"Error on invalid grade",
+			func() fields {
+				repo := translation.MockRepository{}
+				tr, err := translation.NewTranslation("new", "new", []translation.Sense{translation.NewSense("new", "new", []string{})}, "testAuthor", "new")
+				assert.Nil(t, err)
+				repo.On("Get", "testID", "testAuthor").Return(tr, nil)
+				return fields{translationRepo: &repo}
+			},
+			args{cmd: ReviewTranslation{ID: "testID", AuthorID: "testAuthor", Grade: 7}},
+			assert.Error,
+		},
+		{
+			"Error on update",
+			func() fields {
+				repo := translation.MockRepository{}
+				tr, err := translation.NewTranslation("new", "new", []translation.Sense{translation.NewSense("new", "new", []string{})}, "testAuthor", "new")
+				assert.Nil(t, err)
+				repo.On("Get", "testID", "testAuthor").Return(tr, nil)
+				repo.On("Update", mock.AnythingOfType("*translation.Translation")).Return(errors.New("testErr"))
+				return fields{translationRepo: &repo}
+			},
+			args{cmd: ReviewTranslation{ID: "testID", AuthorID: "testAuthor", Grade: 4}},
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewReviewTranslationHandler(tt.fieldsFn().translationRepo)
+			tt.wantErr(t, h.Handle(tt.args.cmd), fmt.Sprintf("Handle(%v)", tt.args.cmd))
+		})
+	}
+}
+
+func TestReviewTranslationHandler_Handle_PositiveCase(t *testing.T) {
+	repo := translation.MockRepository{}
+	tr, err := translation.NewTranslation("new", "new", []translation.Sense{translation.NewSense("new", "new", []string{})}, "testAuthor", "new")
+	assert.Nil(t, err)
+	repo.On("Get", "testID", "testAuthor").Return(tr, nil)
+	repo.On("Update", mock.AnythingOfType("*translation.Translation")).Return(nil)
+
+	h := NewReviewTranslationHandler(&repo)
+	assert.Nil(t, h.Handle(ReviewTranslation{ID: "testID", AuthorID: "testAuthor", Grade: 5}))
+
+	updated := repo.Calls[1].Arguments[0].(*translation.Translation)
+	review := updated.Review()
+	data := review.ToMap()
+	assert.Equal(t, 1, data["repetitions"])
+	assert.Equal(t, 1, data["interval"])
+	assert.True(t, review.DueAt().After(time.Now()))
+}
@@ -0,0 +1,22 @@
+package command
+
+import "github.com/macyan13/webdict/backend/pkg/app/domain/session"
+
+// RevokeAllSessions signs the user out on all devices except the one of ExceptID session, empty ExceptID means all devices
+type RevokeAllSessions struct {
+	UserID   string
+	ExceptID string
+}
+
+type RevokeAllSessionsHandler struct {
+	sessionRepo session.Repository
+}
+
+func NewRevokeAllSessionsHandler(sessionRepo session.Repository) RevokeAllSessionsHandler {
+	return RevokeAllSessionsHandler{sessionRepo: sessionRepo}
+}
+
+// Handle returns amount of revoked sessions
+func (h RevokeAllSessionsHandler) Handle(cmd RevokeAllSessions) (int, error) {
+	return h.sessionRepo.DeleteByUserID(cmd.UserID, cmd.ExceptID)
+}
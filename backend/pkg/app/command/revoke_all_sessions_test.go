@@ -0,0 +1,16 @@
+package command
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRevokeAllSessionsHandler_Handle(t *testing.T) {
+	sessionRepo := session.NewMockRepository(t)
+	sessionRepo.On("DeleteByUserID", "testUser", "currentSession").Return(2, nil)
+
+	count, err := NewRevokeAllSessionsHandler(sessionRepo).Handle(RevokeAllSessions{UserID: "testUser", ExceptID: "currentSession"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,21 @@
+package command
+
+import "github.com/macyan13/webdict/backend/pkg/app/domain/session"
+
+// RevokeSession signs the user out on the device of the session
+type RevokeSession struct {
+	ID     string
+	UserID string
+}
+
+type RevokeSessionHandler struct {
+	sessionRepo session.Repository
+}
+
+func NewRevokeSessionHandler(sessionRepo session.Repository) RevokeSessionHandler {
+	return RevokeSessionHandler{sessionRepo: sessionRepo}
+}
+
+func (h RevokeSessionHandler) Handle(cmd RevokeSession) error {
+	return h.sessionRepo.Delete(cmd.ID, cmd.UserID)
+}
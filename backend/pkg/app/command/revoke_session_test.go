@@ -0,0 +1,18 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRevokeSessionHandler_Handle(t *testing.T) {
+	sessionRepo := session.NewMockRepository(t)
+	sessionRepo.On("Delete", "session1", "testUser").Return(nil)
+	sessionRepo.On("Delete", "session2", "testUser").Return(errors.New("testErr"))
+
+	h := NewRevokeSessionHandler(sessionRepo)
+	assert.Nil(t, h.Handle(RevokeSession{ID: "session1", UserID: "testUser"}))
+	assert.Error(t, h.Handle(RevokeSession{ID: "session2", UserID: "testUser"}))
+}
@@ -0,0 +1,21 @@
+package command
+
+import "github.com/macyan13/webdict/backend/pkg/app/domain/token"
+
+// RevokeToken removes personal access token, so it can not be used anymore
+type RevokeToken struct {
+	ID     string
+	UserID string
+}
+
+type RevokeTokenHandler struct {
+	tokenRepo token.Repository
+}
+
+func NewRevokeTokenHandler(tokenRepo token.Repository) RevokeTokenHandler {
+	return RevokeTokenHandler{tokenRepo: tokenRepo}
+}
+
+func (h RevokeTokenHandler) Handle(cmd RevokeToken) error {
+	return h.tokenRepo.Delete(cmd.ID, cmd.UserID)
+}
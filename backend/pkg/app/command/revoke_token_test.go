@@ -0,0 +1,18 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRevokeTokenHandler_Handle(t *testing.T) {
+	tokenRepo := token.NewMockRepository(t)
+	tokenRepo.On("Delete", "token1", "testUser").Return(nil)
+	tokenRepo.On("Delete", "token2", "testUser").Return(errors.New("testErr"))
+
+	h := NewRevokeTokenHandler(tokenRepo)
+	assert.Nil(t, h.Handle(RevokeToken{ID: "token1", UserID: "testUser"}))
+	assert.Error(t, h.Handle(RevokeToken{ID: "token2", UserID: "testUser"}))
+}
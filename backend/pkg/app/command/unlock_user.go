@@ -0,0 +1,26 @@
+package command
+
+import "github.com/macyan13/webdict/backend/pkg/app/domain/user"
+
+// UnlockUser resets failed sign-in attempts of the user account, so sign-in is not delayed or locked anymore
+type UnlockUser struct {
+	ID string
+}
+
+type UnlockUserHandler struct {
+	userRepo    user.Repository
+	attemptRepo user.AttemptRepository
+}
+
+func NewUnlockUserHandler(userRepo user.Repository, attemptRepo user.AttemptRepository) UnlockUserHandler {
+	return UnlockUserHandler{userRepo: userRepo, attemptRepo: attemptRepo}
+}
+
+func (h UnlockUserHandler) Handle(cmd UnlockUser) error {
+	usr, err := h.userRepo.Get(cmd.ID)
+	if err != nil {
+		return err
+	}
+
+	return h.attemptRepo.DeleteAttempts(user.AccountAttemptsKey(usr.ID()))
+}
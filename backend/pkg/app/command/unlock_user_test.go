@@ -0,0 +1,27 @@
+package command
+
+import (
+	"errors"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnlockUserHandler_Handle(t *testing.T) {
+	usr, err := user.NewUser("John", "John@do.com", "12345678", user.Admin)
+	assert.Nil(t, err)
+
+	userRepo := user.NewMockRepository(t)
+	userRepo.On("Get", usr.ID()).Return(usr, nil)
+	userRepo.On("Get", "notExist").Return(nil, user.ErrNotFound)
+
+	attemptRepo := user.NewMockAttemptRepository(t)
+	attemptRepo.On("DeleteAttempts", user.AccountAttemptsKey(usr.ID())).Once().Return(nil)
+
+	h := NewUnlockUserHandler(userRepo, attemptRepo)
+	assert.Equal(t, user.ErrNotFound, h.Handle(UnlockUser{ID: "notExist"}))
+	assert.Nil(t, h.Handle(UnlockUser{ID: usr.ID()}))
+
+	attemptRepo.On("DeleteAttempts", user.AccountAttemptsKey(usr.ID())).Return(errors.New("testErr"))
+	assert.Error(t, h.Handle(UnlockUser{ID: usr.ID()}))
+}
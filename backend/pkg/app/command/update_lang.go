@@ -1,23 +1,36 @@
 package command
 
 import (
+	"errors"
+	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
 )
 
 type UpdateLang struct {
 	ID       string
 	Name     string
 	AuthorID string
+	Metadata lang.Metadata
+	Fields   []lang.Field // Fields schema of the lang translations custom fields, replaces the existing one
 }
 
 type UpdateLangHandler struct {
-	langRepo lang.Repository
+	langRepo        lang.Repository
+	translationRepo translation.Repository
+	updater         bulkUpdater
 }
 
-func NewUpdateLangHandler(langRepo lang.Repository) UpdateLangHandler {
-	return UpdateLangHandler{langRepo: langRepo}
+func NewUpdateLangHandler(langRepo lang.Repository, translationRepo translation.Repository, revisionRepo translation.RevisionRepository) UpdateLangHandler {
+	return UpdateLangHandler{
+		langRepo:        langRepo,
+		translationRepo: translationRepo,
+		updater:         bulkUpdater{translationRepo: translationRepo, revisionRepo: revisionRepo},
+	}
 }
 
+// Handle applies changes to the lang, translation custom field values which do not match the new fields schema are dropped,
+// the drop is recorded as translation revision so the values can be restored after the schema is changed back
 func (h UpdateLangHandler) Handle(cmd UpdateLang) error {
 	ln, err := h.langRepo.Get(cmd.ID, cmd.AuthorID)
 
@@ -25,9 +38,53 @@ func (h UpdateLangHandler) Handle(cmd UpdateLang) error {
 		return err
 	}
 
-	if err := ln.ApplyChanges(cmd.Name); err != nil {
+	hadFields := len(ln.Fields()) != 0
+
+	if err := ln.ApplyChanges(cmd.Name, cmd.Metadata); err != nil {
+		return err
+	}
+
+	if err := ln.ApplyFields(cmd.Fields); err != nil {
+		return err
+	}
+
+	if err := h.langRepo.Update(ln); err != nil {
 		return err
 	}
 
-	return h.langRepo.Update(ln)
+	// translations can have field values only when the lang defined the fields before
+	if !hadFields {
+		return nil
+	}
+
+	return h.dropInvalidFieldValues(ln)
+}
+
+func (h UpdateLangHandler) dropInvalidFieldValues(ln *lang.Lang) error {
+	records, err := h.translationRepo.GetByLang(ln.ID(), ln.AuthorID())
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	result := BulkResult{Items: make([]BulkItemResult, len(records))}
+	for i, tr := range records {
+		result.Items[i].ID = tr.ID()
+	}
+
+	result = h.updater.apply(records, result, func(tr *translation.Translation) error {
+		return tr.ApplyFields(ln.FilterFieldValues(tr.Fields()))
+	})
+
+	var errs error
+	for _, item := range result.Items {
+		if item.Err != nil {
+			errs = errors.Join(errs, fmt.Errorf("can not drop field values of translation %s: %w", item.ID, item.Err))
+		}
+	}
+
+	return errs
 }
@@ -4,13 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
 func TestUpdateLangHandler_Handle(t *testing.T) {
 	type fields struct {
-		langRepo lang.Repository
+		langRepo        lang.Repository
+		translationRepo translation.Repository
+		revisionRepo    translation.RevisionRepository
 	}
 	type args struct {
 		cmd UpdateLang
@@ -38,11 +42,11 @@ func TestUpdateLangHandler_Handle(t *testing.T) {
 		{
 			"Error on saving",
 			func() fields {
-				ln := lang.UnmarshalFromDB("testID", "en", "testAuthor")
+				ln := lang.UnmarshalFromDB("testID", "en", "testAuthor", lang.Metadata{}, nil)
 				langRepo := lang.MockRepository{}
 				langRepo.On("Get", "testID", "testAuthor").Return(ln, nil)
 
-				updatedLn := lang.UnmarshalFromDB("testID", "de", "testAuthor")
+				updatedLn := lang.UnmarshalFromDB("testID", "de", "testAuthor", lang.Metadata{}, nil)
 				langRepo.On("Update", updatedLn).Return(errors.New("testError"))
 				return fields{langRepo: &langRepo}
 			},
@@ -56,7 +60,7 @@ func TestUpdateLangHandler_Handle(t *testing.T) {
 		{
 			"Error on applying changes",
 			func() fields {
-				ln := lang.UnmarshalFromDB("testID", "en", "testAuthor")
+				ln := lang.UnmarshalFromDB("testID", "en", "testAuthor", lang.Metadata{}, nil)
 				langRepo := lang.MockRepository{}
 				langRepo.On("Get", "testID", "testAuthor").Return(ln, nil)
 				return fields{langRepo: &langRepo}
@@ -71,11 +75,11 @@ func TestUpdateLangHandler_Handle(t *testing.T) {
 		{
 			"Positive case",
 			func() fields {
-				ln := lang.UnmarshalFromDB("testID", "en", "testAuthor")
+				ln := lang.UnmarshalFromDB("testID", "en", "testAuthor", lang.Metadata{}, nil)
 				langRepo := lang.MockRepository{}
 				langRepo.On("Get", "testID", "testAuthor").Return(ln, nil)
 
-				updatedTg := lang.UnmarshalFromDB("testID", "de", "testAuthor")
+				updatedTg := lang.UnmarshalFromDB("testID", "de", "testAuthor", lang.Metadata{}, nil)
 				langRepo.On("Update", updatedTg).Return(nil)
 				return fields{langRepo: &langRepo}
 			},
@@ -89,8 +93,43 @@ func TestUpdateLangHandler_Handle(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := NewUpdateLangHandler(tt.fieldsFn().langRepo)
+			f := tt.fieldsFn()
+			h := NewUpdateLangHandler(f.langRepo, f.translationRepo, f.revisionRepo)
 			tt.wantErr(t, h.Handle(tt.args.cmd), fmt.Sprintf("Handle(%v)", tt.args.cmd))
 		})
 	}
 }
+
+func TestUpdateLangHandler_Handle_DropsInvalidFieldValues(t *testing.T) {
+	ln := lang.UnmarshalFromDB("testID", "DE", "testAuthor", lang.Metadata{}, []lang.Field{
+		lang.NewField("gender", lang.EnumField, []string{"m", "f", "n"}),
+		lang.NewField("plural", lang.TextField, nil),
+	})
+	langRepo := lang.MockRepository{}
+	langRepo.On("Get", "testID", "testAuthor").Return(ln, nil)
+	langRepo.On("Update", ln).Return(nil)
+
+	valid := createTranslationWithSource(t, "Katze", "testID")
+	assert.Nil(t, valid.ApplyFields(map[string]string{"gender": "f"}))
+	invalid := createTranslationWithSource(t, "Hund", "testID")
+	assert.Nil(t, invalid.ApplyFields(map[string]string{"gender": "n", "plural": "Hunde"}))
+
+	translationRepo := translation.NewMockRepository(t)
+	translationRepo.On("GetByLang", "testID", "testAuthor").Return([]*translation.Translation{valid, invalid}, nil)
+	translationRepo.On("UpdateMany", []*translation.Translation{invalid}).Return([]error{nil})
+	revisionRepo := translation.NewMockRevisionRepository(t)
+	revisionRepo.On("Create", mock.MatchedBy(func(revision *translation.Revision) bool {
+		return revision.TranslationID() == invalid.ID() && revision.ToMap()["fields"].(map[string]string)["plural"] == "Hunde"
+	})).Return(nil).Once()
+
+	h := NewUpdateLangHandler(&langRepo, translationRepo, revisionRepo)
+	assert.Nil(t, h.Handle(UpdateLang{
+		ID:       "testID",
+		Name:     "DE",
+		AuthorID: "testAuthor",
+		Fields:   []lang.Field{lang.NewField("gender", lang.EnumField, []string{"m", "f"})},
+	}))
+
+	assert.Equal(t, map[string]string{"gender": "f"}, valid.Fields())
+	assert.Equal(t, map[string]string{}, invalid.Fields())
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 )
 
@@ -15,18 +16,21 @@ type UpdateProfile struct {
 	NewPassword     string
 	DefaultLangID   string
 	ListOptions     user.ListOptions
+	SessionID       string // SessionID current session of the user, it is kept when other sessions are revoked on password change
 }
 
 type UpdateProfileHandler struct {
-	userRepo user.Repository
-	cipher   Cipher
-	langRepo lang.Repository
+	userRepo    user.Repository
+	cipher      Cipher
+	langRepo    lang.Repository
+	sessionRepo session.Repository
 }
 
-func NewUpdateProfileHandler(userRepo user.Repository, cipher Cipher, langRepo lang.Repository) UpdateProfileHandler {
-	return UpdateProfileHandler{userRepo: userRepo, cipher: cipher, langRepo: langRepo}
+func NewUpdateProfileHandler(userRepo user.Repository, cipher Cipher, langRepo lang.Repository, sessionRepo session.Repository) UpdateProfileHandler {
+	return UpdateProfileHandler{userRepo: userRepo, cipher: cipher, langRepo: langRepo, sessionRepo: sessionRepo}
 }
 
+// Handle updates user profile, password change signs the user out on all other devices
 func (h UpdateProfileHandler) Handle(cmd UpdateProfile) error {
 	usr, err := h.userRepo.Get(cmd.ID)
 	if err != nil {
@@ -46,7 +50,16 @@ func (h UpdateProfileHandler) Handle(cmd UpdateProfile) error {
 		return err
 	}
 
-	return h.userRepo.Update(usr)
+	if err = h.userRepo.Update(usr); err != nil {
+		return err
+	}
+
+	if cmd.NewPassword == "" {
+		return nil
+	}
+
+	_, err = h.sessionRepo.DeleteByUserID(cmd.ID, cmd.SessionID)
+	return err
 }
 
 func (h UpdateProfileHandler) processPasswd(cmd UpdateProfile, userHash string) (string, error) {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -306,9 +307,13 @@ func TestUpdateProfileHandler_Handle_PositiveCases(t *testing.T) {
 		NewPassword:     newPasswd,
 		DefaultLangID:   langID,
 		ListOptions:     user.NewListOptions(true),
+		SessionID:       "currentSession",
 	}
 
-	handler := NewUpdateProfileHandler(&usrRepo, &cipher, &langRepo)
+	sessionRepo := session.NewMockRepository(t)
+	sessionRepo.On("DeleteByUserID", ID, "currentSession").Return(2, nil)
+
+	handler := NewUpdateProfileHandler(&usrRepo, &cipher, &langRepo, sessionRepo)
 	assert.Nil(t, handler.Handle(cmd))
 
 	updatedUsr := usrRepo.Calls[1].Arguments[0].(*user.User)
@@ -321,3 +326,16 @@ func TestUpdateProfileHandler_Handle_PositiveCases(t *testing.T) {
 	assert.Equal(t, langID, data["defaultLangID"])
 	assert.Equal(t, true, listData.ToMap()["hideTranscription"])
 }
+
+func TestUpdateProfileHandler_Handle_SessionsAreKeptWithoutPasswordChange(t *testing.T) {
+	usrRepo := user.MockRepository{}
+	usr, err := user.NewUser("test", "test@test.com", "testPasswd", user.Author)
+	assert.Nil(t, err)
+	usrRepo.On("Get", "testID").Return(usr, nil)
+	usrRepo.On("Update", mock.AnythingOfType("*user.User")).Return(nil)
+
+	sessionRepo := session.NewMockRepository(t)
+	handler := NewUpdateProfileHandler(&usrRepo, &MockCipher{}, &lang.MockRepository{}, sessionRepo)
+	assert.Nil(t, handler.Handle(UpdateProfile{ID: "testID", Name: "newName", Email: "test@test.com", SessionID: "currentSession"}))
+	sessionRepo.AssertNotCalled(t, "DeleteByUserID", mock.Anything, mock.Anything)
+}
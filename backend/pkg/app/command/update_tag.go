@@ -8,6 +8,7 @@ import (
 type UpdateTag struct {
 	TagID    string
 	Name     string
+	ParentID string // ParentID is empty for root tags
 	AuthorID string
 }
 
@@ -32,5 +33,9 @@ func (h UpdateTagHandler) Handle(cmd UpdateTag) error {
 		return err
 	}
 
+	if err := moveTag(h.tagRepo, tg, cmd.ParentID); err != nil {
+		return err
+	}
+
 	return h.tagRepo.Update(tg)
 }
@@ -41,11 +41,11 @@ func TestUpdateTagHandler_Handle(t *testing.T) {
 		{
 			"Case 2: error on saving",
 			func() fields {
-				tg := tag.UnmarshalFromDB("testID", "testTag", "testAuthor")
+				tg := tag.UnmarshalFromDB("testID", "testTag", "testAuthor", "")
 				tagRepo := tag.MockRepository{}
 				tagRepo.On("Get", "testID", "testAuthor").Return(tg, nil)
 
-				updatedTg := tag.UnmarshalFromDB("testID", "updatedTag", "testAuthor")
+				updatedTg := tag.UnmarshalFromDB("testID", "updatedTag", "testAuthor", "")
 				tagRepo.On("Update", updatedTg).Return(errors.New("testError"))
 				return fields{tagRepo: &tagRepo}
 			},
@@ -62,7 +62,7 @@ func TestUpdateTagHandler_Handle(t *testing.T) {
 		{
 			"Case 3: error on applying changes",
 			func() fields {
-				tg := tag.UnmarshalFromDB("testID", "testTag", "testAuthor")
+				tg := tag.UnmarshalFromDB("testID", "testTag", "testAuthor", "")
 				tagRepo := tag.MockRepository{}
 				tagRepo.On("Get", "testID", "testAuthor").Return(tg, nil)
 				return fields{tagRepo: &tagRepo}
@@ -80,11 +80,11 @@ func TestUpdateTagHandler_Handle(t *testing.T) {
 		{
 			"Case 4: positive case",
 			func() fields {
-				tg := tag.UnmarshalFromDB("testID", "testTag", "testAuthor")
+				tg := tag.UnmarshalFromDB("testID", "testTag", "testAuthor", "")
 				tagRepo := tag.MockRepository{}
 				tagRepo.On("Get", "testID", "testAuthor").Return(tg, nil)
 
-				updatedTg := tag.UnmarshalFromDB("testID", "updatedTag", "testAuthor")
+				updatedTg := tag.UnmarshalFromDB("testID", "updatedTag", "testAuthor", "")
 				tagRepo.On("Update", updatedTg).Return(nil)
 				return fields{tagRepo: &tagRepo}
 			},
@@ -98,6 +98,46 @@ func TestUpdateTagHandler_Handle(t *testing.T) {
 				return true
 			},
 		},
+		{
+			"Case 5: error on moving tag into its descendant",
+			func() fields {
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("Get", "testID", "testAuthor").Return(tag.UnmarshalFromDB("testID", "testTag", "testAuthor", ""), nil)
+				tagRepo.On("Get", "childID", "testAuthor").Return(tag.UnmarshalFromDB("childID", "child", "testAuthor", "middleID"), nil)
+				tagRepo.On("Get", "middleID", "testAuthor").Return(tag.UnmarshalFromDB("middleID", "middle", "testAuthor", "testID"), nil)
+				return fields{tagRepo: &tagRepo}
+			},
+			args{cmd: UpdateTag{
+				TagID:    "testID",
+				Name:     "testTag",
+				ParentID: "childID",
+				AuthorID: "testAuthor",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, tag.ErrCycle, i)
+				return true
+			},
+		},
+		{
+			"Case 6: tag is nested into parent",
+			func() fields {
+				tagRepo := tag.MockRepository{}
+				tagRepo.On("Get", "testID", "testAuthor").Return(tag.UnmarshalFromDB("testID", "testTag", "testAuthor", ""), nil)
+				tagRepo.On("Get", "parentID", "testAuthor").Return(tag.UnmarshalFromDB("parentID", "parent", "testAuthor", ""), nil)
+				tagRepo.On("Update", tag.UnmarshalFromDB("testID", "testTag", "testAuthor", "parentID")).Return(nil)
+				return fields{tagRepo: &tagRepo}
+			},
+			args{cmd: UpdateTag{
+				TagID:    "testID",
+				Name:     "testTag",
+				ParentID: "parentID",
+				AuthorID: "testAuthor",
+			}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Nil(t, err, i)
+				return true
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -11,33 +11,40 @@ type UpdateTranslation struct {
 	ID            string
 	Source        string
 	Transcription string
-	Target        string
+	Senses        []TranslationSense
 	AuthorID      string
-	Example       string
-	TagIDs        []string
 	LangID        string
+	Fields        map[string]string // Fields custom field values validated against the lang schema, replace the existing ones
 }
 
 // UpdateTranslationHandler update existing translation cmd handler
 type UpdateTranslationHandler struct {
 	translationRepo translation.Repository
+	revisionRepo    translation.RevisionRepository
 	validator       validator
 }
 
-func NewUpdateTranslationHandler(translationRep translation.Repository, tagRepo tag.Repository, langRepo lang.Repository) UpdateTranslationHandler {
+func NewUpdateTranslationHandler(
+	translationRep translation.Repository,
+	revisionRepo translation.RevisionRepository,
+	tagRepo tag.Repository,
+	langRepo lang.Repository,
+) UpdateTranslationHandler {
 	return UpdateTranslationHandler{
 		translationRepo: translationRep,
+		revisionRepo:    revisionRepo,
 		validator:       newValidator(tagRepo, langRepo),
 	}
 }
 
-// Handle apply changes from cmd to existing translation
+// Handle apply changes from cmd to existing translation and records the revision with the previous translation state
 func (h UpdateTranslationHandler) Handle(cmd UpdateTranslation) error {
 	if err := h.validator.validate(translationData{
-		TagIDs:   cmd.TagIDs,
+		TagIDs:   sensesTagIDs(cmd.Senses),
 		LangID:   cmd.LangID,
 		AuthorID: cmd.AuthorID,
 		Source:   cmd.Source,
+		Fields:   cmd.Fields,
 	}); err != nil {
 		return err
 	}
@@ -48,9 +55,24 @@ func (h UpdateTranslationHandler) Handle(cmd UpdateTranslation) error {
 		return err
 	}
 
-	if err = tr.ApplyChanges(cmd.Source, cmd.Transcription, cmd.Target, cmd.Example, cmd.TagIDs, cmd.LangID); err != nil {
+	before := *tr
+
+	if err = tr.ApplyChanges(cmd.Source, cmd.Transcription, toDomainSenses(cmd.Senses), cmd.LangID); err != nil {
 		return err
 	}
 
-	return h.translationRepo.Update(tr)
+	if err = tr.ApplyFields(cmd.Fields); err != nil {
+		return err
+	}
+
+	if err = h.translationRepo.Update(tr); err != nil {
+		return err
+	}
+
+	revision, changed := translation.NewRevision(&before, tr)
+	if !changed {
+		return nil
+	}
+
+	return h.revisionRepo.Create(revision)
 }
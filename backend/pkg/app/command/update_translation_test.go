@@ -3,6 +3,7 @@ package command
 import (
 	"errors"
 	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,7 +31,7 @@ func TestUpdateTranslationHandler_Handle_NegativeCases(t *testing.T) {
 					validator: newFailValidator(),
 				}
 			},
-			args{cmd: UpdateTranslation{TagIDs: []string{"tag1"}, AuthorID: "testAuthor"}},
+			args{cmd: UpdateTranslation{Senses: []TranslationSense{{TagIDs: []string{"tag1"}}}, AuthorID: "testAuthor"}},
 			assert.Error,
 		},
 		{
@@ -43,14 +44,14 @@ func TestUpdateTranslationHandler_Handle_NegativeCases(t *testing.T) {
 					validator:       newSuccessValidator(),
 				}
 			},
-			args{cmd: UpdateTranslation{TagIDs: []string{"tag1"}, AuthorID: "testAuthor", ID: "testID"}},
+			args{cmd: UpdateTranslation{Senses: []TranslationSense{{TagIDs: []string{"tag1"}}}, AuthorID: "testAuthor", ID: "testID"}},
 			assert.Error,
 		},
 		{
 			"Error on applying changes",
 			func() fields {
 				translationRepo := translation.MockRepository{}
-				tr, err := translation.NewTranslation("new", "new", "new", "new", "new", []string{}, "new")
+				tr, err := translation.NewTranslation("new", "new", []translation.Sense{translation.NewSense("new", "new", []string{})}, "new", "new")
 				assert.Nil(t, err)
 				translationRepo.On("Get", "testID", "testAuthor").Return(tr, nil)
 				return fields{
@@ -58,14 +59,14 @@ func TestUpdateTranslationHandler_Handle_NegativeCases(t *testing.T) {
 					validator:       newSuccessValidator(),
 				}
 			},
-			args{cmd: UpdateTranslation{ID: "testID", Source: "test", Target: "test", TagIDs: []string{"tag1"}, AuthorID: "testAuthor"}},
+			args{cmd: UpdateTranslation{ID: "testID", Source: "test", Senses: []TranslationSense{{Target: "test", TagIDs: []string{"tag1"}}}, AuthorID: "testAuthor"}},
 			assert.Error,
 		},
 		{
 			"error on update",
 			func() fields {
 				translationRepo := translation.MockRepository{}
-				tr, err := translation.NewTranslation("new", "new", "new", "new", "new", []string{}, "new")
+				tr, err := translation.NewTranslation("new", "new", []translation.Sense{translation.NewSense("new", "new", []string{})}, "new", "new")
 				assert.Nil(t, err)
 				translationRepo.On("Get", "testID", "testAuthor").Return(tr, nil)
 				translationRepo.On("Update", mock.AnythingOfType("*translation.Translation")).Return(errors.New("testErr"))
@@ -74,7 +75,7 @@ func TestUpdateTranslationHandler_Handle_NegativeCases(t *testing.T) {
 					validator:       newSuccessValidator(),
 				}
 			},
-			args{cmd: UpdateTranslation{ID: "testID", Source: "test", Target: "test", TagIDs: []string{"tag1"}, AuthorID: "testAuthor", LangID: "langID"}},
+			args{cmd: UpdateTranslation{ID: "testID", Source: "test", Senses: []TranslationSense{{Target: "test", TagIDs: []string{"tag1"}}}, AuthorID: "testAuthor", LangID: "langID"}},
 			assert.Error,
 		},
 	}
@@ -96,23 +97,24 @@ func TestUpdateTranslationHandler_Handle_PositiveCase(t *testing.T) {
 	langID := "langID"
 
 	translationRepo := translation.MockRepository{}
-	tr, err := translation.NewTranslation("test", "", "test", authorID, "", []string{}, "new")
+	tr, err := translation.NewTranslation("test", "", []translation.Sense{translation.NewSense("test", "", []string{})}, authorID, "new")
 	assert.Nil(t, err)
 	translationRepo.On("Get", id, authorID).Return(tr, nil)
 	translationRepo.On("Update", mock.AnythingOfType("*translation.Translation")).Return(nil)
+	revisionRepo := translation.MockRevisionRepository{}
+	revisionRepo.On("Create", mock.AnythingOfType("*translation.Revision")).Return(nil)
 
 	handler := UpdateTranslationHandler{
 		translationRepo: &translationRepo,
+		revisionRepo:    &revisionRepo,
 		validator:       newSuccessValidator(),
 	}
 
 	cmd := UpdateTranslation{
 		ID:            id,
-		Target:        "transcription",
 		Transcription: "translation",
 		Source:        "text",
-		Example:       "example",
-		TagIDs:        tags,
+		Senses:        []TranslationSense{{Target: "transcription", Example: "example", TagIDs: tags}},
 		AuthorID:      authorID,
 		LangID:        langID,
 	}
@@ -122,9 +124,74 @@ func TestUpdateTranslationHandler_Handle_PositiveCase(t *testing.T) {
 	data := updatedTranslation.ToMap()
 
 	assert.Equal(t, cmd.Transcription, data["transcription"])
-	assert.Equal(t, cmd.Target, data["target"])
 	assert.Equal(t, cmd.Source, data["source"])
-	assert.Equal(t, cmd.Example, data["example"])
-	assert.Equal(t, cmd.TagIDs, data["tagIDs"])
+	assert.Equal(t, []map[string]interface{}{{"target": "transcription", "example": "example", "tagIDs": tags}}, data["senses"])
 	assert.Equal(t, cmd.LangID, data["langID"])
+
+	revision := revisionRepo.Calls[0].Arguments[0].(*translation.Revision)
+	revisionData := revision.ToMap()
+	assert.Equal(t, tr.ID(), revision.TranslationID())
+	assert.Equal(t, "test", revisionData["source"])
+	assert.Equal(t, "new", revisionData["langID"])
+	assert.Contains(t, revision.Changes(), translation.NewFieldChange("source", "test", "text"))
+}
+
+func TestUpdateTranslationHandler_Handle_NothingChanged(t *testing.T) {
+	authorID := "testAuthor"
+	id := "testID"
+
+	translationRepo := translation.MockRepository{}
+	tr, err := translation.NewTranslation("test", "", []translation.Sense{translation.NewSense("test", "", []string{})}, authorID, "langID")
+	assert.Nil(t, err)
+	translationRepo.On("Get", id, authorID).Return(tr, nil)
+	translationRepo.On("Update", mock.AnythingOfType("*translation.Translation")).Return(nil)
+	revisionRepo := translation.NewMockRevisionRepository(t)
+
+	handler := UpdateTranslationHandler{
+		translationRepo: &translationRepo,
+		revisionRepo:    revisionRepo,
+		validator:       newSuccessValidator(),
+	}
+
+	assert.Nil(t, handler.Handle(UpdateTranslation{
+		ID:       id,
+		Source:   "test",
+		Senses:   []TranslationSense{{Target: "test", TagIDs: []string{}}},
+		AuthorID: authorID,
+		LangID:   "langID",
+	}))
+}
+
+func TestUpdateTranslationHandler_Handle_FieldsChanged(t *testing.T) {
+	authorID := "testAuthor"
+	id := "testID"
+
+	translationRepo := translation.MockRepository{}
+	tr, err := translation.NewTranslation("test", "", []translation.Sense{translation.NewSense("test", "", []string{})}, authorID, "langID")
+	assert.Nil(t, err)
+	translationRepo.On("Get", id, authorID).Return(tr, nil)
+	translationRepo.On("Update", mock.AnythingOfType("*translation.Translation")).Return(nil)
+	revisionRepo := translation.MockRevisionRepository{}
+	revisionRepo.On("Create", mock.AnythingOfType("*translation.Revision")).Return(nil)
+	langRepo := lang.MockRepository{}
+	langRepo.On("Get", "langID", authorID).Return(newLangWithFields(t), nil)
+
+	handler := UpdateTranslationHandler{
+		translationRepo: &translationRepo,
+		revisionRepo:    &revisionRepo,
+		validator:       validator{langRepo: &langRepo},
+	}
+
+	assert.Nil(t, handler.Handle(UpdateTranslation{
+		ID:       id,
+		Source:   "test",
+		Senses:   []TranslationSense{{Target: "test", TagIDs: []string{}}},
+		AuthorID: authorID,
+		LangID:   "langID",
+		Fields:   map[string]string{"gender": "f"},
+	}))
+
+	revision := revisionRepo.Calls[0].Arguments[0].(*translation.Revision)
+	assert.Equal(t, []translation.FieldChange{translation.NewFieldChange("fields.gender", "", "f")}, revision.Changes())
+	assert.Equal(t, map[string]string{}, revision.ToMap()["fields"])
 }
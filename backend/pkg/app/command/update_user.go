@@ -1,6 +1,7 @@
 package command
 
 import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 )
 
@@ -13,14 +14,16 @@ type UpdateUser struct {
 }
 
 type UpdateUserHandler struct {
-	userRepo user.Repository
-	cipher   Cipher
+	userRepo    user.Repository
+	cipher      Cipher
+	sessionRepo session.Repository
 }
 
-func NewUpdateUserHandler(userRepo user.Repository, cipher Cipher) UpdateUserHandler {
-	return UpdateUserHandler{userRepo: userRepo, cipher: cipher}
+func NewUpdateUserHandler(userRepo user.Repository, cipher Cipher, sessionRepo session.Repository) UpdateUserHandler {
+	return UpdateUserHandler{userRepo: userRepo, cipher: cipher, sessionRepo: sessionRepo}
 }
 
+// Handle updates user, password reset signs the user out on all devices
 func (h UpdateUserHandler) Handle(cmd UpdateUser) error {
 	usr, err := h.userRepo.Get(cmd.ID)
 	if err != nil {
@@ -36,7 +39,16 @@ func (h UpdateUserHandler) Handle(cmd UpdateUser) error {
 		return err
 	}
 
-	return h.userRepo.Update(usr)
+	if err = h.userRepo.Update(usr); err != nil {
+		return err
+	}
+
+	if cmd.Password == "" {
+		return nil
+	}
+
+	_, err = h.sessionRepo.DeleteByUserID(cmd.ID, "")
+	return err
 }
 
 func (h UpdateUserHandler) processPasswd(cmd UpdateUser, userHash string) (string, error) {
@@ -3,6 +3,7 @@ package command
 import (
 	"errors"
 	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -137,7 +138,10 @@ func TestUpdateUserHandler_Handle_PositiveCases(t *testing.T) {
 		Role:     newRole,
 	}
 
-	handler := NewUpdateUserHandler(&usrRepo, &cipher)
+	sessionRepo := session.NewMockRepository(t)
+	sessionRepo.On("DeleteByUserID", ID, "").Return(1, nil)
+
+	handler := NewUpdateUserHandler(&usrRepo, &cipher, sessionRepo)
 	assert.Nil(t, handler.Handle(cmd))
 
 	updatedUsr := usrRepo.Calls[1].Arguments[0].(*user.User)
@@ -148,6 +152,7 @@ func TestUpdateUserHandler_Handle_PositiveCases(t *testing.T) {
 	assert.Equal(t, newHash, data["password"])
 	assert.Equal(t, int(cmd.Role), data["role"])
 	assert.Equal(t, usr.DefaultLangID(), data["defaultLangID"])
+	assert.Equal(t, 1, updatedUsr.TokenVersion())
 }
 
 func TestUpdateUserHandler_processPasswd(t *testing.T) {
@@ -17,6 +17,7 @@ type translationData struct {
 	LangID   string
 	AuthorID string
 	Source   string
+	Fields   map[string]string
 }
 
 func newValidator(tagRepo tag.Repository, langRepo lang.Repository) validator {
@@ -52,7 +53,17 @@ func (v validator) validateTags(data translationData) error {
 	return nil
 }
 
+// validateLang checks that lang exists and custom field values match its schema
 func (v validator) validateLang(data translationData) error {
+	if len(data.Fields) != 0 {
+		ln, err := v.langRepo.Get(data.LangID, data.AuthorID)
+		if err != nil {
+			return fmt.Errorf("can not get lang with id: %s: %w", data.LangID, err)
+		}
+
+		return ln.ValidateFieldValues(data.Fields)
+	}
+
 	exist, err := v.langRepo.Exist(data.LangID, data.AuthorID)
 	if err != nil {
 		return err
@@ -125,6 +125,48 @@ func Test_validator_validateLang(t *testing.T) {
 			args{data: translationData{LangID: "langID", AuthorID: "testAuthor"}},
 			assert.NoError,
 		},
+		{
+			"Lang with fields not exist",
+			func() fields {
+				langRepo := lang.MockRepository{}
+				langRepo.On("Get", "langID", "testAuthor").Return(nil, lang.ErrNotFound)
+				return fields{
+					langRepo: &langRepo,
+				}
+			},
+			args{data: translationData{LangID: "langID", AuthorID: "testAuthor", Fields: map[string]string{"gender": "m"}}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, lang.ErrNotFound, i)
+				return true
+			},
+		},
+		{
+			"Field values do not match lang schema",
+			func() fields {
+				langRepo := lang.MockRepository{}
+				langRepo.On("Get", "langID", "testAuthor").Return(newLangWithFields(t), nil)
+				return fields{
+					langRepo: &langRepo,
+				}
+			},
+			args{data: translationData{LangID: "langID", AuthorID: "testAuthor", Fields: map[string]string{"gender": "x"}}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "value x is not one of field gender options [m f n]", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Field values match lang schema",
+			func() fields {
+				langRepo := lang.MockRepository{}
+				langRepo.On("Get", "langID", "testAuthor").Return(newLangWithFields(t), nil)
+				return fields{
+					langRepo: &langRepo,
+				}
+			},
+			args{data: translationData{LangID: "langID", AuthorID: "testAuthor", Fields: map[string]string{"gender": "m", "plural": "Hunde", "separable": "false"}}},
+			assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -196,6 +238,16 @@ func Test_validator_validate(t *testing.T) {
 	}
 }
 
+func newLangWithFields(t *testing.T) *lang.Lang {
+	ln := lang.UnmarshalFromDB("langID", "DE", "testAuthor", lang.Metadata{}, nil)
+	assert.Nil(t, ln.ApplyFields([]lang.Field{
+		lang.NewField("gender", lang.EnumField, []string{"m", "f", "n"}),
+		lang.NewField("plural", lang.TextField, nil),
+		lang.NewField("separable", lang.BooleanField, nil),
+	}))
+	return ln
+}
+
 func newFailValidator() validator {
 	tagRepo := tag.MockRepository{}
 	tagRepo.On("AllExist", mock.Anything, mock.Anything).Return(false, fmt.Errorf("testErr"))
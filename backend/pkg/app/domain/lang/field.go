@@ -0,0 +1,140 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const maxFields = 10
+
+// FieldKind defines which values custom field accepts
+type FieldKind string
+
+const (
+	TextField    FieldKind = "text"
+	EnumField    FieldKind = "enum"
+	BooleanField FieldKind = "boolean"
+)
+
+func (k FieldKind) valid() bool {
+	return k == TextField || k == EnumField || k == BooleanField
+}
+
+// Field custom field of the lang translations, e.g. gender of German nouns
+type Field struct {
+	name    string
+	kind    FieldKind
+	options []string // options allowed values of the enum field
+}
+
+func NewField(name string, kind FieldKind, options []string) Field {
+	return Field{
+		name:    name,
+		kind:    kind,
+		options: options,
+	}
+}
+
+func (f Field) Name() string {
+	return f.name
+}
+
+func (f Field) Kind() FieldKind {
+	return f.kind
+}
+
+func (f Field) Options() []string {
+	return f.options
+}
+
+func (f Field) validate() error {
+	var err error
+	if f.name == "" {
+		err = errors.Join(errors.New("field name can not be empty"), err)
+	}
+
+	nameCount := utf8.RuneCountInString(f.name)
+	if nameCount > 50 {
+		err = errors.Join(fmt.Errorf("field name max size is 50 characters, %d passed (%s)", nameCount, f.name), err)
+	}
+
+	if !f.kind.valid() {
+		err = errors.Join(fmt.Errorf("unknown kind %s of field %s", f.kind, f.name), err)
+	}
+
+	if f.kind == EnumField && len(f.options) == 0 {
+		err = errors.Join(fmt.Errorf("enum field %s should have options", f.name), err)
+	}
+
+	if f.kind != EnumField && len(f.options) != 0 {
+		err = errors.Join(fmt.Errorf("options can be set for enum field only, %s is %s", f.name, f.kind), err)
+	}
+
+	seen := map[string]struct{}{}
+	for _, option := range f.options {
+		if option == "" {
+			err = errors.Join(fmt.Errorf("option of field %s can not be empty", f.name), err)
+		}
+
+		if _, ok := seen[option]; ok {
+			err = errors.Join(fmt.Errorf("option %s of field %s is duplicated", option, f.name), err)
+		}
+		seen[option] = struct{}{}
+	}
+
+	return err
+}
+
+// validateValue checks that value is accepted by the field, boolean values are expected as "true" or "false"
+func (f Field) validateValue(value string) error {
+	switch f.kind {
+	case BooleanField:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("value of boolean field %s should be true or false, %s passed", f.name, value)
+		}
+	case EnumField:
+		for _, option := range f.options {
+			if option == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %s is not one of field %s options %v", value, f.name, f.options)
+	}
+
+	valueCount := utf8.RuneCountInString(value)
+	if valueCount > 255 {
+		return fmt.Errorf("value of field %s max size is 255 characters, %d passed", f.name, valueCount)
+	}
+
+	return nil
+}
+
+func (f Field) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"name":    f.name,
+		"kind":    string(f.kind),
+		"options": append([]string{}, f.options...),
+	}
+}
+
+func validateFields(fields []Field) error {
+	var err error
+	if len(fields) > maxFields {
+		err = errors.Join(fmt.Errorf("field max amount is %d, %d passed", maxFields, len(fields)), err)
+	}
+
+	seen := map[string]struct{}{}
+	for _, field := range fields {
+		if fErr := field.validate(); fErr != nil {
+			err = errors.Join(fErr, err)
+		}
+
+		if _, ok := seen[field.name]; ok {
+			err = errors.Join(fmt.Errorf("field %s is duplicated", field.name), err)
+		}
+		seen[field.name] = struct{}{}
+	}
+
+	return err
+}
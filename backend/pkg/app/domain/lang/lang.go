@@ -10,13 +10,16 @@ type Lang struct {
 	id       string
 	name     string
 	authorID string
+	metadata Metadata
+	fields   []Field
 }
 
-func NewLang(name, authorID string) (*Lang, error) {
+func NewLang(name, authorID string, metadata Metadata) (*Lang, error) {
 	ln := Lang{
 		id:       uuid.New().String(),
 		name:     name,
 		authorID: authorID,
+		metadata: metadata,
 	}
 
 	if err := ln.validate(); err != nil {
@@ -38,20 +41,81 @@ func (l *Lang) Name() string {
 	return l.name
 }
 
-func (l *Lang) ApplyChanges(name string) error {
+func (l *Lang) Metadata() Metadata {
+	return l.metadata
+}
+
+// Fields returns schema of the lang translations custom fields
+func (l *Lang) Fields() []Field {
+	return l.fields
+}
+
+// ApplyFields replaces schema of the lang translations custom fields, values already stored in translations are not changed,
+// UpdateLang cmd drops the ones not matching the new schema
+func (l *Lang) ApplyFields(fields []Field) error {
+	if err := validateFields(fields); err != nil {
+		return err
+	}
+
+	l.fields = fields
+	return nil
+}
+
+// ValidateFieldValues checks translation custom field values against the lang schema
+func (l *Lang) ValidateFieldValues(values map[string]string) error {
+	var err error
+	for name, value := range values {
+		field, ok := l.field(name)
+		if !ok {
+			err = errors.Join(fmt.Errorf("field %s is not defined for lang %s", name, l.name), err)
+			continue
+		}
+
+		if vErr := field.validateValue(value); vErr != nil {
+			err = errors.Join(vErr, err)
+		}
+	}
+
+	return err
+}
+
+// FilterFieldValues returns translation custom field values matching the lang schema, the rest are dropped
+func (l *Lang) FilterFieldValues(values map[string]string) map[string]string {
+	valid := make(map[string]string, len(values))
+	for name, value := range values {
+		if field, ok := l.field(name); ok && field.validateValue(value) == nil {
+			valid[name] = value
+		}
+	}
+
+	return valid
+}
+
+func (l *Lang) field(name string) (Field, bool) {
+	for _, field := range l.fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+
+	return Field{}, false
+}
+
+func (l *Lang) ApplyChanges(name string, metadata Metadata) error {
 	updated := *l
-	updated.applyChanges(name)
+	updated.applyChanges(name, metadata)
 
 	if err := updated.validate(); err != nil {
 		return err
 	}
 
-	l.applyChanges(name)
+	l.applyChanges(name, metadata)
 	return nil
 }
 
-func (l *Lang) applyChanges(name string) {
+func (l *Lang) applyChanges(name string, metadata Metadata) {
 	l.name = name
+	l.metadata = metadata
 }
 
 func (l *Lang) validate() error {
@@ -64,25 +128,45 @@ func (l *Lang) validate() error {
 		err = errors.Join(fmt.Errorf("authorID can not be empty"), err)
 	}
 
+	if metadataErr := l.metadata.validate(); metadataErr != nil {
+		err = errors.Join(metadataErr, err)
+	}
+
 	return err
 }
 
 func (l *Lang) ToMap() map[string]interface{} {
 	return map[string]interface{}{
-		"id":       l.id,
-		"name":     l.name,
-		"authorID": l.authorID,
+		"id":                  l.id,
+		"name":                l.name,
+		"authorID":            l.authorID,
+		"code":                l.metadata.code,
+		"direction":           string(l.metadata.Direction()),
+		"transcriptionScheme": string(l.metadata.transcriptionScheme),
+		"fields":              l.fieldsToMap(),
+	}
+}
+
+func (l *Lang) fieldsToMap() []map[string]interface{} {
+	fields := make([]map[string]interface{}, 0, len(l.fields))
+	for _, field := range l.fields {
+		fields = append(fields, field.ToMap())
 	}
+	return fields
 }
 
 func UnmarshalFromDB(
 	id string,
 	name string,
 	authorID string,
+	metadata Metadata,
+	fields []Field,
 ) *Lang {
 	return &Lang{
 		id:       id,
 		name:     name,
 		authorID: authorID,
+		metadata: metadata,
+		fields:   fields,
 	}
 }
@@ -9,7 +9,8 @@ import (
 
 func TestLang_ApplyChanges(t *testing.T) {
 	type args struct {
-		name string
+		name     string
+		metadata Metadata
 	}
 	tests := []struct {
 		name    string
@@ -18,15 +19,23 @@ func TestLang_ApplyChanges(t *testing.T) {
 	}{
 		{
 			"Error on validation",
-			args{name: ""},
+			args{name: "", metadata: NewMetadata("", "", NoTranscription)},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Equal(t, "name can not be empty", err.Error(), i)
 				return true
 			},
 		},
+		{
+			"Error on metadata validation",
+			args{name: "de", metadata: NewMetadata("xx", "", NoTranscription)},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "unknown ISO 639-1 lang code xx", err.Error(), i)
+				return true
+			},
+		},
 		{
 			"Positive case",
-			args{name: "de"},
+			args{name: "de", metadata: NewMetadata("DE", "", IPA)},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Nil(t, err, i)
 				return false
@@ -38,10 +47,14 @@ func TestLang_ApplyChanges(t *testing.T) {
 			l := &Lang{
 				name:     "en",
 				authorID: "authorID",
+				metadata: NewMetadata("en", "", NoTranscription),
 			}
-			if tt.wantErr(t1, l.ApplyChanges(tt.args.name), fmt.Sprintf("ApplyChanges(%v)", tt.args.name)) {
+			if tt.wantErr(t1, l.ApplyChanges(tt.args.name, tt.args.metadata), fmt.Sprintf("ApplyChanges(%v)", tt.args.name)) {
 				assert.Equal(t1, "en", l.name)
+				assert.Equal(t1, "en", l.metadata.Code())
+				return
 			}
+			assert.Equal(t1, tt.args.metadata, l.metadata)
 		})
 	}
 }
@@ -89,7 +102,7 @@ func TestNewLang(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewLang(tt.args.name, tt.args.authorID)
+			got, err := NewLang(tt.args.name, tt.args.authorID, Metadata{})
 			if !tt.wantErr(t, err, fmt.Sprintf("NewLang(%v, %v)", tt.args.name, tt.args.authorID)) {
 				return
 			}
@@ -181,7 +194,162 @@ func TestUnmarshalFromDB(t *testing.T) {
 		id:       "testId",
 		name:     "testLang",
 		authorID: "testAuthor",
+		metadata: NewMetadata("he", RightToLeft, Romanization),
+		fields:   []Field{NewField("gender", EnumField, []string{"m", "f"})},
 	}
 
-	assert.Equal(t, &ln, UnmarshalFromDB(ln.id, ln.name, ln.authorID))
+	assert.Equal(t, &ln, UnmarshalFromDB(ln.id, ln.name, ln.authorID, ln.metadata, ln.fields))
+}
+
+func TestLang_ApplyFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []Field
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			"Unknown kind",
+			[]Field{NewField("gender", "number", nil)},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "unknown kind number of field gender", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Enum without options",
+			[]Field{NewField("gender", EnumField, nil)},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "enum field gender should have options", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Options of not enum field",
+			[]Field{NewField("plural", TextField, []string{"s"})},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "options can be set for enum field only, plural is text", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Multiple errors",
+			[]Field{NewField("gender", EnumField, []string{"m", "m", ""}), NewField("gender", BooleanField, nil), NewField("", TextField, nil)},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.True(t, strings.Contains(err.Error(), "option m of field gender is duplicated"), i)
+				assert.True(t, strings.Contains(err.Error(), "option of field gender can not be empty"), i)
+				assert.True(t, strings.Contains(err.Error(), "field gender is duplicated"), i)
+				assert.True(t, strings.Contains(err.Error(), "field name can not be empty"), i)
+				return true
+			},
+		},
+		{
+			"Positive case",
+			[]Field{NewField("gender", EnumField, []string{"m", "f"}), NewField("plural", TextField, nil), NewField("separable", BooleanField, nil)},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Nil(t, err, i)
+				return false
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Lang{name: "DE", authorID: "authorID"}
+			if tt.wantErr(t, l.ApplyFields(tt.fields), fmt.Sprintf("ApplyFields(%v)", tt.fields)) {
+				assert.Nil(t, l.Fields())
+				return
+			}
+			assert.Equal(t, tt.fields, l.Fields())
+		})
+	}
+}
+
+func TestLang_ValidateFieldValues(t *testing.T) {
+	l := &Lang{
+		name:   "DE",
+		fields: []Field{NewField("gender", EnumField, []string{"m", "f"}), NewField("plural", TextField, nil), NewField("separable", BooleanField, nil)},
+	}
+
+	assert.Nil(t, l.ValidateFieldValues(map[string]string{"gender": "f", "plural": "Katzen", "separable": "true"}))
+	assert.Nil(t, l.ValidateFieldValues(nil))
+	assert.Equal(t, "field reading is not defined for lang DE", l.ValidateFieldValues(map[string]string{"reading": "ねこ"}).Error())
+	assert.Equal(t, "value of boolean field separable should be true or false, yes passed", l.ValidateFieldValues(map[string]string{"separable": "yes"}).Error())
+	assert.Equal(t, "value x is not one of field gender options [m f]", l.ValidateFieldValues(map[string]string{"gender": "x"}).Error())
+	assert.Equal(t, "value of field plural max size is 255 characters, 256 passed", l.ValidateFieldValues(map[string]string{"plural": strings.Repeat("a", 256)}).Error())
+}
+
+func TestLang_FilterFieldValues(t *testing.T) {
+	l := &Lang{
+		name:   "DE",
+		fields: []Field{NewField("gender", EnumField, []string{"m", "f"}), NewField("separable", BooleanField, nil)},
+	}
+
+	assert.Equal(t, map[string]string{"gender": "f"}, l.FilterFieldValues(map[string]string{"gender": "f", "plural": "Katzen", "separable": "yes"}))
+	assert.Equal(t, map[string]string{}, l.FilterFieldValues(nil))
+}
+
+func TestMetadata_Direction(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata Metadata
+		want     Direction
+	}{
+		{"Detected by right-to-left code", NewMetadata(" HE ", "", Romanization), RightToLeft},
+		{"Detected by left-to-right code", NewMetadata("en", "", IPA), LeftToRight},
+		{"Left-to-right without code", Metadata{}, LeftToRight},
+		{"Declared direction", NewMetadata("ar", LeftToRight, NoTranscription), LeftToRight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, tt.metadata.Direction(), "Direction()")
+		})
+	}
+}
+
+func TestMetadata_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata Metadata
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Unknown code",
+			NewMetadata("english", "", NoTranscription),
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "unknown ISO 639-1 lang code english", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Unknown direction",
+			NewMetadata("en", "ttb", NoTranscription),
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "unknown text direction ttb", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Multiple errors",
+			NewMetadata("xx", "", "hepburn"),
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.True(t, strings.Contains(err.Error(), "unknown ISO 639-1 lang code xx"), i)
+				assert.True(t, strings.Contains(err.Error(), "unknown transcription scheme hepburn"), i)
+				return true
+			},
+		},
+		{
+			"Empty code",
+			NewMetadata("", RightToLeft, Pinyin),
+			assert.NoError,
+		},
+		{
+			"Positive case",
+			NewMetadata("zh", "", Pinyin),
+			assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, tt.metadata.validate(), "validate()")
+		})
+	}
 }
@@ -0,0 +1,98 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Direction of the lang script
+type Direction string
+
+const (
+	LeftToRight Direction = "ltr"
+	RightToLeft Direction = "rtl"
+)
+
+func (d Direction) valid() bool {
+	return d == LeftToRight || d == RightToLeft
+}
+
+// TranscriptionScheme declares how transcriptions of the lang translations are written
+type TranscriptionScheme string
+
+const (
+	NoTranscription TranscriptionScheme = ""
+	IPA             TranscriptionScheme = "ipa"
+	Romanization    TranscriptionScheme = "romanization"
+	Pinyin          TranscriptionScheme = "pinyin"
+)
+
+func (s TranscriptionScheme) valid() bool {
+	return s == NoTranscription || s == IPA || s == Romanization || s == Pinyin
+}
+
+// Metadata optional lang properties used by clients to render translations
+type Metadata struct {
+	code                string
+	direction           Direction
+	transcriptionScheme TranscriptionScheme
+}
+
+// NewMetadata normalizes code, empty direction means the one detected by the code
+func NewMetadata(code string, direction Direction, transcriptionScheme TranscriptionScheme) Metadata {
+	return Metadata{
+		code:                strings.ToLower(strings.TrimSpace(code)),
+		direction:           direction,
+		transcriptionScheme: transcriptionScheme,
+	}
+}
+
+func (m Metadata) Code() string {
+	return m.code
+}
+
+// Direction returns declared direction or the one detected by the code
+func (m Metadata) Direction() Direction {
+	if m.direction == "" {
+		return DefaultDirection(m.code)
+	}
+
+	return m.direction
+}
+
+func (m Metadata) TranscriptionScheme() TranscriptionScheme {
+	return m.transcriptionScheme
+}
+
+func (m Metadata) validate() error {
+	var err error
+	if m.code != "" && !ValidCode(m.code) {
+		err = errors.Join(fmt.Errorf("unknown ISO 639-1 lang code %s", m.code), err)
+	}
+
+	if m.direction != "" && !m.direction.valid() {
+		err = errors.Join(fmt.Errorf("unknown text direction %s", m.direction), err)
+	}
+
+	if !m.transcriptionScheme.valid() {
+		err = errors.Join(fmt.Errorf("unknown transcription scheme %s", m.transcriptionScheme), err)
+	}
+
+	return err
+}
+
+// DefaultDirection returns direction of the script commonly used by the lang with passed ISO 639-1 code
+func DefaultDirection(code string) Direction {
+	if _, ok := rightToLeftCodes[code]; ok {
+		return RightToLeft
+	}
+
+	return LeftToRight
+}
+
+// ValidCode checks passed code against ISO 639-1 codes table
+func ValidCode(code string) bool {
+	_, ok := isoCodes[code]
+	return ok
+}
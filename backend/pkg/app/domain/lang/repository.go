@@ -10,6 +10,7 @@ type Repository interface {
 	Exist(id, authorID string) (bool, error)
 	Update(lang *Lang) error // Update returns ErrLangAlreadyExists if record for pair name-authorID already exists
 	Get(id, authorID string) (*Lang, error)
+	GetByName(name, authorID string) (*Lang, error) // GetByName provide lang by exact name and authorID, return ErrNotFound when lang not exist
 	Delete(id, authorID string) error
 	DeleteByAuthorID(authorID string) (int, error)
 }
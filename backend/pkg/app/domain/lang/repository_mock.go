@@ -112,6 +112,32 @@ func (_m *MockRepository) Get(id string, authorID string) (*Lang, error) {
 	return r0, r1
 }
 
+// GetByName provides a mock function with given fields: name, authorID
+func (_m *MockRepository) GetByName(name string, authorID string) (*Lang, error) {
+	ret := _m.Called(name, authorID)
+
+	var r0 *Lang
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*Lang, error)); ok {
+		return rf(name, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *Lang); ok {
+		r0 = rf(name, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Lang)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: lang
 func (_m *MockRepository) Update(lang *Lang) error {
 	ret := _m.Called(lang)
@@ -0,0 +1,16 @@
+package session
+
+import "errors"
+
+var ErrNotFound = errors.New("can not find session in store")
+
+// Repository defines domain session repository methods
+type Repository interface {
+	Create(s *Session) error
+	Get(id string) (*Session, error) // Get provides not expired session by id, return ErrNotFound if record not exists
+	// Update saves rotated session only when the stored one still keeps presentedTokenID, so the token can be exchanged only once
+	// by parallel refreshes as well, ErrTokenReused is returned when the token was already exchanged or the session was removed
+	Update(s *Session, presentedTokenID string) error
+	Delete(id, userID string) error
+	DeleteByUserID(userID, exceptID string) (int, error) // DeleteByUserID removes all user sessions except the one with exceptID, empty exceptID means all
+}
@@ -0,0 +1,118 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package session
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=Repository --filename=repository_mock.go --output=./ --structname=MockRepository --inpackage
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: s
+func (_m *MockRepository) Create(s *Session) error {
+	ret := _m.Called(s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*Session) error); ok {
+		r0 = rf(s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: id, userID
+func (_m *MockRepository) Delete(id string, userID string) error {
+	ret := _m.Called(id, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUserID provides a mock function with given fields: userID, exceptID
+func (_m *MockRepository) DeleteByUserID(userID string, exceptID string) (int, error) {
+	ret := _m.Called(userID, exceptID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (int, error)); ok {
+		return rf(userID, exceptID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(userID, exceptID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, exceptID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: id
+func (_m *MockRepository) Get(id string) (*Session, error) {
+	ret := _m.Called(id)
+
+	var r0 *Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*Session, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *Session); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: s, presentedTokenID
+func (_m *MockRepository) Update(s *Session, presentedTokenID string) error {
+	ret := _m.Called(s, presentedTokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*Session, string) error); ok {
+		r0 = rf(s, presentedTokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockRepository(t mockConstructorTestingTNewMockRepository) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
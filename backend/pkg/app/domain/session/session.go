@@ -0,0 +1,156 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrTokenReused = errors.New("session: refresh token was already used")
+
+const maxUserAgentLength = 255
+
+// Session is a sign-in of the user on the device, it keeps the id of the only refresh token which can be exchanged
+type Session struct {
+	id          string
+	userID      string
+	tokenID     string // tokenID id of the latest issued refresh token
+	userAgent   string
+	ip          string
+	createdAt   time.Time
+	refreshedAt time.Time
+	expiresAt   time.Time
+}
+
+// NewSession creates session with the first refresh token id, too long user agent is cut to be stored
+func NewSession(userID, userAgent, ip string, expiresAt time.Time) (*Session, error) {
+	now := time.Now()
+	s := Session{
+		id:          uuid.New().String(),
+		userID:      userID,
+		tokenID:     uuid.New().String(),
+		userAgent:   truncate(userAgent, maxUserAgentLength),
+		ip:          ip,
+		createdAt:   now,
+		refreshedAt: now,
+		expiresAt:   expiresAt,
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (s *Session) ID() string {
+	return s.id
+}
+
+func (s *Session) UserID() string {
+	return s.userID
+}
+
+func (s *Session) TokenID() string {
+	return s.tokenID
+}
+
+func (s *Session) UserAgent() string {
+	return s.userAgent
+}
+
+func (s *Session) IP() string {
+	return s.ip
+}
+
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Session) RefreshedAt() time.Time {
+	return s.refreshedAt
+}
+
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// Expired checks whether the session can not be refreshed anymore
+func (s *Session) Expired() bool {
+	return !s.expiresAt.After(time.Now())
+}
+
+// Rotate issues the new refresh token id in exchange for the presented one,
+// ErrTokenReused means the presented token was already exchanged, so it is likely stolen
+func (s *Session) Rotate(presentedTokenID string, expiresAt time.Time) error {
+	if presentedTokenID != s.tokenID {
+		return ErrTokenReused
+	}
+
+	if s.Expired() {
+		return fmt.Errorf("session %s is expired", s.id)
+	}
+
+	s.tokenID = uuid.New().String()
+	s.refreshedAt = time.Now()
+	s.expiresAt = expiresAt
+	return s.validate()
+}
+
+func (s *Session) validate() error {
+	var err error
+	if s.userID == "" {
+		err = errors.Join(errors.New("userID can not be empty"), err)
+	}
+
+	if !s.expiresAt.After(s.refreshedAt) {
+		err = errors.Join(errors.New("expiration time should be after the refresh time"), err)
+	}
+
+	return err
+}
+
+func (s *Session) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          s.id,
+		"userID":      s.userID,
+		"tokenID":     s.tokenID,
+		"userAgent":   s.userAgent,
+		"ip":          s.ip,
+		"createdAt":   s.createdAt,
+		"refreshedAt": s.refreshedAt,
+		"expiresAt":   s.expiresAt,
+	}
+}
+
+func UnmarshalFromDB(
+	id string,
+	userID string,
+	tokenID string,
+	userAgent string,
+	ip string,
+	createdAt time.Time,
+	refreshedAt time.Time,
+	expiresAt time.Time,
+) *Session {
+	return &Session{
+		id:          id,
+		userID:      userID,
+		tokenID:     tokenID,
+		userAgent:   userAgent,
+		ip:          ip,
+		createdAt:   createdAt,
+		refreshedAt: refreshedAt,
+		expiresAt:   expiresAt,
+	}
+}
+
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+
+	return string([]rune(value)[:length])
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestNewSession(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	s, err := NewSession("testUser", "Mozilla/5.0", "127.0.0.1", expiresAt)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, s.ID())
+	assert.NotEmpty(t, s.TokenID())
+	assert.Equal(t, "testUser", s.UserID())
+	assert.Equal(t, "Mozilla/5.0", s.UserAgent())
+	assert.Equal(t, "127.0.0.1", s.IP())
+	assert.Equal(t, expiresAt, s.ExpiresAt())
+	assert.Equal(t, s.CreatedAt(), s.RefreshedAt())
+	assert.False(t, s.Expired())
+
+	s, err = NewSession("testUser", strings.Repeat("ä", 300), "", expiresAt)
+	assert.Nil(t, err)
+	assert.Equal(t, maxUserAgentLength, utf8.RuneCountInString(s.UserAgent()))
+}
+
+func TestNewSession_validate(t *testing.T) {
+	_, err := NewSession("", "", "", time.Now().Add(-time.Minute))
+	assert.True(t, strings.Contains(err.Error(), "userID can not be empty"))
+	assert.True(t, strings.Contains(err.Error(), "expiration time should be after the refresh time"))
+}
+
+func TestSession_Rotate(t *testing.T) {
+	s, err := NewSession("testUser", "", "", time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+
+	tokenID := s.TokenID()
+	expiresAt := time.Now().Add(2 * time.Hour)
+	assert.Nil(t, s.Rotate(tokenID, expiresAt))
+	assert.NotEqual(t, tokenID, s.TokenID())
+	assert.Equal(t, expiresAt, s.ExpiresAt())
+
+	assert.Equal(t, ErrTokenReused, s.Rotate(tokenID, expiresAt))
+
+	expired := UnmarshalFromDB("id", "testUser", "tokenID", "", "", time.Now().Add(-time.Hour), time.Now().Add(-time.Hour), time.Now().Add(-time.Minute))
+	assert.True(t, expired.Expired())
+	assert.Equal(t, "session id is expired", expired.Rotate("tokenID", expiresAt).Error())
+}
@@ -4,12 +4,16 @@ import "errors"
 
 var ErrNotFound = errors.New("can not find tag in store")
 var ErrTagAlreadyExists = errors.New("tag already exists")
+var ErrCycle = errors.New("tag can not be nested into itself or its descendants")
 
 type Repository interface {
-	Create(tag *Tag) error                 // Create returns ErrTagAlreadyExists if record for pair name-authorID already exists
-	Update(tag *Tag) error                 // Update returns ErrTagAlreadyExists if record for pair name-authorID already exists
-	Get(id, authorID string) (*Tag, error) // Get provide tag by id and authorID, return ErrNotFound when tag not exist
+	Create(tag *Tag) error                         // Create returns ErrTagAlreadyExists if record for pair name-authorID already exists
+	Update(tag *Tag) error                         // Update returns ErrTagAlreadyExists if record for pair name-authorID already exists
+	Get(id, authorID string) (*Tag, error)         // Get provide tag by id and authorID, return ErrNotFound when tag not exist
+	GetByName(name, authorID string) (*Tag, error) // GetByName provide tag by exact name and authorID, return ErrNotFound when tag not exist
 	Delete(id, authorID string) error
 	AllExist(ids []string, authorID string) (bool, error)
 	DeleteByAuthorID(authorID string) (int, error)
+	ExistByParent(parentID, authorID string) (bool, error)     // ExistByParent checks if any tag is nested into the parent
+	MoveChildren(parentID, newParentID, authorID string) error // MoveChildren nests all tags nested into the parent into the new parent
 }
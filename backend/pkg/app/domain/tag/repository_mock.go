@@ -86,6 +86,30 @@ func (_m *MockRepository) DeleteByAuthorID(authorID string) (int, error) {
 	return r0, r1
 }
 
+// ExistByParent provides a mock function with given fields: parentID, authorID
+func (_m *MockRepository) ExistByParent(parentID string, authorID string) (bool, error) {
+	ret := _m.Called(parentID, authorID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(parentID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(parentID, authorID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(parentID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: id, authorID
 func (_m *MockRepository) Get(id string, authorID string) (*Tag, error) {
 	ret := _m.Called(id, authorID)
@@ -112,6 +136,46 @@ func (_m *MockRepository) Get(id string, authorID string) (*Tag, error) {
 	return r0, r1
 }
 
+// GetByName provides a mock function with given fields: name, authorID
+func (_m *MockRepository) GetByName(name string, authorID string) (*Tag, error) {
+	ret := _m.Called(name, authorID)
+
+	var r0 *Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*Tag, error)); ok {
+		return rf(name, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *Tag); ok {
+		r0 = rf(name, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveChildren provides a mock function with given fields: parentID, newParentID, authorID
+func (_m *MockRepository) MoveChildren(parentID string, newParentID string, authorID string) error {
+	ret := _m.Called(parentID, newParentID, authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(parentID, newParentID, authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: tag
 func (_m *MockRepository) Update(tag *Tag) error {
 	ret := _m.Called(tag)
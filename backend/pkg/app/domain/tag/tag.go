@@ -6,10 +6,14 @@ import (
 	"unicode/utf8"
 )
 
+// MaxNameLength max amount of symbols in tag name
+const MaxNameLength = 30
+
 type Tag struct {
 	id       string
 	name     string
 	authorID string
+	parentID string
 }
 
 func NewTag(name, authorID string) (*Tag, error) {
@@ -34,6 +38,11 @@ func (t *Tag) AuthorID() string {
 	return t.authorID
 }
 
+// ParentID returns ID of the tag the current one is nested into, empty for root tags
+func (t *Tag) ParentID() string {
+	return t.parentID
+}
+
 func (t *Tag) ApplyChanges(tag string) error {
 	updated := *t
 	updated.name = tag
@@ -46,6 +55,32 @@ func (t *Tag) ApplyChanges(tag string) error {
 	return nil
 }
 
+// MoveTo nests the tag into the parent, ancestorIDs are IDs of all parent ancestors used to prevent cycles,
+// nil parent makes the tag a root one
+func (t *Tag) MoveTo(parent *Tag, ancestorIDs []string) error {
+	if parent == nil {
+		t.parentID = ""
+		return nil
+	}
+
+	if parent.authorID != t.authorID {
+		return fmt.Errorf("parent tag %s belongs to another author", parent.id)
+	}
+
+	if parent.id == t.id {
+		return ErrCycle
+	}
+
+	for _, id := range ancestorIDs {
+		if id == t.id {
+			return ErrCycle
+		}
+	}
+
+	t.parentID = parent.id
+	return nil
+}
+
 func (t *Tag) validate() error {
 	tagCount := utf8.RuneCountInString(t.name)
 
@@ -53,8 +88,8 @@ func (t *Tag) validate() error {
 		return fmt.Errorf("name length should be at least 2 symbols, %d passed (%s)", tagCount, t.name)
 	}
 
-	if tagCount > 30 {
-		return fmt.Errorf("name max length is 30 symbols, %d passed (%s)", tagCount, t.name)
+	if tagCount > MaxNameLength {
+		return fmt.Errorf("name max length is %d symbols, %d passed (%s)", MaxNameLength, tagCount, t.name)
 	}
 
 	if t.authorID == "" {
@@ -69,6 +104,7 @@ func (t *Tag) ToMap() map[string]interface{} {
 		"id":       t.id,
 		"name":     t.name,
 		"authorID": t.authorID,
+		"parentID": t.parentID,
 	}
 }
 
@@ -76,10 +112,12 @@ func UnmarshalFromDB(
 	id string,
 	tag string,
 	authorID string,
+	parentID string,
 ) *Tag {
 	return &Tag{
 		id:       id,
 		name:     tag,
 		authorID: authorID,
+		parentID: parentID,
 	}
 }
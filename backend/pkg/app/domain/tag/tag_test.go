@@ -64,9 +64,26 @@ func TestUnmarshalFromDB(t *testing.T) {
 		id:       "testId",
 		name:     "testTag",
 		authorID: "testAuthor",
+		parentID: "testParent",
 	}
 
-	assert.Equal(t, &tag, UnmarshalFromDB(tag.id, tag.name, tag.authorID))
+	assert.Equal(t, &tag, UnmarshalFromDB(tag.id, tag.name, tag.authorID, tag.parentID))
+}
+
+func TestTag_MoveTo(t *testing.T) {
+	tg := &Tag{id: "tag", name: "tag", authorID: "testAuthor", parentID: "oldParent"}
+	parent := &Tag{id: "parent", name: "parent", authorID: "testAuthor"}
+
+	assert.ErrorIs(t, tg.MoveTo(tg, nil), ErrCycle)
+	assert.ErrorIs(t, tg.MoveTo(parent, []string{"grandParent", "tag"}), ErrCycle)
+	assert.Error(t, tg.MoveTo(&Tag{id: "another", name: "another", authorID: "anotherAuthor"}, nil))
+	assert.Equal(t, "oldParent", tg.ParentID())
+
+	assert.Nil(t, tg.MoveTo(parent, []string{"grandParent"}))
+	assert.Equal(t, "parent", tg.ParentID())
+
+	assert.Nil(t, tg.MoveTo(nil, nil))
+	assert.Equal(t, "", tg.ParentID())
 }
 
 func TestNewTag(t *testing.T) {
@@ -0,0 +1,14 @@
+package token
+
+import "errors"
+
+var ErrNotFound = errors.New("can not find personal access token in store")
+
+// Repository defines domain personal access token repository methods
+type Repository interface {
+	Create(t *Token) error
+	GetByHash(hash string) (*Token, error) // GetByHash provides not expired token by the secret hash, return ErrNotFound if record not exists
+	Update(t *Token) error
+	Delete(id, userID string) error
+	DeleteByUserID(userID string) (int, error)
+}
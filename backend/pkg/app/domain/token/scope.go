@@ -0,0 +1,74 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resource API resource which can be accessed with personal access token
+type Resource string
+
+const (
+	Translations Resource = "translations"
+	Tags         Resource = "tags"
+	Langs        Resource = "langs"
+	Trash        Resource = "trash"
+	Stats        Resource = "stats"
+	Dictionary   Resource = "dictionary"
+)
+
+var resources = []Resource{Translations, Tags, Langs, Trash, Stats, Dictionary}
+
+// Access level of the scope, write access includes read one
+type Access string
+
+const (
+	Read  Access = "read"
+	Write Access = "write"
+)
+
+// Scope grants access to the resource in `<resource>:<access>` format, plain `read` and `write` scopes grant access to all resources
+type Scope string
+
+// ParseScope validates and normalizes the scope
+func ParseScope(value string) (Scope, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	res, access, found := strings.Cut(value, ":")
+	if !found {
+		access, res = res, ""
+	}
+
+	if access != string(Read) && access != string(Write) {
+		return "", fmt.Errorf("scope %s has unknown access, only %s and %s are supported", value, Read, Write)
+	}
+
+	if res != "" && !knownResource(Resource(res)) {
+		return "", fmt.Errorf("scope %s has unknown resource", value)
+	}
+
+	return Scope(value), nil
+}
+
+// Allows checks whether the scope grants the access to the resource
+func (s Scope) Allows(resource Resource, access Access) bool {
+	res, scopeAccess, found := strings.Cut(string(s), ":")
+	if !found {
+		res, scopeAccess = "", res
+	}
+
+	if res != "" && Resource(res) != resource {
+		return false
+	}
+
+	return Access(scopeAccess) == Write || Access(scopeAccess) == access
+}
+
+func knownResource(resource Resource) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+
+	return false
+}
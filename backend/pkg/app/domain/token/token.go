@@ -0,0 +1,192 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SecretPrefix distinguishes personal access tokens from JWTs and makes leaked tokens easy to detect
+const SecretPrefix = "wdt_"
+
+const (
+	secretSize     = 32
+	maxNameLength  = 50
+	lastUsedPeriod = time.Minute // lastUsedPeriod precision of the last usage time, so not every request updates the token in store
+)
+
+// Token is a long-lived personal access token of the user, the secret is shown only once on creation, only its hash is kept
+type Token struct {
+	id         string
+	userID     string
+	name       string
+	hash       string
+	scopes     []Scope
+	createdAt  time.Time
+	expiresAt  time.Time // expiresAt zero time means the token never expires
+	lastUsedAt time.Time
+}
+
+// NewToken creates the token with random secret which is returned to be shown to the user
+func NewToken(userID, name string, scopes []Scope, expiresAt time.Time) (*Token, string, error) {
+	raw := make([]byte, secretSize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+
+	secret := SecretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	t := Token{
+		id:        uuid.New().String(),
+		userID:    userID,
+		name:      strings.TrimSpace(name),
+		hash:      Hash(secret),
+		scopes:    scopes,
+		createdAt: time.Now(),
+		expiresAt: expiresAt,
+	}
+
+	if err := t.validate(); err != nil {
+		return nil, "", err
+	}
+
+	return &t, secret, nil
+}
+
+// Hash secrets are random, so fast hash is enough to look the token up
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *Token) ID() string {
+	return t.id
+}
+
+func (t *Token) UserID() string {
+	return t.userID
+}
+
+func (t *Token) Name() string {
+	return t.name
+}
+
+func (t *Token) Hash() string {
+	return t.hash
+}
+
+func (t *Token) Scopes() []Scope {
+	return t.scopes
+}
+
+func (t *Token) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *Token) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *Token) LastUsedAt() time.Time {
+	return t.lastUsedAt
+}
+
+// Expired checks whether the token has expiration time which is already passed
+func (t *Token) Expired() bool {
+	return !t.expiresAt.IsZero() && !t.expiresAt.After(time.Now())
+}
+
+// Allows checks whether any of token scopes grants the access to the resource
+func (t *Token) Allows(resource Resource, access Access) bool {
+	for _, s := range t.scopes {
+		if s.Allows(resource, access) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MarkUsed updates the last usage time, returns false when it was updated recently, so the token does not need to be saved
+func (t *Token) MarkUsed() bool {
+	now := time.Now()
+	if now.Sub(t.lastUsedAt) < lastUsedPeriod {
+		return false
+	}
+
+	t.lastUsedAt = now
+	return true
+}
+
+func (t *Token) validate() error {
+	var err error
+	if t.userID == "" {
+		err = errors.Join(errors.New("userID can not be empty"), err)
+	}
+
+	nameLength := utf8.RuneCountInString(t.name)
+	if nameLength == 0 || nameLength > maxNameLength {
+		err = errors.Join(fmt.Errorf("name must contain at least 1 character and not be longer than %d characters", maxNameLength), err)
+	}
+
+	if len(t.scopes) == 0 {
+		err = errors.Join(errors.New("at least one scope is required"), err)
+	}
+
+	if !t.expiresAt.IsZero() && !t.expiresAt.After(t.createdAt) {
+		err = errors.Join(errors.New("expiration time should be in the future"), err)
+	}
+
+	return err
+}
+
+func (t *Token) ToMap() map[string]interface{} {
+	scopes := make([]string, 0, len(t.scopes))
+	for _, s := range t.scopes {
+		scopes = append(scopes, string(s))
+	}
+
+	return map[string]interface{}{
+		"id":         t.id,
+		"userID":     t.userID,
+		"name":       t.name,
+		"hash":       t.hash,
+		"scopes":     scopes,
+		"createdAt":  t.createdAt,
+		"expiresAt":  t.expiresAt,
+		"lastUsedAt": t.lastUsedAt,
+	}
+}
+
+func UnmarshalFromDB(
+	id string,
+	userID string,
+	name string,
+	hash string,
+	scopes []string,
+	createdAt time.Time,
+	expiresAt time.Time,
+	lastUsedAt time.Time,
+) *Token {
+	domainScopes := make([]Scope, 0, len(scopes))
+	for _, s := range scopes {
+		domainScopes = append(domainScopes, Scope(s))
+	}
+
+	return &Token{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		hash:       hash,
+		scopes:     domainScopes,
+		createdAt:  createdAt,
+		expiresAt:  expiresAt,
+		lastUsedAt: lastUsedAt,
+	}
+}
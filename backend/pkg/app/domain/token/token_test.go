@@ -0,0 +1,90 @@
+package token
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewToken(t *testing.T) {
+	tok, secret, err := NewToken("testUser", " cron ", []Scope{"read"}, time.Time{})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tok.ID())
+	assert.True(t, strings.HasPrefix(secret, SecretPrefix))
+	assert.Equal(t, Hash(secret), tok.Hash())
+	assert.Equal(t, "testUser", tok.UserID())
+	assert.Equal(t, "cron", tok.Name())
+	assert.False(t, tok.Expired(), "token without expiration time never expires")
+
+	_, other, err := NewToken("testUser", "cron", []Scope{"read"}, time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestNewToken_validate(t *testing.T) {
+	_, _, err := NewToken("", strings.Repeat("a", maxNameLength+1), nil, time.Now().Add(-time.Minute))
+	assert.True(t, strings.Contains(err.Error(), "userID can not be empty"))
+	assert.True(t, strings.Contains(err.Error(), "name must contain at least 1 character"))
+	assert.True(t, strings.Contains(err.Error(), "at least one scope is required"))
+	assert.True(t, strings.Contains(err.Error(), "expiration time should be in the future"))
+}
+
+func TestToken_Expired(t *testing.T) {
+	tok := UnmarshalFromDB("id", "testUser", "cron", "hash", []string{"read"}, time.Now().Add(-time.Hour), time.Now().Add(-time.Minute), time.Time{})
+	assert.True(t, tok.Expired())
+}
+
+func TestToken_Allows(t *testing.T) {
+	tests := []struct {
+		scopes   []string
+		resource Resource
+		access   Access
+		want     bool
+	}{
+		{[]string{"read"}, Translations, Read, true},
+		{[]string{"read"}, Translations, Write, false},
+		{[]string{"write"}, Dictionary, Read, true},
+		{[]string{"write"}, Dictionary, Write, true},
+		{[]string{"tags:read"}, Tags, Read, true},
+		{[]string{"tags:read"}, Tags, Write, false},
+		{[]string{"tags:read"}, Langs, Read, false},
+		{[]string{"tags:read", "translations:write"}, Translations, Write, true},
+		{[]string{"stats:write"}, Stats, Read, true},
+	}
+	for _, tt := range tests {
+		tok := UnmarshalFromDB("id", "testUser", "cron", "hash", tt.scopes, time.Now(), time.Time{}, time.Time{})
+		assert.Equalf(t, tt.want, tok.Allows(tt.resource, tt.access), "Allows(%v, %v, %v)", tt.scopes, tt.resource, tt.access)
+	}
+}
+
+func TestToken_MarkUsed(t *testing.T) {
+	tok := UnmarshalFromDB("id", "testUser", "cron", "hash", []string{"read"}, time.Now(), time.Time{}, time.Time{})
+	assert.True(t, tok.MarkUsed())
+	assert.WithinDuration(t, time.Now(), tok.LastUsedAt(), time.Second)
+	assert.False(t, tok.MarkUsed(), "recent usage is not updated")
+}
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Scope
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{"read", "read", assert.NoError},
+		{" Write ", "write", assert.NoError},
+		{"translations:read", "translations:read", assert.NoError},
+		{"dictionary:write", "dictionary:write", assert.NoError},
+		{"admin", "", assert.Error},
+		{"users:read", "", assert.Error},
+		{"tags:delete", "", assert.Error},
+		{"", "", assert.Error},
+	}
+	for _, tt := range tests {
+		got, err := ParseScope(tt.value)
+		if !tt.wantErr(t, err, "ParseScope(%v)", tt.value) {
+			continue
+		}
+		assert.Equalf(t, tt.want, got, "ParseScope(%v)", tt.value)
+	}
+}
@@ -7,11 +7,28 @@ var ErrSourceAlreadyExists = errors.New("translation with such source already ex
 
 // Repository defines domain translation repository methods
 type Repository interface {
-	Create(translation *Translation) error             // Create returns ErrSourceAlreadyExists if records with values for source-langId-authorID already exists
-	Update(translation *Translation) error             // Update saves the updated translation entity to store, returns ErrSourceAlreadyExists if records with values for source-langId-authorID already exists
-	Get(id, authorID string) (*Translation, error)     // Get provides translation by id and authorID, return ErrNotFound if record not exists
-	ExistByTag(tagID, authorID string) (bool, error)   // ExistByTag checks if at least one translation tagged with tagID exist
-	ExistByLang(langID, authorID string) (bool, error) // ExistByLang checks if at least one translation created with the passed language
+	Create(translation *Translation) error                             // Create returns ErrSourceAlreadyExists if records with values for source-langId-authorID already exists
+	Update(translation *Translation) error                             // Update saves the updated translation entity to store, returns ErrSourceAlreadyExists if records with values for source-langId-authorID already exists
+	Get(id, authorID string) (*Translation, error)                     // Get provides translation by id and authorID, return ErrNotFound if record not exists
+	ExistByTag(tagID, authorID string) (bool, error)                   // ExistByTag checks if at least one translation tagged with tagID exist
+	ExistByLang(langID, authorID string) (bool, error)                 // ExistByLang checks if at least one translation created with the passed language
+	ExistBySource(source, langID, authorID string) (bool, error)       // ExistBySource checks if translation with the source already exists in the lang
+	GetBySource(source, langID, authorID string) (*Translation, error) // GetBySource provides translation by source in the lang, return ErrNotFound if record not exists
+	GetByLang(langID, authorID string) ([]*Translation, error)         // GetByLang provides all author translations of the lang
 	Delete(id, authorID string) error
+	UpdateMany(translations []*Translation) []error           // UpdateMany saves the updated translations, returns errors in order of passed translations with nil for saved ones and ErrSourceAlreadyExists for source conflicts
+	DeleteMany(translations []*Translation) error             // DeleteMany deletes all passed translations
+	ReplaceTag(tagID, newTagID, authorID string) (int, error) // ReplaceTag retags all author translations tagged by tagID with newTagID, returns amount of updated translations
+	RemoveTag(tagID, authorID string) (int, error)            // RemoveTag detaches the tag from all author translations, returns amount of updated translations
+	DeleteByAuthorID(authorID string) (int, error)
+}
+
+var ErrRevisionNotFound = errors.New("can not find translation revision in store")
+
+// RevisionRepository defines domain translation revision repository methods
+type RevisionRepository interface {
+	Create(revision *Revision) error                           // Create saves new translation revision
+	Get(id, translationID, authorID string) (*Revision, error) // Get provides revision of the translation, return ErrRevisionNotFound if record not exists
+	DeleteByTranslationID(translationID, authorID string) error
 	DeleteByAuthorID(authorID string) (int, error)
 }
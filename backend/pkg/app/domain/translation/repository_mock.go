@@ -62,6 +62,20 @@ func (_m *MockRepository) DeleteByAuthorID(authorID string) (int, error) {
 	return r0, r1
 }
 
+// DeleteMany provides a mock function with given fields: translations
+func (_m *MockRepository) DeleteMany(translations []*Translation) error {
+	ret := _m.Called(translations)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*Translation) error); ok {
+		r0 = rf(translations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ExistByLang provides a mock function with given fields: langID, authorID
 func (_m *MockRepository) ExistByLang(langID string, authorID string) (bool, error) {
 	ret := _m.Called(langID, authorID)
@@ -86,6 +100,30 @@ func (_m *MockRepository) ExistByLang(langID string, authorID string) (bool, err
 	return r0, r1
 }
 
+// ExistBySource provides a mock function with given fields: source, langID, authorID
+func (_m *MockRepository) ExistBySource(source string, langID string, authorID string) (bool, error) {
+	ret := _m.Called(source, langID, authorID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (bool, error)); ok {
+		return rf(source, langID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) bool); ok {
+		r0 = rf(source, langID, authorID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(source, langID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExistByTag provides a mock function with given fields: tagID, authorID
 func (_m *MockRepository) ExistByTag(tagID string, authorID string) (bool, error) {
 	ret := _m.Called(tagID, authorID)
@@ -136,6 +174,106 @@ func (_m *MockRepository) Get(id string, authorID string) (*Translation, error)
 	return r0, r1
 }
 
+// GetByLang provides a mock function with given fields: langID, authorID
+func (_m *MockRepository) GetByLang(langID string, authorID string) ([]*Translation, error) {
+	ret := _m.Called(langID, authorID)
+
+	var r0 []*Translation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]*Translation, error)); ok {
+		return rf(langID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []*Translation); ok {
+		r0 = rf(langID, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Translation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(langID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBySource provides a mock function with given fields: source, langID, authorID
+func (_m *MockRepository) GetBySource(source string, langID string, authorID string) (*Translation, error) {
+	ret := _m.Called(source, langID, authorID)
+
+	var r0 *Translation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*Translation, error)); ok {
+		return rf(source, langID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *Translation); ok {
+		r0 = rf(source, langID, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Translation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(source, langID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTag provides a mock function with given fields: tagID, authorID
+func (_m *MockRepository) RemoveTag(tagID string, authorID string) (int, error) {
+	ret := _m.Called(tagID, authorID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (int, error)); ok {
+		return rf(tagID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(tagID, authorID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tagID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceTag provides a mock function with given fields: tagID, newTagID, authorID
+func (_m *MockRepository) ReplaceTag(tagID string, newTagID string, authorID string) (int, error) {
+	ret := _m.Called(tagID, newTagID, authorID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (int, error)); ok {
+		return rf(tagID, newTagID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) int); ok {
+		r0 = rf(tagID, newTagID, authorID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(tagID, newTagID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: translation
 func (_m *MockRepository) Update(translation *Translation) error {
 	ret := _m.Called(translation)
@@ -150,6 +288,22 @@ func (_m *MockRepository) Update(translation *Translation) error {
 	return r0
 }
 
+// UpdateMany provides a mock function with given fields: translations
+func (_m *MockRepository) UpdateMany(translations []*Translation) []error {
+	ret := _m.Called(translations)
+
+	var r0 []error
+	if rf, ok := ret.Get(0).(func([]*Translation) []error); ok {
+		r0 = rf(translations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewMockRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,92 @@
+package translation
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	defaultEase = 2.5
+	minEase     = 1.3
+	maxGrade    = 5
+	passGrade   = 3
+)
+
+// Review keeps the spaced repetition state of a translation, the schedule is calculated with SM-2 algorithm
+type Review struct {
+	ease        float64
+	interval    int // interval in days between the last and the next review
+	repetitions int // amount of successful reviews in a row
+	lapses      int // amount of failed reviews
+	dueAt       time.Time
+	reviewedAt  time.Time
+}
+
+func newReview(dueAt time.Time) Review {
+	return Review{
+		ease:  defaultEase,
+		dueAt: dueAt,
+	}
+}
+
+func NewReview(ease float64, interval, repetitions, lapses int, dueAt, reviewedAt time.Time) Review {
+	return Review{
+		ease:        ease,
+		interval:    interval,
+		repetitions: repetitions,
+		lapses:      lapses,
+		dueAt:       dueAt,
+		reviewedAt:  reviewedAt,
+	}
+}
+
+func (r *Review) DueAt() time.Time {
+	return r.dueAt
+}
+
+// grade calculates the next review state based on the answer quality: 0 - complete blackout, 5 - perfect response
+func (r *Review) grade(quality int, now time.Time) (Review, error) {
+	if quality < 0 || quality > maxGrade {
+		return Review{}, fmt.Errorf("review grade should be in range 0-%d, %d passed", maxGrade, quality)
+	}
+
+	next := *r
+	if next.ease == 0 {
+		next.ease = defaultEase
+	}
+
+	if quality < passGrade {
+		next.repetitions = 0
+		next.interval = 1
+		next.lapses++
+	} else {
+		next.repetitions++
+		switch next.repetitions {
+		case 1:
+			next.interval = 1
+		case 2:
+			next.interval = 6
+		default:
+			next.interval = int(math.Round(float64(next.interval) * next.ease))
+		}
+	}
+
+	failure := float64(maxGrade - quality)
+	next.ease = math.Max(minEase, next.ease+0.1-failure*(0.08+failure*0.02))
+	next.reviewedAt = now
+	next.dueAt = now.AddDate(0, 0, next.interval)
+
+	return next, nil
+}
+
+func (r *Review) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"ease":        r.ease,
+		"interval":    r.interval,
+		"repetitions": r.repetitions,
+		"lapses":      r.lapses,
+		"dueAt":       r.dueAt,
+		"reviewedAt":  r.reviewedAt,
+	}
+}
@@ -0,0 +1,93 @@
+package translation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReview_grade(t *testing.T) {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		review  Review
+		quality int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Review
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			"Grade out of range",
+			args{review: newReview(now), quality: 6},
+			Review{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.True(t, strings.Contains(err.Error(), "review grade should be in range 0-5, 6 passed"), i)
+			},
+		},
+		{
+			"First successful review",
+			args{review: newReview(now), quality: 5},
+			Review{ease: 2.6, interval: 1, repetitions: 1, dueAt: now.AddDate(0, 0, 1), reviewedAt: now},
+			assert.NoError,
+		},
+		{
+			"Second successful review",
+			args{review: Review{ease: 2.5, interval: 1, repetitions: 1}, quality: 4},
+			Review{ease: 2.5, interval: 6, repetitions: 2, dueAt: now.AddDate(0, 0, 6), reviewedAt: now},
+			assert.NoError,
+		},
+		{
+			"Next interval is multiplied by ease",
+			args{review: Review{ease: 2.5, interval: 6, repetitions: 2}, quality: 4},
+			Review{ease: 2.5, interval: 15, repetitions: 3, dueAt: now.AddDate(0, 0, 15), reviewedAt: now},
+			assert.NoError,
+		},
+		{
+			"Failed review resets repetitions and counts lapse",
+			args{review: Review{ease: 2.5, interval: 15, repetitions: 3, lapses: 1}, quality: 1},
+			Review{ease: 1.96, interval: 1, repetitions: 0, lapses: 2, dueAt: now.AddDate(0, 0, 1), reviewedAt: now},
+			assert.NoError,
+		},
+		{
+			"Ease can not be lower than minimum",
+			args{review: Review{ease: 1.4, interval: 1}, quality: 0},
+			Review{ease: minEase, interval: 1, lapses: 1, dueAt: now.AddDate(0, 0, 1), reviewedAt: now},
+			assert.NoError,
+		},
+		{
+			"Review without state uses default ease",
+			args{review: Review{}, quality: 3},
+			Review{ease: 2.36, interval: 1, repetitions: 1, dueAt: now.AddDate(0, 0, 1), reviewedAt: now},
+			assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.review.grade(tt.args.quality, now)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.InDelta(t, tt.want.ease, got.ease, 0.0001)
+			got.ease = tt.want.ease
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReview_ToMap(t *testing.T) {
+	now := time.Now()
+	review := NewReview(2.1, 6, 2, 1, now, now.Add(-time.Hour))
+
+	assert.Equal(t, map[string]interface{}{
+		"ease":        2.1,
+		"interval":    6,
+		"repetitions": 2,
+		"lapses":      1,
+		"dueAt":       now,
+		"reviewedAt":  now.Add(-time.Hour),
+	}, review.ToMap())
+}
@@ -0,0 +1,214 @@
+package translation
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Revision keeps the translation state before the change made by update together with the changed fields
+type Revision struct {
+	id            string
+	translationID string
+	authorID      string
+	source        string
+	transcription string
+	senses        []Sense
+	langID        string
+	fields        map[string]string
+	changes       []FieldChange
+	createdAt     time.Time
+}
+
+// FieldChange describes the changed translation field with its previous and new value
+type FieldChange struct {
+	field    string
+	oldValue string
+	newValue string
+}
+
+// NewRevision creates revision of translation change, returns false if the translation state was not changed
+func NewRevision(before, after *Translation) (*Revision, bool) {
+	changes := diff(before, after)
+	if len(changes) == 0 {
+		return nil, false
+	}
+
+	return &Revision{
+		id:            uuid.New().String(),
+		translationID: before.id,
+		authorID:      before.authorID,
+		source:        before.source,
+		transcription: before.transcription,
+		senses:        before.senses,
+		langID:        before.langID,
+		fields:        before.fieldsToMap(),
+		changes:       changes,
+		createdAt:     time.Now(),
+	}, true
+}
+
+func NewFieldChange(field, oldValue, newValue string) FieldChange {
+	return FieldChange{field: field, oldValue: oldValue, newValue: newValue}
+}
+
+func (r *Revision) ID() string {
+	return r.id
+}
+
+func (r *Revision) TranslationID() string {
+	return r.translationID
+}
+
+func (r *Revision) AuthorID() string {
+	return r.authorID
+}
+
+func (r *Revision) Changes() []FieldChange {
+	return r.changes
+}
+
+func (c FieldChange) Field() string {
+	return c.field
+}
+
+func (c FieldChange) OldValue() string {
+	return c.oldValue
+}
+
+func (c FieldChange) NewValue() string {
+	return c.newValue
+}
+
+// Restore applies the translation state kept in revision to the passed translation
+func (r *Revision) Restore(t *Translation) error {
+	if t.id != r.translationID || t.authorID != r.authorID {
+		return fmt.Errorf("revision %s does not belong to translation %s", r.id, t.id)
+	}
+
+	if err := t.ApplyChanges(r.source, r.transcription, r.senses, r.langID); err != nil {
+		return err
+	}
+
+	return t.ApplyFields(r.fields)
+}
+
+func (r *Revision) ToMap() map[string]interface{} {
+	senses := make([]map[string]interface{}, 0, len(r.senses))
+	for i := range r.senses {
+		senses = append(senses, r.senses[i].ToMap())
+	}
+
+	changes := make([]map[string]interface{}, 0, len(r.changes))
+	for _, change := range r.changes {
+		changes = append(changes, map[string]interface{}{
+			"field":    change.field,
+			"oldValue": change.oldValue,
+			"newValue": change.newValue,
+		})
+	}
+
+	fields := make(map[string]string, len(r.fields))
+	for name, value := range r.fields {
+		fields[name] = value
+	}
+
+	return map[string]interface{}{
+		"id":            r.id,
+		"translationID": r.translationID,
+		"authorID":      r.authorID,
+		"source":        r.source,
+		"transcription": r.transcription,
+		"senses":        senses,
+		"langID":        r.langID,
+		"fields":        fields,
+		"changes":       changes,
+		"createdAt":     r.createdAt,
+	}
+}
+
+// diff compares translation fields which can be changed by user and returns the changed ones
+func diff(before, after *Translation) []FieldChange {
+	var changes []FieldChange
+
+	appendChange := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, NewFieldChange(field, oldValue, newValue))
+		}
+	}
+
+	appendChange("source", before.source, after.source)
+	appendChange("transcription", before.transcription, after.transcription)
+	appendChange("langID", before.langID, after.langID)
+
+	sensesCount := len(before.senses)
+	if len(after.senses) > sensesCount {
+		sensesCount = len(after.senses)
+	}
+
+	for i := 0; i < sensesCount; i++ {
+		var oldSense, newSense Sense
+		if i < len(before.senses) {
+			oldSense = before.senses[i]
+		}
+		if i < len(after.senses) {
+			newSense = after.senses[i]
+		}
+
+		appendChange(fmt.Sprintf("senses[%d].target", i), oldSense.target, newSense.target)
+		appendChange(fmt.Sprintf("senses[%d].example", i), oldSense.example, newSense.example)
+		appendChange(fmt.Sprintf("senses[%d].tagIDs", i), strings.Join(oldSense.tagIDs, ","), strings.Join(newSense.tagIDs, ","))
+	}
+
+	for _, name := range fieldNames(before.fields, after.fields) {
+		appendChange("fields."+name, before.fields[name], after.fields[name])
+	}
+
+	return changes
+}
+
+// fieldNames returns sorted names of the custom fields set in any of passed values, so changes go in stable order
+func fieldNames(before, after map[string]string) []string {
+	seen := make(map[string]struct{}, len(before)+len(after))
+	for name := range before {
+		seen[name] = struct{}{}
+	}
+	for name := range after {
+		seen[name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func UnmarshalRevisionFromDB(
+	id string,
+	translationID string,
+	authorID string,
+	source string,
+	transcription string,
+	senses []Sense,
+	langID string,
+	fields map[string]string,
+	changes []FieldChange,
+	createdAt time.Time,
+) *Revision {
+	return &Revision{
+		id:            id,
+		translationID: translationID,
+		authorID:      authorID,
+		source:        source,
+		transcription: transcription,
+		senses:        senses,
+		langID:        langID,
+		fields:        fields,
+		changes:       changes,
+		createdAt:     createdAt,
+	}
+}
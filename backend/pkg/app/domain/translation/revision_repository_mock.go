@@ -0,0 +1,104 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package translation
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=RevisionRepository --filename=revision_repository_mock.go --output=./ --structname=MockRevisionRepository --inpackage
+// MockRevisionRepository is an autogenerated mock type for the RevisionRepository type
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: revision
+func (_m *MockRevisionRepository) Create(revision *Revision) error {
+	ret := _m.Called(revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*Revision) error); ok {
+		r0 = rf(revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByAuthorID provides a mock function with given fields: authorID
+func (_m *MockRevisionRepository) DeleteByAuthorID(authorID string) (int, error) {
+	ret := _m.Called(authorID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(authorID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByTranslationID provides a mock function with given fields: translationID, authorID
+func (_m *MockRevisionRepository) DeleteByTranslationID(translationID string, authorID string) error {
+	ret := _m.Called(translationID, authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(translationID, authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: id, translationID, authorID
+func (_m *MockRevisionRepository) Get(id string, translationID string, authorID string) (*Revision, error) {
+	ret := _m.Called(id, translationID, authorID)
+
+	var r0 *Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*Revision, error)); ok {
+		return rf(id, translationID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *Revision); ok {
+		r0 = rf(id, translationID, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(id, translationID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockRevisionRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockRevisionRepository creates a new instance of MockRevisionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockRevisionRepository(t mockConstructorTestingTNewMockRevisionRepository) *MockRevisionRepository {
+	mock := &MockRevisionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
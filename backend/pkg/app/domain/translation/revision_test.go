@@ -0,0 +1,78 @@
+package translation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewRevision(t *testing.T) {
+	before, err := NewTranslation("source", "", []Sense{NewSense("target", "example", []string{"tag1"})}, "author", "EN")
+	assert.Nil(t, err)
+
+	after := *before
+	assert.Nil(t, after.ApplyChanges("source", "[trans]", []Sense{NewSense("target", "new example", []string{"tag1", "tag2"}), NewSense("second", "", nil)}, "EN"))
+
+	revision, changed := NewRevision(before, &after)
+	assert.True(t, changed)
+	assert.Equal(t, before.id, revision.TranslationID())
+	assert.Equal(t, before.authorID, revision.AuthorID())
+	assert.Equal(t, before.senses, revision.senses)
+	assert.Equal(t, []FieldChange{
+		NewFieldChange("transcription", "", "[trans]"),
+		NewFieldChange("senses[0].example", "example", "new example"),
+		NewFieldChange("senses[0].tagIDs", "tag1", "tag1,tag2"),
+		NewFieldChange("senses[1].target", "", "second"),
+	}, revision.Changes())
+}
+
+func TestNewRevision_NothingChanged(t *testing.T) {
+	before, err := NewTranslation("source", "", []Sense{NewSense("target", "example", []string{"tag1"})}, "author", "EN")
+	assert.Nil(t, err)
+
+	after := *before
+	assert.Nil(t, after.ApplyChanges("source", "", []Sense{NewSense("target", "example", []string{"tag1"})}, "EN"))
+
+	revision, changed := NewRevision(before, &after)
+	assert.False(t, changed)
+	assert.Nil(t, revision)
+}
+
+func TestNewRevision_FieldsChanged(t *testing.T) {
+	before, err := NewTranslation("Katze", "", []Sense{NewSense("cat", "", nil)}, "author", "DE")
+	assert.Nil(t, err)
+	assert.Nil(t, before.ApplyFields(map[string]string{"gender": "f", "plural": "Katze"}))
+
+	after := *before
+	assert.Nil(t, after.ApplyFields(map[string]string{"gender": "f", "plural": "Katzen", "separable": "false"}))
+
+	revision, changed := NewRevision(before, &after)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]string{"gender": "f", "plural": "Katze"}, revision.fields)
+	assert.Equal(t, []FieldChange{
+		NewFieldChange("fields.plural", "Katze", "Katzen"),
+		NewFieldChange("fields.separable", "", "false"),
+	}, revision.Changes())
+}
+
+func TestRevision_Restore(t *testing.T) {
+	tr, err := NewTranslation("source", "", []Sense{NewSense("target", "", nil)}, "author", "EN")
+	assert.Nil(t, err)
+	assert.Nil(t, tr.ApplyFields(map[string]string{"gender": "m"}))
+
+	before := *tr
+	assert.Nil(t, tr.ApplyChanges("changed", "", []Sense{NewSense("changed", "", nil)}, "DE"))
+	assert.Nil(t, tr.ApplyFields(map[string]string{"gender": "f", "plural": "changed"}))
+
+	revision, changed := NewRevision(&before, tr)
+	assert.True(t, changed)
+
+	assert.Nil(t, revision.Restore(tr))
+	assert.Equal(t, "source", tr.source)
+	assert.Equal(t, "EN", tr.langID)
+	assert.Equal(t, "target", tr.senses[0].target)
+	assert.Equal(t, map[string]string{"gender": "m"}, tr.Fields())
+
+	other, err := NewTranslation("other", "", []Sense{NewSense("target", "", nil)}, "author", "EN")
+	assert.Nil(t, err)
+	assert.Error(t, revision.Restore(other))
+}
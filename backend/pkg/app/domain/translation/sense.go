@@ -0,0 +1,79 @@
+package translation
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	maxSenses = 10
+	maxFields = 10 // maxFields equals to the max amount of lang fields
+)
+
+const (
+	// MaxExampleLength max amount of symbols in sense example
+	MaxExampleLength = 255
+	// MaxTags max amount of tags of a sense
+	MaxTags = 5
+)
+
+// Sense represents one of the translation meanings with its own target, example and tags
+type Sense struct {
+	target  string
+	example string
+	tagIDs  []string
+}
+
+func NewSense(target, example string, tagIDs []string) Sense {
+	return Sense{
+		target:  target,
+		example: example,
+		tagIDs:  tagIDs,
+	}
+}
+
+func (s *Sense) Target() string {
+	return s.target
+}
+
+func (s *Sense) Example() string {
+	return s.example
+}
+
+func (s *Sense) TagIDs() []string {
+	return s.tagIDs
+}
+
+func (s *Sense) validate() error {
+	var err error
+
+	if s.target == "" {
+		err = errors.Join(fmt.Errorf("target can not be empty"), err)
+	}
+
+	translationCount := utf8.RuneCountInString(s.target)
+	if translationCount > 255 {
+		err = errors.Join(fmt.Errorf("target max size is 255 characters, %d passed (%s)", translationCount, s.target), err)
+	}
+
+	exampleCount := utf8.RuneCountInString(s.example)
+	if exampleCount > MaxExampleLength {
+		err = errors.Join(fmt.Errorf("example max size is %d characters, %d passed (%s)", MaxExampleLength, exampleCount, s.example), err)
+	}
+
+	tagsCount := len(s.tagIDs)
+	if tagsCount > MaxTags {
+		err = errors.Join(fmt.Errorf("tag max amount is %d, %d passed", MaxTags, tagsCount), err)
+	}
+
+	return err
+}
+
+func (s *Sense) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"target":  s.target,
+		"example": s.example,
+		"tagIDs":  s.tagIDs,
+	}
+}
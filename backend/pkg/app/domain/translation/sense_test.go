@@ -0,0 +1,16 @@
+package translation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSense_ToMap(t *testing.T) {
+	sense := NewSense("target", "example", []string{"tag1"})
+
+	assert.Equal(t, map[string]interface{}{
+		"target":  "target",
+		"example": "example",
+		"tagIDs":  []string{"tag1"},
+	}, sense.ToMap())
+}
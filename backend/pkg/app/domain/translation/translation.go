@@ -12,28 +12,75 @@ type Translation struct {
 	id            string
 	source        string
 	transcription string
-	target        string
+	senses        []Sense
 	authorID      string
-	example       string
-	tagIDs        []string
 	createdAt     time.Time
 	updatedAt     time.Time
 	langID        string
+	review        Review
+	fields        map[string]string // fields custom field values validated against the lang schema by command handlers
 }
 
-func NewTranslation(source, transcription, target, authorID, example string, tagIDs []string, langID string) (*Translation, error) {
+func NewTranslation(source, transcription string, senses []Sense, authorID, langID string) (*Translation, error) {
 	now := time.Now()
 	tr := Translation{
 		id:            uuid.New().String(),
 		authorID:      authorID,
 		createdAt:     now,
 		updatedAt:     now,
-		target:        target,
+		senses:        senses,
 		transcription: transcription,
 		source:        source,
-		example:       example,
-		tagIDs:        tagIDs,
 		langID:        langID,
+		review:        newReview(now),
+	}
+
+	if err := tr.validate(); err != nil {
+		return nil, err
+	}
+
+	return &tr, nil
+}
+
+// NewImportedTranslation creates new translation keeping timestamps and review state of the imported one,
+// zero timestamps are set to the current time and zero review state is replaced by the initial one
+func NewImportedTranslation(
+	source, transcription string,
+	senses []Sense,
+	authorID, langID string,
+	fields map[string]string,
+	createdAt, updatedAt time.Time,
+	review Review,
+) (*Translation, error) {
+	values, err := fieldValues(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
+
+	if review == (Review{}) {
+		review = newReview(now)
+	}
+
+	tr := Translation{
+		id:            uuid.New().String(),
+		authorID:      authorID,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+		senses:        senses,
+		transcription: transcription,
+		source:        source,
+		langID:        langID,
+		fields:        values,
+		review:        review,
 	}
 
 	if err := tr.validate(); err != nil {
@@ -55,24 +102,159 @@ func (t *Translation) LangID() string {
 	return t.langID
 }
 
-func (t *Translation) ApplyChanges(source, transcription, target, example string, tagIDs []string, langID string) error {
+func (t *Translation) Senses() []Sense {
+	return t.senses
+}
+
+// TagIDs returns unique tag IDs of all translation senses
+func (t *Translation) TagIDs() []string {
+	tagIDs := make([]string, 0)
+	seen := map[string]struct{}{}
+
+	for i := range t.senses {
+		for _, tagID := range t.senses[i].tagIDs {
+			if _, ok := seen[tagID]; ok {
+				continue
+			}
+			seen[tagID] = struct{}{}
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+
+	return tagIDs
+}
+
+func (t *Translation) Fields() map[string]string {
+	return t.fields
+}
+
+// ApplyFields replaces custom field values, empty values are removed
+func (t *Translation) ApplyFields(fields map[string]string) error {
+	values, err := fieldValues(fields)
+	if err != nil {
+		return err
+	}
+
+	t.fields = values
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// fieldValues returns passed custom field values without empty ones
+func fieldValues(fields map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(fields))
+	for name, value := range fields {
+		if value != "" {
+			values[name] = value
+		}
+	}
+
+	if len(values) > maxFields {
+		return nil, fmt.Errorf("field max amount is %d, %d passed", maxFields, len(values))
+	}
+
+	return values, nil
+}
+
+func (t *Translation) Review() Review {
+	return t.review
+}
+
+// Grade applies the answer quality to the review state and schedules the next review
+func (t *Translation) Grade(quality int) error {
+	review, err := t.review.grade(quality, time.Now())
+	if err != nil {
+		return err
+	}
+
+	t.review = review
+	return nil
+}
+
+func (t *Translation) ApplyChanges(source, transcription string, senses []Sense, langID string) error {
 	updated := *t
-	updated.applyChanges(source, transcription, target, example, tagIDs, langID)
+	updated.applyChanges(source, transcription, senses, langID)
 
 	if err := updated.validate(); err != nil {
 		return err
 	}
 
-	t.applyChanges(source, transcription, target, example, tagIDs, langID)
+	t.applyChanges(source, transcription, senses, langID)
 	return nil
 }
 
-func (t *Translation) applyChanges(source, transcription, target, example string, tagIDs []string, langID string) {
-	t.tagIDs = tagIDs
+// AddTags adds tags to every translation sense, tags already set are skipped
+func (t *Translation) AddTags(tagIDs []string) error {
+	senses := make([]Sense, 0, len(t.senses))
+	for i := range t.senses {
+		sense := t.senses[i]
+		sense.tagIDs = append([]string{}, sense.tagIDs...)
+		for _, tagID := range tagIDs {
+			if !containsTag(sense.tagIDs, tagID) {
+				sense.tagIDs = append(sense.tagIDs, tagID)
+			}
+		}
+		senses = append(senses, sense)
+	}
+
+	return t.ApplyChanges(t.source, t.transcription, senses, t.langID)
+}
+
+// RemoveTags removes tags from all translation senses
+func (t *Translation) RemoveTags(tagIDs []string) error {
+	senses := make([]Sense, 0, len(t.senses))
+	for i := range t.senses {
+		sense := t.senses[i]
+		sense.tagIDs = make([]string, 0, len(t.senses[i].tagIDs))
+		for _, tagID := range t.senses[i].tagIDs {
+			if !containsTag(tagIDs, tagID) {
+				sense.tagIDs = append(sense.tagIDs, tagID)
+			}
+		}
+		senses = append(senses, sense)
+	}
+
+	return t.ApplyChanges(t.source, t.transcription, senses, t.langID)
+}
+
+// ReplaceTag replaces the tag by the new one in all senses tagged by it, senses already tagged by the new tag keep it once
+func (t *Translation) ReplaceTag(tagID, newTagID string) error {
+	senses := make([]Sense, 0, len(t.senses))
+	for i := range t.senses {
+		sense := t.senses[i]
+		if containsTag(sense.tagIDs, tagID) {
+			sense.tagIDs = make([]string, 0, len(t.senses[i].tagIDs))
+			for _, id := range t.senses[i].tagIDs {
+				if id != tagID && id != newTagID {
+					sense.tagIDs = append(sense.tagIDs, id)
+				}
+			}
+			sense.tagIDs = append(sense.tagIDs, newTagID)
+		}
+		senses = append(senses, sense)
+	}
+
+	return t.ApplyChanges(t.source, t.transcription, senses, t.langID)
+}
+
+// MoveToLang changes translation lang keeping all other fields
+func (t *Translation) MoveToLang(langID string) error {
+	return t.ApplyChanges(t.source, t.transcription, t.senses, langID)
+}
+
+func containsTag(tagIDs []string, tagID string) bool {
+	for _, id := range tagIDs {
+		if id == tagID {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Translation) applyChanges(source, transcription string, senses []Sense, langID string) {
+	t.senses = senses
 	t.transcription = transcription
 	t.source = source
-	t.target = target
-	t.example = example
 	t.updatedAt = time.Now()
 	t.langID = langID
 }
@@ -93,29 +275,24 @@ func (t *Translation) validate() error {
 		err = errors.Join(fmt.Errorf("transcription max size is 255 characters, %d passed (%s)", transcriptionCount, t.transcription), err)
 	}
 
-	if t.target == "" {
-		err = errors.Join(fmt.Errorf("target can not be empty"), err)
+	if len(t.senses) == 0 {
+		err = errors.Join(fmt.Errorf("at least one sense should be passed"), err)
 	}
 
-	translationCount := utf8.RuneCountInString(t.target)
-	if translationCount > 255 {
-		err = errors.Join(fmt.Errorf("target max size is 255 characters, %d passed (%s)", translationCount, t.target), err)
+	if len(t.senses) > maxSenses {
+		err = errors.Join(fmt.Errorf("sense max amount is %d, %d passed", maxSenses, len(t.senses)), err)
 	}
 
-	exampleCount := utf8.RuneCountInString(t.example)
-	if utf8.RuneCountInString(t.example) > 255 {
-		err = errors.Join(fmt.Errorf("example max size is 255 characters, %d passed (%s)", exampleCount, t.example), err)
+	for i := range t.senses {
+		if sErr := t.senses[i].validate(); sErr != nil {
+			err = errors.Join(sErr, err)
+		}
 	}
 
 	if t.authorID == "" {
 		err = errors.Join(fmt.Errorf("authorID can not be empty"), err)
 	}
 
-	tagsCount := len(t.tagIDs)
-	if tagsCount > 5 {
-		err = errors.Join(fmt.Errorf("tag max amount is 5, %d passed", tagsCount), err)
-	}
-
 	if t.langID == "" {
 		err = errors.Join(fmt.Errorf("langID can not be empty"), err)
 	}
@@ -128,27 +305,43 @@ func (t *Translation) ToMap() map[string]interface{} {
 		"id":            t.id,
 		"source":        t.source,
 		"transcription": t.transcription,
-		"target":        t.target,
+		"senses":        t.sensesToMap(),
 		"authorID":      t.authorID,
-		"example":       t.example,
-		"tagIDs":        t.tagIDs,
 		"createdAt":     t.createdAt,
 		"updatedAt":     t.updatedAt,
 		"langID":        t.langID,
+		"review":        t.review.ToMap(),
+		"fields":        t.fieldsToMap(),
+	}
+}
+
+func (t *Translation) fieldsToMap() map[string]string {
+	fields := make(map[string]string, len(t.fields))
+	for name, value := range t.fields {
+		fields[name] = value
+	}
+	return fields
+}
+
+func (t *Translation) sensesToMap() []map[string]interface{} {
+	senses := make([]map[string]interface{}, 0, len(t.senses))
+	for i := range t.senses {
+		senses = append(senses, t.senses[i].ToMap())
 	}
+	return senses
 }
 
 func UnmarshalFromDB(
 	id string,
 	source string,
 	transcription string,
-	target string,
+	senses []Sense,
 	authorID string,
-	example string,
-	tagIDs []string,
 	createdAt time.Time,
 	updatedAt time.Time,
 	langID string,
+	review Review,
+	fields map[string]string,
 ) *Translation {
 	return &Translation{
 		id:            id,
@@ -156,10 +349,10 @@ func UnmarshalFromDB(
 		createdAt:     createdAt,
 		updatedAt:     updatedAt,
 		transcription: transcription,
-		target:        target,
+		senses:        senses,
 		source:        source,
-		example:       example,
-		tagIDs:        tagIDs,
 		langID:        langID,
+		review:        review,
+		fields:        fields,
 	}
 }
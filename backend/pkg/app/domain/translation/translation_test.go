@@ -1,6 +1,7 @@
 package translation
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -9,19 +10,42 @@ import (
 import "github.com/stretchr/testify/assert"
 
 func TestNewTranslation_PositiveCase(t *testing.T) {
-	translation, err := NewTranslation("new", "", "new", "new", "", []string{}, "EN")
+	translation, err := NewTranslation("new", "", []Sense{NewSense("new", "", []string{})}, "new", "EN")
 	assert.Nil(t, err)
 	assert.Equal(t, translation.updatedAt, translation.createdAt, "NewTranslation - createdAt and updatedAt are the same")
 }
 
 func TestNewTranslation_ValidationError(t *testing.T) {
-	tr, err := NewTranslation("", "", "new", "new", "", []string{}, "EN")
+	tr, err := NewTranslation("", "", []Sense{NewSense("new", "", []string{})}, "new", "EN")
 	assert.Nil(t, tr)
 	assert.True(t, strings.Contains(err.Error(), "source can not be empty"))
 }
 
+func TestNewImportedTranslation(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	review := NewReview(2.1, 6, 2, 1, updatedAt, createdAt)
+
+	translation, err := NewImportedTranslation("new", "", []Sense{NewSense("new", "", nil)}, "author", "EN", map[string]string{"gender": "m", "plural": ""}, createdAt, updatedAt, review)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, translation.ID())
+	assert.Equal(t, createdAt, translation.createdAt)
+	assert.Equal(t, updatedAt, translation.updatedAt)
+	assert.Equal(t, review, translation.review)
+	assert.Equal(t, map[string]string{"gender": "m"}, translation.Fields())
+
+	translation, err = NewImportedTranslation("new", "", []Sense{NewSense("new", "", nil)}, "author", "EN", nil, time.Time{}, time.Time{}, Review{})
+	assert.Nil(t, err)
+	assert.False(t, translation.createdAt.IsZero())
+	assert.Equal(t, translation.createdAt, translation.updatedAt)
+	assert.Equal(t, defaultEase, translation.review.ease)
+
+	_, err = NewImportedTranslation("", "", nil, "author", "EN", nil, createdAt, updatedAt, review)
+	assert.Error(t, err)
+}
+
 func TestTranslation_ApplyChanges_PositiveCase(t *testing.T) {
-	tr, err := NewTranslation("new", "new", "new", "new", "new", []string{}, "EN")
+	tr, err := NewTranslation("new", "new", []Sense{NewSense("new", "new", []string{})}, "new", "EN")
 	assert.Nil(t, err)
 	translation := "test"
 	transcription := "[test]"
@@ -32,25 +56,121 @@ func TestTranslation_ApplyChanges_PositiveCase(t *testing.T) {
 	langID := "lang1"
 
 	time.Sleep(time.Second)
-	err = tr.ApplyChanges(text, transcription, translation, example, []string{tg}, langID)
+	err = tr.ApplyChanges(text, transcription, []Sense{NewSense(translation, example, []string{tg}), NewSense("second", "", []string{tg})}, langID)
 	assert.Nil(t, err)
 
-	assert.Equal(t, tr.target, translation)
+	assert.Len(t, tr.senses, 2)
+	assert.Equal(t, tr.senses[0].target, translation)
 	assert.Equal(t, tr.source, text)
-	assert.Equal(t, tr.example, example)
-	assert.Equal(t, tr.target, translation)
+	assert.Equal(t, tr.senses[0].example, example)
+	assert.Equal(t, tr.senses[1].target, "second")
 	assert.Greaterf(t, tr.updatedAt, updatedAt, "Name.ApplyChanges - updatedAt should be greater createdAt")
-	assert.Equal(t, tr.tagIDs[0], tg)
+	assert.Equal(t, []string{tg}, tr.TagIDs())
 	assert.Equal(t, langID, tr.langID)
 }
 
+func TestTranslation_Grade(t *testing.T) {
+	tr, err := NewTranslation("new", "new", []Sense{NewSense("new", "new", []string{})}, "new", "EN")
+	assert.Nil(t, err)
+	updatedAt := tr.updatedAt
+
+	assert.Nil(t, tr.Grade(4))
+	assert.Equal(t, 1, tr.review.repetitions)
+	assert.Equal(t, 1, tr.review.interval)
+	assert.True(t, tr.review.dueAt.After(tr.createdAt))
+	assert.Equal(t, updatedAt, tr.updatedAt)
+
+	review := tr.review
+	assert.NotNil(t, tr.Grade(6))
+	assert.Equal(t, review, tr.review)
+}
+
 func TestTranslation_ApplyChanges_ValidationError(t *testing.T) {
-	tr, err := NewTranslation("new", "new", "new", "new", "new", []string{}, "EN")
+	tr, err := NewTranslation("new", "new", []Sense{NewSense("new", "new", []string{})}, "new", "EN")
 	assert.Nil(t, err)
 
-	err = tr.ApplyChanges("", "", "test", "", []string{}, "DE")
+	err = tr.ApplyChanges("", "", []Sense{NewSense("test", "", []string{})}, "DE")
 	assert.True(t, strings.Contains(err.Error(), "source can not be empty"))
-	assert.Equal(t, "new", tr.target)
+	assert.Equal(t, "new", tr.senses[0].target)
+}
+
+func TestTranslation_TagIDs(t *testing.T) {
+	tr := Translation{senses: []Sense{
+		NewSense("first", "", []string{"tag1", "tag2"}),
+		NewSense("second", "", nil),
+		NewSense("third", "", []string{"tag2", "tag3"}),
+	}}
+
+	assert.Equal(t, []string{"tag1", "tag2", "tag3"}, tr.TagIDs())
+}
+
+func TestTranslation_AddTags(t *testing.T) {
+	tr, err := NewTranslation("new", "", []Sense{
+		NewSense("first", "", []string{"tag1"}),
+		NewSense("second", "", []string{"tag1", "tag2", "tag3", "tag4"}),
+	}, "new", "EN")
+	assert.Nil(t, err)
+
+	assert.Nil(t, tr.AddTags([]string{"tag2"}))
+	assert.Equal(t, []string{"tag1", "tag2"}, tr.senses[0].tagIDs)
+	assert.Equal(t, []string{"tag1", "tag2", "tag3", "tag4"}, tr.senses[1].tagIDs)
+
+	err = tr.AddTags([]string{"tag5", "tag6"})
+	assert.True(t, strings.Contains(err.Error(), "tag max amount is 5, 6 passed"))
+	assert.Equal(t, []string{"tag1", "tag2"}, tr.senses[0].tagIDs)
+	assert.Equal(t, []string{"tag1", "tag2", "tag3", "tag4"}, tr.senses[1].tagIDs)
+}
+
+func TestTranslation_RemoveTags(t *testing.T) {
+	tr, err := NewTranslation("new", "", []Sense{
+		NewSense("first", "", []string{"tag1", "tag2"}),
+		NewSense("second", "", []string{"tag2", "tag3"}),
+	}, "new", "EN")
+	assert.Nil(t, err)
+
+	assert.Nil(t, tr.RemoveTags([]string{"tag2", "tag4"}))
+	assert.Equal(t, []string{"tag1", "tag3"}, tr.TagIDs())
+}
+
+func TestTranslation_ReplaceTag(t *testing.T) {
+	tr, err := NewTranslation("new", "", []Sense{
+		NewSense("first", "", []string{"tag1", "tag2"}),
+		NewSense("second", "", []string{"tag3", "tag1"}),
+		NewSense("third", "", []string{"tag2"}),
+	}, "new", "EN")
+	assert.Nil(t, err)
+
+	assert.Nil(t, tr.ReplaceTag("tag1", "tag3"))
+	assert.Equal(t, []string{"tag2", "tag3"}, tr.senses[0].tagIDs)
+	assert.Equal(t, []string{"tag3"}, tr.senses[1].tagIDs)
+	assert.Equal(t, []string{"tag2"}, tr.senses[2].tagIDs)
+}
+
+func TestTranslation_MoveToLang(t *testing.T) {
+	tr, err := NewTranslation("new", "", []Sense{NewSense("first", "", nil)}, "new", "EN")
+	assert.Nil(t, err)
+
+	assert.Nil(t, tr.MoveToLang("DE"))
+	assert.Equal(t, "DE", tr.LangID())
+
+	assert.NotNil(t, tr.MoveToLang(""))
+	assert.Equal(t, "DE", tr.LangID())
+}
+
+func TestTranslation_ApplyFields(t *testing.T) {
+	tr, err := NewTranslation("source", "", []Sense{NewSense("target", "", nil)}, "testAuthor", "EN")
+	assert.Nil(t, err)
+
+	assert.Nil(t, tr.ApplyFields(map[string]string{"gender": "m", "plural": ""}))
+	assert.Equal(t, map[string]string{"gender": "m"}, tr.Fields())
+	assert.Equal(t, map[string]string{"gender": "m"}, tr.ToMap()["fields"])
+
+	tooMany := map[string]string{}
+	for i := 0; i <= maxFields; i++ {
+		tooMany[fmt.Sprintf("field%d", i)] = "value"
+	}
+	assert.NotNil(t, tr.ApplyFields(tooMany))
+	assert.Equal(t, map[string]string{"gender": "m"}, tr.Fields())
 }
 
 func TestUnmarshalFromDB(t *testing.T) {
@@ -60,24 +180,24 @@ func TestUnmarshalFromDB(t *testing.T) {
 		createdAt:     time.Now().Add(5 * time.Second),
 		updatedAt:     time.Now().Add(10 * time.Second),
 		transcription: "testTranscription",
-		target:        "testTranslation",
+		senses:        []Sense{NewSense("testTranslation", "testExample", []string{"tag1", "tag2"})},
 		source:        "testText",
-		example:       "testExample",
-		tagIDs:        []string{"tag1", "tag2"},
 		langID:        "EN",
+		review:        NewReview(2.36, 6, 2, 1, time.Now().Add(24*time.Hour), time.Now()),
+		fields:        map[string]string{"gender": "m"},
 	}
 
 	assert.Equal(t, &translation, UnmarshalFromDB(
 		translation.id,
 		translation.source,
 		translation.transcription,
-		translation.target,
+		translation.senses,
 		translation.authorID,
-		translation.example,
-		translation.tagIDs,
 		translation.createdAt,
 		translation.updatedAt,
 		"EN",
+		translation.review,
+		translation.fields,
 	))
 }
 
@@ -89,6 +209,7 @@ func TestTranslation_validate(t *testing.T) {
 		authorID      string
 		example       string
 		tagIDs        []string
+		senses        []Sense
 		langID        string
 	}
 	tests := []struct {
@@ -212,6 +333,32 @@ func TestTranslation_validate(t *testing.T) {
 				return true
 			},
 		},
+		{
+			"Senses are empty",
+			fields{
+				text:     "test",
+				authorID: "test",
+				senses:   []Sense{},
+				langID:   "lang1",
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.True(t, strings.Contains(err.Error(), "at least one sense should be passed"), i)
+				return true
+			},
+		},
+		{
+			"Too many senses",
+			fields{
+				text:     "test",
+				authorID: "test",
+				senses:   make([]Sense, 11),
+				langID:   "lang1",
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.True(t, strings.Contains(err.Error(), "sense max amount is 10, 11 passed"), i)
+				return true
+			},
+		},
 		{
 			"Multiple errors",
 			fields{
@@ -240,13 +387,16 @@ func TestTranslation_validate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			senses := tt.fields.senses
+			if senses == nil {
+				senses = []Sense{NewSense(tt.fields.translation, tt.fields.example, tt.fields.tagIDs)}
+			}
+
 			tr := &Translation{
 				source:        tt.fields.text,
 				transcription: tt.fields.transcription,
-				target:        tt.fields.translation,
+				senses:        senses,
 				authorID:      tt.fields.authorID,
-				example:       tt.fields.example,
-				tagIDs:        tt.fields.tagIDs,
 				langID:        tt.fields.langID,
 			}
 			tt.wantErr(t, tr.validate(), "validate()")
@@ -0,0 +1,169 @@
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"time"
+)
+
+// Kind defines the type of the entity kept in trash
+type Kind string
+
+const (
+	TranslationKind Kind = "translation"
+	TagKind         Kind = "tag"
+	LangKind        Kind = "lang"
+)
+
+// Item keeps the state of the deleted entity, so it can be restored until the trash is purged
+type Item struct {
+	id          string
+	kind        Kind
+	authorID    string
+	deletedAt   time.Time
+	translation *translation.Translation
+	tag         *tag.Tag
+	lang        *lang.Lang
+}
+
+func NewTranslationItem(tr *translation.Translation) (*Item, error) {
+	return newItem(TranslationKind, tr.AuthorID(), tr, nil, nil)
+}
+
+func NewTagItem(tg *tag.Tag) (*Item, error) {
+	return newItem(TagKind, tg.AuthorID(), nil, tg, nil)
+}
+
+func NewLangItem(ln *lang.Lang) (*Item, error) {
+	return newItem(LangKind, ln.AuthorID(), nil, nil, ln)
+}
+
+func newItem(kind Kind, authorID string, tr *translation.Translation, tg *tag.Tag, ln *lang.Lang) (*Item, error) {
+	item := Item{
+		id:          uuid.New().String(),
+		kind:        kind,
+		authorID:    authorID,
+		deletedAt:   time.Now(),
+		translation: tr,
+		tag:         tg,
+		lang:        ln,
+	}
+
+	if err := item.validate(); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (i *Item) ID() string {
+	return i.id
+}
+
+func (i *Item) Kind() Kind {
+	return i.kind
+}
+
+func (i *Item) AuthorID() string {
+	return i.authorID
+}
+
+func (i *Item) DeletedAt() time.Time {
+	return i.deletedAt
+}
+
+// EntityID returns id of the deleted entity
+func (i *Item) EntityID() string {
+	switch i.kind {
+	case TranslationKind:
+		return i.translation.ID()
+	case TagKind:
+		return i.tag.ID()
+	case LangKind:
+		return i.lang.ID()
+	}
+	return ""
+}
+
+func (i *Item) Translation() *translation.Translation {
+	return i.translation
+}
+
+func (i *Item) Tag() *tag.Tag {
+	return i.tag
+}
+
+func (i *Item) Lang() *lang.Lang {
+	return i.lang
+}
+
+func (i *Item) validate() error {
+	var err error
+
+	if i.authorID == "" {
+		err = errors.Join(errors.New("authorID can not be empty"), err)
+	}
+
+	switch i.kind {
+	case TranslationKind:
+		if i.translation == nil {
+			err = errors.Join(errors.New("translation can not be empty"), err)
+		}
+	case TagKind:
+		if i.tag == nil {
+			err = errors.Join(errors.New("tag can not be empty"), err)
+		}
+	case LangKind:
+		if i.lang == nil {
+			err = errors.Join(errors.New("lang can not be empty"), err)
+		}
+	default:
+		err = errors.Join(fmt.Errorf("unknown trash item kind: %s", i.kind), err)
+	}
+
+	return err
+}
+
+func (i *Item) ToMap() map[string]interface{} {
+	data := map[string]interface{}{
+		"id":        i.id,
+		"kind":      string(i.kind),
+		"authorID":  i.authorID,
+		"deletedAt": i.deletedAt,
+	}
+
+	switch i.kind {
+	case TranslationKind:
+		data["translation"] = i.translation.ToMap()
+	case TagKind:
+		data["tag"] = i.tag.ToMap()
+	case LangKind:
+		data["lang"] = i.lang.ToMap()
+	}
+
+	return data
+}
+
+func UnmarshalFromDB(
+	id string,
+	kind Kind,
+	authorID string,
+	deletedAt time.Time,
+	tr *translation.Translation,
+	tg *tag.Tag,
+	ln *lang.Lang,
+) *Item {
+	return &Item{
+		id:          id,
+		kind:        kind,
+		authorID:    authorID,
+		deletedAt:   deletedAt,
+		translation: tr,
+		tag:         tg,
+		lang:        ln,
+	}
+}
@@ -0,0 +1,107 @@
+package trash
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTranslationItem(t *testing.T) {
+	tr, err := translation.NewTranslation("source", "", []translation.Sense{translation.NewSense("target", "", nil)}, "testAuthor", "EN")
+	assert.Nil(t, err)
+
+	item, err := NewTranslationItem(tr)
+	assert.Nil(t, err)
+	assert.Equal(t, TranslationKind, item.Kind())
+	assert.Equal(t, "testAuthor", item.AuthorID())
+	assert.Equal(t, tr.ID(), item.EntityID())
+	assert.Equal(t, tr, item.Translation())
+	assert.NotEmpty(t, item.ID())
+	assert.False(t, item.DeletedAt().IsZero())
+}
+
+func TestNewTagItem(t *testing.T) {
+	tg, err := tag.NewTag("tag", "testAuthor")
+	assert.Nil(t, err)
+
+	item, err := NewTagItem(tg)
+	assert.Nil(t, err)
+	assert.Equal(t, TagKind, item.Kind())
+	assert.Equal(t, tg.ID(), item.EntityID())
+	assert.Equal(t, tg, item.Tag())
+}
+
+func TestNewLangItem(t *testing.T) {
+	ln, err := lang.NewLang("English", "testAuthor", lang.Metadata{})
+	assert.Nil(t, err)
+
+	item, err := NewLangItem(ln)
+	assert.Nil(t, err)
+	assert.Equal(t, LangKind, item.Kind())
+	assert.Equal(t, ln.ID(), item.EntityID())
+	assert.Equal(t, ln, item.Lang())
+}
+
+func TestItem_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    Item
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			"Author is empty",
+			Item{kind: TagKind, tag: &tag.Tag{}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.True(t, strings.Contains(err.Error(), "authorID can not be empty"), i)
+				return true
+			},
+		},
+		{
+			"Entity is missed",
+			Item{kind: TranslationKind, authorID: "testAuthor"},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.True(t, strings.Contains(err.Error(), "translation can not be empty"), i)
+				return true
+			},
+		},
+		{
+			"Unknown kind",
+			Item{kind: "user", authorID: "testAuthor"},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.True(t, strings.Contains(err.Error(), "unknown trash item kind: user"), i)
+				return true
+			},
+		},
+		{
+			"Positive case",
+			Item{kind: LangKind, authorID: "testAuthor", lang: &lang.Lang{}},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Nil(t, err, i)
+				return true
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, tt.item.validate(), "validate()")
+		})
+	}
+}
+
+func TestItem_ToMap(t *testing.T) {
+	tg := tag.UnmarshalFromDB("tagID", "tag", "testAuthor", "")
+	deletedAt := time.Now()
+	item := UnmarshalFromDB("itemID", TagKind, "testAuthor", deletedAt, nil, tg, nil)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":        "itemID",
+		"kind":      "tag",
+		"authorID":  "testAuthor",
+		"deletedAt": deletedAt,
+		"tag":       tg.ToMap(),
+	}, item.ToMap())
+}
@@ -0,0 +1,18 @@
+package trash
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrNotFound = errors.New("can not find trash item in store")
+
+// Repository defines domain trash repository methods
+type Repository interface {
+	Create(item *Item) error                               // Create puts the deleted entity to trash
+	Get(id, authorID string) (*Item, error)                // Get provides trash item by id and authorID, return ErrNotFound if record not exists
+	GetAll(authorID string) ([]*Item, error)               // GetAll provides all trash items of the author
+	GetDeletedBefore(deletedAt time.Time) ([]*Item, error) // GetDeletedBefore provides trash items of all authors which were deleted before passed time
+	Delete(id, authorID string) error
+	DeleteByAuthorID(authorID string) (int, error)
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package trash
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockery --name=Repository --filename=repository_mock.go --output=./ --structname=MockRepository --inpackage
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: item
+func (_m *MockRepository) Create(item *Item) error {
+	ret := _m.Called(item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*Item) error); ok {
+		r0 = rf(item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: id, authorID
+func (_m *MockRepository) Delete(id string, authorID string) error {
+	ret := _m.Called(id, authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByAuthorID provides a mock function with given fields: authorID
+func (_m *MockRepository) DeleteByAuthorID(authorID string) (int, error) {
+	ret := _m.Called(authorID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(authorID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: id, authorID
+func (_m *MockRepository) Get(id string, authorID string) (*Item, error) {
+	ret := _m.Called(id, authorID)
+
+	var r0 *Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*Item, error)); ok {
+		return rf(id, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *Item); ok {
+		r0 = rf(id, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: authorID
+func (_m *MockRepository) GetAll(authorID string) ([]*Item, error) {
+	ret := _m.Called(authorID)
+
+	var r0 []*Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*Item, error)); ok {
+		return rf(authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*Item); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeletedBefore provides a mock function with given fields: deletedAt
+func (_m *MockRepository) GetDeletedBefore(deletedAt time.Time) ([]*Item, error) {
+	ret := _m.Called(deletedAt)
+
+	var r0 []*Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]*Item, error)); ok {
+		return rf(deletedAt)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []*Item); ok {
+		r0 = rf(deletedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(deletedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockRepository(t mockConstructorTestingTNewMockRepository) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
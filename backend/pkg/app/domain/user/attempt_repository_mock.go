@@ -0,0 +1,92 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package user
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=AttemptRepository --filename=attempt_repository_mock.go --output=./ --structname=MockAttemptRepository --inpackage
+// MockAttemptRepository is an autogenerated mock type for the AttemptRepository type
+type MockAttemptRepository struct {
+	mock.Mock
+}
+
+// DeleteAttempts provides a mock function with given fields: key
+func (_m *MockAttemptRepository) DeleteAttempts(key string) error {
+	ret := _m.Called(key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttempts provides a mock function with given fields: key
+func (_m *MockAttemptRepository) GetAttempts(key string) (*SignInAttempts, error) {
+	ret := _m.Called(key)
+
+	var r0 *SignInAttempts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*SignInAttempts, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) *SignInAttempts); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*SignInAttempts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegisterFailure provides a mock function with given fields: key, policy
+func (_m *MockAttemptRepository) RegisterFailure(key string, policy LockoutPolicy) (*SignInAttempts, error) {
+	ret := _m.Called(key, policy)
+
+	var r0 *SignInAttempts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, LockoutPolicy) (*SignInAttempts, error)); ok {
+		return rf(key, policy)
+	}
+	if rf, ok := ret.Get(0).(func(string, LockoutPolicy) *SignInAttempts); ok {
+		r0 = rf(key, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*SignInAttempts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, LockoutPolicy) error); ok {
+		r1 = rf(key, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockAttemptRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockAttemptRepository creates a new instance of MockAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockAttemptRepository(t mockConstructorTestingTNewMockAttemptRepository) *MockAttemptRepository {
+	mock := &MockAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,154 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LockoutPolicy defines how sign-in is delayed and locked after failed attempts
+type LockoutPolicy struct {
+	FreeAttempts    int           // FreeAttempts failed attempts allowed without delay
+	BaseDelay       time.Duration // BaseDelay delay after the first not free failed attempt, it is doubled on every next failure
+	MaxDelay        time.Duration // MaxDelay limits the delay, zero means no limit
+	LockoutAttempts int           // LockoutAttempts failed attempts which lock sign-in for LockoutDuration, zero disables the lockout
+	LockoutDuration time.Duration
+	ResetAfter      time.Duration // ResetAfter period without failures after which failed attempts are forgotten
+}
+
+// Validate checks that the policy does not silently disable itself, e.g. zero ResetAfter forgets every failure before the next one
+func (p LockoutPolicy) Validate() error {
+	if p.FreeAttempts < 0 || p.LockoutAttempts < 0 {
+		return errors.New("free and lockout attempts can not be negative")
+	}
+
+	if p.BaseDelay < 0 || p.MaxDelay < 0 {
+		return errors.New("sign-in delays can not be negative")
+	}
+
+	if p.LockoutAttempts > 0 && p.LockoutDuration <= 0 {
+		return fmt.Errorf("lockout duration should be positive when lockout is enabled, %s passed", p.LockoutDuration)
+	}
+
+	if p.ResetAfter <= 0 {
+		return fmt.Errorf("reset period should be positive, %s passed", p.ResetAfter)
+	}
+
+	return nil
+}
+
+// delay returns exponential back-off for the amount of failures
+func (p LockoutPolicy) delay(failures int) time.Duration {
+	extra := failures - p.FreeAttempts
+	if extra <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < extra; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return delay
+}
+
+// AccountAttemptsKey key of failed sign-in attempts of the user account
+func AccountAttemptsKey(userID string) string {
+	return "account:" + userID
+}
+
+// IPAttemptsKey key of failed sign-in attempts made from the client IP
+func IPAttemptsKey(ip string) string {
+	return "ip:" + ip
+}
+
+// SignInAttempts failed sign-in attempts of the account or the client IP
+type SignInAttempts struct {
+	key          string
+	failures     int
+	lastFailedAt time.Time
+	blockedUntil time.Time // blockedUntil sign-in is rejected without credentials check until the time
+	expiresAt    time.Time // expiresAt the time after which attempts are not needed anymore and can be removed from store
+}
+
+func NewSignInAttempts(key string) *SignInAttempts {
+	return &SignInAttempts{key: key}
+}
+
+func (a *SignInAttempts) Key() string {
+	return a.key
+}
+
+func (a *SignInAttempts) Failures() int {
+	return a.failures
+}
+
+func (a *SignInAttempts) LastFailedAt() time.Time {
+	return a.lastFailedAt
+}
+
+func (a *SignInAttempts) BlockedUntil() time.Time {
+	return a.blockedUntil
+}
+
+func (a *SignInAttempts) ExpiresAt() time.Time {
+	return a.expiresAt
+}
+
+// Blocked checks whether sign-in is delayed or locked at the moment
+func (a *SignInAttempts) Blocked() bool {
+	return a.blockedUntil.After(time.Now())
+}
+
+// RegisterFailure counts failed attempt and blocks sign-in according to the policy
+func (a *SignInAttempts) RegisterFailure(policy LockoutPolicy) {
+	now := time.Now()
+	if !a.lastFailedAt.IsZero() && now.Sub(a.lastFailedAt) > policy.ResetAfter {
+		a.failures = 0
+	}
+
+	a.failures++
+	a.lastFailedAt = now
+	a.Block(policy)
+}
+
+// Block blocks sign-in according to the policy for already counted failures, it is used by stores which count failures atomically
+func (a *SignInAttempts) Block(policy LockoutPolicy) {
+	a.blockedUntil = a.lastFailedAt.Add(policy.delay(a.failures))
+
+	if policy.LockoutAttempts > 0 && a.failures >= policy.LockoutAttempts {
+		a.blockedUntil = a.lastFailedAt.Add(policy.LockoutDuration)
+	}
+
+	a.expiresAt = a.lastFailedAt.Add(policy.ResetAfter)
+	if a.blockedUntil.After(a.expiresAt) {
+		a.expiresAt = a.blockedUntil
+	}
+}
+
+func (a *SignInAttempts) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"key":          a.key,
+		"failures":     a.failures,
+		"lastFailedAt": a.lastFailedAt,
+		"blockedUntil": a.blockedUntil,
+		"expiresAt":    a.expiresAt,
+	}
+}
+
+func UnmarshalAttemptsFromDB(key string, failures int, lastFailedAt, blockedUntil, expiresAt time.Time) *SignInAttempts {
+	return &SignInAttempts{
+		key:          key,
+		failures:     failures,
+		lastFailedAt: lastFailedAt,
+		blockedUntil: blockedUntil,
+		expiresAt:    expiresAt,
+	}
+}
@@ -0,0 +1,117 @@
+package user
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLockoutPolicy_delay(t *testing.T) {
+	policy := LockoutPolicy{FreeAttempts: 2, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, 0},
+		{2, 0},
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{5, 4 * time.Second},
+		{6, 8 * time.Second},
+		{7, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, policy.delay(tt.failures), "delay(%v)", tt.failures)
+	}
+
+	assert.Equal(t, time.Duration(0), LockoutPolicy{}.delay(10))
+}
+
+func TestLockoutPolicy_Validate(t *testing.T) {
+	valid := LockoutPolicy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute, LockoutAttempts: 10, LockoutDuration: time.Hour, ResetAfter: 24 * time.Hour}
+
+	tests := []struct {
+		name    string
+		change  func(p *LockoutPolicy)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{"Positive case", func(p *LockoutPolicy) {}, assert.NoError},
+		{"Disabled lockout without duration", func(p *LockoutPolicy) { p.LockoutAttempts = 0; p.LockoutDuration = 0 }, assert.NoError},
+		{"Zero reset period", func(p *LockoutPolicy) { p.ResetAfter = 0 }, assert.Error},
+		{"Negative reset period", func(p *LockoutPolicy) { p.ResetAfter = -time.Hour }, assert.Error},
+		{"Negative free attempts", func(p *LockoutPolicy) { p.FreeAttempts = -1 }, assert.Error},
+		{"Negative lockout attempts", func(p *LockoutPolicy) { p.LockoutAttempts = -1 }, assert.Error},
+		{"Negative base delay", func(p *LockoutPolicy) { p.BaseDelay = -time.Second }, assert.Error},
+		{"Negative max delay", func(p *LockoutPolicy) { p.MaxDelay = -time.Second }, assert.Error},
+		{"Enabled lockout without duration", func(p *LockoutPolicy) { p.LockoutDuration = 0 }, assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := valid
+			tt.change(&policy)
+			tt.wantErr(t, policy.Validate())
+		})
+	}
+}
+
+func TestSignInAttempts_RegisterFailure(t *testing.T) {
+	policy := LockoutPolicy{
+		FreeAttempts:    1,
+		BaseDelay:       time.Minute,
+		MaxDelay:        time.Hour,
+		LockoutAttempts: 3,
+		LockoutDuration: 24 * time.Hour,
+		ResetAfter:      time.Hour,
+	}
+
+	a := NewSignInAttempts(AccountAttemptsKey("testUser"))
+	assert.Equal(t, "account:testUser", a.Key())
+	assert.False(t, a.Blocked())
+
+	a.RegisterFailure(policy)
+	assert.Equal(t, 1, a.Failures())
+	assert.False(t, a.Blocked())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), a.ExpiresAt(), time.Second)
+
+	a.RegisterFailure(policy)
+	assert.Equal(t, 2, a.Failures())
+	assert.True(t, a.Blocked())
+	assert.WithinDuration(t, time.Now().Add(time.Minute), a.BlockedUntil(), time.Second)
+
+	a.RegisterFailure(policy)
+	assert.Equal(t, 3, a.Failures())
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), a.BlockedUntil(), time.Second)
+	assert.Equal(t, a.BlockedUntil(), a.ExpiresAt())
+}
+
+func TestSignInAttempts_RegisterFailure_reset(t *testing.T) {
+	policy := LockoutPolicy{LockoutAttempts: 3, LockoutDuration: time.Hour, ResetAfter: time.Hour}
+	lastFailedAt := time.Now().Add(-2 * time.Hour)
+	a := UnmarshalAttemptsFromDB(IPAttemptsKey("127.0.0.1"), 2, lastFailedAt, lastFailedAt, lastFailedAt.Add(time.Hour))
+
+	a.RegisterFailure(policy)
+	assert.Equal(t, 1, a.Failures())
+	assert.False(t, a.Blocked())
+}
+
+func TestSignInAttempts_RegisterFailure_emptyPolicy(t *testing.T) {
+	a := NewSignInAttempts(IPAttemptsKey("127.0.0.1"))
+	for i := 0; i < 10; i++ {
+		a.RegisterFailure(LockoutPolicy{})
+		assert.False(t, a.Blocked())
+	}
+}
+
+func TestSignInAttempts_Block(t *testing.T) {
+	policy := LockoutPolicy{LockoutAttempts: 3, LockoutDuration: time.Hour, ResetAfter: time.Minute}
+	lastFailedAt := time.Now()
+
+	// failures are counted by the store, so the block is calculated from the stored amount
+	a := UnmarshalAttemptsFromDB(AccountAttemptsKey("testUser"), 3, lastFailedAt, lastFailedAt, lastFailedAt.Add(time.Minute))
+	a.Block(policy)
+	assert.Equal(t, 3, a.Failures())
+	assert.Equal(t, lastFailedAt.Add(time.Hour), a.BlockedUntil())
+	assert.Equal(t, a.BlockedUntil(), a.ExpiresAt())
+}
@@ -12,4 +12,17 @@ type Repository interface {
 	Get(id string) (*User, error)           // Get gets user by id, return ErrNotFound if user not exists
 	Update(usr *User) error                 // Update saves the updated usr entity to store, return ErrEmailAlreadyExists when user with email already exists
 	Delete(id string) (int, error)          // Delete removes user from DB
+	// UseTwoFactorCode saves accepted two-factor code of the user only when it is not used yet: TOTP step has to be later than
+	// the stored last used one and recovery code has to be still stored, so parallel sign-ins can not accept the same code twice,
+	// return ErrTwoFactorCodeUsed otherwise
+	UseTwoFactorCode(userID string, use TwoFactorUse) error
+}
+
+// AttemptRepository keeps failed sign-in attempts, expired attempts are not provided
+type AttemptRepository interface {
+	GetAttempts(key string) (*SignInAttempts, error) // GetAttempts returns empty attempts if there are no stored not expired ones
+	// RegisterFailure atomically counts failed attempt by key and blocks sign-in according to the policy,
+	// so parallel failures can not be lost. Attempts are started over when the stored ones are expired
+	RegisterFailure(key string, policy LockoutPolicy) (*SignInAttempts, error)
+	DeleteAttempts(key string) error
 }
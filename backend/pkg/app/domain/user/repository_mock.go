@@ -114,6 +114,20 @@ func (_m *MockRepository) Update(usr *User) error {
 	return r0
 }
 
+// UseTwoFactorCode provides a mock function with given fields: userID, use
+func (_m *MockRepository) UseTwoFactorCode(userID string, use TwoFactorUse) error {
+	ret := _m.Called(userID, use)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, TwoFactorUse) error); ok {
+		r0 = rf(userID, use)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewMockRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,239 @@
+package user
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // SHA1 is the default TOTP algorithm supported by all authenticator apps
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+var ErrInvalidTwoFactorCode = errors.New("two-factor authentication code is not valid")
+var ErrTwoFactorCodeUsed = errors.New("two-factor authentication code is already used")
+
+const (
+	totpSecretSize     = 20 // totpSecretSize 160 bits recommended by RFC 4226 for SHA1
+	totpDigits         = 6
+	totpModulo         = 1000000 // totpModulo 10^totpDigits
+	totpPeriod         = 30 * time.Second
+	totpSkew           = 1 // totpSkew amount of time steps before and after the current one accepted because of clock drift
+	recoveryCodesCount = 10
+	recoveryCodeSize   = 10
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactor TOTP (RFC 6238) second factor of the user. The secret is kept as is as it is needed to check codes,
+// recovery codes are kept as hashes only
+type TwoFactor struct {
+	secret        string   // secret base32 encoded shared secret
+	enabled       bool     // enabled the secret is confirmed with the first code, so the code is required on sign-in
+	lastUsedStep  int64    // lastUsedStep time step of the last accepted code, codes of this and previous steps can not be replayed
+	recoveryCodes []string // recoveryCodes hashes of not used one-time recovery codes
+}
+
+func (t *TwoFactor) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"secret":        t.secret,
+		"enabled":       t.enabled,
+		"lastUsedStep":  t.lastUsedStep,
+		"recoveryCodes": t.recoveryCodes,
+	}
+}
+
+// TwoFactorUse accepted two-factor code which has to be saved as used, see Repository.UseTwoFactorCode
+type TwoFactorUse struct {
+	Step             int64  // Step time step of accepted TOTP code, zero for recovery code
+	RecoveryCodeHash string // RecoveryCodeHash hash of accepted recovery code, empty for TOTP code
+}
+
+// verifyTOTP checks the code for the current time step allowing clock drift, the accepted step is remembered
+func (t *TwoFactor) verifyTOTP(code string, now time.Time) bool {
+	key, err := secretEncoding.DecodeString(t.secret)
+	if err != nil || len(code) != totpDigits {
+		return false
+	}
+
+	current := now.Unix() / int64(totpPeriod.Seconds())
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= t.lastUsedStep {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			t.lastUsedStep = step
+			return true
+		}
+	}
+
+	return false
+}
+
+// useRecoveryCode checks the recovery code and removes it, so it can not be used again
+func (t *TwoFactor) useRecoveryCode(code string) bool {
+	hash := hashRecoveryCode(code)
+	for i, stored := range t.recoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			t.recoveryCodes = append(t.recoveryCodes[:i:i], t.recoveryCodes[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (u *User) TwoFactorEnabled() bool {
+	return u.twoFactor.enabled
+}
+
+// RecoveryCodesLeft amount of not used recovery codes
+func (u *User) RecoveryCodesLeft() int {
+	return len(u.twoFactor.recoveryCodes)
+}
+
+// EnrollTwoFactor generates new TOTP secret which has to be confirmed with the first code,
+// enabled two-factor authentication can be only reset
+func (u *User) EnrollTwoFactor() (string, error) {
+	if u.twoFactor.enabled {
+		return "", ErrTwoFactorEnabled
+	}
+
+	key := make([]byte, totpSecretSize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	u.twoFactor = TwoFactor{secret: secretEncoding.EncodeToString(key)}
+	return u.twoFactor.secret, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication when the code of enrolled secret is valid, returns one-time recovery codes
+func (u *User) ConfirmTwoFactor(code string) ([]string, error) {
+	if u.twoFactor.enabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	if u.twoFactor.secret == "" {
+		return nil, errors.New("two-factor authentication is not enrolled")
+	}
+
+	if !u.twoFactor.verifyTOTP(code, time.Now()) {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	u.twoFactor.enabled = true
+	u.twoFactor.recoveryCodes = hashes
+	return codes, nil
+}
+
+// VerifyTwoFactor checks TOTP or one-time recovery code, the returned accepted code has to be saved with Repository.UseTwoFactorCode,
+// so it can not be used again by parallel sign-ins as well
+func (u *User) VerifyTwoFactor(code string) (TwoFactorUse, bool) {
+	if !u.twoFactor.enabled {
+		return TwoFactorUse{}, false
+	}
+
+	if u.twoFactor.verifyTOTP(code, time.Now()) {
+		return TwoFactorUse{Step: u.twoFactor.lastUsedStep}, true
+	}
+
+	if u.twoFactor.useRecoveryCode(code) {
+		return TwoFactorUse{RecoveryCodeHash: hashRecoveryCode(code)}, true
+	}
+
+	return TwoFactorUse{}, false
+}
+
+// ResetTwoFactor disables two-factor authentication, the user has to enroll again to enable it
+func (u *User) ResetTwoFactor() {
+	u.twoFactor = TwoFactor{}
+}
+
+// TwoFactorURI builds otpauth URI of the secret which is shown as QR code to be scanned by authenticator app
+func TwoFactorURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return uri.String()
+}
+
+// GenerateTOTPCode generates the code of the secret for the time, the same as authenticator app shows
+func GenerateTOTPCode(secret string, at time.Time) (string, error) {
+	key, err := secretEncoding.DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return totpCode(key, at.Unix()/int64(totpPeriod.Seconds())), nil
+}
+
+// totpCode HOTP value (RFC 4226) of the time step
+func totpCode(key []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulo)
+}
+
+// newRecoveryCode generates random code in xxxxx-xxxxx format
+func newRecoveryCode() (string, error) {
+	raw := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(secretEncoding.EncodeToString(raw))[:recoveryCodeSize]
+	return code[:recoveryCodeSize/2] + "-" + code[recoveryCodeSize/2:], nil
+}
+
+// hashRecoveryCode recovery codes are random, so fast hash is enough, the code is normalized to ignore case and separators
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func UnmarshalTwoFactorFromDB(secret string, enabled bool, lastUsedStep int64, recoveryCodes []string) TwoFactor {
+	return TwoFactor{
+		secret:        secret,
+		enabled:       enabled,
+		lastUsedStep:  lastUsedStep,
+		recoveryCodes: recoveryCodes,
+	}
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTotpCode(t *testing.T) {
+	// RFC 6238 appendix B SHA1 test vectors truncated to 6 digits
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, totpCode(key, tt.unix/30), "totpCode(%v)", tt.unix)
+	}
+}
+
+func TestUser_TwoFactor(t *testing.T) {
+	usr, err := NewUser("test", "test@test.com", "12345678", Author)
+	assert.Nil(t, err)
+	_, ok := usr.VerifyTwoFactor("000000")
+	assert.False(t, ok)
+
+	_, err = usr.ConfirmTwoFactor("000000")
+	assert.Equal(t, "two-factor authentication is not enrolled", err.Error())
+
+	secret, err := usr.EnrollTwoFactor()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+	assert.False(t, usr.TwoFactorEnabled())
+
+	_, err = usr.ConfirmTwoFactor("invalid")
+	assert.Equal(t, ErrInvalidTwoFactorCode, err)
+
+	key, err := secretEncoding.DecodeString(secret)
+	assert.Nil(t, err)
+	step := time.Now().Unix() / 30
+
+	codes, err := usr.ConfirmTwoFactor(totpCode(key, step))
+	assert.Nil(t, err)
+	assert.True(t, usr.TwoFactorEnabled())
+	assert.Len(t, codes, recoveryCodesCount)
+	assert.Equal(t, recoveryCodesCount, usr.RecoveryCodesLeft())
+
+	_, err = usr.EnrollTwoFactor()
+	assert.Equal(t, ErrTwoFactorEnabled, err)
+
+	_, ok = usr.VerifyTwoFactor(totpCode(key, step))
+	assert.False(t, ok, "code can not be replayed")
+	_, ok = usr.VerifyTwoFactor(totpCode(key, step+5))
+	assert.False(t, ok, "code of far time step is not valid")
+	code, err := GenerateTOTPCode(secret, time.Now().Add(30*time.Second))
+	assert.Nil(t, err)
+	use, ok := usr.VerifyTwoFactor(code)
+	assert.True(t, ok)
+	assert.Equal(t, TwoFactorUse{Step: step + 1}, use)
+
+	use, ok = usr.VerifyTwoFactor(strings.ToUpper(codes[0]))
+	assert.True(t, ok)
+	assert.Equal(t, TwoFactorUse{RecoveryCodeHash: hashRecoveryCode(codes[0])}, use)
+	_, ok = usr.VerifyTwoFactor(codes[0])
+	assert.False(t, ok, "recovery code can be used only once")
+	assert.Equal(t, recoveryCodesCount-1, usr.RecoveryCodesLeft())
+
+	usr.ResetTwoFactor()
+	assert.False(t, usr.TwoFactorEnabled())
+	_, ok = usr.VerifyTwoFactor(codes[1])
+	assert.False(t, ok)
+}
+
+func TestTwoFactorURI(t *testing.T) {
+	assert.Equal(
+		t,
+		"otpauth://totp/webdict:test@test.com?algorithm=SHA1&digits=6&issuer=webdict&period=30&secret=SECRET",
+		TwoFactorURI("webdict", "test@test.com", "SECRET"),
+	)
+}
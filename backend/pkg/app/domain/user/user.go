@@ -41,6 +41,8 @@ type User struct {
 	role          Role
 	defaultLangID string
 	listOptions   ListOptions
+	tokenVersion  int // tokenVersion is increased on password or role change, so tokens issued before become invalid
+	twoFactor     TwoFactor
 }
 
 func NewUser(name, email, password string, role Role) (*User, error) {
@@ -86,6 +88,10 @@ func (u *User) ListOptions() ListOptions {
 	return u.listOptions
 }
 
+func (u *User) TokenVersion() int {
+	return u.tokenVersion
+}
+
 func (u *User) ApplyChanges(name, email, passwd string, role Role, defaultLangID string, listOptions ListOptions) error {
 	updated := *u
 	updated.applyChanges(name, email, passwd, role, defaultLangID, listOptions)
@@ -99,6 +105,10 @@ func (u *User) ApplyChanges(name, email, passwd string, role Role, defaultLangID
 }
 
 func (u *User) applyChanges(name, email, passwd string, role Role, defaultLangID string, listOptions ListOptions) {
+	if passwd != u.password || role != u.role {
+		u.tokenVersion++
+	}
+
 	u.name = name
 	u.email = email
 	u.role = role
@@ -116,6 +126,8 @@ func (u *User) ToMap() map[string]interface{} {
 		"role":          int(u.role),
 		"defaultLangID": u.defaultLangID,
 		"listOptions":   u.listOptions.ToMap(),
+		"tokenVersion":  u.tokenVersion,
+		"twoFactor":     u.twoFactor.ToMap(),
 	}
 }
 
@@ -155,6 +167,8 @@ func UnmarshalFromDB(
 	role Role,
 	defaultLangID string,
 	listOptions ListOptions,
+	tokenVersion int,
+	twoFactor TwoFactor,
 ) *User {
 	return &User{
 		id:            id,
@@ -164,5 +178,7 @@ func UnmarshalFromDB(
 		role:          role,
 		defaultLangID: defaultLangID,
 		listOptions:   listOptions,
+		tokenVersion:  tokenVersion,
+		twoFactor:     twoFactor,
 	}
 }
@@ -136,9 +136,12 @@ func TestUnmarshalFromDB(t *testing.T) {
 		role:          Role(0),
 		defaultLangID: "testLang",
 		listOptions:   ListOptions{hideTranscription: true},
+		tokenVersion:  3,
+		twoFactor:     TwoFactor{secret: "testSecret", enabled: true, lastUsedStep: 10, recoveryCodes: []string{"testCode"}},
 	}
 
-	assert.Equal(t, &user, UnmarshalFromDB(user.id, user.name, user.email, user.password, user.role, user.defaultLangID, user.listOptions))
+	twoFactor := UnmarshalTwoFactorFromDB("testSecret", true, 10, []string{"testCode"})
+	assert.Equal(t, &user, UnmarshalFromDB(user.id, user.name, user.email, user.password, user.role, user.defaultLangID, user.listOptions, user.tokenVersion, twoFactor))
 }
 
 func TestRole_valid(t *testing.T) {
@@ -214,6 +217,7 @@ func TestUser_ApplyChanges(t *testing.T) {
 				assert.Equal(t, "test@mail.com", usr.email)
 				assert.Equal(t, "testPasswd", usr.password)
 				assert.Equal(t, Admin, usr.role)
+				assert.Equal(t, 0, usr.tokenVersion)
 			},
 		},
 		{
@@ -241,6 +245,46 @@ func TestUser_ApplyChanges(t *testing.T) {
 				assert.Equal(t, Author, usr.role)
 				assert.Equal(t, "langID", usr.defaultLangID)
 				assert.Equal(t, false, usr.listOptions.hideTranscription)
+				assert.Equal(t, 1, usr.tokenVersion)
+			},
+		},
+		{
+			"Token version is kept when password and role are not changed",
+			fields{
+				name:     "testName",
+				email:    "test@mail.com",
+				password: "testPasswd",
+				role:     Admin,
+			},
+			args{
+				name:   "name",
+				email:  "updated@email.com",
+				passwd: "testPasswd",
+				role:   Admin,
+			},
+			func(t assert.TestingT, err error, usr *User, details string) {
+				assert.Nil(t, err, details)
+				assert.Equal(t, "updated@email.com", usr.email)
+				assert.Equal(t, 0, usr.tokenVersion)
+			},
+		},
+		{
+			"Token version is increased on role change",
+			fields{
+				name:     "testName",
+				email:    "test@mail.com",
+				password: "testPasswd",
+				role:     Admin,
+			},
+			args{
+				name:   "testName",
+				email:  "test@mail.com",
+				passwd: "testPasswd",
+				role:   Author,
+			},
+			func(t assert.TestingT, err error, usr *User, details string) {
+				assert.Nil(t, err, details)
+				assert.Equal(t, 1, usr.tokenVersion)
 			},
 		},
 	}
@@ -0,0 +1,37 @@
+package query
+
+import "github.com/go-playground/validator/v10"
+
+// AllSessions get all active sessions of the user query
+type AllSessions struct {
+	UserID string `validate:"required"`
+}
+
+// AllSessionsHandler get all active sessions of the user query handler
+type AllSessionsHandler struct {
+	sessionRepo SessionViewRepository
+	sanitizer   *strictSanitizer
+	validator   *validator.Validate
+}
+
+func NewAllSessionsHandler(sessionRepo SessionViewRepository, validate *validator.Validate) AllSessionsHandler {
+	return AllSessionsHandler{sessionRepo: sessionRepo, sanitizer: newStrictSanitizer(), validator: validate}
+}
+
+// Handle performs query to receive all not expired sessions of the user, the latest refreshed go first
+func (h AllSessionsHandler) Handle(query AllSessions) ([]SessionView, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return nil, err
+	}
+
+	sessions, err := h.sessionRepo.GetAllViews(query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].sanitize(h.sanitizer)
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,69 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAllSessionsHandler_Handle(t *testing.T) {
+	type fields struct {
+		sessionRepo SessionViewRepository
+	}
+	type args struct {
+		query AllSessions
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     []SessionView
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on query validation",
+			func() fields {
+				return fields{sessionRepo: &MockSessionViewRepository{}}
+			},
+			args{AllSessions{}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Error on DB query",
+			func() fields {
+				repo := MockSessionViewRepository{}
+				repo.On("GetAllViews", "testUser").Return(nil, errors.New("testErr"))
+				return fields{sessionRepo: &repo}
+			},
+			args{AllSessions{UserID: "testUser"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Positive case with sanitization",
+			func() fields {
+				repo := MockSessionViewRepository{}
+				repo.On("GetAllViews", "testUser").Return([]SessionView{
+					{ID: "session1", IP: "127.0.0.1", UserAgent: `<a href="javascript:alert('XSS1')" onmouseover="alert('XSS2')">Mozilla<a>`},
+				}, nil)
+				return fields{sessionRepo: &repo}
+			},
+			args{AllSessions{UserID: "testUser"}},
+			[]SessionView{{ID: "session1", IP: "127.0.0.1", UserAgent: "Mozilla"}},
+			assert.NoError,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewAllSessionsHandler(tt.fieldsFn().sessionRepo, v)
+			got, err := h.Handle(tt.args.query)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package query
+
+import "github.com/go-playground/validator/v10"
+
+// AllTokens get all personal access tokens of the user query
+type AllTokens struct {
+	UserID string `validate:"required"`
+}
+
+// AllTokensHandler get all personal access tokens of the user query handler
+type AllTokensHandler struct {
+	tokenRepo TokenViewRepository
+	sanitizer *strictSanitizer
+	validator *validator.Validate
+}
+
+func NewAllTokensHandler(tokenRepo TokenViewRepository, validate *validator.Validate) AllTokensHandler {
+	return AllTokensHandler{tokenRepo: tokenRepo, sanitizer: newStrictSanitizer(), validator: validate}
+}
+
+// Handle performs query to receive all personal access tokens of the user, the latest created go first
+func (h AllTokensHandler) Handle(query AllTokens) ([]TokenView, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return nil, err
+	}
+
+	tokens, err := h.tokenRepo.GetAllViews(query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tokens {
+		tokens[i].sanitize(h.sanitizer)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,69 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAllTokensHandler_Handle(t *testing.T) {
+	type fields struct {
+		tokenRepo TokenViewRepository
+	}
+	type args struct {
+		query AllTokens
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     []TokenView
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on query validation",
+			func() fields {
+				return fields{tokenRepo: &MockTokenViewRepository{}}
+			},
+			args{AllTokens{}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Error on DB query",
+			func() fields {
+				repo := MockTokenViewRepository{}
+				repo.On("GetAllViews", "testUser").Return(nil, errors.New("testErr"))
+				return fields{tokenRepo: &repo}
+			},
+			args{AllTokens{UserID: "testUser"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Positive case with sanitization",
+			func() fields {
+				repo := MockTokenViewRepository{}
+				repo.On("GetAllViews", "testUser").Return([]TokenView{
+					{ID: "token1", Name: `<a href="javascript:alert('XSS1')" onmouseover="alert('XSS2')">cron<a>`, Scopes: []string{"read"}},
+				}, nil)
+				return fields{tokenRepo: &repo}
+			},
+			args{AllTokens{UserID: "testUser"}},
+			[]TokenView{{ID: "token1", Name: "cron", Scopes: []string{"read"}}},
+			assert.NoError,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewAllTokensHandler(tt.fieldsFn().tokenRepo, v)
+			got, err := h.Handle(tt.args.query)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package query
+
+import "github.com/go-playground/validator/v10"
+
+// AllTrashItems get all trash items for author query
+type AllTrashItems struct {
+	AuthorID string `validate:"required"`
+}
+
+// AllTrashItemsHandler get all trash items for author query handler
+type AllTrashItemsHandler struct {
+	trashRepo TrashViewRepository
+	sanitizer *strictSanitizer
+	validator *validator.Validate
+}
+
+func NewAllTrashItemsHandler(trashRepo TrashViewRepository, validate *validator.Validate) AllTrashItemsHandler {
+	return AllTrashItemsHandler{trashRepo: trashRepo, sanitizer: newStrictSanitizer(), validator: validate}
+}
+
+// Handle performs query to receive all trash items for author, the latest deleted go first
+func (h AllTrashItemsHandler) Handle(query AllTrashItems) ([]TrashItemView, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return nil, err
+	}
+
+	items, err := h.trashRepo.GetAllViews(query.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].sanitize(h.sanitizer)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,69 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAllTrashItemsHandler_Handle(t *testing.T) {
+	type fields struct {
+		trashRepo TrashViewRepository
+	}
+	type args struct {
+		query AllTrashItems
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     []TrashItemView
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on query validation",
+			func() fields {
+				return fields{trashRepo: &MockTrashViewRepository{}}
+			},
+			args{AllTrashItems{}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Error on DB query",
+			func() fields {
+				repo := MockTrashViewRepository{}
+				repo.On("GetAllViews", "testAuthor").Return(nil, errors.New("testErr"))
+				return fields{trashRepo: &repo}
+			},
+			args{AllTrashItems{AuthorID: "testAuthor"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Positive case with sanitization",
+			func() fields {
+				repo := MockTrashViewRepository{}
+				repo.On("GetAllViews", "testAuthor").Return([]TrashItemView{
+					{ID: "item1", Kind: "tag", EntityID: "tag1", Title: `<a href="javascript:alert('XSS1')" onmouseover="alert('XSS2')">tag<a>`},
+				}, nil)
+				return fields{trashRepo: &repo}
+			},
+			args{AllTrashItems{AuthorID: "testAuthor"}},
+			[]TrashItemView{{ID: "item1", Kind: "tag", EntityID: "tag1", Title: "tag"}},
+			assert.NoError,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewAllTrashItemsHandler(tt.fieldsFn().trashRepo, v)
+			got, err := h.Handle(tt.args.query)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
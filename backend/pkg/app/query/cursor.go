@@ -0,0 +1,55 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SortOrder defines order of translations list
+type SortOrder string
+
+const (
+	SortRelevance   SortOrder = ""             // SortRelevance exact, prefix and substring source and target matches go first, then the latest created
+	SortCreatedDesc SortOrder = "created_desc" // SortCreatedDesc the latest created go first
+	SortCreatedAsc  SortOrder = "created_asc"  // SortCreatedAsc the earliest created go first
+	SortUpdatedDesc SortOrder = "updated_desc" // SortUpdatedDesc the latest updated go first
+	SortSourceAsc   SortOrder = "source_asc"   // SortSourceAsc alphabetical by source ignoring case and diacritics
+	SortTargetAsc   SortOrder = "target_asc"   // SortTargetAsc alphabetical by the first sense target ignoring case and diacritics
+)
+
+// Cursor keeps sort keys of the last returned translation, the next page starts right after it
+type Cursor struct {
+	Sort       SortOrder `json:"s"`
+	SourceRank MatchRank `json:"sr,omitempty"`
+	TargetRank MatchRank `json:"tr,omitempty"`
+	Time       time.Time `json:"t,omitempty"`
+	Text       string    `json:"x,omitempty"`
+	ID         string    `json:"id"`
+}
+
+// Token encodes cursor to opaque continuation token
+func (c Cursor) Token() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes cursor from continuation token
+func ParseCursor(token string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var c Cursor
+	if err = json.Unmarshal(data, &c); err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	if c.ID == "" {
+		return Cursor{}, fmt.Errorf("invalid cursor: translation id is missed")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,41 @@
+package query
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCursor_Token(t *testing.T) {
+	c := Cursor{
+		Sort:       SortRelevance,
+		SourceRank: PrefixMatch,
+		TargetRank: SubstringMatch,
+		Time:       time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC),
+		Text:       "text",
+		ID:         "testID",
+	}
+
+	parsed, err := ParseCursor(c.Token())
+	assert.Nil(t, err)
+	assert.Equal(t, c, parsed)
+}
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{"Not base64 token", "not base64!", assert.Error},
+		{"Not json token", "bm90IGpzb24", assert.Error},
+		{"Missed ID", Cursor{Sort: SortSourceAsc}.Token(), assert.Error},
+		{"Valid token", Cursor{Sort: SortSourceAsc, Text: "a", ID: "id"}.Token(), assert.NoError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCursor(tt.token)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"sort"
+	"time"
+)
+
+// maxStatsPeriods limits amount of periods in translations added statistics
+const maxStatsPeriods = 366
+
+// DictionaryStats get author dictionary statistics query
+type DictionaryStats struct {
+	AuthorID  string      `validate:"required"`
+	AddedFrom time.Time   `validate:"required"`
+	AddedTo   time.Time   `validate:"required,gtefield=AddedFrom"`
+	Period    StatsPeriod `validate:"oneof=day week"`
+}
+
+// DictionaryStatsHandler get author dictionary statistics query handler
+type DictionaryStatsHandler struct {
+	statsRepo StatsViewRepository
+	validator *validator.Validate
+	sanitizer *strictSanitizer
+}
+
+func NewDictionaryStatsHandler(statsRepo StatsViewRepository, validate *validator.Validate) DictionaryStatsHandler {
+	return DictionaryStatsHandler{statsRepo: statsRepo, validator: validate, sanitizer: newStrictSanitizer()}
+}
+
+// Handle performs query to get author dictionary statistics, langs and tags with more translations go first,
+// translations added counts contain every period of the range including empty ones
+func (h DictionaryStatsHandler) Handle(query DictionaryStats) (StatsView, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return StatsView{}, err
+	}
+
+	added := DateRange{From: query.Period.Start(query.AddedFrom), To: query.AddedTo.UTC()}
+	periods := query.Period.Periods(added)
+	if len(periods) > maxStatsPeriods {
+		return StatsView{}, fmt.Errorf("added range can not contain more than %d periods", maxStatsPeriods)
+	}
+
+	stats, err := h.statsRepo.GetStats(query.AuthorID, added, query.Period)
+	if err != nil {
+		return StatsView{}, err
+	}
+
+	stats.Added = h.fillPeriods(periods, stats.Added)
+
+	sort.SliceStable(stats.Langs, func(i, j int) bool {
+		if stats.Langs[i].Count != stats.Langs[j].Count {
+			return stats.Langs[i].Count > stats.Langs[j].Count
+		}
+		return stats.Langs[i].Lang.Name < stats.Langs[j].Lang.Name
+	})
+
+	sort.SliceStable(stats.Tags, func(i, j int) bool {
+		if stats.Tags[i].Count != stats.Tags[j].Count {
+			return stats.Tags[i].Count > stats.Tags[j].Count
+		}
+		return stats.Tags[i].Tag.Name < stats.Tags[j].Tag.Name
+	})
+
+	for i := range stats.Langs {
+		stats.Langs[i].Lang.sanitize(h.sanitizer)
+	}
+
+	for i := range stats.Tags {
+		stats.Tags[i].Tag.sanitize(h.sanitizer)
+	}
+
+	return stats, nil
+}
+
+// fillPeriods returns counts for every passed period, periods missed in counts have zero count
+func (h DictionaryStatsHandler) fillPeriods(periods []time.Time, counts []AddedStatsView) []AddedStatsView {
+	countsByStart := make(map[time.Time]int, len(counts))
+	for _, count := range counts {
+		countsByStart[count.PeriodStart.UTC()] += count.Count
+	}
+
+	result := make([]AddedStatsView, len(periods))
+	for i, start := range periods {
+		result[i] = AddedStatsView{PeriodStart: start, Count: countsByStart[start]}
+	}
+
+	return result
+}
+
+// Start returns start of the period which contains passed time in UTC
+func (p StatsPeriod) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	if p == StatsPeriodWeek {
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+
+	return day
+}
+
+// Periods returns starts of all periods intersecting with the range
+func (p StatsPeriod) Periods(r DateRange) []time.Time {
+	step := 1
+	if p == StatsPeriodWeek {
+		step = 7
+	}
+
+	var periods []time.Time
+	for start := p.Start(r.From); !start.After(r.To); start = start.AddDate(0, 0, step) {
+		periods = append(periods, start)
+		if len(periods) > maxStatsPeriods {
+			break
+		}
+	}
+
+	return periods
+}
@@ -0,0 +1,131 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDictionaryStatsHandler_Handle(t *testing.T) {
+	type fields struct {
+		statsRepo StatsViewRepository
+	}
+	type args struct {
+		query DictionaryStats
+	}
+	from := time.Date(2023, 3, 1, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 3, 3, 10, 0, 0, 0, time.UTC)
+	added := DateRange{From: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), To: to}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     StatsView
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on query validation",
+			func() fields {
+				return fields{statsRepo: &MockStatsViewRepository{}}
+			},
+			args{DictionaryStats{AuthorID: "testAuthor", AddedFrom: to, AddedTo: from, Period: StatsPeriodDay}},
+			StatsView{},
+			assert.Error,
+		},
+		{
+			"Error on too long range",
+			func() fields {
+				return fields{statsRepo: &MockStatsViewRepository{}}
+			},
+			args{DictionaryStats{AuthorID: "testAuthor", AddedFrom: from.AddDate(-2, 0, 0), AddedTo: to, Period: StatsPeriodDay}},
+			StatsView{},
+			assert.Error,
+		},
+		{
+			"Error on DB query",
+			func() fields {
+				repo := MockStatsViewRepository{}
+				repo.On("GetStats", "testAuthor", added, StatsPeriodDay).Return(StatsView{}, errors.New("testErr"))
+				return fields{statsRepo: &repo}
+			},
+			args{DictionaryStats{AuthorID: "testAuthor", AddedFrom: from, AddedTo: to, Period: StatsPeriodDay}},
+			StatsView{},
+			assert.Error,
+		},
+		{
+			"Positive case with sorting, empty periods and sanitization",
+			func() fields {
+				repo := MockStatsViewRepository{}
+				repo.On("GetStats", "testAuthor", added, StatsPeriodDay).Return(StatsView{
+					Total:    5,
+					Untagged: 1,
+					Langs: []LangStatsView{
+						{Lang: LangView{ID: "lang1", Name: "EN"}, Count: 1},
+						{Lang: LangView{ID: "lang2", Name: `<a href="javascript:alert('XSS1')" onmouseover="alert('XSS2')">DE<a>`}, Count: 4},
+					},
+					Tags: []TagStatsView{
+						{Tag: TagView{ID: "tag1", Name: "verb"}, Count: 2},
+						{Tag: TagView{ID: "tag2", Name: "noun"}, Count: 2},
+						{Tag: TagView{ID: "tag3", Name: "adj"}, Count: 3},
+					},
+					Added: []AddedStatsView{{PeriodStart: time.Date(2023, 3, 3, 0, 0, 0, 0, time.UTC), Count: 2}},
+				}, nil)
+				return fields{statsRepo: &repo}
+			},
+			args{DictionaryStats{AuthorID: "testAuthor", AddedFrom: from, AddedTo: to, Period: StatsPeriodDay}},
+			StatsView{
+				Total:    5,
+				Untagged: 1,
+				Langs: []LangStatsView{
+					{Lang: LangView{ID: "lang2", Name: "DE"}, Count: 4},
+					{Lang: LangView{ID: "lang1", Name: "EN"}, Count: 1},
+				},
+				Tags: []TagStatsView{
+					{Tag: TagView{ID: "tag3", Name: "adj"}, Count: 3},
+					{Tag: TagView{ID: "tag2", Name: "noun"}, Count: 2},
+					{Tag: TagView{ID: "tag1", Name: "verb"}, Count: 2},
+				},
+				Added: []AddedStatsView{
+					{PeriodStart: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), Count: 0},
+					{PeriodStart: time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC), Count: 0},
+					{PeriodStart: time.Date(2023, 3, 3, 0, 0, 0, 0, time.UTC), Count: 2},
+				},
+			},
+			assert.NoError,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewDictionaryStatsHandler(tt.fieldsFn().statsRepo, v)
+			got, err := h.Handle(tt.args.query)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatsPeriod_Start(t *testing.T) {
+	wednesday := time.Date(2023, 3, 1, 15, 0, 0, 0, time.FixedZone("UTC+3", 3*3600))
+	sunday := time.Date(2023, 3, 5, 23, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), StatsPeriodDay.Start(wednesday))
+	assert.Equal(t, time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC), StatsPeriodWeek.Start(wednesday))
+	assert.Equal(t, time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC), StatsPeriodWeek.Start(sunday))
+	assert.Equal(t, time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC), StatsPeriodWeek.Start(time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestStatsPeriod_Periods(t *testing.T) {
+	r := DateRange{From: time.Date(2023, 3, 1, 15, 0, 0, 0, time.UTC), To: time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC)}
+
+	assert.Equal(t, []time.Time{
+		time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC),
+	}, StatsPeriodWeek.Periods(r))
+	assert.Equal(t, 13, len(StatsPeriodDay.Periods(r)))
+}
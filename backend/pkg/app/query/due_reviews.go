@@ -0,0 +1,51 @@
+package query
+
+import (
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+// DueReviews get translations which are scheduled for review query
+type DueReviews struct {
+	AuthorID string `validate:"required"`
+	LangID   string `validate:"required"`
+	TagIds   []string
+	Limit    int `validate:"gte=1,lte=200"`
+}
+
+// DueReviewsHandler get translations scheduled for review query handler
+type DueReviewsHandler struct {
+	translationRepo TranslationViewRepository
+	tagRepo         TagViewRepository
+	validator       *validator.Validate
+	strictSntz      *strictSanitizer
+	richSntz        *richTextSanitizer
+}
+
+func NewDueReviewsHandler(translationRepo TranslationViewRepository, tagRepo TagViewRepository, validate *validator.Validate) DueReviewsHandler {
+	return DueReviewsHandler{translationRepo: translationRepo, tagRepo: tagRepo, validator: validate, strictSntz: newStrictSanitizer(), richSntz: newRichTextSanitizer()}
+}
+
+// Handle performs query to get translations which review is due, the most overdue go first
+func (h DueReviewsHandler) Handle(query DueReviews) (DueViews, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return DueViews{}, err
+	}
+
+	tags, err := tagGroups(h.tagRepo, query.TagIds, query.AuthorID)
+	if err != nil {
+		return DueViews{}, err
+	}
+
+	dueViews, err := h.translationRepo.GetDueViews(query.AuthorID, query.LangID, tags, time.Now(), query.Limit)
+
+	if err != nil {
+		return dueViews, err
+	}
+
+	for i := range dueViews.Views {
+		dueViews.Views[i].sanitize(h.strictSntz, h.richSntz)
+	}
+
+	return dueViews, nil
+}
@@ -0,0 +1,82 @@
+package query
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestDueReviewsHandler_Handle(t *testing.T) {
+	type fields struct {
+		translationRepo TranslationViewRepository
+	}
+	type args struct {
+		query DueReviews
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     DueViews
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on query validation",
+			func() fields {
+				return fields{translationRepo: &MockTranslationViewRepository{}}
+			},
+			args{DueReviews{AuthorID: "authorID", TagIds: []string{}, Limit: 10}},
+			DueViews{},
+			assert.Error,
+		},
+		{
+			"Error on getting due views from db",
+			func() fields {
+				repo := MockTranslationViewRepository{}
+				repo.On("GetDueViews", "authorID", "EN", TagGroups(nil), mock.AnythingOfType("time.Time"), 10).Return(DueViews{}, fmt.Errorf("error"))
+				return fields{translationRepo: &repo}
+			},
+			args{DueReviews{AuthorID: "authorID", LangID: "EN", TagIds: []string{}, Limit: 10}},
+			DueViews{},
+			assert.Error,
+		},
+		{
+			"Positive case",
+			func() fields {
+				repo := MockTranslationViewRepository{}
+				repo.On("GetDueViews", "authorID", "EN", TagGroups{{"tag1"}}, mock.AnythingOfType("time.Time"), 10).Return(
+					DueViews{
+						Views: []TranslationView{{
+							ID:     "testID",
+							Source: "TestText",
+							Senses: []SenseView{{Target: `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`}},
+							Review: ReviewView{Ease: 2.5, Interval: 6},
+						}},
+					}, nil)
+				return fields{translationRepo: &repo}
+			},
+			args{DueReviews{AuthorID: "authorID", LangID: "EN", TagIds: []string{"tag1"}, Limit: 10}},
+			DueViews{Views: []TranslationView{{
+				ID:     "testID",
+				Source: "TestText",
+				Senses: []SenseView{{Target: "<br>TestMeaning</br>"}},
+				Review: ReviewView{Ease: 2.5, Interval: 6},
+			}}},
+			assert.NoError,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := NewDueReviewsHandler(f.translationRepo, newTagHierarchyRepo(), v)
+			got, err := h.Handle(tt.args.query)
+			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.query)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "Handle(%v)", tt.args.query)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package query
+
+import "github.com/go-playground/validator/v10"
+
+// ExportDictionary get all author langs, tags and translations query
+type ExportDictionary struct {
+	AuthorID string `validate:"required"`
+}
+
+// ExportDictionaryHandler get all author dictionary data query handler,
+// the data is not sanitized as it is exported to be imported back
+type ExportDictionaryHandler struct {
+	langRepo   LangViewRepository
+	tagRepo    TagViewRepository
+	exportRepo ExportViewRepository
+	validator  *validator.Validate
+}
+
+func NewExportDictionaryHandler(langRepo LangViewRepository, tagRepo TagViewRepository, exportRepo ExportViewRepository, validate *validator.Validate) ExportDictionaryHandler {
+	return ExportDictionaryHandler{langRepo: langRepo, tagRepo: tagRepo, exportRepo: exportRepo, validator: validate}
+}
+
+// Handle performs query to export author dictionary, langs and tags are passed to begin at once,
+// then translations are passed to each one by one in creation order
+func (h ExportDictionaryHandler) Handle(query ExportDictionary, begin func(langs []LangView, tags []TagView) error, each func(ExportTranslationView) error) error {
+	if err := h.validator.Struct(query); err != nil {
+		return err
+	}
+
+	langs, err := h.langRepo.GetAllViews(query.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	tags, err := h.tagRepo.GetAllViews(query.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	if err = begin(langs, tags); err != nil {
+		return err
+	}
+
+	return h.exportRepo.ForEachTranslation(query.AuthorID, each)
+}
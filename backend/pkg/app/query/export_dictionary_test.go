@@ -0,0 +1,93 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestExportDictionaryHandler_Handle(t *testing.T) {
+	type fields struct {
+		langRepo   LangViewRepository
+		tagRepo    TagViewRepository
+		exportRepo ExportViewRepository
+	}
+	langs := []LangView{{ID: "lang1", Name: "EN"}}
+	tags := []TagView{{ID: "tag1", Name: "<b>verb</b>"}}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		query    ExportDictionary
+		wantErr  assert.ErrorAssertionFunc
+		wantCall bool
+	}{
+		{
+			"Error on query validation",
+			func() fields {
+				return fields{}
+			},
+			ExportDictionary{},
+			assert.Error,
+			false,
+		},
+		{
+			"Error on getting langs",
+			func() fields {
+				langRepo := MockLangViewRepository{}
+				langRepo.On("GetAllViews", "testAuthor").Return(nil, errors.New("testErr"))
+				return fields{langRepo: &langRepo}
+			},
+			ExportDictionary{AuthorID: "testAuthor"},
+			assert.Error,
+			false,
+		},
+		{
+			"Error on getting tags",
+			func() fields {
+				langRepo := MockLangViewRepository{}
+				langRepo.On("GetAllViews", "testAuthor").Return(langs, nil)
+				tagRepo := MockTagViewRepository{}
+				tagRepo.On("GetAllViews", "testAuthor").Return(nil, errors.New("testErr"))
+				return fields{langRepo: &langRepo, tagRepo: &tagRepo}
+			},
+			ExportDictionary{AuthorID: "testAuthor"},
+			assert.Error,
+			false,
+		},
+		{
+			"Positive case, the data is not sanitized",
+			func() fields {
+				langRepo := MockLangViewRepository{}
+				langRepo.On("GetAllViews", "testAuthor").Return(langs, nil)
+				tagRepo := MockTagViewRepository{}
+				tagRepo.On("GetAllViews", "testAuthor").Return(tags, nil)
+				exportRepo := MockExportViewRepository{}
+				exportRepo.On("ForEachTranslation", "testAuthor", mock.Anything).Return(nil)
+				return fields{langRepo: &langRepo, tagRepo: &tagRepo, exportRepo: &exportRepo}
+			},
+			ExportDictionary{AuthorID: "testAuthor"},
+			assert.NoError,
+			true,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := NewExportDictionaryHandler(f.langRepo, f.tagRepo, f.exportRepo, v)
+			called := false
+			err := h.Handle(tt.query, func(gotLangs []LangView, gotTags []TagView) error {
+				called = true
+				assert.Equal(t, langs, gotLangs)
+				assert.Equal(t, tags, gotTags)
+				return nil
+			}, func(ExportTranslationView) error {
+				return nil
+			})
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantCall, called)
+		})
+	}
+}
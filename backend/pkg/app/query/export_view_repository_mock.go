@@ -0,0 +1,40 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package query
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=ExportViewRepository --filename=export_view_repository_mock.go --output=./ --structname=MockExportViewRepository --inpackage
+// MockExportViewRepository is an autogenerated mock type for the ExportViewRepository type
+type MockExportViewRepository struct {
+	mock.Mock
+}
+
+// ForEachTranslation provides a mock function with given fields: authorID, fn
+func (_m *MockExportViewRepository) ForEachTranslation(authorID string, fn func(ExportTranslationView) error) error {
+	ret := _m.Called(authorID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, func(ExportTranslationView) error) error); ok {
+		r0 = rf(authorID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockExportViewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockExportViewRepository creates a new instance of MockExportViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockExportViewRepository(t mockConstructorTestingTNewMockExportViewRepository) *MockExportViewRepository {
+	mock := &MockExportViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
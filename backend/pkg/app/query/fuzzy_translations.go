@@ -0,0 +1,45 @@
+package query
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// FuzzyTranslations get translations which source or target is close to the text query
+type FuzzyTranslations struct {
+	AuthorID    string `validate:"required"`
+	LangID      string `validate:"required"`
+	Text        string `validate:"required,max=100"`
+	MaxDistance int    `validate:"gte=0,lte=3"`
+	Limit       int    `validate:"gte=1,lte=200"`
+}
+
+// FuzzyTranslationsHandler get translations close to the text query handler
+type FuzzyTranslationsHandler struct {
+	translationRepo TranslationViewRepository
+	validator       *validator.Validate
+	strictSntz      *strictSanitizer
+	richSntz        *richTextSanitizer
+}
+
+func NewFuzzyTranslationsHandler(translationRepo TranslationViewRepository, validate *validator.Validate) FuzzyTranslationsHandler {
+	return FuzzyTranslationsHandler{translationRepo: translationRepo, validator: validate, strictSntz: newStrictSanitizer(), richSntz: newRichTextSanitizer()}
+}
+
+// Handle performs query to get translations within max edit distance of the text, the closest go first
+func (h FuzzyTranslationsHandler) Handle(query FuzzyTranslations) (FuzzyViews, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return FuzzyViews{}, err
+	}
+
+	fuzzyViews, err := h.translationRepo.GetFuzzyViews(query.AuthorID, query.LangID, query.Text, query.MaxDistance, query.Limit)
+
+	if err != nil {
+		return fuzzyViews, err
+	}
+
+	for i := range fuzzyViews.Views {
+		fuzzyViews.Views[i].Translation.sanitize(h.strictSntz, h.richSntz)
+	}
+
+	return fuzzyViews, nil
+}
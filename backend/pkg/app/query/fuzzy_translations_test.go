@@ -0,0 +1,94 @@
+package query
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFuzzyTranslationsHandler_Handle(t *testing.T) {
+	type fields struct {
+		translationRepo TranslationViewRepository
+	}
+	type args struct {
+		query FuzzyTranslations
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     FuzzyViews
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on query validation, text is empty",
+			func() fields {
+				return fields{translationRepo: &MockTranslationViewRepository{}}
+			},
+			args{FuzzyTranslations{AuthorID: "authorID", LangID: "EN", MaxDistance: 2, Limit: 10}},
+			FuzzyViews{},
+			assert.Error,
+		},
+		{
+			"Error on query validation, distance is too big",
+			func() fields {
+				return fields{translationRepo: &MockTranslationViewRepository{}}
+			},
+			args{FuzzyTranslations{AuthorID: "authorID", LangID: "EN", Text: "word", MaxDistance: 4, Limit: 10}},
+			FuzzyViews{},
+			assert.Error,
+		},
+		{
+			"Error on getting fuzzy views from db",
+			func() fields {
+				repo := MockTranslationViewRepository{}
+				repo.On("GetFuzzyViews", "authorID", "EN", "word", 2, 10).Return(FuzzyViews{}, fmt.Errorf("error"))
+				return fields{translationRepo: &repo}
+			},
+			args{FuzzyTranslations{AuthorID: "authorID", LangID: "EN", Text: "word", MaxDistance: 2, Limit: 10}},
+			FuzzyViews{},
+			assert.Error,
+		},
+		{
+			"Positive case",
+			func() fields {
+				repo := MockTranslationViewRepository{}
+				repo.On("GetFuzzyViews", "authorID", "EN", "word", 1, 10).Return(
+					FuzzyViews{
+						Views: []FuzzyView{{
+							Translation: TranslationView{
+								ID:     "testID",
+								Source: "wrd",
+								Senses: []SenseView{{Target: `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`}},
+							},
+							Distance: 1,
+						}},
+					}, nil)
+				return fields{translationRepo: &repo}
+			},
+			args{FuzzyTranslations{AuthorID: "authorID", LangID: "EN", Text: "word", MaxDistance: 1, Limit: 10}},
+			FuzzyViews{Views: []FuzzyView{{
+				Translation: TranslationView{
+					ID:     "testID",
+					Source: "wrd",
+					Senses: []SenseView{{Target: "<br>TestMeaning</br>"}},
+				},
+				Distance: 1,
+			}}},
+			assert.NoError,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fieldsFn()
+			h := NewFuzzyTranslationsHandler(f.translationRepo, v)
+			got, err := h.Handle(tt.args.query)
+			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.query)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "Handle(%v)", tt.args.query)
+		})
+	}
+}
@@ -3,21 +3,22 @@ package query
 import "github.com/go-playground/validator/v10"
 
 type RandomTranslations struct {
-	AuthorID string `validate:"required"`
-	LangID   string `validate:"required"`
+	AuthorID string   `validate:"required"`
+	LangIDs  []string `validate:"max=50,dive,required"`
 	TagIds   []string
 	Limit    int `validate:"gte=1,lte=200"`
 }
 
 type RandomTranslationsHandler struct {
 	translationRepo TranslationViewRepository
+	tagRepo         TagViewRepository
 	validator       *validator.Validate
 	strictSntz      *strictSanitizer
 	richSntz        *richTextSanitizer
 }
 
-func NewRandomTranslationsHandler(translationRepo TranslationViewRepository, validate *validator.Validate) RandomTranslationsHandler {
-	return RandomTranslationsHandler{translationRepo: translationRepo, validator: validate, strictSntz: newStrictSanitizer(), richSntz: newRichTextSanitizer()}
+func NewRandomTranslationsHandler(translationRepo TranslationViewRepository, tagRepo TagViewRepository, validate *validator.Validate) RandomTranslationsHandler {
+	return RandomTranslationsHandler{translationRepo: translationRepo, tagRepo: tagRepo, validator: validate, strictSntz: newStrictSanitizer(), richSntz: newRichTextSanitizer()}
 }
 
 func (h RandomTranslationsHandler) Handle(query RandomTranslations) (RandomViews, error) {
@@ -25,7 +26,12 @@ func (h RandomTranslationsHandler) Handle(query RandomTranslations) (RandomViews
 		return RandomViews{}, err
 	}
 
-	randomViews, err := h.translationRepo.GetRandomViews(query.AuthorID, query.LangID, query.TagIds, query.Limit)
+	tags, err := tagGroups(h.tagRepo, query.TagIds, query.AuthorID)
+	if err != nil {
+		return RandomViews{}, err
+	}
+
+	randomViews, err := h.translationRepo.GetRandomViews(query.AuthorID, query.LangIDs, tags, query.Limit)
 
 	if err != nil {
 		return randomViews, err
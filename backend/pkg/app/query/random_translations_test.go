@@ -26,7 +26,7 @@ func TestRandomTranslationsHandler_Handle(t *testing.T) {
 			func() fields {
 				return fields{translationRepo: &MockTranslationViewRepository{}}
 			},
-			args{RandomTranslations{AuthorID: "authorID", LangID: "EN", TagIds: []string{}, Limit: 0}},
+			args{RandomTranslations{AuthorID: "authorID", LangIDs: []string{"EN"}, TagIds: []string{}, Limit: 0}},
 			RandomViews{},
 			assert.Error,
 		},
@@ -34,10 +34,10 @@ func TestRandomTranslationsHandler_Handle(t *testing.T) {
 			"Error on getting random views from db",
 			func() fields {
 				repo := MockTranslationViewRepository{}
-				repo.On("GetRandomViews", "authorID", "EN", []string{}, 10).Return(RandomViews{}, fmt.Errorf("error"))
+				repo.On("GetRandomViews", "authorID", []string{"EN"}, TagGroups(nil), 10).Return(RandomViews{}, fmt.Errorf("error"))
 				return fields{translationRepo: &repo}
 			},
-			args{RandomTranslations{AuthorID: "authorID", LangID: "EN", TagIds: []string{}, Limit: 10}},
+			args{RandomTranslations{AuthorID: "authorID", LangIDs: []string{"EN"}, TagIds: []string{}, Limit: 10}},
 			RandomViews{},
 			assert.Error,
 		},
@@ -45,25 +45,23 @@ func TestRandomTranslationsHandler_Handle(t *testing.T) {
 			"Positive case",
 			func() fields {
 				repo := MockTranslationViewRepository{}
-				repo.On("GetRandomViews", "authorID", "EN", []string{}, 10).Return(
+				repo.On("GetRandomViews", "authorID", []string{"EN"}, TagGroups(nil), 10).Return(
 					RandomViews{
 						Views: []TranslationView{{
 							ID:            "testID",
 							Source:        "TestText",
 							Transcription: "testTranscription",
-							Target:        `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`,
-							Example:       "testExample",
+							Senses:        []SenseView{{Target: `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`, Example: "testExample"}},
 						}},
 					}, nil)
 				return fields{translationRepo: &repo}
 			},
-			args{RandomTranslations{AuthorID: "authorID", LangID: "EN", TagIds: []string{}, Limit: 10}},
+			args{RandomTranslations{AuthorID: "authorID", LangIDs: []string{"EN"}, TagIds: []string{}, Limit: 10}},
 			RandomViews{Views: []TranslationView{{
 				ID:            "testID",
 				Source:        "TestText",
 				Transcription: "testTranscription",
-				Target:        "<br>TestMeaning</br>",
-				Example:       "testExample",
+				Senses:        []SenseView{{Target: "<br>TestMeaning</br>", Example: "testExample"}},
 			}}},
 			assert.NoError,
 		},
@@ -72,7 +70,7 @@ func TestRandomTranslationsHandler_Handle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := tt.fieldsFn()
-			h := NewRandomTranslationsHandler(f.translationRepo, v)
+			h := NewRandomTranslationsHandler(f.translationRepo, newTagHierarchyRepo(), v)
 			got, err := h.Handle(tt.args.query)
 			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.query)) {
 				return
@@ -97,7 +95,7 @@ func TestRandomTranslationsValidation(t *testing.T) {
 			args: args{
 				query: RandomTranslations{
 					AuthorID: "123",
-					LangID:   "en",
+					LangIDs:  []string{"en"},
 					TagIds:   []string{"tag1", "tag2"},
 					Limit:    100,
 				},
@@ -108,15 +106,15 @@ func TestRandomTranslationsValidation(t *testing.T) {
 			name: "Missing AuthorID",
 			args: args{
 				query: RandomTranslations{
-					LangID: "en",
-					TagIds: []string{"tag1", "tag2"},
-					Limit:  100,
+					LangIDs: []string{"en"},
+					TagIds:  []string{"tag1", "tag2"},
+					Limit:   100,
 				},
 			},
 			wantErr: assert.Error,
 		},
 		{
-			name: "Missing LangID",
+			name: "Missing LangIDs means all langs",
 			args: args{
 				query: RandomTranslations{
 					AuthorID: "123",
@@ -124,6 +122,17 @@ func TestRandomTranslationsValidation(t *testing.T) {
 					Limit:    100,
 				},
 			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Empty LangID in the list",
+			args: args{
+				query: RandomTranslations{
+					AuthorID: "123",
+					LangIDs:  []string{""},
+					Limit:    100,
+				},
+			},
 			wantErr: assert.Error,
 		},
 		{
@@ -131,7 +140,7 @@ func TestRandomTranslationsValidation(t *testing.T) {
 			args: args{
 				query: RandomTranslations{
 					AuthorID: "123",
-					LangID:   "en",
+					LangIDs:  []string{"en"},
 					TagIds:   []string{"tag1", "tag2"},
 					Limit:    0,
 				},
@@ -143,7 +152,7 @@ func TestRandomTranslationsValidation(t *testing.T) {
 			args: args{
 				query: RandomTranslations{
 					AuthorID: "123",
-					LangID:   "en",
+					LangIDs:  []string{"en"},
 					TagIds:   []string{"tag1", "tag2"},
 					Limit:    201,
 				},
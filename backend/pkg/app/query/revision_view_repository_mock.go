@@ -0,0 +1,52 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package query
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=RevisionViewRepository --filename=revision_view_repository_mock.go --output=./ --structname=MockRevisionViewRepository --inpackage
+// MockRevisionViewRepository is an autogenerated mock type for the RevisionViewRepository type
+type MockRevisionViewRepository struct {
+	mock.Mock
+}
+
+// GetViews provides a mock function with given fields: translationID, authorID
+func (_m *MockRevisionViewRepository) GetViews(translationID string, authorID string) ([]RevisionView, error) {
+	ret := _m.Called(translationID, authorID)
+
+	var r0 []RevisionView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]RevisionView, error)); ok {
+		return rf(translationID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []RevisionView); ok {
+		r0 = rf(translationID, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]RevisionView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(translationID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockRevisionViewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockRevisionViewRepository creates a new instance of MockRevisionViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockRevisionViewRepository(t mockConstructorTestingTNewMockRevisionViewRepository) *MockRevisionViewRepository {
+	mock := &MockRevisionViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,81 @@
+package query
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// MatchRank defines relevance of search part match, lower rank is more relevant
+type MatchRank int
+
+const (
+	ExactMatch MatchRank = iota
+	PrefixMatch
+	SubstringMatch
+	NoMatch
+)
+
+// NormalizeSearchText lowercases the text and strips diacritics, so "Über" and "uber" are equal for search
+func NormalizeSearchText(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for _, r := range norm.NFD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return norm.NFC.String(b.String())
+}
+
+// RankMatch returns how relevant the part match in the value is, both value and part are expected to be normalized
+func RankMatch(value, part string) MatchRank {
+	switch {
+	case value == part:
+		return ExactMatch
+	case strings.HasPrefix(value, part):
+		return PrefixMatch
+	case strings.Contains(value, part):
+		return SubstringMatch
+	default:
+		return NoMatch
+	}
+}
+
+// EditDistance returns Levenshtein distance between two strings counted in runes
+func EditDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = minOf(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
+
+func minOf(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
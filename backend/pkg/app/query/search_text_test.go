@@ -0,0 +1,69 @@
+package query
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeSearchText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"Lower case text is not changed", "uber", "uber"},
+		{"Upper case is lowered", "UBER", "uber"},
+		{"Diacritics are stripped", "Über", "uber"},
+		{"Decomposed diacritics are stripped", "Über", "uber"},
+		{"Multiple diacritics", "Crème Brûlée", "creme brulee"},
+		{"Non latin text is lowered", "Привет", "привет"},
+		{"Empty text", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeSearchText(tt.text))
+		})
+	}
+}
+
+func TestRankMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		part  string
+		want  MatchRank
+	}{
+		{"Exact match", "uber", "uber", ExactMatch},
+		{"Prefix match", "uberall", "uber", PrefixMatch},
+		{"Substring match", "zuber", "uber", SubstringMatch},
+		{"No match", "over", "uber", NoMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RankMatch(tt.value, tt.part))
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"Equal strings", "word", "word", 0},
+		{"Empty string", "", "word", 4},
+		{"Substitution", "word", "wird", 1},
+		{"Insertion", "word", "sword", 1},
+		{"Deletion", "word", "wrd", 1},
+		{"Multiple edits", "kitten", "sitting", 3},
+		{"Runes are counted instead of bytes", "über", "uber", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EditDistance(tt.a, tt.b))
+			assert.Equal(t, tt.want, EditDistance(tt.b, tt.a))
+		})
+	}
+}
@@ -1,28 +1,38 @@
 package query
 
 import (
+	"fmt"
 	"github.com/go-playground/validator/v10"
+	"time"
 )
 
 type SearchTranslations struct {
-	AuthorID   string `validate:"required"`
-	LangID     string `validate:"required"`
-	TagIds     []string
-	SourcePart string `validate:"excluded_with=TargetPart TagIds"`
-	TargetPart string `validate:"excluded_with=SourcePart TagIds"`
-	PageSize   int    `validate:"gte=1,lte=200"`
-	Page       int    `validate:"gte=1"`
+	AuthorID    string   `validate:"required"`
+	LangIDs     []string `validate:"max=50,dive,required"`
+	TagIds      []string
+	SourcePart  string
+	TargetPart  string
+	TextPart    string
+	CreatedFrom time.Time
+	CreatedTo   time.Time `validate:"omitempty,gtefield=CreatedFrom"`
+	UpdatedFrom time.Time
+	UpdatedTo   time.Time `validate:"omitempty,gtefield=UpdatedFrom"`
+	Sort        SortOrder `validate:"omitempty,oneof=created_desc created_asc updated_desc source_asc target_asc"`
+	PageSize    int       `validate:"gte=1,lte=200"`
+	Page        int       `validate:"gte=0"`                  // Page zero means cursor pagination
+	Cursor      string    `validate:"excluded_unless=Page 0"` // Cursor continuation token of the previous page, empty means the first page
 }
 
 type SearchTranslationsHandler struct {
 	translationRepo TranslationViewRepository
+	tagRepo         TagViewRepository
 	validator       *validator.Validate
 	strictSntz      *strictSanitizer
 	richSntz        *richTextSanitizer
 }
 
-func NewSearchTranslationsHandler(translationRepo TranslationViewRepository, validate *validator.Validate) SearchTranslationsHandler {
-	return SearchTranslationsHandler{translationRepo: translationRepo, validator: validate, strictSntz: newStrictSanitizer(), richSntz: newRichTextSanitizer()}
+func NewSearchTranslationsHandler(translationRepo TranslationViewRepository, tagRepo TagViewRepository, validate *validator.Validate) SearchTranslationsHandler {
+	return SearchTranslationsHandler{translationRepo: translationRepo, tagRepo: tagRepo, validator: validate, strictSntz: newStrictSanitizer(), richSntz: newRichTextSanitizer()}
 }
 
 func (h SearchTranslationsHandler) Handle(query SearchTranslations) (LastTranslationViews, error) {
@@ -30,18 +40,7 @@ func (h SearchTranslationsHandler) Handle(query SearchTranslations) (LastTransla
 		return LastTranslationViews{}, err
 	}
 
-	var lastViews LastTranslationViews
-	var err error
-
-	switch {
-	case query.SourcePart != "":
-		lastViews, err = h.translationRepo.GetLastViewsBySourcePart(query.AuthorID, query.LangID, query.SourcePart, query.PageSize, query.Page)
-	case query.TargetPart != "":
-		lastViews, err = h.translationRepo.GetLastViewsByTargetPart(query.AuthorID, query.LangID, query.TargetPart, query.PageSize, query.Page)
-	default:
-		lastViews, err = h.translationRepo.GetLastViewsByTags(query.AuthorID, query.LangID, query.PageSize, query.Page, query.TagIds)
-	}
-
+	lastViews, err := h.getViews(query)
 	if err != nil {
 		return lastViews, err
 	}
@@ -52,3 +51,46 @@ func (h SearchTranslationsHandler) Handle(query SearchTranslations) (LastTransla
 
 	return lastViews, nil
 }
+
+func (h SearchTranslationsHandler) getViews(query SearchTranslations) (LastTranslationViews, error) {
+	tags, err := tagGroups(h.tagRepo, query.TagIds, query.AuthorID)
+	if err != nil {
+		return LastTranslationViews{}, err
+	}
+
+	filter := query.toFilter(tags)
+
+	if query.Page != 0 {
+		return h.translationRepo.GetLastViews(filter, query.PageSize, query.Page)
+	}
+
+	if query.Cursor == "" {
+		return h.translationRepo.GetViewsAfter(filter, nil, query.PageSize)
+	}
+
+	cursor, err := ParseCursor(query.Cursor)
+	if err != nil {
+		return LastTranslationViews{}, err
+	}
+
+	if cursor.Sort != query.Sort {
+		return LastTranslationViews{}, fmt.Errorf("cursor sort order %q does not match requested %q", cursor.Sort, query.Sort)
+	}
+
+	return h.translationRepo.GetViewsAfter(filter, &cursor, query.PageSize)
+}
+
+// toFilter converts query to translation filter, tags are the requested tags expanded with their descendants
+func (q SearchTranslations) toFilter(tags TagGroups) TranslationFilter {
+	return TranslationFilter{
+		AuthorID:   q.AuthorID,
+		LangIDs:    q.LangIDs,
+		SourcePart: q.SourcePart,
+		TargetPart: q.TargetPart,
+		TextPart:   q.TextPart,
+		Tags:       tags,
+		Created:    DateRange{From: q.CreatedFrom, To: q.CreatedTo},
+		Updated:    DateRange{From: q.UpdatedFrom, To: q.UpdatedTo},
+		Sort:       q.Sort,
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestLastTranslationsHandler_Handle(t *testing.T) {
+	createdFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	cursor := Cursor{Sort: SortSourceAsc, Text: "source", ID: "testID"}
+
 	type fields struct {
 		translationRepo TranslationViewRepository
 	}
@@ -26,7 +31,7 @@ func TestLastTranslationsHandler_Handle(t *testing.T) {
 			func() fields {
 				return fields{translationRepo: &MockTranslationViewRepository{}}
 			},
-			args{SearchTranslations{AuthorID: "authorID", PageSize: 10, Page: 1}},
+			args{SearchTranslations{AuthorID: "authorID", LangIDs: []string{""}, PageSize: 10, Page: 1}},
 			LastTranslationViews{},
 			assert.Error,
 		},
@@ -34,10 +39,10 @@ func TestLastTranslationsHandler_Handle(t *testing.T) {
 			"Error on getting last views from repository",
 			func() fields {
 				repo := MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTags", "authorID", "EN", 10, 1, []string{}).Return(LastTranslationViews{}, fmt.Errorf("error"))
+				repo.On("GetLastViews", TranslationFilter{AuthorID: "authorID", LangIDs: []string{"EN"}}, 10, 1).Return(LastTranslationViews{}, fmt.Errorf("error"))
 				return fields{translationRepo: &repo}
 			},
-			args{SearchTranslations{AuthorID: "authorID", LangID: "EN", PageSize: 10, Page: 1, TagIds: []string{}}},
+			args{SearchTranslations{AuthorID: "authorID", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, TagIds: []string{}}},
 			LastTranslationViews{},
 			assert.Error,
 		},
@@ -45,51 +50,68 @@ func TestLastTranslationsHandler_Handle(t *testing.T) {
 			"Search by tags",
 			func() fields {
 				repo := MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTags", "authorID", "EN", 10, 1, []string{"tag1", "tag2"}).Return(
+				repo.On("GetLastViews", TranslationFilter{AuthorID: "authorID", LangIDs: []string{"EN"}, Tags: TagGroups{{"tag1"}, {"tag2", "tag21"}}}, 10, 1).Return(
 					LastTranslationViews{
 						Views: []TranslationView{{
 							ID:            "testID",
 							Source:        "TestText",
 							Transcription: "testTranscription",
-							Target:        `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`,
-							Example:       "testExample",
+							Senses:        []SenseView{{Target: `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`, Example: "testExample"}},
 						}},
 					}, nil)
 				return fields{translationRepo: &repo}
 			},
-			args{SearchTranslations{AuthorID: "authorID", LangID: "EN", PageSize: 10, Page: 1, TagIds: []string{"tag1", "tag2"}}},
+			args{SearchTranslations{AuthorID: "authorID", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, TagIds: []string{"tag1", "tag2"}}},
 			LastTranslationViews{Views: []TranslationView{{
 				ID:            "testID",
 				Source:        "TestText",
 				Transcription: "testTranscription",
-				Target:        "<br>TestMeaning</br>",
-				Example:       "testExample",
+				Senses:        []SenseView{{Target: "<br>TestMeaning</br>", Example: "testExample"}},
 			}}},
 			assert.NoError,
 		},
 		{
-			"Search by tags",
+			"Search by combined filter",
 			func() fields {
 				repo := MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTags", "authorID", "EN", 10, 1, []string{"tag1", "tag2"}).Return(
+				repo.On("GetLastViews", TranslationFilter{
+					AuthorID:   "authorID",
+					LangIDs:    []string{"EN"},
+					SourcePart: "sourcePart",
+					TargetPart: "targetPart",
+					TextPart:   "textPart",
+					Tags:       TagGroups{{"tag1"}, {"tag2", "tag21"}},
+					Created:    DateRange{From: createdFrom, To: createdTo},
+					Updated:    DateRange{From: createdFrom},
+				}, 10, 1).Return(
 					LastTranslationViews{
 						Views: []TranslationView{{
 							ID:            "testID",
 							Source:        "TestText",
 							Transcription: "testTranscription",
-							Target:        `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`,
-							Example:       "testExample",
+							Senses:        []SenseView{{Target: `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`, Example: "testExample"}},
 						}},
 					}, nil)
 				return fields{translationRepo: &repo}
 			},
-			args{SearchTranslations{AuthorID: "authorID", LangID: "EN", PageSize: 10, Page: 1, TagIds: []string{"tag1", "tag2"}}},
+			args{SearchTranslations{
+				AuthorID:    "authorID",
+				LangIDs:     []string{"EN"},
+				PageSize:    10,
+				Page:        1,
+				TagIds:      []string{"tag1", "tag2"},
+				SourcePart:  "sourcePart",
+				TargetPart:  "targetPart",
+				TextPart:    "textPart",
+				CreatedFrom: createdFrom,
+				CreatedTo:   createdTo,
+				UpdatedFrom: createdFrom,
+			}},
 			LastTranslationViews{Views: []TranslationView{{
 				ID:            "testID",
 				Source:        "TestText",
 				Transcription: "testTranscription",
-				Target:        "<br>TestMeaning</br>",
-				Example:       "testExample",
+				Senses:        []SenseView{{Target: "<br>TestMeaning</br>", Example: "testExample"}},
 			}}},
 			assert.NoError,
 		},
@@ -97,25 +119,23 @@ func TestLastTranslationsHandler_Handle(t *testing.T) {
 			"Search by target part",
 			func() fields {
 				repo := MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTargetPart", "authorID", "EN", "targetPart", 10, 1).Return(
+				repo.On("GetLastViews", TranslationFilter{AuthorID: "authorID", LangIDs: []string{"EN"}, TargetPart: "targetPart"}, 10, 1).Return(
 					LastTranslationViews{
 						Views: []TranslationView{{
 							ID:            "testID",
 							Source:        "TestText",
 							Transcription: "testTranscription",
-							Target:        `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`,
-							Example:       "testExample",
+							Senses:        []SenseView{{Target: `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`, Example: "testExample"}},
 						}},
 					}, nil)
 				return fields{translationRepo: &repo}
 			},
-			args{SearchTranslations{AuthorID: "authorID", LangID: "EN", PageSize: 10, Page: 1, TargetPart: "targetPart"}},
+			args{SearchTranslations{AuthorID: "authorID", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, TargetPart: "targetPart"}},
 			LastTranslationViews{Views: []TranslationView{{
 				ID:            "testID",
 				Source:        "TestText",
 				Transcription: "testTranscription",
-				Target:        "<br>TestMeaning</br>",
-				Example:       "testExample",
+				Senses:        []SenseView{{Target: "<br>TestMeaning</br>", Example: "testExample"}},
 			}}},
 			assert.NoError,
 		},
@@ -123,35 +143,75 @@ func TestLastTranslationsHandler_Handle(t *testing.T) {
 			"Search by source part",
 			func() fields {
 				repo := MockTranslationViewRepository{}
-				repo.On("GetLastViewsBySourcePart", "authorID", "EN", "sourcePart", 10, 1).Return(
+				repo.On("GetLastViews", TranslationFilter{AuthorID: "authorID", LangIDs: []string{"EN"}, SourcePart: "sourcePart"}, 10, 1).Return(
 					LastTranslationViews{
 						Views: []TranslationView{{
 							ID:            "testID",
 							Source:        "TestText",
 							Transcription: "testTranscription",
-							Target:        `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`,
-							Example:       "testExample",
+							Senses:        []SenseView{{Target: `<a href=\"javascript:alert('XSS1')\" onmouseover=\"alert('XSS2')\"><br>TestMeaning</br><a>`, Example: "testExample"}},
 						}},
 					}, nil)
 				return fields{translationRepo: &repo}
 			},
-			args{SearchTranslations{AuthorID: "authorID", LangID: "EN", PageSize: 10, Page: 1, SourcePart: "sourcePart"}},
+			args{SearchTranslations{AuthorID: "authorID", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, SourcePart: "sourcePart"}},
 			LastTranslationViews{Views: []TranslationView{{
 				ID:            "testID",
 				Source:        "TestText",
 				Transcription: "testTranscription",
-				Target:        "<br>TestMeaning</br>",
-				Example:       "testExample",
+				Senses:        []SenseView{{Target: "<br>TestMeaning</br>", Example: "testExample"}},
 			}}},
 			assert.NoError,
 		},
+		{
+			"First page of cursor pagination",
+			func() fields {
+				repo := MockTranslationViewRepository{}
+				repo.On("GetViewsAfter", TranslationFilter{AuthorID: "authorID", Sort: SortSourceAsc}, (*Cursor)(nil), 10).Return(
+					LastTranslationViews{Views: []TranslationView{{ID: "testID"}}, Next: &cursor}, nil)
+				return fields{translationRepo: &repo}
+			},
+			args{SearchTranslations{AuthorID: "authorID", PageSize: 10, Sort: SortSourceAsc}},
+			LastTranslationViews{Views: []TranslationView{{ID: "testID"}}, Next: &cursor},
+			assert.NoError,
+		},
+		{
+			"Next page of cursor pagination",
+			func() fields {
+				repo := MockTranslationViewRepository{}
+				repo.On("GetViewsAfter", TranslationFilter{AuthorID: "authorID", Sort: SortSourceAsc}, &cursor, 10).Return(
+					LastTranslationViews{Views: []TranslationView{{ID: "nextID"}}}, nil)
+				return fields{translationRepo: &repo}
+			},
+			args{SearchTranslations{AuthorID: "authorID", PageSize: 10, Sort: SortSourceAsc, Cursor: cursor.Token()}},
+			LastTranslationViews{Views: []TranslationView{{ID: "nextID"}}},
+			assert.NoError,
+		},
+		{
+			"Error on invalid cursor",
+			func() fields {
+				return fields{translationRepo: &MockTranslationViewRepository{}}
+			},
+			args{SearchTranslations{AuthorID: "authorID", PageSize: 10, Sort: SortSourceAsc, Cursor: "invalid"}},
+			LastTranslationViews{},
+			assert.Error,
+		},
+		{
+			"Error on cursor of another sort order",
+			func() fields {
+				return fields{translationRepo: &MockTranslationViewRepository{}}
+			},
+			args{SearchTranslations{AuthorID: "authorID", PageSize: 10, Sort: SortCreatedAsc, Cursor: cursor.Token()}},
+			LastTranslationViews{},
+			assert.Error,
+		},
 	}
 
 	v := validator.New()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := tt.fieldsFn()
-			h := NewSearchTranslationsHandler(f.translationRepo, v)
+			h := NewSearchTranslationsHandler(f.translationRepo, newTagHierarchyRepo(TagView{ID: "tag1"}, TagView{ID: "tag2"}, TagView{ID: "tag21", ParentID: "tag2"}), v)
 			got, err := h.Handle(tt.args.query)
 			if !tt.wantErr(t, err, fmt.Sprintf("Handle(%v)", tt.args.query)) {
 				return
@@ -172,52 +232,82 @@ func TestSearchTranslationsValidation(t *testing.T) {
 	}{
 		{
 			"Error on missing AuthorID",
-			args{SearchTranslations{LangID: "EN", PageSize: 10, Page: 1}},
+			args{SearchTranslations{LangIDs: []string{"EN"}, PageSize: 10, Page: 1}},
 			assert.Error,
 		},
 		{
-			"Error on missing LangID",
+			"Missing LangIDs means all langs",
 			args{SearchTranslations{AuthorID: "123", PageSize: 10, Page: 1}},
+			assert.NoError,
+		},
+		{
+			"Error on empty LangID in the list",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN", ""}, PageSize: 10, Page: 1}},
 			assert.Error,
 		},
 		{
 			"Error on missing PageSize",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", Page: 1}},
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, Page: 1}},
+			assert.Error,
+		},
+		{
+			"Missing Page means cursor pagination",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10}},
+			assert.NoError,
+		},
+		{
+			"Error on Cursor with Page",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, Cursor: "token"}},
 			assert.Error,
 		},
 		{
-			"Error on missing Page",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", PageSize: 10}},
+			"Error on unknown Sort",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, Sort: "random"}},
 			assert.Error,
 		},
 		{
-			"Error on conflicting SourcePart and TargetPart",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", PageSize: 10, Page: 1, SourcePart: "source", TargetPart: "target"}},
+			"Valid Sort",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Cursor: "token", Sort: SortTargetAsc}},
+			assert.NoError,
+		},
+		{
+			"SourcePart, TargetPart and TagIDs can be combined",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, TagIds: []string{"tag1", "tag2"}, SourcePart: "source", TargetPart: "target"}},
+			assert.NoError,
+		},
+		{
+			"Error on CreatedTo before CreatedFrom",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, CreatedFrom: time.Now(), CreatedTo: time.Now().Add(-time.Hour)}},
 			assert.Error,
 		},
 		{
-			"Error on conflicting TagIDs and SourcePart",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", PageSize: 10, Page: 1, TagIds: []string{"tag1", "tag2"}, SourcePart: "source"}},
+			"Error on UpdatedTo before UpdatedFrom",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, UpdatedFrom: time.Now(), UpdatedTo: time.Now().Add(-time.Hour)}},
 			assert.Error,
 		},
+		{
+			"Open date ranges are valid",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: 1, CreatedFrom: time.Now(), UpdatedTo: time.Now()}},
+			assert.NoError,
+		},
 		{
 			"Error on invalid PageSize (less than 1)",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", PageSize: 0, Page: 1}},
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 0, Page: 1}},
 			assert.Error,
 		},
 		{
 			"Error on invalid PageSize (greater than 200)",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", PageSize: 201, Page: 1}},
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 201, Page: 1}},
 			assert.Error,
 		},
 		{
-			"Error on invalid Page (less than 1)",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", PageSize: 10, Page: 0}},
+			"Error on invalid Page (less than 0)",
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: -1}},
 			assert.Error,
 		},
 		{
 			"Valid input",
-			args{SearchTranslations{AuthorID: "123", LangID: "EN", PageSize: 10, Page: 1}},
+			args{SearchTranslations{AuthorID: "123", LangIDs: []string{"EN"}, PageSize: 10, Page: 1}},
 			assert.NoError,
 		},
 	}
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package query
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=SessionViewRepository --filename=session_view_repository_mock.go --output=./ --structname=MockSessionViewRepository --inpackage
+// MockSessionViewRepository is an autogenerated mock type for the SessionViewRepository type
+type MockSessionViewRepository struct {
+	mock.Mock
+}
+
+// GetAllViews provides a mock function with given fields: userID
+func (_m *MockSessionViewRepository) GetAllViews(userID string) ([]SessionView, error) {
+	ret := _m.Called(userID)
+
+	var r0 []SessionView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]SessionView, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []SessionView); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]SessionView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockSessionViewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockSessionViewRepository creates a new instance of MockSessionViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockSessionViewRepository(t mockConstructorTestingTNewMockSessionViewRepository) *MockSessionViewRepository {
+	mock := &MockSessionViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -47,13 +47,13 @@ func TestSingleTranslationHandler_Handle(t *testing.T) {
 			func() fields {
 				repo := MockTranslationViewRepository{}
 				repo.On("GetView", "trID", "testAuthor").Return(TranslationView{
-					Target: "testTranslation",
+					Senses: []SenseView{{Target: "testTranslation"}},
 				}, nil)
 				return fields{translationRepo: &repo}
 			},
 			args{cmd: SingleTranslation{ID: "trID", AuthorID: "testAuthor"}},
 			TranslationView{
-				Target: "testTranslation",
+				Senses: []SenseView{{Target: "testTranslation"}},
 			},
 			false,
 		},
@@ -62,13 +62,13 @@ func TestSingleTranslationHandler_Handle(t *testing.T) {
 			func() fields {
 				repo := MockTranslationViewRepository{}
 				repo.On("GetView", "trID", "testAuthor").Return(TranslationView{
-					Target: `<a href="javascript:alert('XSS1')" onmouseover="alert('XSS2')">Test Target<a>`,
+					Senses: []SenseView{{Target: `<a href="javascript:alert('XSS1')" onmouseover="alert('XSS2')">Test Target<a>`}},
 				}, nil)
 				return fields{translationRepo: &repo}
 			},
 			args{cmd: SingleTranslation{ID: "trID", AuthorID: "testAuthor"}},
 			TranslationView{
-				Target: "Test Target",
+				Senses: []SenseView{{Target: "Test Target"}},
 			},
 			false,
 		},
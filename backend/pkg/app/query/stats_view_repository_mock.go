@@ -0,0 +1,50 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package query
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=StatsViewRepository --filename=stats_view_repository_mock.go --output=./ --structname=MockStatsViewRepository --inpackage
+// MockStatsViewRepository is an autogenerated mock type for the StatsViewRepository type
+type MockStatsViewRepository struct {
+	mock.Mock
+}
+
+// GetStats provides a mock function with given fields: authorID, added, period
+func (_m *MockStatsViewRepository) GetStats(authorID string, added DateRange, period StatsPeriod) (StatsView, error) {
+	ret := _m.Called(authorID, added, period)
+
+	var r0 StatsView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, DateRange, StatsPeriod) (StatsView, error)); ok {
+		return rf(authorID, added, period)
+	}
+	if rf, ok := ret.Get(0).(func(string, DateRange, StatsPeriod) StatsView); ok {
+		r0 = rf(authorID, added, period)
+	} else {
+		r0 = ret.Get(0).(StatsView)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, DateRange, StatsPeriod) error); ok {
+		r1 = rf(authorID, added, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockStatsViewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockStatsViewRepository creates a new instance of MockStatsViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockStatsViewRepository(t mockConstructorTestingTNewMockStatsViewRepository) *MockStatsViewRepository {
+	mock := &MockStatsViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
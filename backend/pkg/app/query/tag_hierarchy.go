@@ -0,0 +1,137 @@
+package query
+
+import "sort"
+
+// TagGroups translation matches when it is tagged by at least one tag of every group,
+// a group consists of the requested tag and all its descendants
+type TagGroups [][]string
+
+// TagHierarchy author tags with their nesting
+type TagHierarchy struct {
+	views    map[string]TagView
+	order    []string            // order IDs of tags in the order of passed views
+	children map[string][]string // children IDs by parent ID, tags without existing parent are kept by empty ID
+}
+
+// NewTagHierarchy builds hierarchy of all author tag views
+func NewTagHierarchy(views []TagView) TagHierarchy {
+	h := TagHierarchy{
+		views:    make(map[string]TagView, len(views)),
+		order:    make([]string, 0, len(views)),
+		children: map[string][]string{},
+	}
+
+	for i := range views {
+		h.views[views[i].ID] = views[i]
+		h.order = append(h.order, views[i].ID)
+	}
+
+	for _, id := range h.order {
+		parentID := h.views[id].ParentID
+		if _, ok := h.views[parentID]; !ok {
+			parentID = ""
+		}
+		h.children[parentID] = append(h.children[parentID], id)
+	}
+
+	return h
+}
+
+// View returns tag view by ID
+func (h TagHierarchy) View(id string) (TagView, bool) {
+	view, ok := h.views[id]
+	return view, ok
+}
+
+// Views returns all tag views in the order they were passed to the hierarchy
+func (h TagHierarchy) Views() []TagView {
+	views := make([]TagView, 0, len(h.order))
+	for _, id := range h.order {
+		views = append(views, h.views[id])
+	}
+	return views
+}
+
+// Descendants returns IDs of all tags nested into the tag on any level, unknown tag has no descendants
+func (h TagHierarchy) Descendants(id string) []string {
+	if _, ok := h.views[id]; !ok {
+		return nil
+	}
+
+	var descendants []string
+	visited := map[string]struct{}{id: {}}
+
+	for queue := append([]string{}, h.children[id]...); len(queue) > 0; queue = queue[1:] {
+		if _, ok := visited[queue[0]]; ok {
+			continue
+		}
+		visited[queue[0]] = struct{}{}
+		descendants = append(descendants, queue[0])
+		queue = append(queue, h.children[queue[0]]...)
+	}
+
+	return descendants
+}
+
+// Groups returns tag groups matching translations tagged by the tags or any of their descendants
+func (h TagHierarchy) Groups(tagIDs []string) TagGroups {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	groups := make(TagGroups, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		groups = append(groups, append([]string{id}, h.Descendants(id)...))
+	}
+
+	return groups
+}
+
+// Tree returns root tags with nested ones, tags of every level are sorted by name
+func (h TagHierarchy) Tree() []TagTreeView {
+	return h.subtree("", map[string]struct{}{})
+}
+
+func (h TagHierarchy) subtree(parentID string, visited map[string]struct{}) []TagTreeView {
+	nodes := make([]TagTreeView, 0, len(h.children[parentID]))
+	for _, id := range h.children[parentID] {
+		if _, ok := visited[id]; ok {
+			continue
+		}
+		visited[id] = struct{}{}
+		nodes = append(nodes, TagTreeView{Tag: h.views[id], Children: h.subtree(id, visited)})
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Tag.Name < nodes[j].Tag.Name
+	})
+
+	return nodes
+}
+
+// TagTreeView tag with all nested tags
+type TagTreeView struct {
+	Tag      TagView
+	Children []TagTreeView
+}
+
+func (v *TagTreeView) sanitize(sanitizer *strictSanitizer) {
+	v.Tag.sanitize(sanitizer)
+	for i := range v.Children {
+		v.Children[i].sanitize(sanitizer)
+	}
+}
+
+// tagGroups expands the requested tags into groups with their descendants
+func tagGroups(tagRepo TagViewRepository, tagIDs []string, authorID string) (TagGroups, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	hierarchy, err := tagRepo.GetHierarchy(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return hierarchy.Groups(tagIDs), nil
+}
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestTagHierarchy_Descendants(t *testing.T) {
+	h := NewTagHierarchy([]TagView{
+		{ID: "root", Name: "root"},
+		{ID: "child1", Name: "child1", ParentID: "root"},
+		{ID: "child2", Name: "child2", ParentID: "root"},
+		{ID: "grandChild", Name: "grandChild", ParentID: "child1"},
+		{ID: "orphan", Name: "orphan", ParentID: "missing"},
+	})
+
+	assert.ElementsMatch(t, []string{"child1", "child2", "grandChild"}, h.Descendants("root"))
+	assert.Equal(t, []string{"grandChild"}, h.Descendants("child1"))
+	assert.Nil(t, h.Descendants("grandChild"))
+	assert.Nil(t, h.Descendants("missing"))
+	assert.Nil(t, h.Descendants("orphan"))
+}
+
+func TestTagHierarchy_DescendantsWithCycle(t *testing.T) {
+	h := NewTagHierarchy([]TagView{
+		{ID: "tag1", Name: "tag1", ParentID: "tag2"},
+		{ID: "tag2", Name: "tag2", ParentID: "tag1"},
+	})
+
+	assert.Equal(t, []string{"tag2"}, h.Descendants("tag1"))
+	assert.Equal(t, []string{"tag1"}, h.Descendants("tag2"))
+}
+
+func TestTagHierarchy_Groups(t *testing.T) {
+	h := NewTagHierarchy([]TagView{
+		{ID: "root", Name: "root"},
+		{ID: "child", Name: "child", ParentID: "root"},
+		{ID: "another", Name: "another"},
+	})
+
+	assert.Nil(t, h.Groups(nil))
+	assert.Equal(t, TagGroups{{"root", "child"}, {"another"}, {"missing"}}, h.Groups([]string{"root", "another", "missing"}))
+}
+
+func TestTagHierarchy_Tree(t *testing.T) {
+	h := NewTagHierarchy([]TagView{
+		{ID: "2", Name: "b"},
+		{ID: "3", Name: "c", ParentID: "2"},
+		{ID: "4", Name: "a", ParentID: "2"},
+		{ID: "1", Name: "d", ParentID: "missing"},
+	})
+
+	assert.Equal(t, []TagTreeView{
+		{
+			Tag: TagView{ID: "2", Name: "b"},
+			Children: []TagTreeView{
+				{Tag: TagView{ID: "4", Name: "a", ParentID: "2"}, Children: []TagTreeView{}},
+				{Tag: TagView{ID: "3", Name: "c", ParentID: "2"}, Children: []TagTreeView{}},
+			},
+		},
+		{Tag: TagView{ID: "1", Name: "d", ParentID: "missing"}, Children: []TagTreeView{}},
+	}, h.Tree())
+	assert.Equal(t, 4, len(h.Views()))
+}
+
+func TestTagGroups(t *testing.T) {
+	repo := MockTagViewRepository{}
+	repo.On("GetHierarchy", "errAuthor").Return(TagHierarchy{}, errors.New("testErr"))
+
+	groups, err := tagGroups(&repo, nil, "errAuthor")
+	assert.Nil(t, err)
+	assert.Nil(t, groups)
+
+	_, err = tagGroups(&repo, []string{"tag"}, "errAuthor")
+	assert.Error(t, err)
+
+	groups, err = tagGroups(newTagHierarchyRepo(TagView{ID: "tag"}, TagView{ID: "child", ParentID: "tag"}), []string{"tag"}, "author")
+	assert.Nil(t, err)
+	assert.Equal(t, TagGroups{{"tag", "child"}}, groups)
+}
+
+func newTagHierarchyRepo(views ...TagView) *MockTagViewRepository {
+	repo := MockTagViewRepository{}
+	repo.On("GetHierarchy", mock.Anything).Return(NewTagHierarchy(views), nil)
+	return &repo
+}
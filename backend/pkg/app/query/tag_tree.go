@@ -0,0 +1,38 @@
+package query
+
+import "github.com/go-playground/validator/v10"
+
+// TagTree get all author tags with their nesting query
+type TagTree struct {
+	AuthorID string `validate:"required"`
+}
+
+// TagTreeHandler get tag tree query handler
+type TagTreeHandler struct {
+	tagRepo   TagViewRepository
+	sanitizer *strictSanitizer
+	validator *validator.Validate
+}
+
+func NewTagTreeHandler(tagRepo TagViewRepository, validate *validator.Validate) TagTreeHandler {
+	return TagTreeHandler{tagRepo: tagRepo, sanitizer: newStrictSanitizer(), validator: validate}
+}
+
+// Handle performs query to receive root author tags with nested ones
+func (h TagTreeHandler) Handle(query TagTree) ([]TagTreeView, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return nil, err
+	}
+
+	hierarchy, err := h.tagRepo.GetHierarchy(query.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := hierarchy.Tree()
+	for i := range tree {
+		tree[i].sanitize(h.sanitizer)
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,30 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTagTreeHandler_Handle(t *testing.T) {
+	v := validator.New()
+
+	_, err := NewTagTreeHandler(&MockTagViewRepository{}, v).Handle(TagTree{})
+	assert.Error(t, err)
+
+	repo := MockTagViewRepository{}
+	repo.On("GetHierarchy", "testAuthor").Return(TagHierarchy{}, errors.New("testErr"))
+	_, err = NewTagTreeHandler(&repo, v).Handle(TagTree{AuthorID: "testAuthor"})
+	assert.Error(t, err)
+
+	got, err := NewTagTreeHandler(newTagHierarchyRepo(
+		TagView{ID: "root", Name: "root"},
+		TagView{ID: "child", Name: `<a href="javascript:alert('XSS1')"><br>child</br><a>`, ParentID: "root"},
+	), v).Handle(TagTree{AuthorID: "testAuthor"})
+	assert.Nil(t, err)
+	assert.Equal(t, []TagTreeView{{
+		Tag:      TagView{ID: "root", Name: "root"},
+		Children: []TagTreeView{{Tag: TagView{ID: "child", Name: "child", ParentID: "root"}, Children: []TagTreeView{}}},
+	}}, got)
+}
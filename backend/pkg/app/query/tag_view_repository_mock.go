@@ -36,6 +36,30 @@ func (_m *MockTagViewRepository) GetAllViews(authorID string) ([]TagView, error)
 	return r0, r1
 }
 
+// GetHierarchy provides a mock function with given fields: authorID
+func (_m *MockTagViewRepository) GetHierarchy(authorID string) (TagHierarchy, error) {
+	ret := _m.Called(authorID)
+
+	var r0 TagHierarchy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (TagHierarchy, error)); ok {
+		return rf(authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string) TagHierarchy); ok {
+		r0 = rf(authorID)
+	} else {
+		r0 = ret.Get(0).(TagHierarchy)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetView provides a mock function with given fields: id, authorID
 func (_m *MockTagViewRepository) GetView(id string, authorID string) (TagView, error) {
 	ret := _m.Called(id, authorID)
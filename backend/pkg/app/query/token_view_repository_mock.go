@@ -0,0 +1,52 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package query
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=TokenViewRepository --filename=token_view_repository_mock.go --output=./ --structname=MockTokenViewRepository --inpackage
+// MockTokenViewRepository is an autogenerated mock type for the TokenViewRepository type
+type MockTokenViewRepository struct {
+	mock.Mock
+}
+
+// GetAllViews provides a mock function with given fields: userID
+func (_m *MockTokenViewRepository) GetAllViews(userID string) ([]TokenView, error) {
+	ret := _m.Called(userID)
+
+	var r0 []TokenView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]TokenView, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []TokenView); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]TokenView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockTokenViewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockTokenViewRepository creates a new instance of MockTokenViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockTokenViewRepository(t mockConstructorTestingTNewMockTokenViewRepository) *MockTokenViewRepository {
+	mock := &MockTokenViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
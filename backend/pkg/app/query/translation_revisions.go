@@ -0,0 +1,38 @@
+package query
+
+import "github.com/go-playground/validator/v10"
+
+// TranslationRevisions get revision history of translation query
+type TranslationRevisions struct {
+	ID       string `validate:"required"`
+	AuthorID string `validate:"required"`
+}
+
+// TranslationRevisionsHandler get translation revisions query handler
+type TranslationRevisionsHandler struct {
+	revisionRepo RevisionViewRepository
+	validator    *validator.Validate
+	richSntz     *richTextSanitizer
+}
+
+func NewTranslationRevisionsHandler(revisionRepo RevisionViewRepository, validate *validator.Validate) TranslationRevisionsHandler {
+	return TranslationRevisionsHandler{revisionRepo: revisionRepo, validator: validate, richSntz: newRichTextSanitizer()}
+}
+
+// Handle performs query to get translation revisions, the latest go first
+func (h TranslationRevisionsHandler) Handle(query TranslationRevisions) ([]RevisionView, error) {
+	if err := h.validator.Struct(query); err != nil {
+		return nil, err
+	}
+
+	views, err := h.revisionRepo.GetViews(query.ID, query.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range views {
+		views[i].sanitize(h.richSntz)
+	}
+
+	return views, nil
+}
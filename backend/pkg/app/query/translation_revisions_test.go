@@ -0,0 +1,81 @@
+package query
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTranslationRevisionsHandler_Handle(t *testing.T) {
+	type fields struct {
+		revisionRepo RevisionViewRepository
+	}
+	type args struct {
+		query TranslationRevisions
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() fields
+		args     args
+		want     []RevisionView
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Error on query validation",
+			func() fields {
+				return fields{revisionRepo: &MockRevisionViewRepository{}}
+			},
+			args{TranslationRevisions{AuthorID: "testAuthor"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Error on DB query",
+			func() fields {
+				repo := MockRevisionViewRepository{}
+				repo.On("GetViews", "trID", "testAuthor").Return(nil, errors.New("testErr"))
+				return fields{revisionRepo: &repo}
+			},
+			args{TranslationRevisions{ID: "trID", AuthorID: "testAuthor"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Positive case with sanitization",
+			func() fields {
+				repo := MockRevisionViewRepository{}
+				repo.On("GetViews", "trID", "testAuthor").Return([]RevisionView{{
+					ID: "revID",
+					Changes: []FieldChangeView{{
+						Field:    "senses[0].target",
+						OldValue: `<a href="javascript:alert('XSS1')" onmouseover="alert('XSS2')">Old Target<a>`,
+						NewValue: "<br>New Target</br>",
+					}},
+				}}, nil)
+				return fields{revisionRepo: &repo}
+			},
+			args{TranslationRevisions{ID: "trID", AuthorID: "testAuthor"}},
+			[]RevisionView{{
+				ID: "revID",
+				Changes: []FieldChangeView{{
+					Field:    "senses[0].target",
+					OldValue: "Old Target",
+					NewValue: "<br>New Target</br>",
+				}},
+			}},
+			assert.NoError,
+		},
+	}
+	v := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTranslationRevisionsHandler(tt.fieldsFn().revisionRepo, v)
+			got, err := h.Handle(tt.args.query)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,7 +2,11 @@
 
 package query
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // mockery --name=TranslationViewRepository --filename=translation_view_repository_mock.go --output=./ --structname=MockTranslationViewRepository --inpackage
 // MockTranslationViewRepository is an autogenerated mock type for the TranslationViewRepository type
@@ -10,23 +14,23 @@ type MockTranslationViewRepository struct {
 	mock.Mock
 }
 
-// GetLastViewsBySourcePart provides a mock function with given fields: authorID, langID, sourcePart, pageSize, page
-func (_m *MockTranslationViewRepository) GetLastViewsBySourcePart(authorID string, langID string, sourcePart string, pageSize int, page int) (LastTranslationViews, error) {
-	ret := _m.Called(authorID, langID, sourcePart, pageSize, page)
+// GetDueViews provides a mock function with given fields: authorID, langID, tags, dueAt, limit
+func (_m *MockTranslationViewRepository) GetDueViews(authorID string, langID string, tags TagGroups, dueAt time.Time, limit int) (DueViews, error) {
+	ret := _m.Called(authorID, langID, tags, dueAt, limit)
 
-	var r0 LastTranslationViews
+	var r0 DueViews
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) (LastTranslationViews, error)); ok {
-		return rf(authorID, langID, sourcePart, pageSize, page)
+	if rf, ok := ret.Get(0).(func(string, string, TagGroups, time.Time, int) (DueViews, error)); ok {
+		return rf(authorID, langID, tags, dueAt, limit)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) LastTranslationViews); ok {
-		r0 = rf(authorID, langID, sourcePart, pageSize, page)
+	if rf, ok := ret.Get(0).(func(string, string, TagGroups, time.Time, int) DueViews); ok {
+		r0 = rf(authorID, langID, tags, dueAt, limit)
 	} else {
-		r0 = ret.Get(0).(LastTranslationViews)
+		r0 = ret.Get(0).(DueViews)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, int, int) error); ok {
-		r1 = rf(authorID, langID, sourcePart, pageSize, page)
+	if rf, ok := ret.Get(1).(func(string, string, TagGroups, time.Time, int) error); ok {
+		r1 = rf(authorID, langID, tags, dueAt, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -34,23 +38,23 @@ func (_m *MockTranslationViewRepository) GetLastViewsBySourcePart(authorID strin
 	return r0, r1
 }
 
-// GetLastViewsByTags provides a mock function with given fields: authorID, langID, pageSize, page, tagIds
-func (_m *MockTranslationViewRepository) GetLastViewsByTags(authorID string, langID string, pageSize int, page int, tagIds []string) (LastTranslationViews, error) {
-	ret := _m.Called(authorID, langID, pageSize, page, tagIds)
+// GetFuzzyViews provides a mock function with given fields: authorID, langID, text, maxDistance, limit
+func (_m *MockTranslationViewRepository) GetFuzzyViews(authorID string, langID string, text string, maxDistance int, limit int) (FuzzyViews, error) {
+	ret := _m.Called(authorID, langID, text, maxDistance, limit)
 
-	var r0 LastTranslationViews
+	var r0 FuzzyViews
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int, []string) (LastTranslationViews, error)); ok {
-		return rf(authorID, langID, pageSize, page, tagIds)
+	if rf, ok := ret.Get(0).(func(string, string, string, int, int) (FuzzyViews, error)); ok {
+		return rf(authorID, langID, text, maxDistance, limit)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int, []string) LastTranslationViews); ok {
-		r0 = rf(authorID, langID, pageSize, page, tagIds)
+	if rf, ok := ret.Get(0).(func(string, string, string, int, int) FuzzyViews); ok {
+		r0 = rf(authorID, langID, text, maxDistance, limit)
 	} else {
-		r0 = ret.Get(0).(LastTranslationViews)
+		r0 = ret.Get(0).(FuzzyViews)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int, int, []string) error); ok {
-		r1 = rf(authorID, langID, pageSize, page, tagIds)
+	if rf, ok := ret.Get(1).(func(string, string, string, int, int) error); ok {
+		r1 = rf(authorID, langID, text, maxDistance, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,23 +62,23 @@ func (_m *MockTranslationViewRepository) GetLastViewsByTags(authorID string, lan
 	return r0, r1
 }
 
-// GetLastViewsByTargetPart provides a mock function with given fields: authorID, langID, targetPart, pageSize, page
-func (_m *MockTranslationViewRepository) GetLastViewsByTargetPart(authorID string, langID string, targetPart string, pageSize int, page int) (LastTranslationViews, error) {
-	ret := _m.Called(authorID, langID, targetPart, pageSize, page)
+// GetLastViews provides a mock function with given fields: filter, pageSize, page
+func (_m *MockTranslationViewRepository) GetLastViews(filter TranslationFilter, pageSize int, page int) (LastTranslationViews, error) {
+	ret := _m.Called(filter, pageSize, page)
 
 	var r0 LastTranslationViews
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) (LastTranslationViews, error)); ok {
-		return rf(authorID, langID, targetPart, pageSize, page)
+	if rf, ok := ret.Get(0).(func(TranslationFilter, int, int) (LastTranslationViews, error)); ok {
+		return rf(filter, pageSize, page)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) LastTranslationViews); ok {
-		r0 = rf(authorID, langID, targetPart, pageSize, page)
+	if rf, ok := ret.Get(0).(func(TranslationFilter, int, int) LastTranslationViews); ok {
+		r0 = rf(filter, pageSize, page)
 	} else {
 		r0 = ret.Get(0).(LastTranslationViews)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, int, int) error); ok {
-		r1 = rf(authorID, langID, targetPart, pageSize, page)
+	if rf, ok := ret.Get(1).(func(TranslationFilter, int, int) error); ok {
+		r1 = rf(filter, pageSize, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -82,23 +86,23 @@ func (_m *MockTranslationViewRepository) GetLastViewsByTargetPart(authorID strin
 	return r0, r1
 }
 
-// GetRandomViews provides a mock function with given fields: authorID, langID, tagIds, limit
-func (_m *MockTranslationViewRepository) GetRandomViews(authorID string, langID string, tagIds []string, limit int) (RandomViews, error) {
-	ret := _m.Called(authorID, langID, tagIds, limit)
+// GetRandomViews provides a mock function with given fields: authorID, langIDs, tags, limit
+func (_m *MockTranslationViewRepository) GetRandomViews(authorID string, langIDs []string, tags TagGroups, limit int) (RandomViews, error) {
+	ret := _m.Called(authorID, langIDs, tags, limit)
 
 	var r0 RandomViews
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, []string, int) (RandomViews, error)); ok {
-		return rf(authorID, langID, tagIds, limit)
+	if rf, ok := ret.Get(0).(func(string, []string, TagGroups, int) (RandomViews, error)); ok {
+		return rf(authorID, langIDs, tags, limit)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, []string, int) RandomViews); ok {
-		r0 = rf(authorID, langID, tagIds, limit)
+	if rf, ok := ret.Get(0).(func(string, []string, TagGroups, int) RandomViews); ok {
+		r0 = rf(authorID, langIDs, tags, limit)
 	} else {
 		r0 = ret.Get(0).(RandomViews)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, []string, int) error); ok {
-		r1 = rf(authorID, langID, tagIds, limit)
+	if rf, ok := ret.Get(1).(func(string, []string, TagGroups, int) error); ok {
+		r1 = rf(authorID, langIDs, tags, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -130,6 +134,30 @@ func (_m *MockTranslationViewRepository) GetView(id string, authorID string) (Tr
 	return r0, r1
 }
 
+// GetViewsAfter provides a mock function with given fields: filter, cursor, limit
+func (_m *MockTranslationViewRepository) GetViewsAfter(filter TranslationFilter, cursor *Cursor, limit int) (LastTranslationViews, error) {
+	ret := _m.Called(filter, cursor, limit)
+
+	var r0 LastTranslationViews
+	var r1 error
+	if rf, ok := ret.Get(0).(func(TranslationFilter, *Cursor, int) (LastTranslationViews, error)); ok {
+		return rf(filter, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(TranslationFilter, *Cursor, int) LastTranslationViews); ok {
+		r0 = rf(filter, cursor, limit)
+	} else {
+		r0 = ret.Get(0).(LastTranslationViews)
+	}
+
+	if rf, ok := ret.Get(1).(func(TranslationFilter, *Cursor, int) error); ok {
+		r1 = rf(filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewMockTranslationViewRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.23.1. DO NOT EDIT.
+
+package query
+
+import mock "github.com/stretchr/testify/mock"
+
+// mockery --name=TrashViewRepository --filename=trash_view_repository_mock.go --output=./ --structname=MockTrashViewRepository --inpackage
+// MockTrashViewRepository is an autogenerated mock type for the TrashViewRepository type
+type MockTrashViewRepository struct {
+	mock.Mock
+}
+
+// GetAllViews provides a mock function with given fields: authorID
+func (_m *MockTrashViewRepository) GetAllViews(authorID string) ([]TrashItemView, error) {
+	ret := _m.Called(authorID)
+
+	var r0 []TrashItemView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]TrashItemView, error)); ok {
+		return rf(authorID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []TrashItemView); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]TrashItemView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockTrashViewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockTrashViewRepository creates a new instance of MockTrashViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockTrashViewRepository(t mockConstructorTestingTNewMockTrashViewRepository) *MockTrashViewRepository {
+	mock := &MockTrashViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,23 +6,96 @@ import (
 
 type TranslationViewRepository interface {
 	GetView(id, authorID string) (TranslationView, error)
-	GetLastViewsByTags(authorID, langID string, pageSize, page int, tagIDs []string) (LastTranslationViews, error)
-	GetRandomViews(authorID, langID string, tagIDs []string, limit int) (RandomViews, error)
-	GetLastViewsBySourcePart(authorID, langID, sourcePart string, pageSize, page int) (LastTranslationViews, error)
-	GetLastViewsByTargetPart(authorID, langID, targetPart string, pageSize, page int) (LastTranslationViews, error)
+	GetLastViews(filter TranslationFilter, pageSize, page int) (LastTranslationViews, error)          // GetLastViews returns page of translations matched all filter conditions in filter sort order, page after the last one is empty
+	GetViewsAfter(filter TranslationFilter, cursor *Cursor, limit int) (LastTranslationViews, error)  // GetViewsAfter returns translations matched all filter conditions following the cursor in filter sort order, nil cursor means from the beginning, total records are not counted
+	GetRandomViews(authorID string, langIDs []string, tags TagGroups, limit int) (RandomViews, error) // GetRandomViews returns random translations of passed langs, empty langs means any lang
+	GetDueViews(authorID, langID string, tags TagGroups, dueAt time.Time, limit int) (DueViews, error)
+	GetFuzzyViews(authorID, langID, text string, maxDistance, limit int) (FuzzyViews, error) // GetFuzzyViews returns translations which source or any target is within max edit distance of the text ignoring case and diacritics, the closest go first
+}
+
+// TranslationFilter defines translation search conditions, all set conditions are applied together, empty ones are ignored
+type TranslationFilter struct {
+	AuthorID   string
+	LangIDs    []string // LangIDs translation matches any of passed langs
+	SourcePart string   // SourcePart is matched ignoring case and diacritics
+	TargetPart string   // TargetPart is matched ignoring case and diacritics in any sense target
+	TextPart   string   // TextPart is matched ignoring case and diacritics in source, transcription, sense targets and examples
+	Tags       TagGroups
+	Created    DateRange
+	Updated    DateRange
+	Sort       SortOrder
+}
+
+// DateRange defines time interval, zero From or To means the interval is not limited from that side
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (r DateRange) IsZero() bool {
+	return r.From.IsZero() && r.To.IsZero()
+}
+
+// Contains checks if passed time is within the range, the range bounds are included
+func (r DateRange) Contains(t time.Time) bool {
+	if !r.From.IsZero() && t.Before(r.From) {
+		return false
+	}
+
+	return r.To.IsZero() || !t.After(r.To)
 }
 
 type LastTranslationViews struct {
 	Views        []TranslationView
 	TotalRecords int
+	Next         *Cursor // Next is set when there can be more translations after the returned ones
 }
 
 type RandomViews struct {
 	Views []TranslationView
 }
 
+type DueViews struct {
+	Views []TranslationView
+}
+
+type FuzzyViews struct {
+	Views []FuzzyView
+}
+
+// FuzzyView translation view with the edit distance of its closest source or target to the searched text
+type FuzzyView struct {
+	Translation TranslationView
+	Distance    int
+}
+
+type StatsViewRepository interface {
+	GetStats(authorID string, added DateRange, period StatsPeriod) (StatsView, error) // GetStats returns author dictionary statistics, translations created within the range are counted per period, periods without translations are omitted
+}
+
+type ExportViewRepository interface {
+	ForEachTranslation(authorID string, fn func(ExportTranslationView) error) error // ForEachTranslation passes all author translations to fn in creation order, iteration stops on the first fn error
+}
+
+type RevisionViewRepository interface {
+	GetViews(translationID, authorID string) ([]RevisionView, error) // GetViews returns translation revisions, the latest go first
+}
+
+type TrashViewRepository interface {
+	GetAllViews(authorID string) ([]TrashItemView, error) // GetAllViews returns author trash items, the latest deleted go first
+}
+
+type SessionViewRepository interface {
+	GetAllViews(userID string) ([]SessionView, error) // GetAllViews returns not expired user sessions, the latest refreshed go first
+}
+
+type TokenViewRepository interface {
+	GetAllViews(userID string) ([]TokenView, error) // GetAllViews returns all user personal access tokens including expired ones, the latest created go first
+}
+
 type TagViewRepository interface {
 	GetAllViews(authorID string) ([]TagView, error)
+	GetHierarchy(authorID string) (TagHierarchy, error) // GetHierarchy returns all author tags with their nesting
 	GetView(id, authorID string) (TagView, error)
 	GetViews(ids []string, authorID string) ([]TagView, error)
 }
@@ -41,11 +114,136 @@ type TranslationView struct {
 	ID            string
 	Source        string
 	Transcription string
-	Target        string
-	Example       string
-	Tags          []TagView
+	Senses        []SenseView
 	CreatedAd     time.Time
 	Lang          LangView
+	Review        ReviewView
+	Fields        map[string]string // Fields custom field values defined by the lang schema
+}
+
+type SenseView struct {
+	Target  string
+	Example string
+	Tags    []TagView
+}
+
+type ReviewView struct {
+	Ease        float64
+	Interval    int
+	Repetitions int
+	Lapses      int
+	DueAt       time.Time
+	ReviewedAt  time.Time
+}
+
+// ExportTranslationView complete translation data, tags and lang are referenced by IDs
+type ExportTranslationView struct {
+	ID            string
+	LangID        string
+	Source        string
+	Transcription string
+	Senses        []ExportSenseView
+	Fields        map[string]string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Review        ReviewView
+}
+
+type ExportSenseView struct {
+	Target  string
+	Example string
+	TagIDs  []string
+}
+
+type RevisionView struct {
+	ID        string
+	CreatedAt time.Time
+	Changes   []FieldChangeView
+}
+
+type FieldChangeView struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+func (v *RevisionView) sanitize(reachSntz *richTextSanitizer) {
+	for i := range v.Changes {
+		v.Changes[i].OldValue = reachSntz.Sanitize(v.Changes[i].OldValue)
+		v.Changes[i].NewValue = reachSntz.Sanitize(v.Changes[i].NewValue)
+	}
+}
+
+// StatsPeriod defines the length of the period translations added counts are grouped by
+type StatsPeriod string
+
+const (
+	StatsPeriodDay  StatsPeriod = "day"
+	StatsPeriodWeek StatsPeriod = "week" // StatsPeriodWeek ISO week starting on Monday
+)
+
+// StatsView author dictionary statistics
+type StatsView struct {
+	Total    int
+	Untagged int // Untagged amount of translations without tags in all senses
+	Langs    []LangStatsView
+	Tags     []TagStatsView
+	Added    []AddedStatsView
+}
+
+type LangStatsView struct {
+	Lang  LangView
+	Count int
+}
+
+// TagStatsView amount of translations with the tag in any sense
+type TagStatsView struct {
+	Tag   TagView
+	Count int
+}
+
+// AddedStatsView amount of translations created within the period started at PeriodStart in UTC
+type AddedStatsView struct {
+	PeriodStart time.Time
+	Count       int
+}
+
+type TrashItemView struct {
+	ID        string
+	Kind      string
+	EntityID  string
+	Title     string // Title is a translation source, a tag or a lang name
+	DeletedAt time.Time
+}
+
+func (v *TrashItemView) sanitize(sanitizer *strictSanitizer) {
+	v.Title = sanitizer.Sanitize(v.Title)
+}
+
+type SessionView struct {
+	ID          string
+	UserAgent   string
+	IP          string
+	CreatedAt   time.Time
+	RefreshedAt time.Time
+	ExpiresAt   time.Time
+}
+
+func (v *SessionView) sanitize(sanitizer *strictSanitizer) {
+	v.UserAgent = sanitizer.Sanitize(v.UserAgent)
+}
+
+type TokenView struct {
+	ID         string
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time // ExpiresAt zero time means the token never expires
+	LastUsedAt time.Time
+}
+
+func (v *TokenView) sanitize(sanitizer *strictSanitizer) {
+	v.Name = sanitizer.Sanitize(v.Name)
 }
 
 type RoleView struct {
@@ -57,9 +255,24 @@ type RoleView struct {
 func (v *TranslationView) sanitize(strictSntz *strictSanitizer, reachSntz *richTextSanitizer) {
 	v.Source = reachSntz.SanitizeAndEscape(v.Source)
 	v.Transcription = reachSntz.SanitizeAndEscape(v.Transcription)
+	v.Lang.sanitize(strictSntz)
+
+	for i := range v.Senses {
+		v.Senses[i].sanitize(strictSntz, reachSntz)
+	}
+
+	if len(v.Fields) != 0 {
+		fields := make(map[string]string, len(v.Fields))
+		for name, value := range v.Fields {
+			fields[strictSntz.Sanitize(name)] = strictSntz.Sanitize(value)
+		}
+		v.Fields = fields
+	}
+}
+
+func (v *SenseView) sanitize(strictSntz *strictSanitizer, reachSntz *richTextSanitizer) {
 	v.Target = reachSntz.Sanitize(v.Target)
 	v.Example = reachSntz.Sanitize(v.Example)
-	v.Lang.sanitize(strictSntz)
 
 	for i := range v.Tags {
 		v.Tags[i].sanitize(strictSntz)
@@ -67,8 +280,9 @@ func (v *TranslationView) sanitize(strictSntz *strictSanitizer, reachSntz *richT
 }
 
 type TagView struct {
-	ID   string
-	Name string
+	ID       string
+	Name     string
+	ParentID string // ParentID is empty for root tags
 }
 
 func (v *TagView) sanitize(sanitizer *strictSanitizer) {
@@ -76,12 +290,32 @@ func (v *TagView) sanitize(sanitizer *strictSanitizer) {
 }
 
 type LangView struct {
-	ID   string
-	Name string
+	ID                  string
+	Name                string
+	Code                string // Code ISO 639-1 code, empty when it is not declared
+	Direction           string
+	TranscriptionScheme string
+	Fields              []LangFieldView // Fields schema of the lang translations custom fields
+}
+
+type LangFieldView struct {
+	Name    string
+	Kind    string
+	Options []string
 }
 
 func (v *LangView) sanitize(sanitizer *strictSanitizer) {
 	v.Name = sanitizer.Sanitize(v.Name)
+	for i := range v.Fields {
+		v.Fields[i].sanitize(sanitizer)
+	}
+}
+
+func (v *LangFieldView) sanitize(sanitizer *strictSanitizer) {
+	v.Name = sanitizer.Sanitize(v.Name)
+	for i := range v.Options {
+		v.Options[i] = sanitizer.Sanitize(v.Options[i])
+	}
 }
 
 type UserView struct {
@@ -91,6 +325,8 @@ type UserView struct {
 	Role        RoleView
 	DefaultLang LangView
 	ListOptions UserListOptionsView
+
+	TwoFactorEnabled bool
 }
 
 type UserListOptionsView struct {
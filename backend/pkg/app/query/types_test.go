@@ -3,6 +3,7 @@ package query
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestTagView_sanitize(t *testing.T) {
@@ -111,18 +112,20 @@ func TestTranslationView_sanitize(t *testing.T) {
 				ID:            id,
 				Source:        tt.rawFields.Text,
 				Transcription: tt.rawFields.Transcription,
-				Target:        tt.rawFields.Translation,
-				Example:       tt.rawFields.Example,
-				Tags:          []TagView{{Name: tt.rawFields.Tag}},
-				Lang:          tt.rawFields.LangView,
+				Senses: []SenseView{{
+					Target:  tt.rawFields.Translation,
+					Example: tt.rawFields.Example,
+					Tags:    []TagView{{Name: tt.rawFields.Tag}},
+				}},
+				Lang: tt.rawFields.LangView,
 			}
 			v.sanitize(strictSntz, reachSntz)
 			assert.Equal(t, id, v.ID)
 			assert.Equal(t, tt.sanitizedFields.Text, v.Source)
 			assert.Equal(t, tt.sanitizedFields.Transcription, v.Transcription)
-			assert.Equal(t, tt.sanitizedFields.Translation, v.Target)
-			assert.Equal(t, tt.sanitizedFields.Example, v.Example)
-			assert.Equal(t, tt.sanitizedFields.Tag, v.Tags[0].Name)
+			assert.Equal(t, tt.sanitizedFields.Translation, v.Senses[0].Target)
+			assert.Equal(t, tt.sanitizedFields.Example, v.Senses[0].Example)
+			assert.Equal(t, tt.sanitizedFields.Tag, v.Senses[0].Tags[0].Name)
 			assert.Equal(t, tt.sanitizedFields.LangView, v.Lang)
 		})
 	}
@@ -219,3 +222,15 @@ func TestLangView_sanitize(t *testing.T) {
 		})
 	}
 }
+
+func TestDateRange_Contains(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, DateRange{}.Contains(from))
+	assert.True(t, DateRange{From: from, To: to}.Contains(from))
+	assert.True(t, DateRange{From: from, To: to}.Contains(to))
+	assert.False(t, DateRange{From: from, To: to}.Contains(to.Add(time.Second)))
+	assert.False(t, DateRange{From: from}.Contains(from.Add(-time.Second)))
+	assert.True(t, DateRange{To: to}.Contains(from))
+}
@@ -4,38 +4,107 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 )
 
 const userContextKey = "user"
+const personalTokenContextKey = "personalToken"
 const authType = "Bearer"
 
 var ErrInvalidCredentials = errors.New("auth: can not authenticate, invalid email or password")
 var ErrExpiredRefreshToken = errors.New("auth: can not refresh auth token, refresh token is expired")
+var ErrRevokedRefreshToken = errors.New("auth: can not refresh auth token, session of refresh token is revoked")
+var ErrReusedRefreshToken = errors.New("auth: can not refresh auth token, refresh token was already used, session is revoked")
+var ErrTooManyAttempts = errors.New("auth: can not authenticate, too many failed attempts")
+var ErrTwoFactorRequired = errors.New("auth: two-factor authentication code is required")
+var ErrInvalidChallenge = errors.New("auth: two-factor challenge token is invalid or expired")
+
+// BlockedError is returned when sign-in is blocked after failed attempts, the next attempt is allowed after Until
+type BlockedError struct {
+	Until time.Time
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%v, blocked until %s", ErrTooManyAttempts, e.Until.Format(time.RFC3339))
+}
+
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// TwoFactorRequiredError is returned when the password is valid, but the user has to pass the second factor,
+// ChallengeToken has to be passed with the code to VerifyTwoFactor
+type TwoFactorRequiredError struct {
+	ChallengeToken string
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return ErrTwoFactorRequired.Error()
+}
+
+func (e *TwoFactorRequiredError) Is(target error) bool {
+	return target == ErrTwoFactorRequired
+}
 
 type tokener interface {
-	generateToken(email string, expiresAt time.Time) (string, error)
+	generateToken(userID string, tokenVersion int, expiresAt time.Time) (string, error)
+	generateRefreshToken(userID, sessionID, tokenID string, expiresAt time.Time) (string, error)
+	generateChallengeToken(userID string, tokenVersion int, expiresAt time.Time) (string, error)
 	parseToken(signedToken string) (*JWTClaim, error)
 }
 
 type Handler struct {
-	userRepo user.Repository
-	tokener  tokener
-	cipher   Cipher
-	params   Params
+	userRepo    user.Repository
+	attemptRepo user.AttemptRepository
+	sessionRepo session.Repository
+	tokenRepo   token.Repository
+	tokener     tokener
+	cipher      Cipher
+	params      Params
 }
 
-func NewHandler(userRepo user.Repository, cipher Cipher, params Params) *Handler {
-	return &Handler{userRepo: userRepo, tokener: jwtTokener{params: params}, cipher: cipher, params: params}
+func NewHandler(
+	userRepo user.Repository,
+	attemptRepo user.AttemptRepository,
+	sessionRepo session.Repository,
+	tokenRepo token.Repository,
+	cipher Cipher,
+	params Params,
+) *Handler {
+	return &Handler{
+		userRepo:    userRepo,
+		attemptRepo: attemptRepo,
+		sessionRepo: sessionRepo,
+		tokenRepo:   tokenRepo,
+		tokener:     jwtTokener{params: params},
+		cipher:      cipher,
+		params:      params,
+	}
 }
 
-func (h Handler) Authenticate(email, password string) (AuthenticationToken, error) {
+// Authenticate checks credentials and issues auth token. Failed attempts are counted per account and per client ip,
+// sign-in is delayed and then locked according to the lockout params, BlockedError is returned until it is allowed again.
+// TwoFactorRequiredError with challenge token is returned for users with two-factor authentication, see VerifyTwoFactor.
+// Empty ip skips the client ip tracking
+func (h Handler) Authenticate(email, password, ip string) (AuthenticationToken, error) {
+	ipAttempts, err := h.clientAttempts(ip)
+	if err != nil {
+		return AuthenticationToken{}, err
+	}
+
 	usr, err := h.userRepo.GetByEmail(email)
 
 	if err == user.ErrNotFound {
+		if err = h.registerFailure(ipAttempts, h.params.IPLockout); err != nil {
+			return AuthenticationToken{}, err
+		}
 		return AuthenticationToken{}, ErrInvalidCredentials
 	}
 
@@ -43,38 +112,306 @@ func (h Handler) Authenticate(email, password string) (AuthenticationToken, erro
 		return AuthenticationToken{}, err
 	}
 
+	accountAttempts, err := h.accountAttempts(usr)
+	if err != nil {
+		return AuthenticationToken{}, err
+	}
+
 	if !h.cipher.ComparePasswords(usr.Password(), password) {
-		return AuthenticationToken{}, ErrInvalidCredentials
+		return AuthenticationToken{}, h.registerFailures(ipAttempts, accountAttempts)
+	}
+
+	// account attempts are kept until the second factor is passed, so codes can not be guessed by repeating the first step
+	if usr.TwoFactorEnabled() {
+		challenge, cErr := h.tokener.generateChallengeToken(usr.ID(), usr.TokenVersion(), time.Now().Add(h.params.ChallengeTTL))
+		if cErr != nil {
+			return AuthenticationToken{}, cErr
+		}
+		return AuthenticationToken{}, &TwoFactorRequiredError{ChallengeToken: challenge}
 	}
 
-	return h.generateAuthToken(email)
+	if err = h.resetAttempts(accountAttempts); err != nil {
+		return AuthenticationToken{}, err
+	}
+
+	return h.generateAuthToken(usr)
 }
 
-func (h Handler) GenerateRefreshToken(email string) (RefreshToken, error) {
-	expiresAt := time.Now().Add(h.params.RefreshTTL)
-	token, err := h.tokener.generateToken(email, expiresAt)
+// VerifyTwoFactor passes the second step of sign-in with TOTP or recovery code and starts new session of the user on the device.
+// Failed attempts are counted the same way as on the first step
+func (h Handler) VerifyTwoFactor(challengeToken, code, userAgent, ip string) (AuthenticationToken, RefreshToken, error) {
+	claims, err := h.tokener.parseToken(challengeToken)
+	if err != nil || claims.Purpose != challengePurpose {
+		return AuthenticationToken{}, RefreshToken{}, ErrInvalidChallenge
+	}
+
+	ipAttempts, err := h.clientAttempts(ip)
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	usr, err := h.userRepo.Get(claims.Subject)
+	if err == user.ErrNotFound {
+		return AuthenticationToken{}, RefreshToken{}, ErrInvalidChallenge
+	}
+
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	// password change or two-factor reset after the first step invalidates the challenge
+	if usr.TokenVersion() != claims.TokenVersion || !usr.TwoFactorEnabled() {
+		return AuthenticationToken{}, RefreshToken{}, ErrInvalidChallenge
+	}
+
+	accountAttempts, err := h.accountAttempts(usr)
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	use, ok := usr.VerifyTwoFactor(code)
+	if !ok {
+		return AuthenticationToken{}, RefreshToken{}, h.registerFailures(ipAttempts, accountAttempts)
+	}
+
+	// used code is saved only if parallel sign-in has not used it yet, so it can not be replayed
+	err = h.userRepo.UseTwoFactorCode(usr.ID(), use)
+	if err == user.ErrTwoFactorCodeUsed {
+		return AuthenticationToken{}, RefreshToken{}, h.registerFailures(ipAttempts, accountAttempts)
+	}
+
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	if err = h.resetAttempts(accountAttempts); err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
 
+	authToken, err := h.generateAuthToken(usr)
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	refreshToken, err := h.startSession(usr, userAgent, ip)
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	return authToken, refreshToken, nil
+}
+
+// clientAttempts returns failed sign-in attempts from the client ip, nil attempts are returned for empty ip
+func (h Handler) clientAttempts(ip string) (*user.SignInAttempts, error) {
+	if ip == "" {
+		return nil, nil
+	}
+
+	attempts, err := h.attemptRepo.GetAttempts(user.IPAttemptsKey(ip))
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts.Blocked() {
+		return nil, &BlockedError{Until: attempts.BlockedUntil()}
+	}
+
+	return attempts, nil
+}
+
+func (h Handler) accountAttempts(usr *user.User) (*user.SignInAttempts, error) {
+	attempts, err := h.attemptRepo.GetAttempts(user.AccountAttemptsKey(usr.ID()))
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts.Blocked() {
+		return nil, &BlockedError{Until: attempts.BlockedUntil()}
+	}
+
+	return attempts, nil
+}
+
+// registerFailures counts failed attempt for the client ip and the account, returns ErrInvalidCredentials when it is saved
+func (h Handler) registerFailures(ipAttempts, accountAttempts *user.SignInAttempts) error {
+	err := errors.Join(
+		h.registerFailure(ipAttempts, h.params.IPLockout),
+		h.registerFailure(accountAttempts, h.params.AccountLockout),
+	)
+	if err != nil {
+		return err
+	}
+
+	return ErrInvalidCredentials
+}
+
+// registerFailure counts failed sign-in attempt, nil attempts are not tracked
+func (h Handler) registerFailure(attempts *user.SignInAttempts, policy user.LockoutPolicy) error {
+	if attempts == nil {
+		return nil
+	}
+
+	_, err := h.attemptRepo.RegisterFailure(attempts.Key(), policy)
+	return err
+}
+
+// resetAttempts removes account attempts on successful sign-in, client ip attempts are kept
+// as successful sign-in does not prove other guesses from the ip were not malicious
+func (h Handler) resetAttempts(accountAttempts *user.SignInAttempts) error {
+	if accountAttempts.Failures() == 0 {
+		return nil
+	}
+
+	return h.attemptRepo.DeleteAttempts(accountAttempts.Key())
+}
+
+// GenerateRefreshToken starts new session of the user on the device and returns its first refresh token
+func (h Handler) GenerateRefreshToken(email, userAgent, ip string) (RefreshToken, error) {
+	usr, err := h.userRepo.GetByEmail(email)
 	if err != nil {
 		return RefreshToken{}, err
 	}
 
-	return RefreshToken{
-		Token: token,
-	}, nil
+	return h.startSession(usr, userAgent, ip)
+}
+
+func (h Handler) startSession(usr *user.User, userAgent, ip string) (RefreshToken, error) {
+	sess, err := session.NewSession(usr.ID(), userAgent, ip, time.Now().Add(h.params.RefreshTTL))
+	if err != nil {
+		return RefreshToken{}, err
+	}
+
+	if err = h.sessionRepo.Create(sess); err != nil {
+		return RefreshToken{}, err
+	}
+
+	return h.generateRefreshToken(sess)
+}
+
+// Refresh exchanges refresh token for the new auth and refresh tokens, every refresh token can be exchanged only once,
+// the session is revoked when already exchanged token is presented as it means the token is likely stolen.
+// Refresh tokens are not bound to the user token version, they are invalidated by the session revocation
+func (h Handler) Refresh(token string) (AuthenticationToken, RefreshToken, error) {
+	claims, err := h.parseRefreshToken(token)
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	sess, err := h.sessionRepo.Get(claims.SessionID)
+	if err == session.ErrNotFound || (err == nil && sess.UserID() != claims.Subject) {
+		return AuthenticationToken{}, RefreshToken{}, ErrRevokedRefreshToken
+	}
+
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	usr, err := h.userRepo.Get(sess.UserID())
+	if err == user.ErrNotFound {
+		return AuthenticationToken{}, RefreshToken{}, ErrRevokedRefreshToken
+	}
+
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	err = sess.Rotate(claims.ID, time.Now().Add(h.params.RefreshTTL))
+	if err == nil {
+		// the token can be exchanged by parallel refresh after the session was read, the store checks it once more
+		err = h.sessionRepo.Update(sess, claims.ID)
+	}
+
+	if err == session.ErrTokenReused {
+		return AuthenticationToken{}, RefreshToken{}, h.revokeReusedSession(sess)
+	}
+
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	authToken, err := h.generateAuthToken(usr)
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	refreshToken, err := h.generateRefreshToken(sess)
+	if err != nil {
+		return AuthenticationToken{}, RefreshToken{}, err
+	}
+
+	return authToken, refreshToken, nil
 }
 
-func (h Handler) Refresh(token string) (AuthenticationToken, error) {
+// revokeReusedSession revokes the session of already exchanged refresh token as the token is likely stolen
+func (h Handler) revokeReusedSession(sess *session.Session) error {
+	if err := h.sessionRepo.Delete(sess.ID(), sess.UserID()); err != nil {
+		return errors.Join(ErrReusedRefreshToken, err)
+	}
+
+	return ErrReusedRefreshToken
+}
+
+// Logout revokes the session of refresh token, already revoked session is not an error
+func (h Handler) Logout(token string) error {
+	claims, err := h.parseRefreshToken(token)
+	if err != nil {
+		return err
+	}
+
+	sess, err := h.sessionRepo.Get(claims.SessionID)
+	if err == session.ErrNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return h.sessionRepo.Delete(sess.ID(), sess.UserID())
+}
+
+// SessionID returns id of the session of valid refresh token, empty string is returned for invalid one
+func (h Handler) SessionID(token string) string {
+	claims, err := h.parseRefreshToken(token)
+	if err != nil {
+		return ""
+	}
+
+	return claims.SessionID
+}
+
+// parseRefreshToken validates refresh token, tokens issued before sessions were introduced are considered revoked
+func (h Handler) parseRefreshToken(token string) (*JWTClaim, error) {
 	claims, err := h.tokener.parseToken(token)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrExpiredRefreshToken
+	}
 
 	if err != nil {
-		return AuthenticationToken{}, err
+		return nil, err
+	}
+
+	if claims.SessionID == "" {
+		return nil, ErrRevokedRefreshToken
 	}
 
-	return h.generateAuthToken(claims.Email)
+	return claims, nil
 }
 
-func (h Handler) generateAuthToken(email string) (AuthenticationToken, error) {
-	token, err := h.tokener.generateToken(email, time.Now().Add(h.params.AuthTTL))
+func (h Handler) generateRefreshToken(sess *session.Session) (RefreshToken, error) {
+	token, err := h.tokener.generateRefreshToken(sess.UserID(), sess.ID(), sess.TokenID(), sess.ExpiresAt())
+
+	if err != nil {
+		return RefreshToken{}, err
+	}
+
+	return RefreshToken{
+		Token: token,
+	}, nil
+}
+
+func (h Handler) generateAuthToken(usr *user.User) (AuthenticationToken, error) {
+	token, err := h.tokener.generateToken(usr.ID(), usr.TokenVersion(), time.Now().Add(h.params.AuthTTL))
 
 	if err != nil {
 		return AuthenticationToken{}, err
@@ -86,16 +423,30 @@ func (h Handler) generateAuthToken(email string) (AuthenticationToken, error) {
 	}, nil
 }
 
-func (h Handler) Middleware() gin.HandlerFunc {
+// Middleware authenticates the request with auth token or personal access token. Personal access tokens are accepted
+// only by route groups of the resources, see personalTokenUser, groups without resources accept auth tokens only
+func (h Handler) Middleware(resources ...token.Resource) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := h.tokenFromHeader(c.Request)
+		authToken := h.tokenFromHeader(c.Request)
 
-		if token == "" {
+		if authToken == "" {
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		claims, err := h.tokener.parseToken(token)
+		if strings.HasPrefix(authToken, token.SecretPrefix) {
+			usr, t, status := h.personalTokenUser(authToken, resources, requestAccess(c.Request))
+			if status != http.StatusOK {
+				c.AbortWithStatus(status)
+				return
+			}
+
+			c.Set(userContextKey, usr)
+			c.Set(personalTokenContextKey, t)
+			return
+		}
+
+		claims, err := h.tokener.parseToken(authToken)
 
 		if err != nil {
 			log.Printf("[INFO] Can not parse auth token: %v", err)
@@ -103,7 +454,14 @@ func (h Handler) Middleware() gin.HandlerFunc {
 			return
 		}
 
-		usr, err := h.userRepo.GetByEmail(claims.Email)
+		// refresh tokens have session id and challenge tokens have purpose, they can not be used for the auth
+		if claims.SessionID != "" || claims.Purpose != "" {
+			log.Printf("[INFO] Attempt to authenticate with refresh or challenge token")
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		usr, err := h.userRepo.Get(claims.Subject)
 
 		if err == user.ErrNotFound {
 			log.Printf("[ERROR] Attempt to authenticate with not existing user and valid token")
@@ -117,6 +475,13 @@ func (h Handler) Middleware() gin.HandlerFunc {
 			return
 		}
 
+		// token version is increased on password or role change, so tokens issued before are not valid anymore
+		if usr.TokenVersion() != claims.TokenVersion {
+			log.Printf("[INFO] Attempt to authenticate with outdated token version")
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
 		c.Set(userContextKey, User{
 			ID:    usr.ID(),
 			Email: usr.Email(),
@@ -125,6 +490,85 @@ func (h Handler) Middleware() gin.HandlerFunc {
 	}
 }
 
+// personalTokenUser checks that personal access token is valid and grants the access to all the resources,
+// returns the user and the token or http status to abort the request with
+func (h Handler) personalTokenUser(secret string, resources []token.Resource, access token.Access) (User, *token.Token, int) {
+	t, err := h.tokenRepo.GetByHash(token.Hash(secret))
+
+	if err == token.ErrNotFound {
+		log.Printf("[INFO] Attempt to authenticate with not existing, revoked or expired personal access token")
+		return User{}, nil, http.StatusUnauthorized
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Can not get personal access token from DB: %v", err)
+		return User{}, nil, http.StatusUnauthorized
+	}
+
+	usr, err := h.userRepo.Get(t.UserID())
+	if err != nil {
+		log.Printf("[ERROR] Can not get user of personal access token %s: %v", t.ID(), err)
+		return User{}, nil, http.StatusUnauthorized
+	}
+
+	if len(resources) == 0 {
+		log.Printf("[INFO] Attempt to use personal access token %s for the route which accepts auth token only", t.ID())
+		return User{}, nil, http.StatusForbidden
+	}
+
+	for _, resource := range resources {
+		if !t.Allows(resource, access) {
+			log.Printf("[INFO] Personal access token %s does not grant %s access to %s", t.ID(), access, resource)
+			return User{}, nil, http.StatusForbidden
+		}
+	}
+
+	if t.MarkUsed() {
+		if err = h.tokenRepo.Update(t); err != nil {
+			log.Printf("[ERROR] Can not save usage of personal access token %s: %v", t.ID(), err)
+		}
+	}
+
+	return User{
+		ID:    usr.ID(),
+		Email: usr.Email(),
+		Role:  usr.Role(),
+	}, t, http.StatusOK
+}
+
+// TokenAllows checks that personal access token of the request grants the access to all the resources,
+// it is used by routes which change resources of other groups, requests with auth token are allowed
+func (h Handler) TokenAllows(c *gin.Context, access token.Access, resources ...token.Resource) bool {
+	value, exists := c.Get(personalTokenContextKey)
+	if !exists {
+		return true
+	}
+
+	t, ok := value.(*token.Token)
+	if !ok {
+		return false
+	}
+
+	for _, resource := range resources {
+		if !t.Allows(resource, access) {
+			log.Printf("[INFO] Personal access token %s does not grant %s access to %s", t.ID(), access, resource)
+			return false
+		}
+	}
+
+	return true
+}
+
+// requestAccess safe methods only read resources, all others change them
+func requestAccess(r *http.Request) token.Access {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return token.Read
+	default:
+		return token.Write
+	}
+}
+
 func (h Handler) AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		usr, err := h.UserFromContext(c)
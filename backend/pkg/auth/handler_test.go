@@ -3,6 +3,9 @@ package auth
 import (
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,14 +18,21 @@ import (
 func TestHandler_Authenticate(t *testing.T) {
 	var cipher = Cipher{}
 	var hashedPwd, _ = cipher.GenerateHash("password")
+	var params = Params{
+		AccountLockout: user.LockoutPolicy{LockoutAttempts: 1, LockoutDuration: time.Hour, ResetAfter: time.Hour},
+		IPLockout:      user.LockoutPolicy{LockoutAttempts: 2, LockoutDuration: time.Hour, ResetAfter: time.Hour},
+	}
+	var blocked = user.UnmarshalAttemptsFromDB("key", 5, time.Now(), time.Now().Add(time.Hour), time.Now().Add(time.Hour))
 
 	type fields struct {
-		userRepo user.Repository
-		tokener  tokener
+		userRepo    user.Repository
+		attemptRepo user.AttemptRepository
+		tokener     tokener
 	}
 	type args struct {
 		email    string
 		password string
+		ip       string
 	}
 	tests := []struct {
 		name     string
@@ -31,19 +41,72 @@ func TestHandler_Authenticate(t *testing.T) {
 		want     AuthenticationToken
 		wantErr  assert.ErrorAssertionFunc
 	}{
+		{
+			"Error on getting client ip attempts",
+			func() fields {
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", user.IPAttemptsKey("127.0.0.1")).Return(nil, fmt.Errorf("testErr"))
+				return fields{
+					userRepo:    &user.MockRepository{},
+					attemptRepo: &attemptRepo,
+					tokener:     &mockTokener{},
+				}
+			},
+			args{
+				email:    "test@email.com",
+				password: "password",
+				ip:       "127.0.0.1",
+			},
+			AuthenticationToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testErr", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Client ip is blocked",
+			func() fields {
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", user.IPAttemptsKey("127.0.0.1")).Return(blocked, nil)
+				return fields{
+					userRepo:    &user.MockRepository{},
+					attemptRepo: &attemptRepo,
+					tokener:     &mockTokener{},
+				}
+			},
+			args{
+				email:    "test@email.com",
+				password: "password",
+				ip:       "127.0.0.1",
+			},
+			AuthenticationToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, ErrTooManyAttempts, i)
+				var blockedErr *BlockedError
+				assert.ErrorAs(t, err, &blockedErr, i)
+				assert.Equal(t, blocked.BlockedUntil(), blockedErr.Until, i)
+				return true
+			},
+		},
 		{
 			"User does not exist",
 			func() fields {
 				repository := user.MockRepository{}
 				repository.On("GetByEmail", "notExist").Return(nil, user.ErrNotFound)
+
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", user.IPAttemptsKey("127.0.0.1")).Return(user.NewSignInAttempts(user.IPAttemptsKey("127.0.0.1")), nil)
+				attemptRepo.On("RegisterFailure", user.IPAttemptsKey("127.0.0.1"), params.IPLockout).Return(user.NewSignInAttempts(user.IPAttemptsKey("127.0.0.1")), nil)
 				return fields{
-					userRepo: &repository,
-					tokener:  &mockTokener{},
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &mockTokener{},
 				}
 			},
 			args{
 				email:    "notExist",
 				password: "test",
+				ip:       "127.0.0.1",
 			},
 			AuthenticationToken{},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
@@ -51,6 +114,34 @@ func TestHandler_Authenticate(t *testing.T) {
 				return true
 			},
 		},
+		{
+			"Account is blocked",
+			func() fields {
+				email := "test@email.com"
+				existingUser, err := user.NewUser("test", email, hashedPwd, user.Admin)
+				assert.NoError(t, err)
+
+				repository := user.MockRepository{}
+				repository.On("GetByEmail", email).Return(existingUser, nil)
+
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", user.AccountAttemptsKey(existingUser.ID())).Return(blocked, nil)
+				return fields{
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &mockTokener{},
+				}
+			},
+			args{
+				email:    "test@email.com",
+				password: "password",
+			},
+			AuthenticationToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, ErrTooManyAttempts, i)
+				return true
+			},
+		},
 		{
 			"Password is not valid",
 			func() fields {
@@ -60,14 +151,23 @@ func TestHandler_Authenticate(t *testing.T) {
 
 				repository := user.MockRepository{}
 				repository.On("GetByEmail", email).Return(existingUser, nil)
+
+				accountKey := user.AccountAttemptsKey(existingUser.ID())
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", user.IPAttemptsKey("127.0.0.1")).Return(user.NewSignInAttempts(user.IPAttemptsKey("127.0.0.1")), nil)
+				attemptRepo.On("GetAttempts", accountKey).Return(user.NewSignInAttempts(accountKey), nil)
+				attemptRepo.On("RegisterFailure", user.IPAttemptsKey("127.0.0.1"), params.IPLockout).Once().Return(user.NewSignInAttempts(user.IPAttemptsKey("127.0.0.1")), nil)
+				attemptRepo.On("RegisterFailure", accountKey, params.AccountLockout).Once().Return(user.NewSignInAttempts(accountKey), nil)
 				return fields{
-					userRepo: &repository,
-					tokener:  &mockTokener{},
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &mockTokener{},
 				}
 			},
 			args{
 				email:    "test@email.com",
 				password: "test",
+				ip:       "127.0.0.1",
 			},
 			AuthenticationToken{},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
@@ -75,6 +175,36 @@ func TestHandler_Authenticate(t *testing.T) {
 				return true
 			},
 		},
+		{
+			"Error on saving failed attempt",
+			func() fields {
+				email := "test@email.com"
+				existingUser, err := user.NewUser("test", email, hashedPwd, user.Admin)
+				assert.NoError(t, err)
+
+				repository := user.MockRepository{}
+				repository.On("GetByEmail", email).Return(existingUser, nil)
+
+				accountKey := user.AccountAttemptsKey(existingUser.ID())
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", accountKey).Return(user.NewSignInAttempts(accountKey), nil)
+				attemptRepo.On("RegisterFailure", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("testErr"))
+				return fields{
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &mockTokener{},
+				}
+			},
+			args{
+				email:    "test@email.com",
+				password: "test",
+			},
+			AuthenticationToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "testErr", err.Error(), i)
+				return true
+			},
+		},
 		{
 			"Error on token generation",
 			func() fields {
@@ -85,11 +215,16 @@ func TestHandler_Authenticate(t *testing.T) {
 				repository := user.MockRepository{}
 				repository.On("GetByEmail", email).Return(existingUser, nil)
 
+				accountKey := user.AccountAttemptsKey(existingUser.ID())
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", accountKey).Return(user.NewSignInAttempts(accountKey), nil)
+
 				tokener := mockTokener{}
-				tokener.On("generateToken", email, mock.IsType(time.Time{})).Return("", fmt.Errorf("noToken"))
+				tokener.On("generateToken", existingUser.ID(), 0, mock.IsType(time.Time{})).Return("", fmt.Errorf("noToken"))
 				return fields{
-					userRepo: &repository,
-					tokener:  &tokener,
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &tokener,
 				}
 			},
 			args{
@@ -102,6 +237,79 @@ func TestHandler_Authenticate(t *testing.T) {
 				return true
 			},
 		},
+		{
+			"Two-factor authentication is required, failed attempts of account are kept",
+			func() fields {
+				existingUser := newTwoFactorUser(t, hashedPwd)
+
+				repository := user.MockRepository{}
+				repository.On("GetByEmail", existingUser.Email()).Return(existingUser, nil)
+
+				accountKey := user.AccountAttemptsKey(existingUser.ID())
+				failed := user.UnmarshalAttemptsFromDB(accountKey, 2, time.Now().Add(-time.Minute), time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", accountKey).Return(failed, nil)
+
+				tokener := mockTokener{}
+				tokener.On("generateChallengeToken", existingUser.ID(), 0, mock.IsType(time.Time{})).Return("challengeToken", nil)
+				return fields{
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &tokener,
+				}
+			},
+			args{
+				email:    "test@email.com",
+				password: "password",
+			},
+			AuthenticationToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.ErrorIs(t, err, ErrTwoFactorRequired, i)
+				var requiredErr *TwoFactorRequiredError
+				assert.ErrorAs(t, err, &requiredErr, i)
+				assert.Equal(t, "challengeToken", requiredErr.ChallengeToken, i)
+				return true
+			},
+		},
+		{
+			"Positive case, previous failed attempts of account are reset",
+			func() fields {
+				email := "test@email.com"
+				existingUser, err := user.NewUser("test", email, hashedPwd, user.Admin)
+				assert.NoError(t, err)
+
+				repository := user.MockRepository{}
+				repository.On("GetByEmail", email).Return(existingUser, nil)
+
+				accountKey := user.AccountAttemptsKey(existingUser.ID())
+				failed := user.UnmarshalAttemptsFromDB(accountKey, 2, time.Now().Add(-time.Minute), time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", user.IPAttemptsKey("127.0.0.1")).Return(user.NewSignInAttempts(user.IPAttemptsKey("127.0.0.1")), nil)
+				attemptRepo.On("GetAttempts", accountKey).Return(failed, nil)
+				attemptRepo.On("DeleteAttempts", accountKey).Once().Return(nil)
+
+				tokener := mockTokener{}
+				tokener.On("generateToken", existingUser.ID(), 0, mock.IsType(time.Time{})).Return("validToken", nil)
+				return fields{
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &tokener,
+				}
+			},
+			args{
+				email:    "test@email.com",
+				password: "password",
+				ip:       "127.0.0.1",
+			},
+			AuthenticationToken{
+				Token: "validToken",
+				Type:  authType,
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Nil(t, err, i)
+				return false
+			},
+		},
 		{
 			"Positive Case",
 			func() fields {
@@ -112,11 +320,16 @@ func TestHandler_Authenticate(t *testing.T) {
 				repository := user.MockRepository{}
 				repository.On("GetByEmail", email).Return(existingUser, nil)
 
+				accountKey := user.AccountAttemptsKey(existingUser.ID())
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", accountKey).Return(user.NewSignInAttempts(accountKey), nil)
+
 				tokener := mockTokener{}
-				tokener.On("generateToken", email, mock.IsType(time.Time{})).Return("validToken", nil)
+				tokener.On("generateToken", existingUser.ID(), 0, mock.IsType(time.Time{})).Return("validToken", nil)
 				return fields{
-					userRepo: &repository,
-					tokener:  &tokener,
+					userRepo:    &repository,
+					attemptRepo: &attemptRepo,
+					tokener:     &tokener,
 				}
 			},
 			args{
@@ -137,23 +350,207 @@ func TestHandler_Authenticate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fields := tt.fieldsFn()
 			h := Handler{
-				userRepo: fields.userRepo,
-				tokener:  fields.tokener,
-				cipher:   cipher,
+				userRepo:    fields.userRepo,
+				attemptRepo: fields.attemptRepo,
+				tokener:     fields.tokener,
+				cipher:      cipher,
+				params:      params,
+			}
+			got, err := h.Authenticate(tt.args.email, tt.args.password, tt.args.ip)
+			if !tt.wantErr(t, err, fmt.Sprintf("Authenticate(%v, %v, %v)", tt.args.email, tt.args.password, tt.args.ip)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "Authenticate(%v, %v, %v)", tt.args.email, tt.args.password, tt.args.ip)
+		})
+	}
+}
+
+func TestHandler_VerifyTwoFactor(t *testing.T) {
+	challengeClaims := func(usr *user.User) *JWTClaim {
+		return &JWTClaim{
+			TokenVersion:     usr.TokenVersion(),
+			Purpose:          challengePurpose,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: usr.ID()},
+		}
+	}
+	params := Params{RefreshTTL: time.Hour, AccountLockout: user.LockoutPolicy{LockoutAttempts: 3, LockoutDuration: time.Hour, ResetAfter: time.Hour}}
+
+	type fields struct {
+		userRepo    user.Repository
+		attemptRepo user.AttemptRepository
+		sessionRepo session.Repository
+		tokener     tokener
+	}
+	tests := []struct {
+		name     string
+		fieldsFn func() (fields, string)
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			"Challenge token can not be parsed",
+			func() (fields, string) {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "challengeToken").Return(nil, fmt.Errorf("testErr"))
+				return fields{tokener: &tokener}, ""
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrInvalidChallenge, err, i)
+				return true
+			},
+		},
+		{
+			"Auth token is passed as challenge",
+			func() (fields, string) {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "challengeToken").Return(&JWTClaim{RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}, nil)
+				return fields{tokener: &tokener}, ""
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrInvalidChallenge, err, i)
+				return true
+			},
+		},
+		{
+			"Two-factor authentication is reset after the first step",
+			func() (fields, string) {
+				usr := newTwoFactorUser(t, "password")
+				tokener := mockTokener{}
+				tokener.On("parseToken", "challengeToken").Return(challengeClaims(usr), nil)
+				usr.ResetTwoFactor()
+
+				userRepo := user.MockRepository{}
+				userRepo.On("Get", usr.ID()).Return(usr, nil)
+				return fields{tokener: &tokener, userRepo: &userRepo}, ""
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrInvalidChallenge, err, i)
+				return true
+			},
+		},
+		{
+			"Code is not valid",
+			func() (fields, string) {
+				usr := newTwoFactorUser(t, "password")
+				tokener := mockTokener{}
+				tokener.On("parseToken", "challengeToken").Return(challengeClaims(usr), nil)
+
+				userRepo := user.MockRepository{}
+				userRepo.On("Get", usr.ID()).Return(usr, nil)
+
+				accountKey := user.AccountAttemptsKey(usr.ID())
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", accountKey).Return(user.NewSignInAttempts(accountKey), nil)
+				attemptRepo.On("RegisterFailure", accountKey, params.AccountLockout).Once().Return(user.NewSignInAttempts(accountKey), nil)
+				return fields{tokener: &tokener, userRepo: &userRepo, attemptRepo: &attemptRepo}, "000000x"
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrInvalidCredentials, err, i)
+				return true
+			},
+		},
+		{
+			"Code is used by parallel sign-in",
+			func() (fields, string) {
+				usr := newTwoFactorUser(t, "password")
+				tokener := mockTokener{}
+				tokener.On("parseToken", "challengeToken").Return(challengeClaims(usr), nil)
+
+				userRepo := user.MockRepository{}
+				userRepo.On("Get", usr.ID()).Return(usr, nil)
+				userRepo.On("UseTwoFactorCode", usr.ID(), mock.AnythingOfType("user.TwoFactorUse")).Once().Return(user.ErrTwoFactorCodeUsed)
+
+				accountKey := user.AccountAttemptsKey(usr.ID())
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", accountKey).Return(user.NewSignInAttempts(accountKey), nil)
+				attemptRepo.On("RegisterFailure", accountKey, params.AccountLockout).Once().Return(user.NewSignInAttempts(accountKey), nil)
+
+				code, err := user.GenerateTOTPCode(twoFactorSecret, time.Now())
+				assert.Nil(t, err)
+				return fields{tokener: &tokener, userRepo: &userRepo, attemptRepo: &attemptRepo}, code
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrInvalidCredentials, err, i)
+				return true
+			},
+		},
+		{
+			"Positive case",
+			func() (fields, string) {
+				usr := newTwoFactorUser(t, "password")
+				tokener := mockTokener{}
+				tokener.On("parseToken", "challengeToken").Return(challengeClaims(usr), nil)
+				tokener.On("generateToken", usr.ID(), 0, mock.IsType(time.Time{})).Return("authToken", nil)
+				tokener.On("generateRefreshToken", usr.ID(), mock.Anything, mock.Anything, mock.IsType(time.Time{})).Return("refreshToken", nil)
+
+				userRepo := user.MockRepository{}
+				userRepo.On("Get", usr.ID()).Return(usr, nil)
+				userRepo.On("UseTwoFactorCode", usr.ID(), mock.AnythingOfType("user.TwoFactorUse")).Once().Return(nil)
+
+				accountKey := user.AccountAttemptsKey(usr.ID())
+				failed := user.UnmarshalAttemptsFromDB(accountKey, 1, time.Now(), time.Now(), time.Now().Add(time.Hour))
+				attemptRepo := user.MockAttemptRepository{}
+				attemptRepo.On("GetAttempts", accountKey).Return(failed, nil)
+				attemptRepo.On("DeleteAttempts", accountKey).Once().Return(nil)
+
+				sessionRepo := session.MockRepository{}
+				sessionRepo.On("Create", mock.Anything).Once().Return(nil)
+
+				code, err := user.GenerateTOTPCode(twoFactorSecret, time.Now().Add(30*time.Second))
+				assert.Nil(t, err)
+				return fields{tokener: &tokener, userRepo: &userRepo, attemptRepo: &attemptRepo, sessionRepo: &sessionRepo}, code
+			},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Nil(t, err, i)
+				return false
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, code := tt.fieldsFn()
+			h := Handler{
+				userRepo:    fields.userRepo,
+				attemptRepo: fields.attemptRepo,
+				sessionRepo: fields.sessionRepo,
+				tokener:     fields.tokener,
+				params:      params,
 			}
-			got, err := h.Authenticate(tt.args.email, tt.args.password)
-			if !tt.wantErr(t, err, fmt.Sprintf("Authenticate(%v, %v)", tt.args.email, tt.args.password)) {
+			authToken, refreshToken, err := h.VerifyTwoFactor("challengeToken", code, "", "")
+			if tt.wantErr(t, err, fmt.Sprintf("VerifyTwoFactor(%v)", code)) {
 				return
 			}
-			assert.Equalf(t, tt.want, got, "Authenticate(%v, %v)", tt.args.email, tt.args.password)
+			assert.Equal(t, AuthenticationToken{Token: "authToken", Type: authType}, authToken)
+			assert.Equal(t, RefreshToken{Token: "refreshToken"}, refreshToken)
 		})
 	}
 }
 
+// twoFactorSecret fixed secret of the user with two-factor authentication, it is the RFC 6238 SHA1 test secret
+const twoFactorSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func newTwoFactorUser(t *testing.T, hashedPwd string) *user.User {
+	t.Helper()
+	return user.UnmarshalFromDB(
+		"userID",
+		"test",
+		"test@email.com",
+		hashedPwd,
+		user.Author,
+		"",
+		user.NewListOptions(false),
+		0,
+		user.UnmarshalTwoFactorFromDB(twoFactorSecret, true, 0, nil),
+	)
+}
+
 func TestHandler_GenerateRefreshToken(t *testing.T) {
+	usr, err := user.NewUser("test", "test@email.com", "12345678", user.Author)
+	assert.Nil(t, err)
+
 	type fields struct {
-		userRepo user.Repository
-		tokener  tokener
+		userRepo    user.Repository
+		sessionRepo session.Repository
+		tokener     tokener
 	}
 	type args struct {
 		email string
@@ -165,20 +562,68 @@ func TestHandler_GenerateRefreshToken(t *testing.T) {
 		want     RefreshToken
 		wantErr  assert.ErrorAssertionFunc
 	}{
+		{
+			"User does not exist",
+			func() fields {
+				repository := user.MockRepository{}
+				repository.On("GetByEmail", "test@email.com").Return(nil, user.ErrNotFound)
+
+				return fields{
+					userRepo:    &repository,
+					sessionRepo: session.NewMockRepository(t),
+					tokener:     &mockTokener{},
+				}
+			},
+			args{
+				email: "test@email.com",
+			},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, user.ErrNotFound, err, i)
+				return true
+			},
+		},
+		{
+			"Error on session saving",
+			func() fields {
+				repository := user.MockRepository{}
+				repository.On("GetByEmail", "test@email.com").Return(usr, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Create", mock.AnythingOfType("*session.Session")).Return(fmt.Errorf("noSession"))
+
+				return fields{
+					userRepo:    &repository,
+					sessionRepo: sessionRepo,
+					tokener:     &mockTokener{},
+				}
+			},
+			args{
+				email: "test@email.com",
+			},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "noSession", err.Error(), i)
+				return true
+			},
+		},
 		{
 			"Error on token generation",
 			func() fields {
 				repository := user.MockRepository{}
+				repository.On("GetByEmail", "test@email.com").Return(usr, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Create", mock.AnythingOfType("*session.Session")).Return(nil)
 				tokener := mockTokener{}
-				tokener.On("generateToken", "testEmail", mock.IsType(time.Time{})).Return("", fmt.Errorf("noToken"))
+				tokener.On("generateRefreshToken", usr.ID(), mock.IsType(""), mock.IsType(""), mock.IsType(time.Time{})).Return("", fmt.Errorf("noToken"))
 
 				return fields{
-					userRepo: &repository,
-					tokener:  &tokener,
+					userRepo:    &repository,
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
 				}
 			},
 			args{
-				email: "testEmail",
+				email: "test@email.com",
 			},
 			RefreshToken{},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
@@ -190,16 +635,22 @@ func TestHandler_GenerateRefreshToken(t *testing.T) {
 			"Positive case",
 			func() fields {
 				repository := user.MockRepository{}
+				repository.On("GetByEmail", "test@email.com").Return(usr, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Create", mock.MatchedBy(func(s *session.Session) bool {
+					return s.UserID() == usr.ID() && s.UserAgent() == "Mozilla/5.0" && s.IP() == "127.0.0.1"
+				})).Return(nil)
 				tokener := mockTokener{}
-				tokener.On("generateToken", "testEmail", mock.IsType(time.Time{})).Return("validToken", nil)
+				tokener.On("generateRefreshToken", usr.ID(), mock.IsType(""), mock.IsType(""), mock.IsType(time.Time{})).Return("validToken", nil)
 
 				return fields{
-					userRepo: &repository,
-					tokener:  &tokener,
+					userRepo:    &repository,
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
 				}
 			},
 			args{
-				email: "testEmail",
+				email: "test@email.com",
 			},
 			RefreshToken{
 				Token: "validToken",
@@ -214,10 +665,12 @@ func TestHandler_GenerateRefreshToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			args := tt.fieldsFn()
 			h := Handler{
-				userRepo: args.userRepo,
-				tokener:  args.tokener,
+				userRepo:    args.userRepo,
+				sessionRepo: args.sessionRepo,
+				tokener:     args.tokener,
+				params:      Params{RefreshTTL: time.Hour},
 			}
-			got, err := h.GenerateRefreshToken(tt.args.email)
+			got, err := h.GenerateRefreshToken(tt.args.email, "Mozilla/5.0", "127.0.0.1")
 			if !tt.wantErr(t, err, fmt.Sprintf("GenerateRefreshToken(%v)", tt.args.email)) {
 				return
 			}
@@ -227,61 +680,241 @@ func TestHandler_GenerateRefreshToken(t *testing.T) {
 }
 
 func TestHandler_Refresh(t *testing.T) {
+	newSession := func() *session.Session {
+		return session.UnmarshalFromDB("sessionID", "userID", "tokenID", "", "", time.Now(), time.Now(), time.Now().Add(time.Hour))
+	}
+	claims := JWTClaim{
+		SessionID:        "sessionID",
+		RegisteredClaims: jwt.RegisteredClaims{ID: "tokenID", Subject: "userID"},
+	}
+	newUserRepo := func() *user.MockRepository {
+		repository := user.MockRepository{}
+		repository.On("Get", "userID").Return(user.UnmarshalFromDB("userID", "test", "test@email.com", "12345678", user.Author, "", user.NewListOptions(false), 2, user.TwoFactor{}), nil)
+		return &repository
+	}
+
 	type fields struct {
-		userRepo user.Repository
-		tokener  tokener
+		userRepo    user.Repository
+		sessionRepo session.Repository
+		tokener     tokener
 	}
 	type args struct {
 		token string
 	}
 	tests := []struct {
-		name     string
-		fieldsFn func() fields
-		args     args
-		want     AuthenticationToken
-		wantErr  assert.ErrorAssertionFunc
+		name        string
+		fieldsFn    func() fields
+		args        args
+		want        AuthenticationToken
+		wantRefresh RefreshToken
+		wantErr     assert.ErrorAssertionFunc
 	}{
 		{
 			"Error on token parse",
 			func() fields {
-				repository := user.MockRepository{}
 				tokener := mockTokener{}
 				tokener.On("parseToken", "testToken").Return(nil, fmt.Errorf("noValidToken"))
 
 				return fields{
-					userRepo: &repository,
-					tokener:  &tokener,
+					sessionRepo: session.NewMockRepository(t),
+					tokener:     &tokener,
 				}
 			},
 			args{
 				token: "testToken",
 			},
 			AuthenticationToken{},
+			RefreshToken{},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Equal(t, "noValidToken", err.Error(), i)
 				return true
 			},
 		},
 		{
-			"Error on token generation",
+			"Token is expired",
 			func() fields {
-				repository := user.MockRepository{}
 				tokener := mockTokener{}
-				claims := JWTClaim{
-					Email: "testEmail",
+				tokener.On("parseToken", "testToken").Return(&JWTClaim{}, jwt.NewValidationError("token is expired", jwt.ValidationErrorExpired))
+
+				return fields{
+					sessionRepo: session.NewMockRepository(t),
+					tokener:     &tokener,
 				}
+			},
+			args{
+				token: "testToken",
+			},
+			AuthenticationToken{},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrExpiredRefreshToken, err, i)
+				return true
+			},
+		},
+		{
+			"Token without session",
+			func() fields {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "testToken").Return(&JWTClaim{RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}, nil)
+
+				return fields{
+					sessionRepo: session.NewMockRepository(t),
+					tokener:     &tokener,
+				}
+			},
+			args{
+				token: "testToken",
+			},
+			AuthenticationToken{},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrRevokedRefreshToken, err, i)
+				return true
+			},
+		},
+		{
+			"Session is revoked",
+			func() fields {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "testToken").Return(&claims, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Get", "sessionID").Return(nil, session.ErrNotFound)
+
+				return fields{
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
+				}
+			},
+			args{
+				token: "testToken",
+			},
+			AuthenticationToken{},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrRevokedRefreshToken, err, i)
+				return true
+			},
+		},
+		{
+			"Session belongs to another user",
+			func() fields {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "testToken").Return(&JWTClaim{SessionID: "sessionID", RegisteredClaims: jwt.RegisteredClaims{ID: "tokenID", Subject: "anotherUserID"}}, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Get", "sessionID").Return(newSession(), nil)
+
+				return fields{
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
+				}
+			},
+			args{
+				token: "testToken",
+			},
+			AuthenticationToken{},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrRevokedRefreshToken, err, i)
+				return true
+			},
+		},
+		{
+			"Reused token revokes session",
+			func() fields {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "testToken").Return(&JWTClaim{SessionID: "sessionID", RegisteredClaims: jwt.RegisteredClaims{ID: "usedTokenID", Subject: "userID"}}, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Get", "sessionID").Return(newSession(), nil)
+				sessionRepo.On("Delete", "sessionID", "userID").Return(nil)
+
+				return fields{
+					userRepo:    newUserRepo(),
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
+				}
+			},
+			args{
+				token: "testToken",
+			},
+			AuthenticationToken{},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrReusedRefreshToken, err, i)
+				return true
+			},
+		},
+		{
+			"Token is exchanged by parallel refresh",
+			func() fields {
+				tokener := mockTokener{}
 				tokener.On("parseToken", "testToken").Return(&claims, nil)
-				tokener.On("generateToken", "testEmail", mock.IsType(time.Time{})).Return("", fmt.Errorf("noToken"))
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Get", "sessionID").Return(newSession(), nil)
+				sessionRepo.On("Update", mock.AnythingOfType("*session.Session"), "tokenID").Return(session.ErrTokenReused)
+				sessionRepo.On("Delete", "sessionID", "userID").Once().Return(nil)
 
 				return fields{
-					userRepo: &repository,
-					tokener:  &tokener,
+					userRepo:    newUserRepo(),
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
 				}
 			},
 			args{
 				token: "testToken",
 			},
 			AuthenticationToken{},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, ErrReusedRefreshToken, err, i)
+				return true
+			},
+		},
+		{
+			"Error on session saving",
+			func() fields {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "testToken").Return(&claims, nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Get", "sessionID").Return(newSession(), nil)
+				sessionRepo.On("Update", mock.AnythingOfType("*session.Session"), "tokenID").Return(fmt.Errorf("noSession"))
+
+				return fields{
+					userRepo:    newUserRepo(),
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
+				}
+			},
+			args{
+				token: "testToken",
+			},
+			AuthenticationToken{},
+			RefreshToken{},
+			func(t assert.TestingT, err error, i ...interface{}) bool {
+				assert.Equal(t, "noSession", err.Error(), i)
+				return true
+			},
+		},
+		{
+			"Error on token generation",
+			func() fields {
+				tokener := mockTokener{}
+				tokener.On("parseToken", "testToken").Return(&claims, nil)
+				tokener.On("generateToken", "userID", 2, mock.IsType(time.Time{})).Return("", fmt.Errorf("noToken"))
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Get", "sessionID").Return(newSession(), nil)
+				sessionRepo.On("Update", mock.AnythingOfType("*session.Session"), "tokenID").Return(nil)
+
+				return fields{
+					userRepo:    newUserRepo(),
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
+				}
+			},
+			args{
+				token: "testToken",
+			},
+			AuthenticationToken{},
+			RefreshToken{},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Equal(t, "noToken", err.Error(), i)
 				return true
@@ -290,17 +923,20 @@ func TestHandler_Refresh(t *testing.T) {
 		{
 			"Positive Case",
 			func() fields {
-				repository := user.MockRepository{}
 				tokener := mockTokener{}
-				claims := JWTClaim{
-					Email: "testEmail",
-				}
 				tokener.On("parseToken", "testToken").Return(&claims, nil)
-				tokener.On("generateToken", "testEmail", mock.IsType(time.Time{})).Return("validToken", nil)
+				tokener.On("generateToken", "userID", 2, mock.IsType(time.Time{})).Return("validToken", nil)
+				tokener.On("generateRefreshToken", "userID", "sessionID", mock.MatchedBy(func(tokenID string) bool {
+					return tokenID != "" && tokenID != "tokenID"
+				}), mock.IsType(time.Time{})).Return("validRefreshToken", nil)
+				sessionRepo := session.NewMockRepository(t)
+				sessionRepo.On("Get", "sessionID").Return(newSession(), nil)
+				sessionRepo.On("Update", mock.AnythingOfType("*session.Session"), "tokenID").Return(nil)
 
 				return fields{
-					userRepo: &repository,
-					tokener:  &tokener,
+					userRepo:    newUserRepo(),
+					sessionRepo: sessionRepo,
+					tokener:     &tokener,
 				}
 			},
 			args{
@@ -310,6 +946,9 @@ func TestHandler_Refresh(t *testing.T) {
 				Token: "validToken",
 				Type:  authType,
 			},
+			RefreshToken{
+				Token: "validRefreshToken",
+			},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Nil(t, err, i)
 				return false
@@ -320,18 +959,42 @@ func TestHandler_Refresh(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			args := tt.fieldsFn()
 			h := Handler{
-				userRepo: args.userRepo,
-				tokener:  args.tokener,
+				userRepo:    args.userRepo,
+				sessionRepo: args.sessionRepo,
+				tokener:     args.tokener,
+				params:      Params{RefreshTTL: time.Hour},
 			}
-			got, err := h.Refresh(tt.args.token)
+			got, gotRefresh, err := h.Refresh(tt.args.token)
 			if !tt.wantErr(t, err, fmt.Sprintf("Refresh(%v)", tt.args.token)) {
 				return
 			}
 			assert.Equalf(t, tt.want, got, "Refresh(%v)", tt.args.token)
+			assert.Equalf(t, tt.wantRefresh, gotRefresh, "Refresh(%v)", tt.args.token)
 		})
 	}
 }
 
+func TestHandler_Logout(t *testing.T) {
+	claims := JWTClaim{SessionID: "sessionID", RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}
+	tokener := mockTokener{}
+	tokener.On("parseToken", "validToken").Return(&claims, nil)
+	tokener.On("parseToken", "revokedToken").Return(&JWTClaim{SessionID: "revokedID", RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}, nil)
+	tokener.On("parseToken", "invalidToken").Return(nil, fmt.Errorf("noValidToken"))
+
+	sessionRepo := session.NewMockRepository(t)
+	sessionRepo.On("Get", "sessionID").Return(session.UnmarshalFromDB("sessionID", "userID", "tokenID", "", "", time.Now(), time.Now(), time.Now().Add(time.Hour)), nil)
+	sessionRepo.On("Get", "revokedID").Return(nil, session.ErrNotFound)
+	sessionRepo.On("Delete", "sessionID", "userID").Return(nil)
+
+	h := Handler{sessionRepo: sessionRepo, tokener: &tokener}
+	assert.Nil(t, h.Logout("validToken"))
+	assert.Nil(t, h.Logout("revokedToken"))
+	assert.Error(t, h.Logout("invalidToken"))
+
+	assert.Equal(t, "sessionID", h.SessionID("validToken"))
+	assert.Equal(t, "", h.SessionID("invalidToken"))
+}
+
 func TestHandler_Middleware(t *testing.T) {
 	type fields struct {
 		userRepo user.Repository
@@ -376,15 +1039,53 @@ func TestHandler_Middleware(t *testing.T) {
 				assert.True(t, c.IsAborted())
 			},
 		},
+		{
+			"Refresh token is used",
+			func() fields {
+				tokener := mockTokener{}
+				claims := &JWTClaim{SessionID: "sessionID", RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}
+				tokener.On("parseToken", "testToken").Return(claims, nil)
+				return fields{tokener: &tokener, userRepo: &user.MockRepository{}}
+			},
+			func(r *httptest.ResponseRecorder) *gin.Context {
+				c, _ := gin.CreateTestContext(r)
+				c.Request = &http.Request{Header: http.Header{"Authorization": {"Bearer testToken"}}}
+				return c
+			},
+			func(t *testing.T, c *gin.Context, r *httptest.ResponseRecorder, tokener *mockTokener, repo *user.MockRepository) {
+				repo.AssertNotCalled(t, "Get", "userID")
+				assert.Equal(t, http.StatusUnauthorized, r.Code)
+				assert.True(t, c.IsAborted())
+			},
+		},
+		{
+			"Two-factor challenge token is used",
+			func() fields {
+				tokener := mockTokener{}
+				claims := &JWTClaim{Purpose: challengePurpose, RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}
+				tokener.On("parseToken", "testToken").Return(claims, nil)
+				return fields{tokener: &tokener, userRepo: &user.MockRepository{}}
+			},
+			func(r *httptest.ResponseRecorder) *gin.Context {
+				c, _ := gin.CreateTestContext(r)
+				c.Request = &http.Request{Header: http.Header{"Authorization": {"Bearer testToken"}}}
+				return c
+			},
+			func(t *testing.T, c *gin.Context, r *httptest.ResponseRecorder, tokener *mockTokener, repo *user.MockRepository) {
+				repo.AssertNotCalled(t, "Get", "userID")
+				assert.Equal(t, http.StatusUnauthorized, r.Code)
+				assert.True(t, c.IsAborted())
+			},
+		},
 		{
 			"User from claims not exist",
 			func() fields {
 				tokener := mockTokener{}
-				claims := &JWTClaim{Email: "testEmail"}
+				claims := &JWTClaim{RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}
 				tokener.On("parseToken", "testToken").Return(claims, nil)
 
 				userRepo := user.MockRepository{}
-				userRepo.On("GetByEmail", "testEmail").Return(nil, user.ErrNotFound)
+				userRepo.On("Get", "userID").Return(nil, user.ErrNotFound)
 				return fields{tokener: &tokener, userRepo: &userRepo}
 			},
 			func(r *httptest.ResponseRecorder) *gin.Context {
@@ -394,20 +1095,45 @@ func TestHandler_Middleware(t *testing.T) {
 			},
 			func(t *testing.T, c *gin.Context, r *httptest.ResponseRecorder, tokener *mockTokener, repo *user.MockRepository) {
 				tokener.AssertCalled(t, "parseToken", "testToken")
-				repo.AssertCalled(t, "GetByEmail", "testEmail")
+				repo.AssertCalled(t, "Get", "userID")
+				assert.Equal(t, http.StatusUnauthorized, r.Code)
+				assert.True(t, c.IsAborted())
+			},
+		},
+		{
+			"Token version is outdated",
+			func() fields {
+				tokener := mockTokener{}
+				claims := &JWTClaim{TokenVersion: 1, RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}
+				tokener.On("parseToken", "testToken").Return(claims, nil)
+
+				userRepo := user.MockRepository{}
+				userRepo.On("Get", "userID").Return(user.UnmarshalFromDB("userID", "test", "test@email.com", "12345678", user.Admin, "", user.NewListOptions(false), 2, user.TwoFactor{}), nil)
+				return fields{tokener: &tokener, userRepo: &userRepo}
+			},
+			func(r *httptest.ResponseRecorder) *gin.Context {
+				c, _ := gin.CreateTestContext(r)
+				c.Request = &http.Request{Header: http.Header{"Authorization": {"Bearer testToken"}}}
+				return c
+			},
+			func(t *testing.T, c *gin.Context, r *httptest.ResponseRecorder, tokener *mockTokener, repo *user.MockRepository) {
+				repo.AssertCalled(t, "Get", "userID")
 				assert.Equal(t, http.StatusUnauthorized, r.Code)
 				assert.True(t, c.IsAborted())
+
+				_, exist := c.Get(userContextKey)
+				assert.False(t, exist)
 			},
 		},
 		{
 			"Positive case",
 			func() fields {
 				tokener := mockTokener{}
-				claims := &JWTClaim{Email: "test@email.com"}
+				claims := &JWTClaim{TokenVersion: 2, RegisteredClaims: jwt.RegisteredClaims{Subject: "userID"}}
 				tokener.On("parseToken", "testToken").Return(claims, nil)
 
 				userRepo := user.MockRepository{}
-				userRepo.On("GetByEmail", "test@email.com").Return(user.NewUser("test", "test@email.com", "12345678", user.Admin))
+				userRepo.On("Get", "userID").Return(user.UnmarshalFromDB("userID", "test", "test@email.com", "12345678", user.Admin, "", user.NewListOptions(false), 2, user.TwoFactor{}), nil)
 				return fields{tokener: &tokener, userRepo: &userRepo}
 			},
 			func(r *httptest.ResponseRecorder) *gin.Context {
@@ -417,13 +1143,14 @@ func TestHandler_Middleware(t *testing.T) {
 			},
 			func(t *testing.T, c *gin.Context, r *httptest.ResponseRecorder, tokener *mockTokener, repo *user.MockRepository) {
 				tokener.AssertCalled(t, "parseToken", "testToken")
-				repo.AssertCalled(t, "GetByEmail", "test@email.com")
+				repo.AssertCalled(t, "Get", "userID")
 
 				usr, exist := c.Get(userContextKey)
 				assert.True(t, exist)
 
 				authUsr, _ := usr.(User)
 
+				assert.Equal(t, "userID", authUsr.ID)
 				assert.Equal(t, "test@email.com", authUsr.Email)
 				assert.Equal(t, user.Admin, authUsr.Role)
 			},
@@ -447,6 +1174,73 @@ func TestHandler_Middleware(t *testing.T) {
 	}
 }
 
+func TestHandler_Middleware_personalToken(t *testing.T) {
+	const secret = token.SecretPrefix + "testSecret"
+	readToken := token.UnmarshalFromDB("tokenID", "userID", "cron", token.Hash(secret), []string{"read", "tags:write"}, time.Now(), time.Time{}, time.Time{})
+
+	tokenRepo := token.MockRepository{}
+	tokenRepo.On("GetByHash", token.Hash("wdt_unknown")).Return(nil, token.ErrNotFound)
+	tokenRepo.On("GetByHash", token.Hash(secret)).Return(readToken, nil)
+	tokenRepo.On("Update", readToken).Return(nil)
+
+	userRepo := user.MockRepository{}
+	userRepo.On("Get", "userID").Return(user.UnmarshalFromDB("userID", "test", "test@email.com", "12345678", user.Author, "", user.NewListOptions(false), 2, user.TwoFactor{}), nil)
+
+	// JWT tokener is not expected to be called for personal access tokens
+	handler := Handler{userRepo: &userRepo, tokenRepo: &tokenRepo, tokener: &mockTokener{}}
+
+	tests := []struct {
+		name      string
+		secret    string
+		method    string
+		resources []token.Resource
+		want      int
+	}{
+		{"Unknown token", "wdt_unknown", http.MethodGet, []token.Resource{token.Translations}, http.StatusUnauthorized},
+		{"Route accepts auth token only", secret, http.MethodGet, nil, http.StatusForbidden},
+		{"No write scope", secret, http.MethodPost, []token.Resource{token.Translations}, http.StatusForbidden},
+		{"Resource write scope", secret, http.MethodDelete, []token.Resource{token.Tags}, http.StatusOK},
+		{"Read scope", secret, http.MethodGet, []token.Resource{token.Translations}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(r)
+			c.Request = &http.Request{Method: tt.method, Header: http.Header{"Authorization": {"Bearer " + tt.secret}}}
+			handler.Middleware(tt.resources...)(c)
+
+			usr, exist := c.Get(userContextKey)
+			if tt.want != http.StatusOK {
+				assert.Equal(t, tt.want, r.Code)
+				assert.True(t, c.IsAborted())
+				assert.False(t, exist)
+				return
+			}
+
+			assert.False(t, c.IsAborted())
+			assert.Equal(t, User{ID: "userID", Email: "test@email.com", Role: user.Author}, usr)
+		})
+	}
+
+	tokenRepo.AssertNumberOfCalls(t, "Update", 1)
+	assert.WithinDuration(t, time.Now(), readToken.LastUsedAt(), time.Second)
+}
+
+func TestHandler_TokenAllows(t *testing.T) {
+	handler := Handler{}
+	tagsToken := token.UnmarshalFromDB("tokenID", "userID", "cron", "hash", []string{"translations:write", "tags:write"}, time.Now(), time.Time{}, time.Time{})
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	assert.True(t, handler.TokenAllows(c, token.Write, token.Langs, token.Tags), "auth token request is allowed")
+
+	c.Set(personalTokenContextKey, tagsToken)
+	assert.True(t, handler.TokenAllows(c, token.Write, token.Tags))
+	assert.False(t, handler.TokenAllows(c, token.Write, token.Langs, token.Tags))
+
+	c.Set(personalTokenContextKey, "test")
+	assert.False(t, handler.TokenAllows(c, token.Write, token.Tags))
+}
+
 func TestHandler_tokenFromHeader(t *testing.T) {
 	type fields struct {
 		userRepo user.Repository
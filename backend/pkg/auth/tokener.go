@@ -5,17 +5,46 @@ import (
 	"time"
 )
 
+const challengePurpose = "2fa"
+
 type jwtTokener struct {
 	params Params
 }
 
-func (t jwtTokener) generateToken(email string, expiresAt time.Time) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512, JWTClaim{
-		Email: email,
+func (t jwtTokener) generateToken(userID string, tokenVersion int, expiresAt time.Time) (string, error) {
+	return t.sign(JWTClaim{
+		TokenVersion: tokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+}
+
+func (t jwtTokener) generateRefreshToken(userID, sessionID, tokenID string, expiresAt time.Time) (string, error) {
+	return t.sign(JWTClaim{
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	})
+}
+
+func (t jwtTokener) generateChallengeToken(userID string, tokenVersion int, expiresAt time.Time) (string, error) {
+	return t.sign(JWTClaim{
+		TokenVersion: tokenVersion,
+		Purpose:      challengePurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+}
+
+func (t jwtTokener) sign(claims JWTClaim) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
 
 	tokenString, err := token.SignedString([]byte(t.params.Secret))
 	if err != nil {
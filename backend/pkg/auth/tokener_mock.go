@@ -1,32 +1,84 @@
-// Code generated by mockery v2.14.0. DO NOT EDIT.
+// Code generated by mockery v2.23.1. DO NOT EDIT.
 
 package auth
 
 import (
-	mock "github.com/stretchr/testify/mock"
-
 	time "time"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
-// mockTokener is an autogenerated mock type for the mockTokener type
+// mockery --name=tokener --filename=tokener_mock.go --output=./ --structname=mockTokener --inpackage
+// mockTokener is an autogenerated mock type for the tokener type
 type mockTokener struct {
 	mock.Mock
 }
 
-// generateToken provides a mock function with given fieldsFn: email, expiresAt
-func (_m *mockTokener) generateToken(email string, expiresAt time.Time) (string, error) {
-	ret := _m.Called(email, expiresAt)
+// generateChallengeToken provides a mock function with given fields: userID, tokenVersion, expiresAt
+func (_m *mockTokener) generateChallengeToken(userID string, tokenVersion int, expiresAt time.Time) (string, error) {
+	ret := _m.Called(userID, tokenVersion, expiresAt)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, time.Time) (string, error)); ok {
+		return rf(userID, tokenVersion, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, time.Time) string); ok {
+		r0 = rf(userID, tokenVersion, expiresAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, time.Time) error); ok {
+		r1 = rf(userID, tokenVersion, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// generateRefreshToken provides a mock function with given fields: userID, sessionID, tokenID, expiresAt
+func (_m *mockTokener) generateRefreshToken(userID string, sessionID string, tokenID string, expiresAt time.Time) (string, error) {
+	ret := _m.Called(userID, sessionID, tokenID, expiresAt)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, time.Time) string); ok {
-		r0 = rf(email, expiresAt)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time) (string, error)); ok {
+		return rf(userID, sessionID, tokenID, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time) string); ok {
+		r0 = rf(userID, sessionID, tokenID, expiresAt)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
+	if rf, ok := ret.Get(1).(func(string, string, string, time.Time) error); ok {
+		r1 = rf(userID, sessionID, tokenID, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// generateToken provides a mock function with given fields: userID, tokenVersion, expiresAt
+func (_m *mockTokener) generateToken(userID string, tokenVersion int, expiresAt time.Time) (string, error) {
+	ret := _m.Called(userID, tokenVersion, expiresAt)
+
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
-		r1 = rf(email, expiresAt)
+	if rf, ok := ret.Get(0).(func(string, int, time.Time) (string, error)); ok {
+		return rf(userID, tokenVersion, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, time.Time) string); ok {
+		r0 = rf(userID, tokenVersion, expiresAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, time.Time) error); ok {
+		r1 = rf(userID, tokenVersion, expiresAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -34,11 +86,15 @@ func (_m *mockTokener) generateToken(email string, expiresAt time.Time) (string,
 	return r0, r1
 }
 
-// parseToken provides a mock function with given fieldsFn: signedToken
+// parseToken provides a mock function with given fields: signedToken
 func (_m *mockTokener) parseToken(signedToken string) (*JWTClaim, error) {
 	ret := _m.Called(signedToken)
 
 	var r0 *JWTClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*JWTClaim, error)); ok {
+		return rf(signedToken)
+	}
 	if rf, ok := ret.Get(0).(func(string) *JWTClaim); ok {
 		r0 = rf(signedToken)
 	} else {
@@ -47,7 +103,6 @@ func (_m *mockTokener) parseToken(signedToken string) (*JWTClaim, error) {
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
 		r1 = rf(signedToken)
 	} else {
@@ -57,13 +112,13 @@ func (_m *mockTokener) parseToken(signedToken string) (*JWTClaim, error) {
 	return r0, r1
 }
 
-type mockConstructorTestingTnewTokener interface {
+type mockConstructorTestingTnewMockTokener interface {
 	mock.TestingT
 	Cleanup(func())
 }
 
-// newTokener creates a new instance of mockTokener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func newTokener(t mockConstructorTestingTnewTokener) *mockTokener {
+// newMockTokener creates a new instance of mockTokener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func newMockTokener(t mockConstructorTestingTnewMockTokener) *mockTokener {
 	mock := &mockTokener{}
 	mock.Mock.Test(t)
 
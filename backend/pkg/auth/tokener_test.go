@@ -9,7 +9,7 @@ import (
 
 func TestJwtToken_parseExpiredToken(t *testing.T) {
 	tokener := jwtTokener{params: Params{Secret: "secret"}}
-	token, err := tokener.generateToken("testEmail", time.Now().Add(-time.Minute))
+	token, err := tokener.generateToken("testUser", 1, time.Now().Add(-time.Minute))
 	assert.Nil(t, err)
 
 	_, err = tokener.parseToken(token)
@@ -18,11 +18,38 @@ func TestJwtToken_parseExpiredToken(t *testing.T) {
 
 func TestJwtToken_parseGeneratedToken(t *testing.T) {
 	tokener := jwtTokener{params: Params{Secret: "secret"}}
-	email := "testEmail"
-	token, err := tokener.generateToken(email, time.Now().Add(time.Minute))
+	userID := "testUser"
+	token, err := tokener.generateToken(userID, 3, time.Now().Add(time.Minute))
 	assert.Nil(t, err)
 
 	claims, err := tokener.parseToken(token)
 	assert.Nil(t, err)
-	assert.Equalf(t, email, claims.Email, "jwtTokener::parseToken - email from generated token (%s) parsed correctly", token)
+	assert.Equalf(t, userID, claims.Subject, "jwtTokener::parseToken - user id from generated token (%s) parsed correctly", token)
+	assert.Equal(t, 3, claims.TokenVersion)
+	assert.Empty(t, claims.SessionID)
+}
+
+func TestJwtToken_parseGeneratedRefreshToken(t *testing.T) {
+	tokener := jwtTokener{params: Params{Secret: "secret"}}
+	token, err := tokener.generateRefreshToken("testUser", "sessionID", "tokenID", time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+
+	claims, err := tokener.parseToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "testUser", claims.Subject)
+	assert.Equal(t, "sessionID", claims.SessionID)
+	assert.Equal(t, "tokenID", claims.ID)
+}
+
+func TestJwtToken_parseGeneratedChallengeToken(t *testing.T) {
+	tokener := jwtTokener{params: Params{Secret: "secret"}}
+	token, err := tokener.generateChallengeToken("testUser", 2, time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+
+	claims, err := tokener.parseToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "testUser", claims.Subject)
+	assert.Equal(t, 2, claims.TokenVersion)
+	assert.Equal(t, challengePurpose, claims.Purpose)
+	assert.Empty(t, claims.SessionID)
 }
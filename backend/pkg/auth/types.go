@@ -6,8 +6,12 @@ import (
 	"time"
 )
 
+// JWTClaim is used for auth, refresh and two-factor challenge tokens, the user id is kept in sub claim,
+// auth and challenge tokens keep user token version, refresh token keeps its session id and its own id in jti claim
 type JWTClaim struct {
-	Email string `json:"email"`
+	TokenVersion int    `json:"ver,omitempty"`
+	SessionID    string `json:"sid,omitempty"`
+	Purpose      string `json:"pur,omitempty"` // Purpose is set for tokens which can not be used for the auth
 	jwt.RegisteredClaims
 }
 
@@ -31,7 +35,10 @@ func (u User) IsAdmin() bool {
 }
 
 type Params struct {
-	AuthTTL    time.Duration
-	RefreshTTL time.Duration
-	Secret     string
+	AuthTTL        time.Duration
+	RefreshTTL     time.Duration
+	ChallengeTTL   time.Duration // ChallengeTTL time to pass the second step of sign-in for users with two-factor authentication
+	Secret         string
+	AccountLockout user.LockoutPolicy // AccountLockout limits failed sign-in attempts to the account
+	IPLockout      user.LockoutPolicy // IPLockout limits failed sign-in attempts from the client ip
 }
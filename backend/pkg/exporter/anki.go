@@ -0,0 +1,255 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/macyan13/webdict/backend/pkg/sqlite"
+	"hash/fnv"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AnkiModelID ID of the note type of exported notes, it is kept the same for all exports,
+// so Anki reuses the note type on repeated imports
+const AnkiModelID int64 = 1680307200000
+
+// AnkiFields names of the exported note fields
+var AnkiFields = []string{"Source", "Transcription", "Target", "Example"}
+
+// ankiFieldSeparator separates note fields in Anki notes table
+const ankiFieldSeparator = "\x1f"
+
+const ankiFrontTemplate = "{{Source}}{{#Transcription}}<br><span class=transcription>[{{Transcription}}]</span>{{/Transcription}}"
+const ankiBackTemplate = "{{FrontSide}}<hr id=answer>{{Target}}{{#Example}}<br><br><i>{{Example}}</i>{{/Example}}"
+const ankiCSS = ".card {font-family: arial; font-size: 20px; text-align: center; color: black; background-color: white;}\n" +
+	".transcription {color: grey;}"
+
+var htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// ankiSchema tables and indexes of Anki collection of schema version 11 which is supported by all Anki versions
+var ankiSchema = []struct {
+	name    string
+	sql     string
+	indexes []sqlite.Index
+}{
+	{
+		"col",
+		"CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null, ver integer not null, " +
+			"dty integer not null, usn integer not null, ls integer not null, conf text not null, models text not null, decks text not null, " +
+			"dconf text not null, tags text not null)",
+		nil,
+	},
+	{
+		"notes",
+		"CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null, usn integer not null, " +
+			"tags text not null, flds text not null, sfld integer not null, csum integer not null, flags integer not null, data text not null)",
+		[]sqlite.Index{
+			{Name: "ix_notes_usn", SQL: "CREATE INDEX ix_notes_usn on notes (usn)", Columns: []int{4}},
+			{Name: "ix_notes_csum", SQL: "CREATE INDEX ix_notes_csum on notes (csum)", Columns: []int{8}},
+		},
+	},
+	{
+		"cards",
+		"CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null, ord integer not null, mod integer not null, " +
+			"usn integer not null, type integer not null, queue integer not null, due integer not null, ivl integer not null, " +
+			"factor integer not null, reps integer not null, lapses integer not null, left integer not null, odue integer not null, " +
+			"odid integer not null, flags integer not null, data text not null)",
+		[]sqlite.Index{
+			{Name: "ix_cards_usn", SQL: "CREATE INDEX ix_cards_usn on cards (usn)", Columns: []int{5}},
+			{Name: "ix_cards_nid", SQL: "CREATE INDEX ix_cards_nid on cards (nid)", Columns: []int{1}},
+			{Name: "ix_cards_sched", SQL: "CREATE INDEX ix_cards_sched on cards (did, queue, due)", Columns: []int{2, 7, 8}},
+		},
+	},
+	{
+		"revlog",
+		"CREATE TABLE revlog (id integer primary key, cid integer not null, usn integer not null, ease integer not null, ivl integer not null, " +
+			"lastIvl integer not null, factor integer not null, time integer not null, type integer not null)",
+		[]sqlite.Index{
+			{Name: "ix_revlog_usn", SQL: "CREATE INDEX ix_revlog_usn on revlog (usn)", Columns: []int{2}},
+			{Name: "ix_revlog_cid", SQL: "CREATE INDEX ix_revlog_cid on revlog (cid)", Columns: []int{1}},
+		},
+	},
+	{
+		"graves",
+		"CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null)",
+		nil,
+	},
+}
+
+// WriteAnki writes Anki package with a deck of new cards, source and transcription go on the front,
+// targets and examples go on the back, views are expected to be sanitized as Anki fields are HTML
+func WriteAnki(w io.Writer, deckName string, views []query.TranslationView) error {
+	now := time.Now()
+	deckID := ankiDeckID(deckName)
+
+	col, err := ankiCollection(now, deckID, deckName)
+	if err != nil {
+		return err
+	}
+
+	notes := make([]sqlite.Row, 0, len(views))
+	cards := make([]sqlite.Row, 0, len(views))
+	for i, view := range views {
+		id := now.UnixMilli() + int64(i)
+		fields := ankiFields(view)
+
+		notes = append(notes, sqlite.Row{RowID: id, Values: []interface{}{
+			nil, view.ID, AnkiModelID, now.Unix(), int64(-1), ankiTags(view), strings.Join(fields, ankiFieldSeparator),
+			fields[0], ankiChecksum(fields[0]), int64(0), "",
+		}})
+		cards = append(cards, sqlite.Row{RowID: id, Values: []interface{}{
+			nil, id, deckID, int64(0), now.Unix(), int64(-1), int64(0), int64(0), int64(i + 1),
+			int64(0), int64(0), int64(0), int64(0), int64(0), int64(0), int64(0), int64(0), "",
+		}})
+	}
+
+	rows := map[string][]sqlite.Row{"col": {col}, "notes": notes, "cards": cards}
+	tables := make([]sqlite.Table, 0, len(ankiSchema))
+	for _, table := range ankiSchema {
+		tables = append(tables, sqlite.Table{Name: table.name, SQL: table.sql, Rows: rows[table.name], Indexes: table.indexes})
+	}
+
+	db := bytes.Buffer{}
+	if err = sqlite.Write(&db, tables); err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{"collection.anki2", db.Bytes()},
+		{"media", []byte("{}")},
+	}
+
+	for _, entry := range entries {
+		file, err := archive.Create(entry.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err = file.Write(entry.data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func ankiFields(view query.TranslationView) []string {
+	var targets, examples []string
+	for _, sense := range view.Senses {
+		if sense.Target != "" {
+			targets = append(targets, sense.Target)
+		}
+		if sense.Example != "" {
+			examples = append(examples, sense.Example)
+		}
+	}
+
+	return []string{view.Source, view.Transcription, strings.Join(targets, "<br>"), strings.Join(examples, "<br>")}
+}
+
+// ankiTags returns space separated names of all translation tags, spaces inside names are replaced as Anki tags can not contain them
+func ankiTags(view query.TranslationView) string {
+	var tags []string
+	seen := map[string]struct{}{}
+
+	for _, sense := range view.Senses {
+		for _, tag := range sense.Tags {
+			name := strings.Join(strings.Fields(tag.Name), "_")
+			if _, ok := seen[name]; ok || name == "" {
+				continue
+			}
+			seen[name] = struct{}{}
+			tags = append(tags, name)
+		}
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(tags, " ") + " "
+}
+
+// ankiChecksum returns checksum of the first field Anki uses to find duplicates, first 8 hex digits of SHA1 of the field text without HTML
+func ankiChecksum(field string) int64 {
+	sum := sha1.Sum([]byte(htmlTagRegexp.ReplaceAllString(field, "")))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+// ankiDeckID returns deck ID derived from its name, so Anki puts cards of repeated exports to the same deck
+func ankiDeckID(name string) int64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return AnkiModelID + int64(h.Sum32())
+}
+
+func ankiCollection(now time.Time, deckID int64, deckName string) (sqlite.Row, error) {
+	fields := make([]map[string]interface{}, 0, len(AnkiFields))
+	for i, name := range AnkiFields {
+		fields = append(fields, map[string]interface{}{
+			"name": name, "ord": i, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []string{},
+		})
+	}
+
+	models := map[string]interface{}{
+		fmt.Sprint(AnkiModelID): map[string]interface{}{
+			"id": AnkiModelID, "name": "Webdict", "type": 0, "mod": now.Unix(), "usn": -1, "sortf": 0, "did": deckID,
+			"tmpls": []map[string]interface{}{{
+				"name": "Card 1", "ord": 0, "qfmt": ankiFrontTemplate, "afmt": ankiBackTemplate, "did": nil, "bqfmt": "", "bafmt": "",
+			}},
+			"flds":      fields,
+			"css":       ankiCSS,
+			"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+			"latexPost": "\\end{document}",
+			"latexsvg":  false,
+			"tags":      []string{},
+			"vers":      []string{},
+			"req":       []interface{}{[]interface{}{0, "any", []int{0}}},
+		},
+	}
+
+	deck := func(id int64, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "desc": "", "mod": now.Unix(), "usn": -1, "collapsed": false, "browserCollapsed": false,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+			"dyn": 0, "conf": 1, "extendNew": 10, "extendRev": 50,
+		}
+	}
+	decks := map[string]interface{}{"1": deck(1, "Default"), fmt.Sprint(deckID): deck(deckID, deckName)}
+
+	dconf := map[string]interface{}{
+		"1": map[string]interface{}{
+			"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+			"new":   map[string]interface{}{"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": 2500, "order": 1, "perDay": 20, "bury": false, "separate": true},
+			"lapse": map[string]interface{}{"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0},
+			"rev":   map[string]interface{}{"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "ivlFct": 1, "maxIvl": 36500, "bury": false, "minSpace": 1},
+		},
+	}
+
+	conf := map[string]interface{}{
+		"nextPos": 1, "estTimes": true, "activeDecks": []int64{deckID}, "sortType": "noteFld", "timeLim": 0, "sortBackwards": false,
+		"addToCur": true, "curDeck": deckID, "newSpread": 0, "dueCounts": true, "curModel": fmt.Sprint(AnkiModelID), "collapseTime": 1200,
+	}
+
+	values := []interface{}{nil, now.Unix(), now.UnixMilli(), now.UnixMilli(), int64(11), int64(0), int64(0), int64(0)}
+	for _, data := range []interface{}{conf, models, decks, dconf, map[string]interface{}{}} {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return sqlite.Row{}, err
+		}
+		values = append(values, string(encoded))
+	}
+
+	return sqlite.Row{RowID: 1, Values: values}, nil
+}
@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestWriteAnki(t *testing.T) {
+	buf := bytes.Buffer{}
+	err := WriteAnki(&buf, "EN", []query.TranslationView{
+		{ID: "id1", Source: "go", Senses: []query.SenseView{{Target: "gehen"}}},
+		{ID: "id2", Source: "run", Senses: []query.SenseView{{Target: "laufen"}}},
+	})
+	assert.Nil(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(archive.File))
+	assert.Equal(t, "collection.anki2", archive.File[0].Name)
+	assert.Equal(t, "media", archive.File[1].Name)
+
+	collection := readZipFile(t, archive.File[0])
+	assert.Equal(t, "SQLite format 3\x00", string(collection[:16]))
+	assert.Equal(t, "{}", string(readZipFile(t, archive.File[1])))
+}
+
+func TestAnkiFields(t *testing.T) {
+	view := query.TranslationView{
+		Source:        "go",
+		Transcription: "gəʊ",
+		Senses: []query.SenseView{
+			{Target: "gehen", Example: "let's <b>go</b>"},
+			{Target: "fahren"},
+			{Target: "laufen", Example: "go home"},
+		},
+	}
+
+	assert.Equal(t, []string{"go", "gəʊ", "gehen<br>fahren<br>laufen", "let's <b>go</b><br>go home"}, ankiFields(view))
+}
+
+func TestAnkiTags(t *testing.T) {
+	view := query.TranslationView{
+		Senses: []query.SenseView{
+			{Tags: []query.TagView{{Name: "phrasal verb"}, {Name: "A1"}}},
+			{Tags: []query.TagView{{Name: "A1"}}},
+		},
+	}
+
+	assert.Equal(t, " phrasal_verb A1 ", ankiTags(view))
+	assert.Equal(t, "", ankiTags(query.TranslationView{}))
+}
+
+func TestAnkiChecksum(t *testing.T) {
+	assert.Equal(t, int64(516249766), ankiChecksum("go"))
+	assert.Equal(t, ankiChecksum("go"), ankiChecksum("<b>go</b>"))
+}
+
+func readZipFile(t *testing.T, file *zip.File) []byte {
+	reader, err := file.Open()
+	assert.Nil(t, err)
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	return data
+}
@@ -0,0 +1,286 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/sqlite"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCollectionSize limits size of unpacked Anki collection
+const maxCollectionSize = 200 << 20
+
+// ankiFieldSeparator separates note fields in Anki notes table
+const ankiFieldSeparator = "\x1f"
+
+// ankiFieldNames names of note fields mapped to translation fields, the first found field is used
+var ankiFieldNames = struct {
+	source, transcription, target, example []string
+}{
+	source:        []string{"source", "front", "word", "expression"},
+	transcription: []string{"transcription", "reading", "pronunciation", "ipa"},
+	target:        []string{"target", "back", "meaning", "translation"},
+	example:       []string{"example", "sentence", "context"},
+}
+
+var (
+	htmlLineBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>|</?div[^>]*>`)
+	htmlTagRegexp       = regexp.MustCompile(`<[^>]*>`)
+	ankiSoundRegexp     = regexp.MustCompile(`\[sound:[^\]]*]`)
+)
+
+// ankiModel note type with translation fields positions, -1 means the field is missing
+type ankiModel struct {
+	source, transcription, target, example int
+}
+
+// ReadAnki reads notes of Anki package, note fields are mapped to translation fields by their names,
+// notes of types without known names get source from the first field and target from the second one
+func ReadAnki(r io.ReaderAt, size int64) ([]command.ImportRow, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("not an Anki package: %v", err)
+	}
+
+	data, err := readCollection(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sqlite.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := readAnkiModels(db)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := db.Records("notes")
+	if err != nil {
+		return nil, fmt.Errorf("can not read notes: %w", err)
+	}
+
+	rows := make([]command.ImportRow, 0, len(notes))
+	for i, note := range notes {
+		mid, _ := note["mid"].(int64)
+		model, ok := models[mid]
+		if !ok {
+			model = ankiModel{source: 0, transcription: -1, target: 1, example: -1}
+		}
+
+		flds, _ := note["flds"].(string)
+		fields := strings.Split(flds, ankiFieldSeparator)
+		tags, _ := note["tags"].(string)
+
+		rows = append(rows, command.ImportRow{
+			Line:          i + 1,
+			Source:        ankiText(fields, model.source, " "),
+			Transcription: ankiText(fields, model.transcription, " "),
+			Target:        ankiText(fields, model.target, "; "),
+			Example:       ankiText(fields, model.example, " "),
+			Tags:          tagNames(strings.Fields(tags)),
+		})
+	}
+
+	return rows, nil
+}
+
+// readCollection returns unpacked collection database, the newer collection.anki21 is preferred
+// as collection.anki2 contains only a stub note in packages exported for new Anki versions
+func readCollection(archive *zip.Reader) ([]byte, error) {
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	file, ok := files["collection.anki21"]
+	if !ok {
+		file, ok = files["collection.anki2"]
+	}
+
+	if !ok {
+		if _, ok = files["collection.anki21b"]; ok {
+			return nil, errors.New("Anki package of the latest format is not supported, export it with \"Support older Anki versions\" option")
+		}
+		return nil, errors.New("Anki package does not contain collection")
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(io.LimitReader(content, maxCollectionSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxCollectionSize {
+		return nil, fmt.Errorf("max size of Anki collection is %d bytes", maxCollectionSize)
+	}
+
+	return data, nil
+}
+
+// readAnkiModels returns note types of the collection by their IDs, the collection of schema 18
+// keeps note type fields in the separate table instead of the collection JSON
+func readAnkiModels(db *sqlite.Database) (map[int64]ankiModel, error) {
+	col, err := db.Records("col")
+	if err != nil {
+		return nil, fmt.Errorf("can not read collection: %w", err)
+	}
+
+	if len(col) == 0 {
+		return nil, errors.New("Anki collection is empty")
+	}
+
+	var fieldNames map[int64][]string
+	if data, _ := col[0]["models"].(string); data != "" && data != "{}" {
+		fieldNames, err = parseAnkiModels(data)
+	} else {
+		fieldNames, err = readAnkiFields(db)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	models := make(map[int64]ankiModel, len(fieldNames))
+	for id, names := range fieldNames {
+		model := ankiModel{
+			source:        fieldPosition(names, ankiFieldNames.source),
+			transcription: fieldPosition(names, ankiFieldNames.transcription),
+			target:        fieldPosition(names, ankiFieldNames.target),
+			example:       fieldPosition(names, ankiFieldNames.example),
+		}
+
+		if model.source < 0 {
+			model.source = 0
+		}
+
+		if model.target < 0 && len(names) > 1 {
+			model.target = 1
+		}
+
+		models[id] = model
+	}
+
+	return models, nil
+}
+
+// parseAnkiModels returns field names of note types from the collection JSON
+func parseAnkiModels(data string) (map[int64][]string, error) {
+	var raw map[string]struct {
+		ID   int64 `json:"id"`
+		Flds []struct {
+			Name string `json:"name"`
+			Ord  int    `json:"ord"`
+		} `json:"flds"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("can not parse note types: %v", err)
+	}
+
+	fieldNames := make(map[int64][]string, len(raw))
+	for _, m := range raw {
+		sort.Slice(m.Flds, func(i, j int) bool {
+			return m.Flds[i].Ord < m.Flds[j].Ord
+		})
+
+		for _, fld := range m.Flds {
+			fieldNames[m.ID] = append(fieldNames[m.ID], fld.Name)
+		}
+	}
+
+	return fieldNames, nil
+}
+
+// readAnkiFields returns field names of note types from the fields table
+func readAnkiFields(db *sqlite.Database) (map[int64][]string, error) {
+	fields, err := db.Records("fields")
+	if err == sqlite.ErrTableNotFound {
+		return map[int64][]string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("can not read note types: %w", err)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		ordI, _ := fields[i]["ord"].(int64)
+		ordJ, _ := fields[j]["ord"].(int64)
+		return ordI < ordJ
+	})
+
+	fieldNames := map[int64][]string{}
+	for _, field := range fields {
+		id, _ := field["ntid"].(int64)
+		name, _ := field["name"].(string)
+		fieldNames[id] = append(fieldNames[id], name)
+	}
+
+	return fieldNames, nil
+}
+
+// fieldPosition returns position of the first field with one of the names, -1 if it is not found
+func fieldPosition(fields, names []string) int {
+	for _, name := range names {
+		for i, field := range fields {
+			if strings.EqualFold(strings.TrimSpace(field), name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// ankiText returns text of the field without HTML and media references, line breaks are replaced by the separator
+func ankiText(fields []string, position int, lineSeparator string) string {
+	if position < 0 || position >= len(fields) {
+		return ""
+	}
+
+	text := ankiSoundRegexp.ReplaceAllString(fields[position], "")
+	text = htmlLineBreakRegexp.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(htmlTagRegexp.ReplaceAllString(text, ""))
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, lineSeparator)
+}
+
+// tagNames returns names cut to the max tag name length, so long names do not fail the rows
+func tagNames(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if utf8.RuneCountInString(name) > tag.MaxNameLength {
+			name = strings.TrimSpace(string([]rune(name)[:tag.MaxNameLength]))
+		}
+		tags = append(tags, name)
+	}
+
+	return tags
+}
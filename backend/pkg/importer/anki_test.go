@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testAnkiModels = `{
+	"1": {"id": 1, "flds": [{"name": "Back", "ord": 1}, {"name": "Front", "ord": 0}]},
+	"2": {"id": 2, "flds": [{"name": "Word", "ord": 0}, {"name": "IPA", "ord": 1}, {"name": "Meaning", "ord": 2}, {"name": "Sentence", "ord": 3}]},
+	"3": {"id": 3, "flds": [{"name": "Question", "ord": 0}, {"name": "Answer", "ord": 1}]}
+}`
+
+func TestReadAnki(t *testing.T) {
+	data := ankiPackage(t, "collection.anki21", testAnkiModels, nil, []sqlite.Row{
+		{RowID: 3, Values: []interface{}{nil, int64(3), "q\x1fa", ""}},
+		{RowID: 1, Values: []interface{}{nil, int64(1), "<b>go</b>&nbsp;on\x1fgehen<br>weitergehen<div>los</div>", " verb A1 "}},
+		{RowID: 2, Values: []interface{}{nil, int64(2), "run[sound:run.mp3]\x1frʌn\x1frennen\x1f<i>Run &amp; hide</i>", "a_very_long_tag_name_exceeding_the_limit"}},
+		{RowID: 4, Values: []interface{}{nil, int64(100), "unknown\x1ftype\x1fignored", ""}},
+	})
+
+	rows, err := ReadAnki(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+	assert.Equal(t, []command.ImportRow{
+		{Line: 1, Source: "go on", Target: "gehen; weitergehen; los", Tags: []string{"verb", "A1"}},
+		{Line: 2, Source: "run", Transcription: "rʌn", Target: "rennen", Example: "Run & hide", Tags: []string{"a_very_long_tag_name_exceeding"}},
+		{Line: 3, Source: "q", Target: "a"},
+		{Line: 4, Source: "unknown", Target: "type"},
+	}, rows)
+}
+
+func TestReadAnki_FieldsTable(t *testing.T) {
+	data := ankiPackage(t, "collection.anki2", "{}", []sqlite.Row{
+		{Values: []interface{}{int64(1), int64(1), "Translation"}},
+		{Values: []interface{}{int64(1), int64(0), "Expression"}},
+	}, []sqlite.Row{
+		{RowID: 1, Values: []interface{}{nil, int64(1), "go\x1fgehen", ""}},
+	})
+
+	rows, err := ReadAnki(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+	assert.Equal(t, []command.ImportRow{{Line: 1, Source: "go", Target: "gehen"}}, rows)
+}
+
+func TestReadAnki_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data func() []byte
+	}{
+		{"Not a zip", func() []byte { return []byte("text") }},
+		{"Latest format", func() []byte { return zipFiles(t, map[string][]byte{"collection.anki21b": {1}}) }},
+		{"No collection", func() []byte { return zipFiles(t, map[string][]byte{"media": []byte("{}")}) }},
+		{"Not a database", func() []byte { return zipFiles(t, map[string][]byte{"collection.anki2": []byte("text")}) }},
+		{"Invalid models", func() []byte { return ankiPackage(t, "collection.anki2", "[", nil, nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data()
+			_, err := ReadAnki(bytes.NewReader(data), int64(len(data)))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func ankiPackage(t *testing.T, name, models string, fields, notes []sqlite.Row) []byte {
+	tables := []sqlite.Table{
+		{Name: "col", SQL: "CREATE TABLE col (id integer primary key, models text not null)", Rows: []sqlite.Row{{RowID: 1, Values: []interface{}{nil, models}}}},
+		{Name: "notes", SQL: "CREATE TABLE notes (id integer primary key, mid integer not null, flds text not null, tags text not null)", Rows: notes},
+	}
+
+	if fields != nil {
+		tables = append(tables, sqlite.Table{
+			Name:         "fields",
+			SQL:          "CREATE TABLE fields (ntid integer NOT NULL, ord integer NOT NULL, name text NOT NULL, PRIMARY KEY (ntid, ord)) without rowid",
+			Rows:         fields,
+			WithoutRowID: true,
+		})
+	}
+
+	db := bytes.Buffer{}
+	assert.Nil(t, sqlite.Write(&db, tables))
+	return zipFiles(t, map[string][]byte{name: db.Bytes()})
+}
+
+func zipFiles(t *testing.T, files map[string][]byte) []byte {
+	buf := bytes.Buffer{}
+	archive := zip.NewWriter(&buf)
+	for name, data := range files {
+		file, err := archive.Create(name)
+		assert.Nil(t, err)
+		_, err = file.Write(data)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, archive.Close())
+	return buf.Bytes()
+}
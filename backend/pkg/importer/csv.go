@@ -0,0 +1,195 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultTagSeparator separates tag names in the tags column when other separator is not set
+const DefaultTagSeparator = ";"
+
+// utf8BOM is added by spreadsheet editors to the beginning of exported files
+const utf8BOM = "\ufeff"
+
+// CSVOptions defines how the delimited word list is read
+type CSVOptions struct {
+	Delimiter    rune // Delimiter is comma for CSV and tab for TSV
+	Header       bool // Header the first line contains column names
+	Columns      Columns
+	TagSeparator string
+}
+
+// Columns maps translation fields to columns, every column is set by number starting from 1 or by header name,
+// empty value means the field is not imported. Without any column set source and target are read from the first two columns
+// or from columns with the same name in header
+type Columns struct {
+	Source        string
+	Transcription string
+	Target        string
+	Example       string
+	Tags          string
+	Lang          string
+}
+
+// columnIndexes zero based indexes of the mapped columns, -1 means the column is not mapped
+type columnIndexes struct {
+	source, transcription, target, example, tags, lang int
+}
+
+// ReadCSV reads translations from the delimited word list, empty lines are skipped
+func ReadCSV(r io.Reader, opts CSVOptions) ([]command.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	if opts.TagSeparator == "" {
+		opts.TagSeparator = DefaultTagSeparator
+	}
+
+	var header []string
+	if opts.Header {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		header = record
+	}
+
+	indexes, err := resolveColumns(opts.Columns, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []command.ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := command.ImportRow{
+			Line:          line,
+			Source:        field(record, indexes.source),
+			Transcription: field(record, indexes.transcription),
+			Target:        field(record, indexes.target),
+			Example:       field(record, indexes.example),
+			Lang:          field(record, indexes.lang),
+		}
+
+		if tags := field(record, indexes.tags); tags != "" {
+			row.Tags = strings.Split(tags, opts.TagSeparator)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func resolveColumns(columns Columns, header []string) (columnIndexes, error) {
+	defaults := columns == (Columns{})
+	if defaults {
+		if header == nil {
+			columns = Columns{Source: "1", Target: "2"}
+		} else {
+			columns = Columns{Source: "source", Transcription: "transcription", Target: "target", Example: "example", Tags: "tags", Lang: "lang"}
+		}
+	}
+
+	var indexes columnIndexes
+	var err error
+
+	specs := []struct {
+		name  string
+		spec  string
+		index *int
+	}{
+		{"source", columns.Source, &indexes.source},
+		{"transcription", columns.Transcription, &indexes.transcription},
+		{"target", columns.Target, &indexes.target},
+		{"example", columns.Example, &indexes.example},
+		{"tags", columns.Tags, &indexes.tags},
+		{"lang", columns.Lang, &indexes.lang},
+	}
+
+	for _, s := range specs {
+		index, cErr := columnIndex(s.spec, header)
+		switch {
+		case cErr == nil && index < 0 && s.index == &indexes.source:
+			cErr = errors.New("column is required")
+		case cErr == nil && index < 0 && s.spec != "" && !defaults:
+			cErr = fmt.Errorf("column %s is not found in header", s.spec)
+		}
+
+		if cErr != nil {
+			err = errors.Join(err, fmt.Errorf("%s column: %v", s.name, cErr))
+			continue
+		}
+
+		*s.index = index
+	}
+
+	return indexes, err
+}
+
+// columnIndex returns zero based index of the column set by number or header name, -1 for empty spec or not found name
+func columnIndex(spec string, header []string) (int, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return -1, nil
+	}
+
+	if number, err := strconv.Atoi(spec); err == nil {
+		if number < 1 {
+			return 0, fmt.Errorf("column number should be positive, %d passed", number)
+		}
+		return number - 1, nil
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, utf8BOM)), spec) {
+			return i, nil
+		}
+	}
+
+	if header == nil {
+		return 0, fmt.Errorf("column %s can be set by name only for file with header", spec)
+	}
+
+	return -1, nil
+}
+
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(record[index], utf8BOM))
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
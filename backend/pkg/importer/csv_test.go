@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		opts    CSVOptions
+		want    []command.ImportRow
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			"Default columns without header",
+			"go,gehen\n\n\"run, fast\",rennen,ignored\n",
+			CSVOptions{Delimiter: ','},
+			[]command.ImportRow{
+				{Line: 1, Source: "go", Target: "gehen"},
+				{Line: 3, Source: "run, fast", Target: "rennen"},
+			},
+			assert.NoError,
+		},
+		{
+			"Default columns by header names",
+			"\ufeffSource\tTarget\tTags\tLang\ngo\tgehen\tverb; A1\tDE\n",
+			CSVOptions{Delimiter: '\t', Header: true},
+			[]command.ImportRow{
+				{Line: 2, Source: "go", Target: "gehen", Tags: []string{"verb", " A1"}, Lang: "DE"},
+			},
+			assert.NoError,
+		},
+		{
+			"Mapped columns by numbers and names",
+			"word,meaning,usage,ipa\ngo,gehen,let's go,gəʊ\nrun\n",
+			CSVOptions{Delimiter: ',', Header: true, Columns: Columns{Source: "word", Target: "2", Example: "usage", Transcription: "4"}},
+			[]command.ImportRow{
+				{Line: 2, Source: "go", Transcription: "gəʊ", Target: "gehen", Example: "let's go"},
+				{Line: 3, Source: "run"},
+			},
+			assert.NoError,
+		},
+		{
+			"Custom tag separator",
+			"go|gehen|verb,A1\n",
+			CSVOptions{Delimiter: '|', Columns: Columns{Source: "1", Target: "2", Tags: "3"}, TagSeparator: ","},
+			[]command.ImportRow{
+				{Line: 1, Source: "go", Target: "gehen", Tags: []string{"verb", "A1"}},
+			},
+			assert.NoError,
+		},
+		{
+			"Error on missed source column",
+			"word,meaning\ngo,gehen\n",
+			CSVOptions{Delimiter: ',', Header: true, Columns: Columns{Target: "meaning"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Error on column name not found in header",
+			"word,meaning\ngo,gehen\n",
+			CSVOptions{Delimiter: ',', Header: true, Columns: Columns{Source: "word", Target: "translation"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Error on column name without header",
+			"go,gehen\n",
+			CSVOptions{Delimiter: ',', Columns: Columns{Source: "word"}},
+			nil,
+			assert.Error,
+		},
+		{
+			"Error on not positive column number",
+			"go,gehen\n",
+			CSVOptions{Delimiter: ',', Columns: Columns{Source: "0"}},
+			nil,
+			assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCSV(strings.NewReader(tt.data), tt.opts)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package importer
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/sqlite"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultKindleTarget is set as target of imported words as Kindle keeps only looked up words without translations
+const DefaultKindleTarget = "?"
+
+// KindleOptions defines which words are read from Kindle Vocabulary Builder database
+type KindleOptions struct {
+	Lang   string // Lang Kindle code of the words language like en or de, empty value means all words
+	Target string // Target is set to all imported words, DefaultKindleTarget is used when it is empty
+}
+
+// kindleLookup word lookup in a book with the sentence containing the word
+type kindleLookup struct {
+	book      string
+	usage     string
+	timestamp int64
+}
+
+// ReadKindle reads looked up words of Kindle vocab.db in order of adding to Vocabulary Builder,
+// the word stem is used as source, the first usage sentence as example and titles of the books as tags,
+// the example is cut to the max example length and only titles of the first books are kept to fit the max amount of tags
+func ReadKindle(data []byte, opts KindleOptions) ([]command.ImportRow, error) {
+	if opts.Target == "" {
+		opts.Target = DefaultKindleTarget
+	}
+
+	db, err := sqlite.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := readKindleBooks(db)
+	if err != nil {
+		return nil, err
+	}
+
+	lookups, err := readKindleLookups(db, books)
+	if err != nil {
+		return nil, err
+	}
+
+	words, err := db.Records("WORDS")
+	if err != nil {
+		return nil, fmt.Errorf("can not read words: %w", err)
+	}
+
+	sort.SliceStable(words, func(i, j int) bool {
+		timestampI, _ := words[i]["timestamp"].(int64)
+		timestampJ, _ := words[j]["timestamp"].(int64)
+		return timestampI < timestampJ
+	})
+
+	var rows []command.ImportRow
+	for _, word := range words {
+		if lang, _ := word["lang"].(string); opts.Lang != "" && !strings.EqualFold(lang, opts.Lang) {
+			continue
+		}
+
+		source, _ := word["stem"].(string)
+		if strings.TrimSpace(source) == "" {
+			source, _ = word["word"].(string)
+		}
+
+		id, _ := word["id"].(string)
+		row := command.ImportRow{
+			Line:   len(rows) + 1,
+			Source: strings.TrimSpace(source),
+			Target: opts.Target,
+		}
+
+		var titles []string
+		for _, lookup := range lookups[id] {
+			if row.Example == "" {
+				row.Example = kindleExample(lookup.usage)
+			}
+			if lookup.book != "" && len(titles) < translation.MaxTags && !containsString(titles, lookup.book) {
+				titles = append(titles, lookup.book)
+			}
+		}
+		row.Tags = tagNames(titles)
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// kindleExample returns the usage sentence with collapsed spaces cut to the max example length
+func kindleExample(usage string) string {
+	example := strings.Join(strings.Fields(usage), " ")
+	if utf8.RuneCountInString(example) > translation.MaxExampleLength {
+		example = strings.TrimSpace(string([]rune(example)[:translation.MaxExampleLength]))
+	}
+
+	return example
+}
+
+// readKindleBooks returns book titles by their keys
+func readKindleBooks(db *sqlite.Database) (map[string]string, error) {
+	records, err := db.Records("BOOK_INFO")
+	if err != nil {
+		return nil, fmt.Errorf("can not read books: %w", err)
+	}
+
+	books := make(map[string]string, len(records))
+	for _, record := range records {
+		id, _ := record["id"].(string)
+		title, _ := record["title"].(string)
+		books[id] = strings.Join(strings.Fields(title), " ")
+	}
+
+	return books, nil
+}
+
+// readKindleLookups returns lookups of words by word keys in order of lookup time
+func readKindleLookups(db *sqlite.Database, books map[string]string) (map[string][]kindleLookup, error) {
+	records, err := db.Records("LOOKUPS")
+	if err != nil {
+		return nil, fmt.Errorf("can not read lookups: %w", err)
+	}
+
+	lookups := map[string][]kindleLookup{}
+	for _, record := range records {
+		wordKey, _ := record["word_key"].(string)
+		bookKey, _ := record["book_key"].(string)
+		usage, _ := record["usage"].(string)
+		timestamp, _ := record["timestamp"].(int64)
+
+		lookups[wordKey] = append(lookups[wordKey], kindleLookup{book: books[bookKey], usage: usage, timestamp: timestamp})
+	}
+
+	for _, wordLookups := range lookups {
+		sort.SliceStable(wordLookups, func(i, j int) bool {
+			return wordLookups[i].timestamp < wordLookups[j].timestamp
+		})
+	}
+
+	return lookups, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
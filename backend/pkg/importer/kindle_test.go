@@ -0,0 +1,112 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestReadKindle(t *testing.T) {
+	data := kindleVocab(t,
+		[]sqlite.Row{
+			{RowID: 1, Values: []interface{}{"en:running", "running", "run", "en", int64(0), int64(300), ""}},
+			{RowID: 2, Values: []interface{}{"de:gehen", "ging", "gehen", "de", int64(0), int64(200), ""}},
+			{RowID: 3, Values: []interface{}{"en:Went", "Went", "", "en", int64(0), int64(100), ""}},
+		},
+		[]sqlite.Row{
+			{RowID: 1, Values: []interface{}{"l1", "en:running", "b2", "", "0", "She was running\n home.", int64(310)}},
+			{RowID: 2, Values: []interface{}{"l2", "en:running", "b1", "", "0", "He kept running.", int64(301)}},
+			{RowID: 3, Values: []interface{}{"l3", "en:running", "b1", "", "0", "Running again.", int64(320)}},
+			{RowID: 4, Values: []interface{}{"l4", "de:gehen", "b1", "", "0", "Er ging.", int64(200)}},
+		},
+		[]sqlite.Row{
+			{RowID: 1, Values: []interface{}{"b1", "A1", "g1", "en", "The Hobbit", "Tolkien"}},
+			{RowID: 2, Values: []interface{}{"b2", "A2", "g2", "en", "Harry Potter and the Philosopher's Stone", "Rowling"}},
+		},
+	)
+
+	tests := []struct {
+		name string
+		opts KindleOptions
+		want []command.ImportRow
+	}{
+		{
+			"All words",
+			KindleOptions{},
+			[]command.ImportRow{
+				{Line: 1, Source: "Went", Target: DefaultKindleTarget},
+				{Line: 2, Source: "gehen", Target: DefaultKindleTarget, Example: "Er ging.", Tags: []string{"The Hobbit"}},
+				{Line: 3, Source: "run", Target: DefaultKindleTarget, Example: "He kept running.", Tags: []string{"The Hobbit", "Harry Potter and the Philosoph"}},
+			},
+		},
+		{
+			"Words of lang with target",
+			KindleOptions{Lang: "EN", Target: "-"},
+			[]command.ImportRow{
+				{Line: 1, Source: "Went", Target: "-"},
+				{Line: 2, Source: "run", Target: "-", Example: "He kept running.", Tags: []string{"The Hobbit", "Harry Potter and the Philosoph"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ReadKindle(data, tt.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, rows)
+		})
+	}
+}
+
+func TestReadKindle_Limits(t *testing.T) {
+	var lookups, books []sqlite.Row
+	for i := 1; i <= 7; i++ {
+		book := fmt.Sprintf("b%d", i)
+		lookups = append(lookups, sqlite.Row{RowID: int64(i), Values: []interface{}{fmt.Sprintf("l%d", i), "en:run", book, "", "0", strings.Repeat("run ", 100), int64(i)}})
+		books = append(books, sqlite.Row{RowID: int64(i), Values: []interface{}{book, "A", "g", "en", fmt.Sprintf("Book %d", i), "Author"}})
+	}
+	data := kindleVocab(t, []sqlite.Row{{RowID: 1, Values: []interface{}{"en:run", "run", "run", "en", int64(0), int64(100), ""}}}, lookups, books)
+
+	rows, err := ReadKindle(data, KindleOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, strings.TrimSpace(strings.Repeat("run ", 64)[:translation.MaxExampleLength]), rows[0].Example)
+	assert.Equal(t, []string{"Book 1", "Book 2", "Book 3", "Book 4", "Book 5"}, rows[0].Tags)
+}
+
+func TestReadKindle_Errors(t *testing.T) {
+	_, err := ReadKindle([]byte("text"), KindleOptions{})
+	assert.Error(t, err)
+
+	db := bytes.Buffer{}
+	assert.Nil(t, sqlite.Write(&db, []sqlite.Table{{Name: "notes", SQL: "CREATE TABLE notes (id integer primary key)"}}))
+	_, err = ReadKindle(db.Bytes(), KindleOptions{})
+	assert.ErrorIs(t, err, sqlite.ErrTableNotFound)
+}
+
+func kindleVocab(t *testing.T, words, lookups, books []sqlite.Row) []byte {
+	db := bytes.Buffer{}
+	err := sqlite.Write(&db, []sqlite.Table{
+		{
+			Name: "WORDS",
+			SQL:  "CREATE TABLE WORDS (id TEXT PRIMARY KEY NOT NULL UNIQUE, word TEXT, stem TEXT, lang TEXT, category INTEGER DEFAULT 0, timestamp INTEGER DEFAULT 0, profileid TEXT)",
+			Rows: words,
+		},
+		{
+			Name: "LOOKUPS",
+			SQL:  "CREATE TABLE LOOKUPS (id TEXT PRIMARY KEY NOT NULL, word_key TEXT, book_key TEXT, dict_key TEXT, pos TEXT, usage TEXT, timestamp INTEGER DEFAULT 0)",
+			Rows: lookups,
+		},
+		{
+			Name: "BOOK_INFO",
+			SQL:  "CREATE TABLE BOOK_INFO (id TEXT PRIMARY KEY NOT NULL, asin TEXT, guid TEXT, lang TEXT, title TEXT, authors TEXT)",
+			Rows: books,
+		},
+	})
+	assert.Nil(t, err)
+	return db.Bytes()
+}
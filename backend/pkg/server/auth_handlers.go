@@ -1,11 +1,14 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/macyan13/webdict/backend/pkg/auth"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -21,7 +24,20 @@ func (s *HTTPServer) SighIn() gin.HandlerFunc {
 			return
 		}
 
-		authToken, err := s.authHandler.Authenticate(request.Email, request.Password)
+		authToken, err := s.authHandler.Authenticate(request.Email, request.Password, c.ClientIP())
+
+		if s.signInBlocked(c, err) {
+			return
+		}
+
+		var requiredErr *auth.TwoFactorRequiredError
+		if errors.As(err, &requiredErr) {
+			c.JSON(http.StatusOK, twoFactorChallengeResponse{
+				TwoFactorRequired: true,
+				ChallengeToken:    requiredErr.ChallengeToken,
+			})
+			return
+		}
 
 		if err != nil {
 			if err != auth.ErrInvalidCredentials {
@@ -31,14 +47,48 @@ func (s *HTTPServer) SighIn() gin.HandlerFunc {
 			return
 		}
 
-		refreshToken, err := s.authHandler.GenerateRefreshToken(request.Email)
+		refreshToken, err := s.authHandler.GenerateRefreshToken(request.Email, c.Request.UserAgent(), c.ClientIP())
 
 		if err != nil {
 			s.unauthorized(c, fmt.Errorf("[ERROR] Can not generate Refresh token: %v", err))
 			return
 		}
 
-		c.SetCookie(refreshTokenCookieName, refreshToken.Token, int(time.Now().Add(s.opts.Auth.TTL.Cookie).Unix()), "/", s.opts.WebdictURL, false, true)
+		s.setRefreshTokenCookie(c, refreshToken.Token)
+
+		c.JSON(http.StatusOK, AuthTokenResponse{
+			AccessToken: authToken.Token,
+			Type:        authToken.Type,
+		})
+	}
+}
+
+// SignInTwoFactor passes the second step of sign-in with the challenge token from SighIn and TOTP or recovery code
+func (s *HTTPServer) SignInTwoFactor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		var request twoFactorSignInRequest
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			s.badRequest(c, fmt.Errorf("[ERROR] Can not parse SignInTwoFactor request: %v", err))
+			return
+		}
+
+		authToken, refreshToken, err := s.authHandler.VerifyTwoFactor(request.ChallengeToken, request.Code, c.Request.UserAgent(), c.ClientIP())
+
+		if s.signInBlocked(c, err) {
+			return
+		}
+
+		if err != nil {
+			if err != auth.ErrInvalidCredentials && err != auth.ErrInvalidChallenge {
+				log.Printf("[ERROR] Can not handle two-factor auth request: %v", err)
+			}
+			c.JSON(http.StatusUnauthorized, nil)
+			return
+		}
+
+		s.setRefreshTokenCookie(c, refreshToken.Token)
 
 		c.JSON(http.StatusOK, AuthTokenResponse{
 			AccessToken: authToken.Token,
@@ -47,6 +97,21 @@ func (s *HTTPServer) SighIn() gin.HandlerFunc {
 	}
 }
 
+// signInBlocked responds with Retry-After header when sign-in is blocked after failed attempts
+func (s *HTTPServer) signInBlocked(c *gin.Context, err error) bool {
+	var blockedErr *auth.BlockedError
+	if !errors.As(err, &blockedErr) {
+		return false
+	}
+
+	log.Printf("[WARN] Sign-in is blocked after failed attempts: %v", err)
+	retryAfter := int(math.Ceil(time.Until(blockedErr.Until).Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusTooManyRequests, nil)
+	return true
+}
+
+// Refresh exchanges refresh token from the cookie for the new auth token and rotates the refresh token
 func (s *HTTPServer) Refresh() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
@@ -55,21 +120,69 @@ func (s *HTTPServer) Refresh() gin.HandlerFunc {
 
 		if err != nil {
 			c.JSON(http.StatusBadRequest, nil)
+			return
 		}
 
-		authToken, err := s.authHandler.Refresh(refreshToken)
+		authToken, newRefreshToken, err := s.authHandler.Refresh(refreshToken)
 
 		if err != nil {
-			if err != auth.ErrExpiredRefreshToken {
+			switch {
+			case errors.Is(err, auth.ErrReusedRefreshToken):
+				log.Printf("[WARN] Refresh token reuse is detected, the session is revoked: %v", err)
+			case err != auth.ErrExpiredRefreshToken && err != auth.ErrRevokedRefreshToken:
 				log.Printf("[ERROR] Can not handle Refresh token request: %v", err)
 			}
+			s.clearRefreshTokenCookie(c)
 			c.JSON(http.StatusUnauthorized, nil)
 			return
 		}
 
+		s.setRefreshTokenCookie(c, newRefreshToken.Token)
+
 		c.JSON(http.StatusOK, AuthTokenResponse{
 			AccessToken: authToken.Token,
 			Type:        authToken.Type,
 		})
 	}
 }
+
+// Logout revokes the session of refresh token from the cookie, auth tokens issued for the session are valid until they expire
+func (s *HTTPServer) Logout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		refreshToken, err := c.Cookie(refreshTokenCookieName)
+
+		if err != nil {
+			c.JSON(http.StatusOK, http.NoBody)
+			return
+		}
+
+		s.clearRefreshTokenCookie(c)
+
+		if err = s.authHandler.Logout(refreshToken); err != nil && err != auth.ErrExpiredRefreshToken && err != auth.ErrRevokedRefreshToken {
+			s.badRequest(c, fmt.Errorf("can not revoke session: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, http.NoBody)
+	}
+}
+
+// currentSessionID returns id of the session of refresh token from the cookie, empty string means the session is unknown
+func (s *HTTPServer) currentSessionID(c *gin.Context) string {
+	refreshToken, err := c.Cookie(refreshTokenCookieName)
+	if err != nil {
+		return ""
+	}
+
+	return s.authHandler.SessionID(refreshToken)
+}
+
+func (s *HTTPServer) setRefreshTokenCookie(c *gin.Context, token string) {
+	c.SetCookie(refreshTokenCookieName, token, int(time.Now().Add(s.opts.Auth.TTL.Cookie).Unix()), "/", s.opts.WebdictURL, false, true)
+}
+
+func (s *HTTPServer) clearRefreshTokenCookie(c *gin.Context) {
+	c.SetCookie(refreshTokenCookieName, "", -1, "/", s.opts.WebdictURL, false, true)
+}
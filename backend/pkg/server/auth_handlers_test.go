@@ -3,10 +3,12 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -59,6 +61,53 @@ func TestServer_Refresh(t *testing.T) {
 	assert.NotEmpty(t, response.AccessToken, "Route:SignIn -AccessToken must present")
 }
 
+func TestServer_TokenVersion(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	passwd := "testPassword"
+	response := createUser(t, s, "John Do", email, passwd)
+
+	token, err := s.authHandler.Authenticate(email, passwd, "")
+	assert.Nil(t, err)
+	getProfileWithToken(t, s, token.Token, http.StatusOK)
+
+	// email is not a part of the token, so the token is still valid
+	updateProfile(t, s, token.Token, updateProfileRequest{Name: "John Do", Email: "updated@test.com"})
+	getProfileWithToken(t, s, token.Token, http.StatusOK)
+
+	updateProfile(t, s, token.Token, updateProfileRequest{Name: "John Do", Email: "updated@test.com", CurrentPassword: passwd, NewPassword: "newPasswd12345"})
+	getProfileWithToken(t, s, token.Token, http.StatusUnauthorized)
+
+	token, err = s.authHandler.Authenticate("updated@test.com", "newPasswd12345", "")
+	assert.Nil(t, err)
+	getProfileWithToken(t, s, token.Token, http.StatusOK)
+
+	jsonValue, _ := json.Marshal(userRequest{Name: "John Do", Email: "updated@test.com", Role: int(user.Admin)})
+	req, _ := http.NewRequest("PUT", v1UserAPI+"/"+response.ID, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	getProfileWithToken(t, s, token.Token, http.StatusUnauthorized)
+}
+
+func getProfileWithToken(t *testing.T, s *testHTTPServer, token string, code int) {
+	req, _ := http.NewRequest("GET", v1ProfileAPI, http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+}
+
+func updateProfile(t *testing.T, s *testHTTPServer, token string, request updateProfileRequest) {
+	jsonValue, _ := json.Marshal(request)
+	req, _ := http.NewRequest("PUT", v1ProfileAPI, bytes.NewBuffer(jsonValue))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func getRefreshToken(s *testHTTPServer) string {
 	request := signInRequest{
 		Email:    s.opts.Admin.AdminEmail,
@@ -73,3 +122,107 @@ func getRefreshToken(s *testHTTPServer) string {
 	token := []rune(strings.Split(w.Header().Get("Set-Cookie"), ";")[0])
 	return string(token[13:]) // `refreshToken=` - 13
 }
+
+func TestServer_SighInLockout(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	passwd := "testPassword"
+	response := createUser(t, s, "John Do", email, passwd)
+	unlockReq, _ := http.NewRequest("POST", v1UserAPI+"/"+response.ID+"/unlock", http.NoBody)
+	setAuthTokenWithCredentials(t, s, unlockReq, email, passwd)
+
+	// test server locks the account after 3 failed attempts
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusUnauthorized, signInWithCredentials(s, email, "wrongPassword").Code)
+	}
+
+	w := signInWithCredentials(s, email, passwd)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, unlockReq)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "only admin can unlock the account")
+
+	req, _ := http.NewRequest("POST", v1UserAPI+"/"+response.ID+"/unlock", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, http.StatusOK, signInWithCredentials(s, email, passwd).Code)
+}
+
+func signInWithCredentials(s *testHTTPServer, email, passwd string) *httptest.ResponseRecorder {
+	jsonValue, _ := json.Marshal(signInRequest{Email: email, Password: passwd})
+	req, _ := http.NewRequest("POST", authAPI+"/signin", bytes.NewBuffer(jsonValue))
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestServer_SighInLockout_parallel(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	passwd := "testPassword"
+	response := createUser(t, s, "John Do", email, passwd)
+
+	// parallel guesses are counted one by one, so they can not bypass the lockout after 3 failed attempts
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			signInWithCredentials(s, email, "wrongPassword")
+		}()
+	}
+	wg.Wait()
+
+	attempts, err := s.userRepo.(user.AttemptRepository).GetAttempts(user.AccountAttemptsKey(response.ID))
+	assert.Nil(t, err)
+	// requests which come after the lockout are rejected without counting
+	assert.GreaterOrEqual(t, attempts.Failures(), 3)
+	assert.Equal(t, http.StatusTooManyRequests, signInWithCredentials(s, email, passwd).Code)
+}
+
+func TestServer_DeleteUserRemovesSignInAttempts(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	response := createUser(t, s, "John Do", email, "testPassword")
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusUnauthorized, signInWithCredentials(s, email, "wrongPassword").Code)
+	}
+
+	req, _ := http.NewRequest("DELETE", v1UserAPI+"/"+response.ID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	attempts, err := s.userRepo.(user.AttemptRepository).GetAttempts(user.AccountAttemptsKey(response.ID))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, attempts.Failures())
+}
+
+func TestServer_SighIn_forwardedFor(t *testing.T) {
+	s := initTestServer()
+
+	// forwarded header of not trusted client is ignored, so it can not be rotated to escape the client IP limit
+	jsonValue, _ := json.Marshal(signInRequest{Email: "notExist@test.com", Password: "wrongPassword"})
+	req, _ := http.NewRequest("POST", authAPI+"/signin", bytes.NewBuffer(jsonValue))
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	attemptRepo := s.userRepo.(user.AttemptRepository)
+	attempts, err := attemptRepo.GetAttempts(user.IPAttemptsKey("192.0.2.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, attempts.Failures())
+
+	attempts, err = attemptRepo.GetAttempts(user.IPAttemptsKey("10.0.0.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, attempts.Failures())
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"net/http"
+)
+
+// bulkPageSize amount of translations requested from search at once when bulk operation uses search filter
+const bulkPageSize = 200
+
+// bulkPagingParams search params which are not supported by bulk operations, as all matching translations are changed
+var bulkPagingParams = []string{"page", "pageSize", "cursor"}
+
+// BulkTagTranslations adds and removes tags of translations passed by IDs or matching search filter params
+func (s *HTTPServer) BulkTagTranslations() gin.HandlerFunc {
+	return s.bulkHandler(func(authorID string, ids []string, request bulkTranslationsRequest) (command.BulkResult, error) {
+		return s.app.Commands.BulkTagTranslations.Handle(command.BulkTagTranslations{
+			IDs:          ids,
+			AddTagIDs:    request.AddTagIDs,
+			RemoveTagIDs: request.RemoveTagIDs,
+			AuthorID:     authorID,
+		})
+	})
+}
+
+// BulkMoveTranslations moves translations passed by IDs or matching search filter params to another lang
+func (s *HTTPServer) BulkMoveTranslations() gin.HandlerFunc {
+	return s.bulkHandler(func(authorID string, ids []string, request bulkTranslationsRequest) (command.BulkResult, error) {
+		return s.app.Commands.BulkMoveTranslations.Handle(command.BulkMoveTranslations{
+			IDs:      ids,
+			LangID:   request.LangID,
+			AuthorID: authorID,
+		})
+	})
+}
+
+// BulkDeleteTranslations moves translations passed by IDs or matching search filter params to trash
+func (s *HTTPServer) BulkDeleteTranslations() gin.HandlerFunc {
+	return s.bulkHandler(func(authorID string, ids []string, request bulkTranslationsRequest) (command.BulkResult, error) {
+		return s.app.Commands.BulkDeleteTranslations.Handle(command.BulkDeleteTranslations{
+			IDs:      ids,
+			AuthorID: authorID,
+		})
+	})
+}
+
+type bulkCommand func(authorID string, ids []string, request bulkTranslationsRequest) (command.BulkResult, error)
+
+// bulkHandler resolves translations of bulk request and returns result of every translation
+func (s *HTTPServer) bulkHandler(handle bulkCommand) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		var request bulkTranslationsRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse bulk request: %v", err))
+			return
+		}
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		ids := request.IDs
+		if len(ids) == 0 {
+			if ids, err = s.searchTranslationIDs(c, user.ID); err != nil {
+				s.badRequest(c, err)
+				return
+			}
+		}
+
+		if len(ids) == 0 {
+			c.JSON(http.StatusOK, s.bulkResultToResponse(command.BulkResult{}))
+			return
+		}
+
+		result, err := handle(user.ID, ids, request)
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not perform bulk operation: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, s.bulkResultToResponse(result))
+	}
+}
+
+// searchTranslationIDs returns IDs of all translations matching search filter params, empty filter is not allowed
+// to prevent changing of the whole dictionary by mistake, paging params are rejected as they can not limit the operation
+func (s *HTTPServer) searchTranslationIDs(c *gin.Context, authorID string) ([]string, error) {
+	params := c.Request.URL.Query()
+	for _, param := range bulkPagingParams {
+		if params.Has(param) {
+			return nil, fmt.Errorf("%s param is not supported, bulk operation is applied to all translations matching filter", param)
+		}
+	}
+
+	searchQuery, err := s.searchQueryFromRequest(c, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.hasSearchConditions(searchQuery) {
+		return nil, fmt.Errorf("translation ids or search filter params should be passed")
+	}
+
+	searchQuery.PageSize = bulkPageSize
+	if searchQuery.Sort == query.SortRelevance {
+		searchQuery.Sort = query.SortCreatedAsc
+	}
+
+	var ids []string
+	for {
+		lastViews, err := s.app.Queries.SearchTranslations.Handle(searchQuery)
+		if err != nil {
+			return nil, fmt.Errorf("can not get translations matching filter - %v", err)
+		}
+
+		for i := range lastViews.Views {
+			ids = append(ids, lastViews.Views[i].ID)
+		}
+
+		if len(ids) > command.MaxBulkItems {
+			return nil, fmt.Errorf("max amount of translations changed at once is %d, use tags or other filters to change less", command.MaxBulkItems)
+		}
+
+		if lastViews.Next == nil {
+			return ids, nil
+		}
+		searchQuery.Cursor = lastViews.Next.Token()
+	}
+}
+
+// hasSearchConditions checks that search query limits translations by any condition, unknown params are not conditions
+func (s *HTTPServer) hasSearchConditions(searchQuery query.SearchTranslations) bool {
+	return s.hasNotEmpty(searchQuery.LangIDs) || s.hasNotEmpty(searchQuery.TagIds) ||
+		searchQuery.SourcePart != "" || searchQuery.TargetPart != "" || searchQuery.TextPart != "" ||
+		!searchQuery.CreatedFrom.IsZero() || !searchQuery.CreatedTo.IsZero() ||
+		!searchQuery.UpdatedFrom.IsZero() || !searchQuery.UpdatedTo.IsZero()
+}
+
+func (s *HTTPServer) hasNotEmpty(values []string) bool {
+	for _, value := range values {
+		if value != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *HTTPServer) bulkResultToResponse(result command.BulkResult) bulkResponse {
+	response := bulkResponse{
+		Succeeded: result.Succeeded(),
+		Failed:    len(result.Items) - result.Succeeded(),
+		Items:     make([]bulkItemResponse, 0, len(result.Items)),
+	}
+
+	for _, item := range result.Items {
+		itemResponse := bulkItemResponse{ID: item.ID}
+		if item.Err == translation.ErrSourceAlreadyExists {
+			itemResponse.Error = "translation with the same source already exists in the lang"
+		} else if item.Err != nil {
+			itemResponse.Error = item.Err.Error()
+		}
+
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	return response
+}
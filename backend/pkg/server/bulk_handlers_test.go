@@ -0,0 +1,132 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_BulkTagTranslations(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	createTag(t, s, "verb")
+	tagID := getExistingTags(t, s)[0].ID
+	importFile(t, s, "?langId="+enID, "go,gehen\nrun,laufen\ntable,Tisch\n", http.StatusOK)
+
+	translations := getExistingTranslationsByPart(t, s, enID, "", "go")
+	assert.Equal(t, 1, len(translations))
+
+	response := bulkRequest(t, s, "/tags", "", bulkTranslationsRequest{IDs: []string{translations[0].ID, "missing"}, AddTagIDs: []string{tagID}}, http.StatusOK)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, bulkItemResponse{ID: translations[0].ID}, response.Items[0])
+	assert.Equal(t, "missing", response.Items[1].ID)
+	assert.NotEmpty(t, response.Items[1].Error)
+
+	response = bulkRequest(t, s, "/tags", "?sourcePart=r", bulkTranslationsRequest{AddTagIDs: []string{tagID}}, http.StatusOK)
+	assert.Equal(t, 1, response.Succeeded)
+
+	response = bulkRequest(t, s, "/tags", "?tagId[]="+tagID, bulkTranslationsRequest{RemoveTagIDs: []string{tagID}}, http.StatusOK)
+	assert.Equal(t, 2, response.Succeeded)
+
+	for _, tr := range getExistingTranslations(t, s, enID) {
+		assert.Equal(t, 0, len(tr.Senses[0].Tags))
+	}
+
+	bulkRequest(t, s, "/tags", "?sourcePart=r", bulkTranslationsRequest{AddTagIDs: []string{"notExistingTag"}}, http.StatusBadRequest)
+	bulkRequest(t, s, "/tags", "", bulkTranslationsRequest{AddTagIDs: []string{tagID}}, http.StatusBadRequest)
+}
+
+func TestServer_BulkMoveTranslations(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	deID := createLang(t, s, "DE")
+	importFile(t, s, "?langId="+enID, "go,gehen\nrun,laufen\n", http.StatusOK)
+	importFile(t, s, "?langId="+deID, "go,gehen\n", http.StatusOK)
+
+	response := bulkRequest(t, s, "/lang", "?langId="+enID, bulkTranslationsRequest{LangID: deID}, http.StatusOK)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, 2, len(response.Items))
+
+	for _, item := range response.Items {
+		if item.Error != "" {
+			assert.Equal(t, "translation with the same source already exists in the lang", item.Error)
+		}
+	}
+
+	assert.Equal(t, 1, len(getExistingTranslations(t, s, enID)))
+	assert.Equal(t, 2, len(getExistingTranslations(t, s, deID)))
+
+	bulkRequest(t, s, "/lang", "?langId="+enID, bulkTranslationsRequest{LangID: "notExistingLang"}, http.StatusBadRequest)
+}
+
+func TestServer_BulkDeleteTranslations(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	importFile(t, s, "?langId="+enID, "go,gehen\nrun,laufen\ntable,Tisch\n", http.StatusOK)
+
+	response := bulkRequest(t, s, "/delete", "?targetPart=en", bulkTranslationsRequest{}, http.StatusOK)
+	assert.Equal(t, 2, response.Succeeded)
+	assert.Equal(t, 0, response.Failed)
+
+	translations := getExistingTranslations(t, s, enID)
+	assert.Equal(t, 1, len(translations))
+	assert.Equal(t, "table", translations[0].Source)
+	assert.Equal(t, 2, len(getTrashItems(t, s)))
+
+	response = bulkRequest(t, s, "/delete", "?targetPart=en", bulkTranslationsRequest{}, http.StatusOK)
+	assert.Equal(t, 0, response.Succeeded)
+	assert.Equal(t, 0, len(response.Items))
+}
+
+func TestServer_BulkDeleteTranslationsByFilterAllPages(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+
+	var csv strings.Builder
+	for i := 0; i < bulkPageSize+5; i++ {
+		csv.WriteString(fmt.Sprintf("source%d,target\n", i))
+	}
+	importFile(t, s, "?langId="+enID, csv.String(), http.StatusOK)
+
+	bulkRequest(t, s, "/delete", "?langId="+enID+"&page=1", bulkTranslationsRequest{}, http.StatusBadRequest)
+	bulkRequest(t, s, "/delete", "?langId="+enID+"&cursor=token", bulkTranslationsRequest{}, http.StatusBadRequest)
+	bulkRequest(t, s, "/delete", "?sort=source_asc", bulkTranslationsRequest{}, http.StatusBadRequest)
+	bulkRequest(t, s, "/delete", "?foo=1", bulkTranslationsRequest{}, http.StatusBadRequest)
+	bulkRequest(t, s, "/delete", "?sourcePart=&tagId[]=", bulkTranslationsRequest{}, http.StatusBadRequest)
+
+	response := bulkRequest(t, s, "/delete", "?langId="+enID, bulkTranslationsRequest{}, http.StatusOK)
+	assert.Equal(t, bulkPageSize+5, response.Succeeded)
+	assert.Empty(t, getExistingTranslations(t, s, enID))
+}
+
+func TestServer_BulkTranslationsUnauthorised(t *testing.T) {
+	s := initTestServer()
+	jsonValue, _ := json.Marshal(bulkTranslationsRequest{IDs: []string{"id"}})
+	req, _ := http.NewRequest("POST", v1TranslationAPI+"/bulk/delete", bytes.NewBuffer(jsonValue))
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func bulkRequest(t *testing.T, s *testHTTPServer, operation, params string, request bulkTranslationsRequest, code int) bulkResponse {
+	jsonValue, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", v1TranslationAPI+"/bulk"+operation+params, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+
+	var response bulkResponse
+	if code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+
+	return response
+}
@@ -0,0 +1,316 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/macyan13/webdict/backend/pkg/exporter"
+	"log"
+	"net/http"
+	"time"
+)
+
+// maxAnkiNotes limits amount of translations exported to Anki package at once
+const maxAnkiNotes = 10000
+
+// ankiPageSize amount of translations requested from search at once during Anki export
+const ankiPageSize = 200
+
+// dictionaryVersion version of the exported dictionary document format, increased on incompatible changes
+const dictionaryVersion = 1
+const maxDictionaryFileSize = 50 << 20
+
+// ExportDictionary streams all user langs, tags and translations as dictionaryDocument,
+// translations are written one by one to avoid loading the whole dictionary to memory
+func (s *HTTPServer) ExportDictionary() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		exportedAt := time.Now().UTC()
+		started := false
+		count := 0
+
+		begin := func(langs []query.LangView, tags []query.TagView) error {
+			header, err := s.dictionaryHeader(exportedAt, langs, tags)
+			if err != nil {
+				return err
+			}
+
+			c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=webdict-export-%s.json", exportedAt.Format("2006-01-02")))
+			c.Status(http.StatusOK)
+			started = true
+			_, err = c.Writer.Write(header)
+			return err
+		}
+
+		each := func(view query.ExportTranslationView) error {
+			data, err := json.Marshal(s.exportViewToDictionaryTranslation(view))
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				data = append([]byte{','}, data...)
+			}
+			count++
+
+			_, err = c.Writer.Write(data)
+			return err
+		}
+
+		err = s.app.Queries.ExportDictionary.Handle(query.ExportDictionary{AuthorID: user.ID}, begin, each)
+		if err != nil && !started {
+			s.badRequest(c, fmt.Errorf("can not export dictionary - %v", err))
+			return
+		}
+
+		if err != nil {
+			// the response is already started, the client gets incomplete document
+			log.Printf("[ERROR] Can not finish dictionary export - %v", err)
+			c.Abort()
+			return
+		}
+
+		if _, err = c.Writer.Write([]byte("]}")); err != nil {
+			log.Printf("[ERROR] Can not finish dictionary export - %v", err)
+		}
+	}
+}
+
+// ExportAnki returns Anki package with translations of a lang matched the same filter params as translations search
+func (s *HTTPServer) ExportAnki() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		langView, err := s.app.Queries.SingleLang.Handle(query.SingleLang{ID: c.Query("langId"), AuthorID: user.ID})
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not get exported lang - %v", err))
+			return
+		}
+
+		searchQuery, err := s.searchQueryFromRequest(c, user.ID)
+		if err != nil {
+			s.badRequest(c, err)
+			return
+		}
+
+		searchQuery.LangIDs = []string{langView.ID}
+		searchQuery.PageSize = ankiPageSize
+		if searchQuery.Sort == query.SortRelevance {
+			searchQuery.Sort = query.SortCreatedAsc
+		}
+
+		var views []query.TranslationView
+		for {
+			lastViews, err := s.app.Queries.SearchTranslations.Handle(searchQuery)
+			if err != nil {
+				s.badRequest(c, fmt.Errorf("can not get exported translations - %v", err))
+				return
+			}
+
+			views = append(views, lastViews.Views...)
+			if len(views) > maxAnkiNotes {
+				s.badRequest(c, fmt.Errorf("max amount of exported translations is %d, use tags or other filters to export less", maxAnkiNotes))
+				return
+			}
+
+			if lastViews.Next == nil {
+				break
+			}
+			searchQuery.Cursor = lastViews.Next.Token()
+		}
+
+		data := bytes.Buffer{}
+		if err = exporter.WriteAnki(&data, langView.Name, views); err != nil {
+			s.badRequest(c, fmt.Errorf("can not create Anki package - %v", err))
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=webdict-%s.apkg", time.Now().Format("2006-01-02")))
+		c.Header("Content-Type", "application/zip")
+		c.Data(http.StatusOK, "application/zip", data.Bytes())
+	}
+}
+
+// dictionaryHeader returns beginning of the dictionary document up to the opening of translations list
+func (s *HTTPServer) dictionaryHeader(exportedAt time.Time, langs []query.LangView, tags []query.TagView) ([]byte, error) {
+	document := dictionaryDocument{
+		Version:    dictionaryVersion,
+		ExportedAt: exportedAt,
+		Langs:      make([]langResponse, 0, len(langs)),
+		Tags:       make([]tagResponse, 0, len(tags)),
+	}
+
+	for _, ln := range langs {
+		document.Langs = append(document.Langs, s.langViewToResponse(ln))
+	}
+
+	for _, tag := range tags {
+		document.Tags = append(document.Tags, tagResponse{ID: tag.ID, Name: tag.Name, ParentID: tag.ParentID})
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// replace closing of empty translations list and document with opening of the list
+	suffix := []byte(`"translations":null}`)
+	return append(data[:len(data)-len(suffix)], []byte(`"translations":[`)...), nil
+}
+
+func (s *HTTPServer) exportViewToDictionaryTranslation(view query.ExportTranslationView) dictionaryTranslation {
+	item := dictionaryTranslation{
+		ID:            view.ID,
+		LangID:        view.LangID,
+		Source:        view.Source,
+		Transcription: view.Transcription,
+		Senses:        make([]dictionarySense, 0, len(view.Senses)),
+		Fields:        view.Fields,
+		CreatedAt:     view.CreatedAt,
+		UpdatedAt:     view.UpdatedAt,
+		Review: reviewResponse{
+			Ease:        view.Review.Ease,
+			Interval:    view.Review.Interval,
+			Repetitions: view.Review.Repetitions,
+			Lapses:      view.Review.Lapses,
+			DueAt:       view.Review.DueAt,
+			ReviewedAt:  view.Review.ReviewedAt,
+		},
+	}
+
+	for _, sense := range view.Senses {
+		item.Senses = append(item.Senses, dictionarySense{
+			Target:  sense.Target,
+			Example: sense.Example,
+			TagIDs:  append(make([]string, 0, len(sense.TagIDs)), sense.TagIDs...),
+		})
+	}
+
+	return item
+}
+
+// ImportDictionary restores the exported dictionary document, conflicting translations are merged or replaced according to the mode
+func (s *HTTPServer) ImportDictionary() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		// dictionary import creates missing langs and tags and replaces existing ones in replace mode
+		if !s.authHandler.TokenAllows(c, token.Write, token.Langs, token.Tags) {
+			s.forbidden(c, fmt.Errorf("langs and tags write access is required to import dictionary"))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxDictionaryFileSize)
+		var document dictionaryDocument
+		if err = c.ShouldBindJSON(&document); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse dictionary document: %v", err))
+			return
+		}
+
+		if document.Version != dictionaryVersion {
+			s.badRequest(c, fmt.Errorf("unsupported dictionary version %d, %d expected", document.Version, dictionaryVersion))
+			return
+		}
+
+		result, err := s.app.Commands.ImportDictionary.Handle(s.dictionaryDocumentToCommand(document, user.ID, command.ImportMode(c.DefaultQuery("mode", string(command.ImportModeMerge)))))
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not import dictionary: %v", err))
+			return
+		}
+
+		response := importDictionaryResponse{
+			Created:      result.Created,
+			Merged:       result.Merged,
+			Replaced:     result.Replaced,
+			Unchanged:    result.Unchanged,
+			CreatedTags:  append(make([]string, 0, len(result.CreatedTags)), result.CreatedTags...),
+			CreatedLangs: append(make([]string, 0, len(result.CreatedLangs)), result.CreatedLangs...),
+			Failed:       make([]importRowErrorResponse, 0, len(result.Failed)),
+		}
+
+		for _, itemErr := range result.Failed {
+			response.Failed = append(response.Failed, importRowErrorResponse{
+				Line:   itemErr.Line,
+				Source: itemErr.Source,
+				Error:  itemErr.Err.Error(),
+			})
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func (s *HTTPServer) dictionaryDocumentToCommand(document dictionaryDocument, authorID string, mode command.ImportMode) command.ImportDictionary {
+	cmd := command.ImportDictionary{
+		AuthorID:     authorID,
+		Mode:         mode,
+		Langs:        make([]command.DictionaryEntity, 0, len(document.Langs)),
+		Tags:         make([]command.DictionaryEntity, 0, len(document.Tags)),
+		Translations: make([]command.DictionaryTranslation, 0, len(document.Translations)),
+	}
+
+	for _, ln := range document.Langs {
+		cmd.Langs = append(cmd.Langs, command.DictionaryEntity{
+			ID:       ln.ID,
+			Name:     ln.Name,
+			Metadata: lang.NewMetadata(ln.Code, lang.Direction(ln.Direction), lang.TranscriptionScheme(ln.TranscriptionScheme)),
+			Fields:   s.langFieldResponsesToFields(ln.Fields),
+		})
+	}
+
+	for _, tag := range document.Tags {
+		cmd.Tags = append(cmd.Tags, command.DictionaryEntity{ID: tag.ID, Name: tag.Name, ParentID: tag.ParentID})
+	}
+
+	for _, item := range document.Translations {
+		senses := make([]command.TranslationSense, 0, len(item.Senses))
+		for _, sense := range item.Senses {
+			senses = append(senses, command.TranslationSense{Target: sense.Target, Example: sense.Example, TagIDs: sense.TagIDs})
+		}
+
+		cmd.Translations = append(cmd.Translations, command.DictionaryTranslation{
+			ID:            item.ID,
+			LangID:        item.LangID,
+			Source:        item.Source,
+			Transcription: item.Transcription,
+			Senses:        senses,
+			Fields:        item.Fields,
+			CreatedAt:     item.CreatedAt,
+			UpdatedAt:     item.UpdatedAt,
+			Review: command.DictionaryReview{
+				Ease:        item.Review.Ease,
+				Interval:    item.Review.Interval,
+				Repetitions: item.Review.Repetitions,
+				Lapses:      item.Review.Lapses,
+				DueAt:       item.Review.DueAt,
+				ReviewedAt:  item.Review.ReviewedAt,
+			},
+		})
+	}
+
+	return cmd
+}
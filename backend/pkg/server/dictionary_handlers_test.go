@@ -0,0 +1,204 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const v1DictionaryAPI = "/v1/api/dictionary"
+
+func TestServer_ExportImportDictionary(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+	createTag(t, s, "verb")
+	tagID := getExistingTags(t, s)[0].ID
+
+	for _, source := range []string{"go", "run"} {
+		jsonValue, _ := json.Marshal(translationRequest{Source: source, Target: "test", TagIds: []string{tagID}, LangID: langID})
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req, _ := http.NewRequest("GET", v1DictionaryAPI+"/export", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=webdict-export-")
+
+	exported := w.Body.Bytes()
+	var document dictionaryDocument
+	assert.Nil(t, json.Unmarshal(exported, &document))
+	assert.Equal(t, dictionaryVersion, document.Version)
+	assert.Equal(t, []langResponse{{ID: langID, Name: "EN", Direction: "ltr", Fields: []langFieldResponse{}}}, document.Langs)
+	assert.Equal(t, []tagResponse{{ID: tagID, Name: "verb"}}, document.Tags)
+	assert.Equal(t, 2, len(document.Translations))
+	assert.Equal(t, langID, document.Translations[0].LangID)
+	assert.Equal(t, []string{tagID}, document.Translations[0].Senses[0].TagIDs)
+
+	response := importDictionary(t, s, "", exported, http.StatusOK)
+	assert.Equal(t, importDictionaryResponse{CreatedTags: []string{}, CreatedLangs: []string{}, Unchanged: 2, Failed: []importRowErrorResponse{}}, response)
+
+	createUser(t, s, "test", "test@test.com", "testPasswd")
+	req, _ = http.NewRequest("POST", v1DictionaryAPI+"/import?mode=replace", bytes.NewBuffer(exported))
+	setAuthTokenWithCredentials(t, s, req, "test@test.com", "testPasswd")
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Created)
+	assert.Equal(t, []string{"verb"}, response.CreatedTags)
+	assert.Equal(t, []string{"EN"}, response.CreatedLangs)
+	assert.Equal(t, 0, len(response.Failed))
+
+	req, _ = http.NewRequest("GET", v1DictionaryAPI+"/export", http.NoBody)
+	setAuthTokenWithCredentials(t, s, req, "test@test.com", "testPasswd")
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var imported dictionaryDocument
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &imported))
+	assert.Equal(t, 2, len(imported.Translations))
+	assert.NotEqual(t, document.Langs[0].ID, imported.Langs[0].ID)
+	assert.NotEqual(t, document.Translations[0].ID, imported.Translations[0].ID)
+	assert.Equal(t, imported.Langs[0].ID, imported.Translations[0].LangID)
+	assert.Equal(t, []string{imported.Tags[0].ID}, imported.Translations[0].Senses[0].TagIDs)
+	assert.True(t, document.Translations[0].CreatedAt.Equal(imported.Translations[0].CreatedAt))
+	assert.Equal(t, document.Translations[0].Source, imported.Translations[0].Source)
+}
+
+func TestServer_ExportImportDictionary_customFields(t *testing.T) {
+	s := initTestServer()
+	deID := createLangWithRequest(t, s, langRequest{Name: "DE", Fields: []langFieldRequest{
+		{Name: "gender", Kind: "enum", Options: []string{"m", "f", "n"}},
+		{Name: "plural", Kind: "text"},
+	}}, http.StatusCreated)
+	createTranslationWithFields(t, s, deID, map[string]string{"gender": "m", "plural": "Hunde"}, http.StatusCreated)
+
+	req, _ := http.NewRequest("GET", v1DictionaryAPI+"/export", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	exported := w.Body.Bytes()
+	var document dictionaryDocument
+	assert.Nil(t, json.Unmarshal(exported, &document))
+	assert.Equal(t, map[string]string{"gender": "m", "plural": "Hunde"}, document.Translations[0].Fields)
+
+	createUser(t, s, "test", "test@test.com", "testPasswd")
+	req, _ = http.NewRequest("POST", v1DictionaryAPI+"/import", bytes.NewBuffer(exported))
+	setAuthTokenWithCredentials(t, s, req, "test@test.com", "testPasswd")
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response importDictionaryResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 0, len(response.Failed))
+
+	req, _ = http.NewRequest("GET", v1DictionaryAPI+"/export", http.NoBody)
+	setAuthTokenWithCredentials(t, s, req, "test@test.com", "testPasswd")
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var imported dictionaryDocument
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &imported))
+	assert.Equal(t, document.Langs[0].Fields, imported.Langs[0].Fields)
+	assert.Equal(t, document.Translations[0].Fields, imported.Translations[0].Fields)
+
+	// values which do not match schema of the existing lang fail the translation only
+	document.Translations[0].Source = "Katze"
+	document.Translations[0].Fields = map[string]string{"gender": "x"}
+	data, _ := json.Marshal(document)
+	response = importDictionary(t, s, "", data, http.StatusOK)
+	assert.Equal(t, 0, response.Created)
+	assert.Equal(t, 1, len(response.Failed))
+}
+
+func TestServer_ExportEmptyDictionary(t *testing.T) {
+	s := initTestServer()
+
+	req, _ := http.NewRequest("GET", v1DictionaryAPI+"/export", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var document dictionaryDocument
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &document))
+	assert.Equal(t, 0, len(document.Translations))
+	assert.Equal(t, 0, len(document.Langs))
+}
+
+func TestServer_ImportDictionaryInvalidRequest(t *testing.T) {
+	s := initTestServer()
+
+	importDictionary(t, s, "", []byte(`{"version":2,"langs":[],"tags":[],"translations":[]}`), http.StatusBadRequest)
+	importDictionary(t, s, "?mode=append", []byte(`{"version":1,"langs":[],"tags":[],"translations":[]}`), http.StatusBadRequest)
+	importDictionary(t, s, "", []byte(`{"version":1,`), http.StatusBadRequest)
+}
+
+func TestServer_ExportAnki(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+	createTag(t, s, "verb")
+	tagID := getExistingTags(t, s)[0].ID
+
+	req, _ := http.NewRequest("GET", v1DictionaryAPI+"/export/anki?langId="+langID+"&tagId[]="+tagID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+
+	// more than one search page is exported
+	for i := 0; i < 2*ankiPageSize+10; i++ {
+		request := translationRequest{Source: fmt.Sprintf("word%d", i), Target: "test", LangID: langID}
+		if i%2 == 0 {
+			request.TagIds = []string{tagID}
+		}
+		jsonValue, _ := json.Marshal(request)
+		createReq, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		createReq.Header.Set("Authorization", req.Header.Get("Authorization"))
+		s.engine.ServeHTTP(httptest.NewRecorder(), createReq)
+	}
+
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".apkg")
+
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	assert.Nil(t, err)
+	assert.Equal(t, "collection.anki2", archive.File[0].Name)
+
+	req, _ = http.NewRequest("GET", v1DictionaryAPI+"/export/anki", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func importDictionary(t *testing.T, s *testHTTPServer, params string, data []byte, code int) importDictionaryResponse {
+	req, _ := http.NewRequest("POST", v1DictionaryAPI+"/import"+params, bytes.NewBuffer(data))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+
+	var response importDictionaryResponse
+	if code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+
+	return response
+}
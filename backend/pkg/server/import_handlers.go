@@ -0,0 +1,153 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/importer"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const importFileField = "file"
+const maxImportFileSize = 5 << 20
+const maxImportPackageSize = 50 << 20
+
+// ImportTranslations imports translations from csv or tsv word list, Anki package (apkg) or Kindle vocab.db (kindle)
+func (s *HTTPServer) ImportTranslations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		format := c.DefaultQuery("format", "csv")
+		maxSize := int64(maxImportFileSize)
+		switch format {
+		case "csv", "tsv":
+		case "apkg", "kindle":
+			maxSize = maxImportPackageSize
+		default:
+			s.badRequest(c, fmt.Errorf("unsupported import format %s, csv, tsv, apkg or kindle expected", format))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+		file, err := c.FormFile(importFileField)
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not get imported file: %v", err))
+			return
+		}
+
+		content, err := file.Open()
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not open imported file: %v", err))
+			return
+		}
+		defer content.Close()
+
+		var rows []command.ImportRow
+		switch format {
+		case "apkg":
+			rows, err = importer.ReadAnki(content, file.Size)
+		case "kindle":
+			rows, err = s.readKindleRows(c, content)
+		default:
+			rows, err = s.readCSVRows(c, content, format)
+		}
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not read imported file: %v", err))
+			return
+		}
+
+		s.importRows(c, user.ID, rows)
+	}
+}
+
+func (s *HTTPServer) readCSVRows(c *gin.Context, content io.Reader, format string) ([]command.ImportRow, error) {
+	delimiter := ','
+	if format == "tsv" {
+		delimiter = '\t'
+	}
+
+	header, _ := strconv.ParseBool(c.Query("header"))
+	return importer.ReadCSV(content, importer.CSVOptions{
+		Delimiter: delimiter,
+		Header:    header,
+		Columns: importer.Columns{
+			Source:        c.Query("sourceColumn"),
+			Transcription: c.Query("transcriptionColumn"),
+			Target:        c.Query("targetColumn"),
+			Example:       c.Query("exampleColumn"),
+			Tags:          c.Query("tagsColumn"),
+			Lang:          c.Query("langColumn"),
+		},
+		TagSeparator: c.Query("tagSeparator"),
+	})
+}
+
+func (s *HTTPServer) readKindleRows(c *gin.Context, content io.Reader) ([]command.ImportRow, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return importer.ReadKindle(data, importer.KindleOptions{Lang: c.Query("kindleLang"), Target: c.Query("target")})
+}
+
+// importRows performs import of rows read from the imported file with options passed in query
+func (s *HTTPServer) importRows(c *gin.Context, authorID string, rows []command.ImportRow) {
+	createMissing, _ := strconv.ParseBool(c.Query("createMissing"))
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+
+	if createMissing && !s.authHandler.TokenAllows(c, token.Write, token.Langs, token.Tags) {
+		s.forbidden(c, fmt.Errorf("langs and tags write access is required to create missing ones"))
+		return
+	}
+
+	result, err := s.app.Commands.ImportTranslations.Handle(command.ImportTranslations{
+		AuthorID:      authorID,
+		LangID:        c.Query("langId"),
+		Rows:          rows,
+		CreateMissing: createMissing,
+		DryRun:        dryRun,
+	})
+	if err != nil {
+		s.badRequest(c, fmt.Errorf("can not import translations: %v", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, s.importResultToResponse(result, dryRun))
+}
+
+func (s *HTTPServer) importResultToResponse(result command.ImportResult, dryRun bool) importResponse {
+	response := importResponse{
+		DryRun:       dryRun,
+		Imported:     result.Imported,
+		CreatedTags:  append(make([]string, 0, len(result.CreatedTags)), result.CreatedTags...),
+		CreatedLangs: append(make([]string, 0, len(result.CreatedLangs)), result.CreatedLangs...),
+		Failed:       make([]importRowErrorResponse, 0, len(result.Failed)),
+	}
+
+	for _, rowErr := range result.Failed {
+		message := rowErr.Err.Error()
+		if rowErr.Err == translation.ErrSourceAlreadyExists {
+			message = fmt.Sprintf("translation with source %s already exists", rowErr.Source)
+		}
+
+		response.Failed = append(response.Failed, importRowErrorResponse{
+			Line:   rowErr.Line,
+			Source: rowErr.Source,
+			Error:  message,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/macyan13/webdict/backend/pkg/importer"
+	"github.com/macyan13/webdict/backend/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServer_ImportTranslations(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	createTag(t, s, "verb")
+
+	data := "source\ttarget\texample\ttags\tlang\n" +
+		"go\tgehen\tlet's go\tverb\tEN\n" +
+		"run\tlaufen\t\tverb;A1\tEN\n" +
+		"go\tgehen\t\t\tEN\n" +
+		"Hund\tdog\t\t\tDE\n" +
+		"table\t\t\t\tEN\n"
+
+	response := importFile(t, s, "?format=tsv&header=true&createMissing=true&dryRun=true", data, http.StatusOK)
+	assert.True(t, response.DryRun)
+	assert.Equal(t, 3, response.Imported)
+	assert.Equal(t, []string{"A1"}, response.CreatedTags)
+	assert.Equal(t, []string{"DE"}, response.CreatedLangs)
+	assert.Equal(t, 2, len(response.Failed))
+	assert.Equal(t, importRowErrorResponse{Line: 4, Source: "go", Error: "translation with source go already exists"}, response.Failed[0])
+	assert.Equal(t, 6, response.Failed[1].Line)
+	assert.Equal(t, 0, len(getExistingTranslations(t, s, enID)))
+	assert.Equal(t, 1, len(getExistingTags(t, s)))
+
+	response = importFile(t, s, "?format=tsv&header=true&createMissing=true", data, http.StatusOK)
+	assert.False(t, response.DryRun)
+	assert.Equal(t, 3, response.Imported)
+	assert.Equal(t, 2, len(response.Failed))
+
+	translations := getExistingTranslations(t, s, enID)
+	assert.Equal(t, 2, len(translations))
+	assert.Equal(t, 2, len(getExistingTags(t, s)))
+
+	response = importFile(t, s, "?format=tsv&header=true", data, http.StatusOK)
+	assert.Equal(t, 0, response.Imported)
+	assert.Equal(t, 5, len(response.Failed))
+	assert.Equal(t, "translation with source go already exists", response.Failed[0].Error)
+}
+
+func TestServer_ImportTranslationsToDefaultLangWithoutHeader(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+
+	response := importFile(t, s, "?langId="+enID, "go,gehen\nrun,laufen\n", http.StatusOK)
+	assert.Equal(t, 2, response.Imported)
+	assert.Equal(t, 0, len(response.Failed))
+	assert.Equal(t, 2, len(getExistingTranslations(t, s, enID)))
+}
+
+func TestServer_ImportTranslationsInvalidRequest(t *testing.T) {
+	s := initTestServer()
+
+	importFile(t, s, "?format=xls", "go,gehen\n", http.StatusBadRequest)
+	importFile(t, s, "?sourceColumn=word", "go,gehen\n", http.StatusBadRequest)
+
+	req, _ := http.NewRequest("POST", v1TranslationAPI+"/import", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_ImportAnkiPackage(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	deID := createLang(t, s, "DE")
+	createTag(t, s, "verb")
+	tagID := getExistingTags(t, s)[0].ID
+
+	req, _ := http.NewRequest("GET", v1DictionaryAPI+"/export/anki?langId="+enID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+
+	for _, source := range []string{"go", "run"} {
+		jsonValue, _ := json.Marshal(translationRequest{Source: source, Transcription: "t", Target: "test", Example: "example", TagIds: []string{tagID}, LangID: enID})
+		createReq, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		createReq.Header.Set("Authorization", req.Header.Get("Authorization"))
+		s.engine.ServeHTTP(httptest.NewRecorder(), createReq)
+	}
+
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	exported := w.Body.String()
+
+	response := importFile(t, s, "?format=apkg&langId="+deID, exported, http.StatusOK)
+	assert.Equal(t, 2, response.Imported)
+	assert.Equal(t, 0, len(response.Failed))
+
+	translations := getExistingTranslations(t, s, deID)
+	assert.Equal(t, 2, len(translations))
+	assert.Equal(t, "t", translations[0].Transcription)
+	assert.Equal(t, "example", translations[0].Senses[0].Example)
+	assert.Equal(t, tagID, translations[0].Senses[0].Tags[0].ID)
+
+	response = importFile(t, s, "?format=apkg&langId="+deID, exported, http.StatusOK)
+	assert.Equal(t, 0, response.Imported)
+	assert.Equal(t, 2, len(response.Failed))
+	assert.Equal(t, "translation with source go already exists", response.Failed[0].Error)
+
+	importFile(t, s, "?format=apkg&langId="+deID, "go,gehen\n", http.StatusBadRequest)
+}
+
+func TestServer_ImportKindleVocabulary(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+
+	db := bytes.Buffer{}
+	err := sqlite.Write(&db, []sqlite.Table{
+		{
+			Name: "WORDS",
+			SQL:  "CREATE TABLE WORDS (id TEXT PRIMARY KEY NOT NULL UNIQUE, word TEXT, stem TEXT, lang TEXT, category INTEGER DEFAULT 0, timestamp INTEGER DEFAULT 0, profileid TEXT)",
+			Rows: []sqlite.Row{
+				{RowID: 1, Values: []interface{}{"en:running", "running", "run", "en", int64(0), int64(1), ""}},
+				{RowID: 2, Values: []interface{}{"de:ging", "ging", "gehen", "de", int64(0), int64(2), ""}},
+			},
+		},
+		{
+			Name: "LOOKUPS",
+			SQL:  "CREATE TABLE LOOKUPS (id TEXT PRIMARY KEY NOT NULL, word_key TEXT, book_key TEXT, dict_key TEXT, pos TEXT, usage TEXT, timestamp INTEGER DEFAULT 0)",
+			Rows: []sqlite.Row{{RowID: 1, Values: []interface{}{"l1", "en:running", "b1", "", "0", "He kept running.", int64(1)}}},
+		},
+		{
+			Name: "BOOK_INFO",
+			SQL:  "CREATE TABLE BOOK_INFO (id TEXT PRIMARY KEY NOT NULL, asin TEXT, guid TEXT, lang TEXT, title TEXT, authors TEXT)",
+			Rows: []sqlite.Row{{RowID: 1, Values: []interface{}{"b1", "A1", "g1", "en", "The Hobbit", "Tolkien"}}},
+		},
+	})
+	assert.Nil(t, err)
+
+	response := importFile(t, s, "?format=kindle&kindleLang=en&createMissing=true&langId="+enID, db.String(), http.StatusOK)
+	assert.Equal(t, 1, response.Imported)
+	assert.Equal(t, []string{"The Hobbit"}, response.CreatedTags)
+
+	translations := getExistingTranslations(t, s, enID)
+	assert.Equal(t, 1, len(translations))
+	assert.Equal(t, "run", translations[0].Source)
+	assert.Equal(t, importer.DefaultKindleTarget, translations[0].Senses[0].Target)
+	assert.Equal(t, "He kept running.", translations[0].Senses[0].Example)
+}
+
+func importFile(t *testing.T, s *testHTTPServer, params, data string, code int) importResponse {
+	body := bytes.Buffer{}
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(importFileField, "words.csv")
+	assert.Nil(t, err)
+	_, err = part.Write([]byte(data))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	req, _ := http.NewRequest("POST", v1TranslationAPI+"/import"+params, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+
+	var response importResponse
+	if code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+
+	return response
+}
@@ -30,6 +30,8 @@ func (s *HTTPServer) CreateLang() gin.HandlerFunc { //nolint:dupl // it's not fu
 		id, err := s.app.Commands.AddLang.Handle(command.AddLang{
 			Name:     request.Name,
 			AuthorID: user.ID,
+			Metadata: s.langRequestToMetadata(request),
+			Fields:   s.langRequestToFields(request),
 		})
 
 		if err == lang.ErrLangAlreadyExists {
@@ -66,6 +68,8 @@ func (s *HTTPServer) UpdateLang() gin.HandlerFunc { //nolint:dupl // it's not fu
 			ID:       c.Param(langIDParam),
 			Name:     request.Name,
 			AuthorID: user.ID,
+			Metadata: s.langRequestToMetadata(request),
+			Fields:   s.langRequestToFields(request),
 		}); err != nil {
 			if err == lang.ErrLangAlreadyExists {
 				s.badRequest(c, fmt.Errorf("lang %s already exists", request.Name))
@@ -89,15 +93,24 @@ func (s *HTTPServer) DeleteLangByID() gin.HandlerFunc {
 			return
 		}
 
-		if err := s.app.Commands.DeleteLang.Handle(command.DeleteLang{
-			ID:       c.Param(langIDParam),
-			AuthorID: user.ID,
-		}); err != nil {
+		result, err := s.app.Commands.DeleteLang.Handle(command.DeleteLang{
+			ID:           c.Param(langIDParam),
+			AuthorID:     user.ID,
+			Mode:         command.DeleteLangMode(c.Query("mode")),
+			TargetLangID: c.Query("targetLangId"),
+		})
+
+		if err != nil {
 			s.badRequest(c, fmt.Errorf("can not delete lang: %v", err))
 			return
 		}
 
-		c.JSON(http.StatusOK, http.NoBody)
+		c.JSON(http.StatusOK, deleteLangResponse{
+			Moved:       result.Moved,
+			Deleted:     result.Deleted,
+			LangDeleted: result.LangDeleted,
+			Conflicts:   s.bulkResultToResponse(command.BulkResult{Items: result.Conflicts}).Items,
+		})
 	}
 }
 
@@ -147,10 +160,28 @@ func (s *HTTPServer) GetLangs() gin.HandlerFunc {
 }
 
 func (s *HTTPServer) langViewToResponse(ln query.LangView) langResponse {
-	return langResponse{
-		ID:   ln.ID,
-		Name: ln.Name,
+	response := langResponse{
+		ID:                  ln.ID,
+		Name:                ln.Name,
+		Code:                ln.Code,
+		Direction:           ln.Direction,
+		TranscriptionScheme: ln.TranscriptionScheme,
+		Fields:              make([]langFieldResponse, 0, len(ln.Fields)),
+	}
+
+	for _, field := range ln.Fields {
+		response.Fields = append(response.Fields, langFieldResponse{
+			Name:    field.Name,
+			Kind:    field.Kind,
+			Options: append([]string{}, field.Options...),
+		})
 	}
+
+	return response
+}
+
+func (s *HTTPServer) langRequestToMetadata(request langRequest) lang.Metadata {
+	return lang.NewMetadata(request.Code, lang.Direction(request.Direction), lang.TranscriptionScheme(request.TranscriptionScheme))
 }
 
 func (s *HTTPServer) langViewsToResponse(langs []query.LangView) []langResponse {
@@ -162,3 +193,20 @@ func (s *HTTPServer) langViewsToResponse(langs []query.LangView) []langResponse
 
 	return responses
 }
+
+func (s *HTTPServer) langRequestToFields(request langRequest) []lang.Field {
+	fields := make([]lang.Field, 0, len(request.Fields))
+	for _, field := range request.Fields {
+		fields = append(fields, lang.NewField(field.Name, lang.FieldKind(field.Kind), field.Options))
+	}
+	return fields
+}
+
+// langFieldResponsesToFields converts fields schema of the exported lang
+func (s *HTTPServer) langFieldResponsesToFields(responses []langFieldResponse) []lang.Field {
+	fields := make([]lang.Field, 0, len(responses))
+	for _, field := range responses {
+		fields = append(fields, lang.NewField(field.Name, lang.FieldKind(field.Kind), field.Options))
+	}
+	return fields
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const v1LangAPI = "/v1/api/langs"
+
+func TestServer_LangMetadata(t *testing.T) {
+	s := initTestServer()
+	id := createLangWithRequest(t, s, langRequest{Name: "Hebrew", Code: "HE", TranscriptionScheme: "romanization"}, http.StatusCreated)
+	assert.Equal(t, langResponse{ID: id, Name: "Hebrew", Code: "he", Direction: "rtl", TranscriptionScheme: "romanization", Fields: []langFieldResponse{}}, getLang(t, s, id))
+
+	createLangWithRequest(t, s, langRequest{Name: "Unknown", Code: "xx"}, http.StatusBadRequest)
+	createLangWithRequest(t, s, langRequest{Name: "Unknown", Direction: "ttb"}, http.StatusBadRequest)
+	createLangWithRequest(t, s, langRequest{Name: "Unknown", TranscriptionScheme: "hepburn"}, http.StatusBadRequest)
+
+	jsonValue, _ := json.Marshal(langRequest{Name: "Chinese", Code: "zh", Direction: "ltr", TranscriptionScheme: "pinyin"})
+	req, _ := http.NewRequest("PUT", v1LangAPI+"/"+id, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, langResponse{ID: id, Name: "Chinese", Code: "zh", Direction: "ltr", TranscriptionScheme: "pinyin", Fields: []langFieldResponse{}}, getLang(t, s, id))
+}
+
+func TestServer_TranslationCustomFields(t *testing.T) {
+	s := initTestServer()
+	deID := createLangWithRequest(t, s, langRequest{Name: "DE", Fields: []langFieldRequest{
+		{Name: "gender", Kind: "enum", Options: []string{"m", "f", "n"}},
+		{Name: "plural", Kind: "text"},
+		{Name: "separable", Kind: "boolean"},
+	}}, http.StatusCreated)
+	createLangWithRequest(t, s, langRequest{Name: "JA", Fields: []langFieldRequest{{Name: "reading", Kind: "enum"}}}, http.StatusBadRequest)
+
+	fields := getLang(t, s, deID).Fields
+	assert.Equal(t, 3, len(fields))
+	assert.Equal(t, langFieldResponse{Name: "gender", Kind: "enum", Options: []string{"m", "f", "n"}}, fields[0])
+
+	createTranslationWithFields(t, s, deID, map[string]string{"gender": "x"}, http.StatusBadRequest)
+	createTranslationWithFields(t, s, deID, map[string]string{"reading": "hunt"}, http.StatusBadRequest)
+	createTranslationWithFields(t, s, deID, map[string]string{"separable": "yes"}, http.StatusBadRequest)
+	createTranslationWithFields(t, s, deID, map[string]string{"gender": "m", "plural": "Hunde", "separable": "false"}, http.StatusCreated)
+
+	translations := getExistingTranslations(t, s, deID)
+	assert.Equal(t, 1, len(translations))
+	assert.Equal(t, map[string]string{"gender": "m", "plural": "Hunde", "separable": "false"}, translations[0].Fields)
+	assert.Equal(t, 3, len(translations[0].Lang.Fields))
+}
+
+func TestServer_DeleteLangRestrict(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	importFile(t, s, "?langId="+enID, "go,gehen\n", http.StatusOK)
+
+	deleteLang(t, s, enID, "", http.StatusBadRequest)
+	deleteLang(t, s, enID, "?mode=unknown", http.StatusBadRequest)
+	deleteLang(t, s, enID, "?mode=move", http.StatusBadRequest)
+	deleteLang(t, s, enID, "?mode=move&targetLangId="+enID, http.StatusBadRequest)
+	deleteLang(t, s, enID, "?mode=move&targetLangId=notExistingLang", http.StatusBadRequest)
+	assert.Equal(t, 1, len(getExistingTranslations(t, s, enID)))
+}
+
+func TestServer_DeleteLangMove(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	deID := createLang(t, s, "DE")
+	importFile(t, s, "?langId="+enID, "go,gehen\nrun,laufen\n", http.StatusOK)
+	importFile(t, s, "?langId="+deID, "go,gehen\n", http.StatusOK)
+
+	response := deleteLang(t, s, enID, "?mode=move&targetLangId="+deID, http.StatusOK)
+	assert.Equal(t, 1, response.Moved)
+	assert.False(t, response.LangDeleted)
+	assert.Equal(t, 1, len(response.Conflicts))
+	assert.Equal(t, "translation with the same source already exists in the lang", response.Conflicts[0].Error)
+	assert.Equal(t, 1, len(getExistingTranslations(t, s, enID)))
+	assert.Equal(t, 2, len(getExistingTranslations(t, s, deID)))
+
+	response = deleteLang(t, s, enID, "?mode=cascade", http.StatusOK)
+	assert.Equal(t, 1, response.Deleted)
+	assert.True(t, response.LangDeleted)
+	assert.Equal(t, 0, len(response.Conflicts))
+	assert.Equal(t, 2, len(getTrashItems(t, s)))
+}
+
+func TestServer_DeleteLangCascade(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	importFile(t, s, "?langId="+enID, "go,gehen\nrun,laufen\ntable,Tisch\n", http.StatusOK)
+
+	response := deleteLang(t, s, enID, "?mode=cascade", http.StatusOK)
+	assert.Equal(t, 3, response.Deleted)
+	assert.Equal(t, 0, response.Moved)
+	assert.True(t, response.LangDeleted)
+	assert.Equal(t, 0, len(getExistingTranslations(t, s, enID)))
+	assert.Equal(t, 4, len(getTrashItems(t, s)))
+}
+
+func deleteLang(t *testing.T, s *testHTTPServer, langID, params string, code int) deleteLangResponse {
+	req, _ := http.NewRequest("DELETE", v1LangAPI+"/"+langID+params, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+
+	var response deleteLangResponse
+	if code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+
+	return response
+}
+
+func createLangWithRequest(t *testing.T, s *testHTTPServer, request langRequest, code int) string {
+	jsonValue, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", v1LangAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+
+	var response idResponse
+	if code == http.StatusCreated {
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return response.ID
+}
+
+func getLang(t *testing.T, s *testHTTPServer, id string) langResponse {
+	req, _ := http.NewRequest("GET", v1LangAPI+"/"+id, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response langResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func createTranslationWithFields(t *testing.T, s *testHTTPServer, langID string, fields map[string]string, code int) {
+	jsonValue, _ := json.Marshal(translationRequest{Source: "Hund", Target: "dog", LangID: langID, Fields: fields})
+	req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+}
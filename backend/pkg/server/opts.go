@@ -1,6 +1,9 @@
 package server
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Opts flags and envs to run server
 type Opts struct {
@@ -8,20 +11,34 @@ type Opts struct {
 	Admin AdminGroup `group:"admin" namespace:"admin" env-namespace:"ADMIN"`
 	Mongo MongoGroup `group:"mongo" namespace:"mongo" env-namespace:"MONGO"`
 	Cache CacheGroup `group:"cache" namespace:"cache" env-namespace:"CACHE"`
+	Trash TrashGroup `group:"trash" namespace:"trash" env-namespace:"TRASH"`
 
-	Port       int    `long:"port" env:"PORT" default:"4000" description:"port"`
-	WebdictURL string `long:"url" env:"URL" description:"url to webdict"`
-	Dbg        bool   `long:"dbg" env:"DEBUG" description:"debug mode"`
+	Port           int      `long:"port" env:"PORT" default:"4000" description:"port"`
+	WebdictURL     string   `long:"url" env:"URL" description:"url to webdict"`
+	TrustedProxies []string `long:"trusted_proxy" env:"TRUSTED_PROXIES" env-delim:"," description:"IPs or CIDRs of proxies allowed to pass the client IP in forwarded headers, none by default"`
+	Dbg            bool     `long:"dbg" env:"DEBUG" description:"debug mode"`
 }
 
 // AuthGroup defines options group for auth params
 type AuthGroup struct {
 	TTL struct {
-		Auth    time.Duration `long:"auth" env:"AUTH" default:"2h" description:"auth JWT TTL"`
-		Refresh time.Duration `long:"refresh" env:"REFRESH" default:"24h" description:"refresh JWT TTL"`
-		Cookie  time.Duration `long:"cookie" env:"COOKIE" default:"200h" description:"refresh cookie TTL"`
+		Auth      time.Duration `long:"auth" env:"AUTH" default:"2h" description:"auth JWT TTL"`
+		Refresh   time.Duration `long:"refresh" env:"REFRESH" default:"24h" description:"refresh JWT TTL"`
+		Cookie    time.Duration `long:"cookie" env:"COOKIE" default:"200h" description:"refresh cookie TTL"`
+		Challenge time.Duration `long:"challenge" env:"CHALLENGE" default:"5m" description:"two-factor sign-in challenge JWT TTL"`
 	} `group:"ttl" namespace:"ttl" env-namespace:"TTL"`
 
+	SignIn struct {
+		FreeAttempts    int           `long:"free_attempts" env:"FREE_ATTEMPTS" default:"3" description:"failed sign-in attempts to the account allowed without delay"`
+		IPFreeAttempts  int           `long:"ip_free_attempts" env:"IP_FREE_ATTEMPTS" default:"10" description:"failed sign-in attempts from the client IP allowed without delay"`
+		BaseDelay       time.Duration `long:"base_delay" env:"BASE_DELAY" default:"1s" description:"sign-in delay after the first not free failed attempt, doubled on every next failure"`
+		MaxDelay        time.Duration `long:"max_delay" env:"MAX_DELAY" default:"5m" description:"max sign-in delay after failed attempt"`
+		AccountLockout  int           `long:"account_lockout" env:"ACCOUNT_LOCKOUT" default:"10" description:"failed attempts which lock sign-in to the account, 0 disables the lockout"`
+		IPLockout       int           `long:"ip_lockout" env:"IP_LOCKOUT" default:"50" description:"failed attempts which lock sign-in from the client IP, 0 disables the lockout"`
+		LockoutDuration time.Duration `long:"lockout_duration" env:"LOCKOUT_DURATION" default:"30m" description:"how long sign-in is locked"`
+		ResetAfter      time.Duration `long:"reset_after" env:"RESET_AFTER" default:"24h" description:"failed attempts are forgotten after the period without failures"`
+	} `group:"signin" namespace:"signin" env-namespace:"SIGNIN"`
+
 	Secret string `long:"secret" env:"SECRET" required:"true" description:"the secret key used to sign JWT, should be a random, long, hard-to-guess string"`
 }
 
@@ -42,8 +59,44 @@ type MongoGroup struct {
 
 // CacheGroup defines options group for in memory cache
 type CacheGroup struct {
-	TagCacheTTL                time.Duration `long:"tag_cache_ttl" env:"TAG_CACHE_TTL" default:"3600s" description:"Cache TTL for tags"`
-	TranslationCacheTTL        time.Duration `long:"translation_cache_ttl" env:"TRANSLATION_CACHE_TTL" default:"3600s" description:"Cache TTL for translations"`
-	TranslationsSearchCacheTTL time.Duration `long:"translations_search_cache_ttl" env:"TRANSLATIONS_SEARCH_CACHE_TTL" default:"600s" description:"Cache TTL for translations search results"`
-	LangCacheTTL               time.Duration `long:"lang_cache_ttl" env:"LANG_CACHE_TTL" default:"3600s" description:"Cache TTL for languages"`
+	TagCacheTTL         time.Duration `long:"tag_cache_ttl" env:"TAG_CACHE_TTL" default:"3600s" description:"Cache TTL for tags"`
+	TranslationCacheTTL time.Duration `long:"translation_cache_ttl" env:"TRANSLATION_CACHE_TTL" default:"3600s" description:"Cache TTL for translations"`
+	LangCacheTTL        time.Duration `long:"lang_cache_ttl" env:"LANG_CACHE_TTL" default:"3600s" description:"Cache TTL for languages"`
+}
+
+// TrashGroup defines options group for trash cleanup
+type TrashGroup struct {
+	Retention     time.Duration `long:"retention" env:"RETENTION" default:"720h" description:"how long deleted entities are kept in trash before purging"`
+	PurgeInterval time.Duration `long:"purge_interval" env:"PURGE_INTERVAL" default:"1h" description:"how often expired trash items are purged"`
+}
+
+// validate checks options which can not be checked by flags parser
+func (o Opts) validate() error {
+	if err := o.Trash.validate(); err != nil {
+		return err
+	}
+
+	params := authParams(o.Auth)
+	if err := params.AccountLockout.Validate(); err != nil {
+		return fmt.Errorf("invalid account sign-in options: %w", err)
+	}
+
+	if err := params.IPLockout.Validate(); err != nil {
+		return fmt.Errorf("invalid client IP sign-in options: %w", err)
+	}
+
+	return nil
+}
+
+// validate checks that trash items are kept and purged with positive periods
+func (g TrashGroup) validate() error {
+	if g.Retention <= 0 {
+		return fmt.Errorf("trash retention should be positive, %s passed", g.Retention)
+	}
+
+	if g.PurgeInterval <= 0 {
+		return fmt.Errorf("trash purge interval should be positive, %s passed", g.PurgeInterval)
+	}
+
+	return nil
 }
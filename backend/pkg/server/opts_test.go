@@ -0,0 +1,46 @@
+package server
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTrashGroup_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   TrashGroup
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{"Positive case", TrashGroup{Retention: time.Hour, PurgeInterval: time.Minute}, assert.NoError},
+		{"Zero retention", TrashGroup{PurgeInterval: time.Minute}, assert.Error},
+		{"Zero purge interval", TrashGroup{Retention: time.Hour}, assert.Error},
+		{"Negative purge interval", TrashGroup{Retention: time.Hour, PurgeInterval: -time.Minute}, assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, tt.group.validate())
+		})
+	}
+}
+
+func TestOpts_validate(t *testing.T) {
+	opts := Opts{Trash: TrashGroup{Retention: time.Hour, PurgeInterval: time.Minute}}
+	opts.Auth.SignIn.AccountLockout = 10
+	opts.Auth.SignIn.IPLockout = 50
+	opts.Auth.SignIn.LockoutDuration = 30 * time.Minute
+	opts.Auth.SignIn.ResetAfter = 24 * time.Hour
+	assert.Nil(t, opts.validate())
+
+	invalid := opts
+	invalid.Auth.SignIn.ResetAfter = 0
+	assert.Error(t, invalid.validate())
+
+	invalid = opts
+	invalid.Auth.SignIn.BaseDelay = -time.Second
+	assert.Error(t, invalid.validate())
+
+	invalid = opts
+	invalid.Trash.PurgeInterval = 0
+	assert.Error(t, invalid.validate())
+}
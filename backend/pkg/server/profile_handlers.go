@@ -54,6 +54,7 @@ func (s *HTTPServer) UpdateProfile() gin.HandlerFunc {
 			NewPassword:     request.NewPassword,
 			DefaultLangID:   request.DefaultLangID,
 			ListOptions:     user.NewListOptions(request.ListOptions.HideTranscription),
+			SessionID:       s.currentSessionID(c),
 		}); err != nil {
 			if errors.Is(err, user.ErrEmailAlreadyExists) {
 				s.badRequest(c, fmt.Errorf("user with email %s already exists", request.Email))
@@ -66,3 +67,60 @@ func (s *HTTPServer) UpdateProfile() gin.HandlerFunc {
 		c.JSON(http.StatusOK, http.NoBody)
 	}
 }
+
+// EnrollTwoFactor generates new two-factor authentication secret, it has to be confirmed with ConfirmTwoFactor
+func (s *HTTPServer) EnrollTwoFactor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		var request twoFactorEnrollRequest
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse two-factor enroll request: %v", err))
+			return
+		}
+
+		usr, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		enrollment, err := s.app.Commands.EnrollTwoFactor.Handle(command.EnrollTwoFactor{UserID: usr.ID, CurrentPassword: request.CurrentPassword})
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not enroll two-factor authentication: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, twoFactorEnrollmentResponse{
+			Secret: enrollment.Secret,
+			URI:    enrollment.URI,
+		})
+	}
+}
+
+// ConfirmTwoFactor enables two-factor authentication with the first code and returns recovery codes, they are shown only once
+func (s *HTTPServer) ConfirmTwoFactor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		var request twoFactorCodeRequest
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse two-factor confirm request: %v", err))
+			return
+		}
+
+		usr, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		codes, err := s.app.Commands.ConfirmTwoFactor.Handle(command.ConfirmTwoFactor{UserID: usr.ID, Code: request.Code})
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not confirm two-factor authentication: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+	}
+}
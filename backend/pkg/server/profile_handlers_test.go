@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 const v1ProfileAPI = "/v1/api/profile"
@@ -104,3 +106,94 @@ func getProfile(t *testing.T, s *testHTTPServer, email, passwd string) userRespo
 	assert.Nil(t, err)
 	return profile
 }
+
+func TestHTTPServer_TwoFactor(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	passwd := "testPassword"
+	response := createUser(t, s, "John Do", email, passwd)
+
+	token, err := s.authHandler.Authenticate(email, passwd, "")
+	assert.Nil(t, err)
+
+	w := profileRequest(s, "POST", "/2fa", token.Token, twoFactorEnrollRequest{CurrentPassword: "wrongPassword"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = profileRequest(s, "POST", "/2fa", token.Token, twoFactorEnrollRequest{CurrentPassword: passwd})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var enrollment twoFactorEnrollmentResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &enrollment))
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/")
+
+	w = profileRequest(s, "POST", "/2fa/confirm", token.Token, twoFactorCodeRequest{Code: "000000x"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	code, err := user.GenerateTOTPCode(enrollment.Secret, time.Now())
+	assert.Nil(t, err)
+	w = profileRequest(s, "POST", "/2fa/confirm", token.Token, twoFactorCodeRequest{Code: code})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var recovery recoveryCodesResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &recovery))
+	assert.NotEmpty(t, recovery.RecoveryCodes)
+
+	w = profileRequest(s, "GET", "", token.Token, nil)
+	var profile userResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &profile))
+	assert.True(t, profile.TwoFactorEnabled)
+
+	w = signInWithCredentials(s, email, passwd)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Set-Cookie"), "session is not started before the second step")
+	var challenge twoFactorChallengeResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+	assert.True(t, challenge.TwoFactorRequired)
+	assert.NotEmpty(t, challenge.ChallengeToken)
+
+	w = profileRequest(s, "GET", "", challenge.ChallengeToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "challenge token can not be used for the auth")
+
+	w = signInTwoFactor(s, challenge.ChallengeToken, code)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "code used for confirmation can not be replayed")
+
+	w = signInTwoFactor(s, challenge.ChallengeToken, recovery.RecoveryCodes[0])
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, refreshTokenFromCookie(w))
+	var authResponse AuthTokenResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &authResponse))
+	assert.Equal(t, http.StatusOK, profileRequest(s, "GET", "", authResponse.AccessToken, nil).Code)
+
+	req, _ := http.NewRequest("DELETE", v1UserAPI+"/"+response.ID+"/2fa", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = signInWithCredentials(s, email, passwd)
+	assert.Equal(t, http.StatusOK, w.Code)
+	authResponse = AuthTokenResponse{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &authResponse))
+	assert.NotEmpty(t, authResponse.AccessToken, "the second step is not required after reset")
+}
+
+func profileRequest(s *testHTTPServer, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var payload io.Reader = http.NoBody
+	if body != nil {
+		jsonValue, _ := json.Marshal(body)
+		payload = bytes.NewBuffer(jsonValue)
+	}
+
+	req, _ := http.NewRequest(method, v1ProfileAPI+path, payload)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+func signInTwoFactor(s *testHTTPServer, challengeToken, code string) *httptest.ResponseRecorder {
+	jsonValue, _ := json.Marshal(twoFactorSignInRequest{ChallengeToken: challengeToken, Code: code})
+	req, _ := http.NewRequest("POST", authAPI+"/signin/2fa", bytes.NewBuffer(jsonValue))
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
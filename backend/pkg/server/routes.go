@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
 )
 
 func (s *HTTPServer) buildRoutes() {
@@ -13,22 +14,34 @@ func (s *HTTPServer) buildRoutes() {
 	{
 		authAPI := v1.Group("/auth")
 		authAPI.POST("/signin", s.SighIn())
+		authAPI.POST("/signin/2fa", s.SignInTwoFactor())
 		authAPI.POST("/refresh", s.Refresh())
+		authAPI.POST("/logout", s.Logout())
 
-		translationAPI := v1.Group("/translations", s.authHandler.Middleware())
+		translationAPI := v1.Group("/translations", s.authHandler.Middleware(token.Translations))
 		translationAPI.POST("", s.CreateTranslation())
 		translationAPI.GET("", s.SearchTranslations())
 		translationAPI.GET("/random", s.GetRandomTranslations())
+		translationAPI.GET("/due", s.GetDueTranslations())
+		translationAPI.GET("/fuzzy", s.GetFuzzyTranslations())
+		translationAPI.POST("/import", s.ImportTranslations())
+		translationAPI.POST("/bulk/tags", s.BulkTagTranslations())
+		translationAPI.POST("/bulk/lang", s.BulkMoveTranslations())
+		translationAPI.POST("/bulk/delete", s.BulkDeleteTranslations())
+		translationAPI.POST(fmt.Sprintf("/:%s/review", translationIDParam), s.ReviewTranslation())
+		translationAPI.GET(fmt.Sprintf("/:%s/revisions", translationIDParam), s.GetTranslationRevisions())
+		translationAPI.POST(fmt.Sprintf("/:%s/revisions/:%s/restore", translationIDParam, revisionIDParam), s.RestoreTranslationRevision())
 		translationAPI.PUT(fmt.Sprintf("/:%s", translationIDParam), s.UpdateTranslation())
 		translationAPI.GET(fmt.Sprintf("/:%s", translationIDParam), s.GetTranslationByID())
 		translationAPI.DELETE(fmt.Sprintf("/:%s", translationIDParam), s.DeleteTranslationByID())
 
-		tagAPI := v1.Group("/tags", s.authHandler.Middleware())
+		tagAPI := v1.Group("/tags", s.authHandler.Middleware(token.Tags))
 		tagAPI.POST("", s.CreateTag())
 		tagAPI.GET("", s.GetTags())
 		tagAPI.PUT(fmt.Sprintf("/:%s", tagIDParam), s.UpdateTag())
 		tagAPI.GET(fmt.Sprintf("/:%s", tagIDParam), s.GetTagByID())
 		tagAPI.DELETE(fmt.Sprintf("/:%s", tagIDParam), s.DeleteTagByID())
+		tagAPI.POST(fmt.Sprintf("/:%s/merge", tagIDParam), s.MergeTags())
 
 		userAPI := v1.Group("/users", s.authHandler.Middleware(), s.authHandler.AdminMiddleware())
 		userAPI.POST("", s.CreateUser())
@@ -36,19 +49,48 @@ func (s *HTTPServer) buildRoutes() {
 		userAPI.GET("", s.GetUsers())
 		userAPI.GET(fmt.Sprintf("/:%s", userIDParam), s.GetUserByID())
 		userAPI.DELETE(fmt.Sprintf("/:%s", userIDParam), s.DeleteUser())
+		userAPI.POST(fmt.Sprintf("/:%s/unlock", userIDParam), s.UnlockUser())
+		userAPI.DELETE(fmt.Sprintf("/:%s/2fa", userIDParam), s.ResetTwoFactor())
 
 		roleAPI := v1.Group("/roles", s.authHandler.Middleware(), s.authHandler.AdminMiddleware())
 		roleAPI.GET("", s.GetRoles())
 
-		langAPI := v1.Group("/langs", s.authHandler.Middleware())
+		langAPI := v1.Group("/langs", s.authHandler.Middleware(token.Langs))
 		langAPI.GET("", s.GetLangs())
 		langAPI.POST("", s.CreateLang())
 		langAPI.PUT(fmt.Sprintf("/:%s", langIDParam), s.UpdateLang())
 		langAPI.GET(fmt.Sprintf("/:%s", langIDParam), s.GetLangByID())
 		langAPI.DELETE(fmt.Sprintf("/:%s", langIDParam), s.DeleteLangByID())
 
+		trashAPI := v1.Group("/trash", s.authHandler.Middleware(token.Trash))
+		trashAPI.GET("", s.GetTrashItems())
+		trashAPI.DELETE("", s.PurgeTrash())
+		trashAPI.POST(fmt.Sprintf("/:%s/restore", trashItemIDParam), s.RestoreTrashItem())
+		trashAPI.DELETE(fmt.Sprintf("/:%s", trashItemIDParam), s.PurgeTrashItem())
+
+		statsAPI := v1.Group("/stats", s.authHandler.Middleware(token.Stats))
+		statsAPI.GET("", s.GetStats())
+
+		dictionaryAPI := v1.Group("/dictionary", s.authHandler.Middleware(token.Dictionary))
+		dictionaryAPI.GET("/export", s.ExportDictionary())
+		dictionaryAPI.GET("/export/anki", s.ExportAnki())
+		dictionaryAPI.POST("/import", s.ImportDictionary())
+
 		profileAPI := v1.Group("/profile", s.authHandler.Middleware())
 		profileAPI.GET("", s.GetProfile())
 		profileAPI.PUT("", s.UpdateProfile())
+		profileAPI.POST("/2fa", s.EnrollTwoFactor())
+		profileAPI.POST("/2fa/confirm", s.ConfirmTwoFactor())
+
+		sessionAPI := v1.Group("/sessions", s.authHandler.Middleware())
+		sessionAPI.GET("", s.GetSessions())
+		sessionAPI.DELETE("", s.RevokeAllSessions())
+		sessionAPI.DELETE(fmt.Sprintf("/:%s", sessionIDParam), s.RevokeSession())
+
+		// personal access tokens are not accepted to manage tokens, so a leaked token can not issue new ones
+		tokenAPI := v1.Group("/tokens", s.authHandler.Middleware())
+		tokenAPI.GET("", s.GetTokens())
+		tokenAPI.POST("", s.CreateToken())
+		tokenAPI.DELETE(fmt.Sprintf("/:%s", tokenIDParam), s.RevokeToken())
 	}
 }
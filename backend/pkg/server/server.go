@@ -19,6 +19,7 @@ import (
 	"os/signal"
 	"runtime"
 	"syscall"
+	"time"
 )
 
 type HTTPServer struct {
@@ -29,6 +30,10 @@ type HTTPServer struct {
 }
 
 func InitServer(opts Opts) (*HTTPServer, error) {
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() { // catch signal and invoke graceful termination
 		stop := make(chan os.Signal, 1)
@@ -49,7 +54,7 @@ func InitServer(opts Opts) (*HTTPServer, error) {
 		return nil, err
 	}
 
-	cacheOpts := cache.Opts{TagCacheTTL: opts.Cache.TagCacheTTL, TranslationCacheTTL: opts.Cache.TranslationCacheTTL, TranslationsSearchCacheTTL: opts.Cache.TranslationsSearchCacheTTL, LangCacheTTL: opts.Cache.LangCacheTTL}
+	cacheOpts := cache.Opts{TagCacheTTL: opts.Cache.TagCacheTTL, TranslationCacheTTL: opts.Cache.TranslationCacheTTL, LangCacheTTL: opts.Cache.LangCacheTTL}
 
 	tagRepo, err := mongo.NewTagRepo(dbConnect)
 	if err != nil {
@@ -70,6 +75,26 @@ func InitServer(opts Opts) (*HTTPServer, error) {
 		return nil, err
 	}
 
+	revisionRepo, err := mongo.NewRevisionRepo(dbConnect)
+	if err != nil {
+		return nil, err
+	}
+
+	trashRepo, err := mongo.NewTrashRepo(dbConnect)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionRepo, err := mongo.NewSessionRepo(dbConnect)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenRepo, err := mongo.NewTokenRepo(dbConnect)
+	if err != nil {
+		return nil, err
+	}
+
 	userRepo, err := mongo.NewUserRepo(dbConnect, cachedLangRepo, query.NewRoleMapper())
 	if err != nil {
 		return nil, err
@@ -80,34 +105,62 @@ func InitServer(opts Opts) (*HTTPServer, error) {
 	cachedTranslationRepo := cache.NewTranslationRepo(ctx, translationRepo, translationRepo, cacheOpts.TranslationCacheTTL)
 
 	cmd := app.Commands{
-		AddTranslation:    command.NewAddTranslationHandler(cachedTranslationRepo, cachedTagRepo, cachedLangRepo),
-		UpdateTranslation: command.NewUpdateTranslationHandler(cachedTranslationRepo, cachedTagRepo, cachedLangRepo),
-		DeleteTranslation: command.NewDeleteTranslationHandler(cachedTranslationRepo),
-		AddTag:            command.NewAddTagHandler(cachedTagRepo),
-		UpdateTag:         command.NewUpdateTagHandler(cachedTagRepo),
-		DeleteTag:         command.NewDeleteTagHandler(cachedTagRepo, cachedTranslationRepo),
-		AddUser:           command.NewAddUserHandler(userRepo, cipher),
-		UpdateUser:        command.NewUpdateUserHandler(userRepo, cipher),
-		DeleteUser:        command.NewDeleteUserHandler(userRepo, cachedLangRepo, cachedTagRepo, cachedTranslationRepo),
-		AddLang:           command.NewAddLangHandler(cachedLangRepo),
-		UpdateLang:        command.NewUpdateLangHandler(cachedLangRepo),
-		DeleteLang:        command.NewDeleteLangHandler(cachedLangRepo, cachedTranslationRepo),
-		UpdateProfile:     command.NewUpdateProfileHandler(userRepo, cipher, cachedLangRepo),
+		AddTranslation:             command.NewAddTranslationHandler(cachedTranslationRepo, cachedTagRepo, cachedLangRepo),
+		UpdateTranslation:          command.NewUpdateTranslationHandler(cachedTranslationRepo, revisionRepo, cachedTagRepo, cachedLangRepo),
+		DeleteTranslation:          command.NewDeleteTranslationHandler(cachedTranslationRepo, trashRepo),
+		ReviewTranslation:          command.NewReviewTranslationHandler(cachedTranslationRepo),
+		RestoreTranslationRevision: command.NewRestoreTranslationRevisionHandler(cachedTranslationRepo, revisionRepo, cachedTagRepo, cachedLangRepo),
+		ImportTranslations:         command.NewImportTranslationsHandler(cachedTranslationRepo, cachedTagRepo, cachedLangRepo),
+		ImportDictionary:           command.NewImportDictionaryHandler(cachedTranslationRepo, revisionRepo, cachedTagRepo, cachedLangRepo),
+		BulkTagTranslations:        command.NewBulkTagTranslationsHandler(cachedTranslationRepo, revisionRepo, cachedTagRepo, cachedLangRepo),
+		BulkMoveTranslations:       command.NewBulkMoveTranslationsHandler(cachedTranslationRepo, revisionRepo, cachedLangRepo),
+		BulkDeleteTranslations:     command.NewBulkDeleteTranslationsHandler(cachedTranslationRepo, trashRepo),
+		AddTag:                     command.NewAddTagHandler(cachedTagRepo),
+		UpdateTag:                  command.NewUpdateTagHandler(cachedTagRepo),
+		DeleteTag:                  command.NewDeleteTagHandler(cachedTagRepo, cachedTranslationRepo, trashRepo),
+		MergeTags:                  command.NewMergeTagsHandler(cachedTagRepo, cachedTranslationRepo),
+		AddUser:                    command.NewAddUserHandler(userRepo, cipher),
+		UpdateUser:                 command.NewUpdateUserHandler(userRepo, cipher, sessionRepo),
+		DeleteUser:                 command.NewDeleteUserHandler(userRepo, cachedLangRepo, cachedTagRepo, cachedTranslationRepo, revisionRepo, trashRepo, sessionRepo, tokenRepo, userRepo),
+		UnlockUser:                 command.NewUnlockUserHandler(userRepo, userRepo),
+		AddLang:                    command.NewAddLangHandler(cachedLangRepo),
+		UpdateLang:                 command.NewUpdateLangHandler(cachedLangRepo, cachedTranslationRepo, revisionRepo),
+		DeleteLang:                 command.NewDeleteLangHandler(cachedLangRepo, cachedTranslationRepo, revisionRepo, trashRepo),
+		UpdateProfile:              command.NewUpdateProfileHandler(userRepo, cipher, cachedLangRepo, sessionRepo),
+		EnrollTwoFactor:            command.NewEnrollTwoFactorHandler(userRepo, cipher),
+		ConfirmTwoFactor:           command.NewConfirmTwoFactorHandler(userRepo),
+		ResetTwoFactor:             command.NewResetTwoFactorHandler(userRepo),
+		RevokeSession:              command.NewRevokeSessionHandler(sessionRepo),
+		RevokeAllSessions:          command.NewRevokeAllSessionsHandler(sessionRepo),
+		CreateToken:                command.NewCreateTokenHandler(tokenRepo),
+		RevokeToken:                command.NewRevokeTokenHandler(tokenRepo),
+		RestoreTrashItem:           command.NewRestoreTrashItemHandler(trashRepo, cachedTranslationRepo, cachedTagRepo, cachedLangRepo),
+		PurgeTrash:                 command.NewPurgeTrashHandler(trashRepo, revisionRepo),
+		PurgeExpiredTrash:          command.NewPurgeExpiredTrashHandler(trashRepo, revisionRepo),
 	}
 
 	validate := validator.New()
 
 	queries := app.Queries{
-		SingleTranslation:  query.NewSingleTranslationHandler(cachedTranslationRepo, validate),
-		SearchTranslations: query.NewSearchTranslationsHandler(cachedTranslationRepo, validate),
-		RandomTranslations: query.NewRandomTranslationsHandler(cachedTranslationRepo, validate),
-		SingleTag:          query.NewSingleTagHandler(cachedTagRepo, validate),
-		AllTags:            query.NewAllTagsHandler(cachedTagRepo, validate),
-		SingleUser:         query.NewSingleUserHandler(userRepo, validate),
-		AllUsers:           query.NewAllUsersHandler(userRepo),
-		SingleLang:         query.NewSingleLangHandler(cachedLangRepo, validate),
-		AllLangs:           query.NewAllLangsHandler(cachedLangRepo, validate),
-		AllRoles:           query.NewAllRolesHandler(),
+		SingleTranslation:    query.NewSingleTranslationHandler(cachedTranslationRepo, validate),
+		SearchTranslations:   query.NewSearchTranslationsHandler(cachedTranslationRepo, cachedTagRepo, validate),
+		RandomTranslations:   query.NewRandomTranslationsHandler(cachedTranslationRepo, cachedTagRepo, validate),
+		DueReviews:           query.NewDueReviewsHandler(cachedTranslationRepo, cachedTagRepo, validate),
+		FuzzyTranslations:    query.NewFuzzyTranslationsHandler(cachedTranslationRepo, validate),
+		TranslationRevisions: query.NewTranslationRevisionsHandler(revisionRepo, validate),
+		SingleTag:            query.NewSingleTagHandler(cachedTagRepo, validate),
+		AllTags:              query.NewAllTagsHandler(cachedTagRepo, validate),
+		TagTree:              query.NewTagTreeHandler(cachedTagRepo, validate),
+		SingleUser:           query.NewSingleUserHandler(userRepo, validate),
+		AllUsers:             query.NewAllUsersHandler(userRepo),
+		SingleLang:           query.NewSingleLangHandler(cachedLangRepo, validate),
+		AllLangs:             query.NewAllLangsHandler(cachedLangRepo, validate),
+		AllRoles:             query.NewAllRolesHandler(),
+		AllTrashItems:        query.NewAllTrashItemsHandler(trashRepo, validate),
+		AllSessions:          query.NewAllSessionsHandler(sessionRepo, validate),
+		AllTokens:            query.NewAllTokensHandler(tokenRepo, validate),
+		DictionaryStats:      query.NewDictionaryStatsHandler(translationRepo, validate),
+		ExportDictionary:     query.NewExportDictionaryHandler(cachedLangRepo, cachedTagRepo, translationRepo, validate),
 	}
 
 	application := app.Application{
@@ -115,13 +168,12 @@ func InitServer(opts Opts) (*HTTPServer, error) {
 		Queries:  queries,
 	}
 
-	authHandler := auth.NewHandler(userRepo, cipher, auth.Params{
-		AuthTTL:    opts.Auth.TTL.Auth,
-		RefreshTTL: opts.Auth.TTL.Refresh,
-		Secret:     opts.Auth.Secret,
-	})
+	authHandler := auth.NewHandler(userRepo, userRepo, sessionRepo, tokenRepo, cipher, authParams(opts.Auth))
 
-	router := gin.Default()
+	router, err := newRouter(opts)
+	if err != nil {
+		return nil, err
+	}
 	router.Use(cors.Default())
 
 	s := HTTPServer{
@@ -134,9 +186,47 @@ func InitServer(opts Opts) (*HTTPServer, error) {
 	s.buildRoutes()
 	s.loadStaticData()
 	s.populateInitData()
+	go s.purgeExpiredTrash(ctx)
 	return &s, nil
 }
 
+// newRouter creates gin engine which takes the client IP from forwarded headers of trusted proxies only,
+// so the IP used for sign-in limits and sessions can not be forged by the client
+func newRouter(opts Opts) (*gin.Engine, error) {
+	router := gin.Default()
+	if err := router.SetTrustedProxies(opts.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	return router, nil
+}
+
+// authParams converts auth options to auth handler params, account and client IP lockouts share the back-off settings
+func authParams(opts AuthGroup) auth.Params {
+	return auth.Params{
+		AuthTTL:      opts.TTL.Auth,
+		RefreshTTL:   opts.TTL.Refresh,
+		ChallengeTTL: opts.TTL.Challenge,
+		Secret:       opts.Secret,
+		AccountLockout: user.LockoutPolicy{
+			FreeAttempts:    opts.SignIn.FreeAttempts,
+			BaseDelay:       opts.SignIn.BaseDelay,
+			MaxDelay:        opts.SignIn.MaxDelay,
+			LockoutAttempts: opts.SignIn.AccountLockout,
+			LockoutDuration: opts.SignIn.LockoutDuration,
+			ResetAfter:      opts.SignIn.ResetAfter,
+		},
+		IPLockout: user.LockoutPolicy{
+			FreeAttempts:    opts.SignIn.IPFreeAttempts,
+			BaseDelay:       opts.SignIn.BaseDelay,
+			MaxDelay:        opts.SignIn.MaxDelay,
+			LockoutAttempts: opts.SignIn.IPLockout,
+			LockoutDuration: opts.SignIn.LockoutDuration,
+			ResetAfter:      opts.SignIn.ResetAfter,
+		},
+	}
+}
+
 func (s *HTTPServer) Run() error {
 	err := s.engine.Run(fmt.Sprintf(":%d", s.opts.Port))
 
@@ -165,6 +255,29 @@ func (s *HTTPServer) populateInitData() {
 	}
 }
 
+// purgeExpiredTrash periodically removes trash items which were deleted earlier than the retention period
+func (s *HTTPServer) purgeExpiredTrash(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.Trash.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.app.Commands.PurgeExpiredTrash.Handle(command.PurgeExpiredTrash{
+				DeletedBefore: time.Now().Add(-s.opts.Trash.Retention),
+			})
+			if err != nil {
+				log.Printf("[ERROR] can not purge expired trash - %v", err)
+			}
+			if count > 0 {
+				log.Printf("[INFO] %d expired trash items purged", count)
+			}
+		}
+	}
+}
+
 func (s *HTTPServer) unauthorized(c *gin.Context, err error) {
 	pc := make([]uintptr, 15)
 	n := runtime.Callers(2, pc)
@@ -175,6 +288,11 @@ func (s *HTTPServer) unauthorized(c *gin.Context, err error) {
 	c.JSON(http.StatusUnauthorized, nil)
 }
 
+func (s *HTTPServer) forbidden(c *gin.Context, err error) {
+	log.Printf("[INFO] Forbidden action - %v", err)
+	c.JSON(http.StatusForbidden, err.Error())
+}
+
 func (s *HTTPServer) badRequest(c *gin.Context, err error) {
 	log.Printf("[ERROR] Can not handle request - %v", err)
 	c.JSON(http.StatusBadRequest, err.Error())
@@ -11,6 +11,7 @@ import (
 	"github.com/macyan13/webdict/backend/pkg/store/inmemory"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -25,6 +26,10 @@ func initTestServer() *testHTTPServer {
 	authGroup.TTL.Auth = time.Minute * 10
 	authGroup.TTL.Refresh = time.Minute * 10
 	authGroup.TTL.Cookie = time.Hour
+	authGroup.TTL.Challenge = time.Minute
+	authGroup.SignIn.AccountLockout = 3
+	authGroup.SignIn.LockoutDuration = time.Hour
+	authGroup.SignIn.ResetAfter = time.Hour
 
 	opts := Opts{
 		Auth: authGroup,
@@ -40,38 +45,70 @@ func initTestServer() *testHTTPServer {
 	tagRepo := inmemory.NewTagRepository()
 	langRepo := inmemory.NewLangRepository()
 	translationRepo := inmemory.NewTranslationRepository(*tagRepo, *langRepo)
+	revisionRepo := inmemory.NewRevisionRepository()
+	trashRepo := inmemory.NewTrashRepository()
+	sessionRepo := inmemory.NewSessionRepository()
+	tokenRepo := inmemory.NewTokenRepository()
 	userRepo := inmemory.NewUserRepository(query.NewRoleMapper())
 
 	cipher := auth.Cipher{}
 	cmd := app.Commands{
-		AddTranslation:    command.NewAddTranslationHandler(translationRepo, tagRepo, langRepo),
-		UpdateTranslation: command.NewUpdateTranslationHandler(translationRepo, tagRepo, langRepo),
-		DeleteTranslation: command.NewDeleteTranslationHandler(translationRepo),
-		AddTag:            command.NewAddTagHandler(tagRepo),
-		UpdateTag:         command.NewUpdateTagHandler(tagRepo),
-		DeleteTag:         command.NewDeleteTagHandler(tagRepo, translationRepo),
-		AddUser:           command.NewAddUserHandler(userRepo, cipher),
-		UpdateUser:        command.NewUpdateUserHandler(userRepo, cipher),
-		DeleteUser:        command.NewDeleteUserHandler(userRepo, langRepo, tagRepo, translationRepo),
-		AddLang:           command.NewAddLangHandler(langRepo),
-		UpdateLang:        command.NewUpdateLangHandler(langRepo),
-		DeleteLang:        command.NewDeleteLangHandler(langRepo, translationRepo),
-		UpdateProfile:     command.NewUpdateProfileHandler(userRepo, cipher, langRepo),
+		AddTranslation:             command.NewAddTranslationHandler(translationRepo, tagRepo, langRepo),
+		UpdateTranslation:          command.NewUpdateTranslationHandler(translationRepo, revisionRepo, tagRepo, langRepo),
+		DeleteTranslation:          command.NewDeleteTranslationHandler(translationRepo, trashRepo),
+		ReviewTranslation:          command.NewReviewTranslationHandler(translationRepo),
+		RestoreTranslationRevision: command.NewRestoreTranslationRevisionHandler(translationRepo, revisionRepo, tagRepo, langRepo),
+		ImportTranslations:         command.NewImportTranslationsHandler(translationRepo, tagRepo, langRepo),
+		ImportDictionary:           command.NewImportDictionaryHandler(translationRepo, revisionRepo, tagRepo, langRepo),
+		BulkTagTranslations:        command.NewBulkTagTranslationsHandler(translationRepo, revisionRepo, tagRepo, langRepo),
+		BulkMoveTranslations:       command.NewBulkMoveTranslationsHandler(translationRepo, revisionRepo, langRepo),
+		BulkDeleteTranslations:     command.NewBulkDeleteTranslationsHandler(translationRepo, trashRepo),
+		AddTag:                     command.NewAddTagHandler(tagRepo),
+		UpdateTag:                  command.NewUpdateTagHandler(tagRepo),
+		DeleteTag:                  command.NewDeleteTagHandler(tagRepo, translationRepo, trashRepo),
+		MergeTags:                  command.NewMergeTagsHandler(tagRepo, translationRepo),
+		AddUser:                    command.NewAddUserHandler(userRepo, cipher),
+		UpdateUser:                 command.NewUpdateUserHandler(userRepo, cipher, sessionRepo),
+		DeleteUser:                 command.NewDeleteUserHandler(userRepo, langRepo, tagRepo, translationRepo, revisionRepo, trashRepo, sessionRepo, tokenRepo, userRepo),
+		UnlockUser:                 command.NewUnlockUserHandler(userRepo, userRepo),
+		AddLang:                    command.NewAddLangHandler(langRepo),
+		UpdateLang:                 command.NewUpdateLangHandler(langRepo, translationRepo, revisionRepo),
+		DeleteLang:                 command.NewDeleteLangHandler(langRepo, translationRepo, revisionRepo, trashRepo),
+		UpdateProfile:              command.NewUpdateProfileHandler(userRepo, cipher, langRepo, sessionRepo),
+		EnrollTwoFactor:            command.NewEnrollTwoFactorHandler(userRepo, cipher),
+		ConfirmTwoFactor:           command.NewConfirmTwoFactorHandler(userRepo),
+		ResetTwoFactor:             command.NewResetTwoFactorHandler(userRepo),
+		RevokeSession:              command.NewRevokeSessionHandler(sessionRepo),
+		RevokeAllSessions:          command.NewRevokeAllSessionsHandler(sessionRepo),
+		CreateToken:                command.NewCreateTokenHandler(tokenRepo),
+		RevokeToken:                command.NewRevokeTokenHandler(tokenRepo),
+		RestoreTrashItem:           command.NewRestoreTrashItemHandler(trashRepo, translationRepo, tagRepo, langRepo),
+		PurgeTrash:                 command.NewPurgeTrashHandler(trashRepo, revisionRepo),
+		PurgeExpiredTrash:          command.NewPurgeExpiredTrashHandler(trashRepo, revisionRepo),
 	}
 
 	validate := validator.New()
 
 	queries := app.Queries{
-		SingleTranslation:  query.NewSingleTranslationHandler(translationRepo, validate),
-		SearchTranslations: query.NewSearchTranslationsHandler(translationRepo, validate),
-		RandomTranslations: query.NewRandomTranslationsHandler(translationRepo, validate),
-		SingleTag:          query.NewSingleTagHandler(tagRepo, validate),
-		AllTags:            query.NewAllTagsHandler(tagRepo, validate),
-		SingleUser:         query.NewSingleUserHandler(userRepo, validate),
-		AllUsers:           query.NewAllUsersHandler(userRepo),
-		SingleLang:         query.NewSingleLangHandler(langRepo, validate),
-		AllLangs:           query.NewAllLangsHandler(langRepo, validate),
-		AllRoles:           query.NewAllRolesHandler(),
+		SingleTranslation:    query.NewSingleTranslationHandler(translationRepo, validate),
+		SearchTranslations:   query.NewSearchTranslationsHandler(translationRepo, tagRepo, validate),
+		RandomTranslations:   query.NewRandomTranslationsHandler(translationRepo, tagRepo, validate),
+		DueReviews:           query.NewDueReviewsHandler(translationRepo, tagRepo, validate),
+		FuzzyTranslations:    query.NewFuzzyTranslationsHandler(translationRepo, validate),
+		TranslationRevisions: query.NewTranslationRevisionsHandler(revisionRepo, validate),
+		SingleTag:            query.NewSingleTagHandler(tagRepo, validate),
+		AllTags:              query.NewAllTagsHandler(tagRepo, validate),
+		TagTree:              query.NewTagTreeHandler(tagRepo, validate),
+		SingleUser:           query.NewSingleUserHandler(userRepo, validate),
+		AllUsers:             query.NewAllUsersHandler(userRepo),
+		SingleLang:           query.NewSingleLangHandler(langRepo, validate),
+		AllLangs:             query.NewAllLangsHandler(langRepo, validate),
+		AllRoles:             query.NewAllRolesHandler(),
+		AllTrashItems:        query.NewAllTrashItemsHandler(trashRepo, validate),
+		AllSessions:          query.NewAllSessionsHandler(sessionRepo, validate),
+		AllTokens:            query.NewAllTokensHandler(tokenRepo, validate),
+		DictionaryStats:      query.NewDictionaryStatsHandler(translationRepo, validate),
+		ExportDictionary:     query.NewExportDictionaryHandler(langRepo, tagRepo, translationRepo, validate),
 	}
 
 	application := app.Application{
@@ -79,13 +116,12 @@ func initTestServer() *testHTTPServer {
 		Queries:  queries,
 	}
 
-	authHandler := auth.NewHandler(userRepo, cipher, auth.Params{
-		AuthTTL:    opts.Auth.TTL.Auth,
-		RefreshTTL: opts.Auth.TTL.Refresh,
-		Secret:     opts.Auth.Secret,
-	})
+	authHandler := auth.NewHandler(userRepo, userRepo, sessionRepo, tokenRepo, cipher, authParams(opts.Auth))
 
-	router := gin.Default()
+	router, err := newRouter(opts)
+	if err != nil {
+		panic(err)
+	}
 
 	s := HTTPServer{
 		engine:      router,
@@ -100,13 +136,36 @@ func initTestServer() *testHTTPServer {
 }
 
 func setAdminAuthToken(t *testing.T, s *testHTTPServer, r *http.Request) {
-	token, err := s.authHandler.Authenticate(s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd)
+	token, err := s.authHandler.Authenticate(s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd, "")
 	assert.NoError(t, err)
 	r.Header.Set("Authorization", token.Type+" "+token.Token)
 }
 
 func setAuthTokenWithCredentials(t *testing.T, s *testHTTPServer, r *http.Request, email, passwd string) {
-	token, err := s.authHandler.Authenticate(email, passwd)
+	token, err := s.authHandler.Authenticate(email, passwd, "")
 	assert.NoError(t, err)
 	r.Header.Set("Authorization", token.Type+" "+token.Token)
 }
+
+func TestNewRouter_trustedProxies(t *testing.T) {
+	clientIP := func(router *gin.Engine, remoteAddr string) string {
+		router.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+		req, _ := http.NewRequest("GET", "/ip", http.NoBody)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", "10.0.0.1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	router, err := newRouter(Opts{})
+	assert.Nil(t, err)
+	assert.Equal(t, "192.0.2.1", clientIP(router, "192.0.2.1:1234"), "proxies are not trusted by default")
+
+	router, err = newRouter(Opts{TrustedProxies: []string{"172.16.0.0/12"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", clientIP(router, "172.18.0.5:1234"))
+
+	_, err = newRouter(Opts{TrustedProxies: []string{"invalid"}})
+	assert.Error(t, err)
+}
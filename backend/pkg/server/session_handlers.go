@@ -0,0 +1,96 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"net/http"
+)
+
+const sessionIDParam = "sessionId"
+
+func (s *HTTPServer) GetSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		views, err := s.app.Queries.AllSessions.Handle(query.AllSessions{UserID: user.ID})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not get sessions from DB - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, sessionsResponse{Items: s.sessionViewsToResponse(views, s.currentSessionID(c))})
+	}
+}
+
+func (s *HTTPServer) RevokeSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		if err = s.app.Commands.RevokeSession.Handle(command.RevokeSession{
+			ID:     c.Param(sessionIDParam),
+			UserID: user.ID,
+		}); err != nil {
+			s.badRequest(c, fmt.Errorf("can not revoke session: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, http.NoBody)
+	}
+}
+
+// RevokeAllSessions signs the user out on all other devices, the session of the request refresh token cookie is kept
+func (s *HTTPServer) RevokeAllSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		count, err := s.app.Commands.RevokeAllSessions.Handle(command.RevokeAllSessions{
+			UserID:   user.ID,
+			ExceptID: s.currentSessionID(c),
+		})
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not revoke sessions: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, sessionRevokeResponse{Count: count})
+	}
+}
+
+func (s *HTTPServer) sessionViewsToResponse(views []query.SessionView, currentID string) []sessionResponse {
+	responses := make([]sessionResponse, len(views))
+
+	for i, view := range views {
+		responses[i] = sessionResponse{
+			ID:          view.ID,
+			UserAgent:   view.UserAgent,
+			IP:          view.IP,
+			CreatedAt:   view.CreatedAt,
+			RefreshedAt: view.RefreshedAt,
+			ExpiresAt:   view.ExpiresAt,
+			Current:     view.ID == currentID,
+		}
+	}
+
+	return responses
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const v1SessionAPI = "/v1/api/sessions"
+
+func TestServer_RefreshTokenRotation(t *testing.T) {
+	s := initTestServer()
+	token := signIn(t, s, s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd)
+
+	rotated := refreshToken(t, s, token, http.StatusOK)
+	assert.NotEmpty(t, rotated)
+	assert.NotEqual(t, token, rotated)
+
+	// the used token is presented again, so the session is considered stolen and revoked
+	refreshToken(t, s, token, http.StatusUnauthorized)
+	refreshToken(t, s, rotated, http.StatusUnauthorized)
+}
+
+func TestServer_RefreshTokenRotation_parallel(t *testing.T) {
+	s := initTestServer()
+	token := signIn(t, s, s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd)
+
+	const requests = 5
+	codes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("POST", authAPI+"/refresh", http.NoBody)
+			req.AddCookie(&http.Cookie{Name: refreshTokenCookieName, Value: token})
+			w := httptest.NewRecorder()
+			s.engine.ServeHTTP(w, req)
+			codes <- w.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	succeeded := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			succeeded++
+		}
+	}
+	// only one request exchanges the token, the rest are treated as its reuse
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestServer_Logout(t *testing.T) {
+	s := initTestServer()
+	token := signIn(t, s, s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd)
+
+	req, _ := http.NewRequest("POST", authAPI+"/logout", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: refreshTokenCookieName, Value: token})
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Set-Cookie"), "refreshToken=;")
+
+	refreshToken(t, s, token, http.StatusUnauthorized)
+}
+
+func TestServer_Sessions(t *testing.T) {
+	s := initTestServer()
+	first := signIn(t, s, s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd)
+	second := signIn(t, s, s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd)
+	current := signIn(t, s, s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd)
+
+	sessions := getSessions(t, s, current)
+	assert.Equal(t, 3, len(sessions))
+	currentCount := 0
+	for _, session := range sessions {
+		if session.Current {
+			currentCount++
+		}
+	}
+	assert.Equal(t, 1, currentCount)
+
+	req, _ := http.NewRequest("DELETE", v1SessionAPI+"/"+s.authHandler.SessionID(first), http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	refreshToken(t, s, first, http.StatusUnauthorized)
+
+	req, _ = http.NewRequest("DELETE", v1SessionAPI, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	req.AddCookie(&http.Cookie{Name: refreshTokenCookieName, Value: current})
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response sessionRevokeResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	refreshToken(t, s, second, http.StatusUnauthorized)
+
+	sessions = getSessions(t, s, current)
+	assert.Equal(t, 1, len(sessions))
+	assert.True(t, sessions[0].Current)
+}
+
+func TestServer_PasswordChangeRevokesOtherSessions(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	passwd := "testPassword"
+	createUser(t, s, "John Do", email, passwd)
+
+	other := signIn(t, s, email, passwd)
+	current := signIn(t, s, email, passwd)
+
+	jsonValue, _ := json.Marshal(updateProfileRequest{Name: "John Do", Email: email, CurrentPassword: passwd, NewPassword: "newPasswd12345"})
+	req, _ := http.NewRequest("PUT", v1ProfileAPI, bytes.NewBuffer(jsonValue))
+	setAuthTokenWithCredentials(t, s, req, email, passwd)
+	req.AddCookie(&http.Cookie{Name: refreshTokenCookieName, Value: current})
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	refreshToken(t, s, other, http.StatusUnauthorized)
+	refreshToken(t, s, current, http.StatusOK)
+}
+
+func TestServer_DeleteUserRevokesSessions(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	passwd := "testPassword"
+	response := createUser(t, s, "John Do", email, passwd)
+	token := signIn(t, s, email, passwd)
+
+	req, _ := http.NewRequest("DELETE", v1UserAPI+"/"+response.ID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	refreshToken(t, s, token, http.StatusUnauthorized)
+}
+
+func signIn(t *testing.T, s *testHTTPServer, email, passwd string) string {
+	jsonValue, _ := json.Marshal(signInRequest{Email: email, Password: passwd})
+	req, _ := http.NewRequest("POST", authAPI+"/signin", bytes.NewBuffer(jsonValue))
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	return refreshTokenFromCookie(w)
+}
+
+// refreshToken exchanges refresh token and returns the rotated one
+func refreshToken(t *testing.T, s *testHTTPServer, token string, code int) string {
+	req, _ := http.NewRequest("POST", authAPI+"/refresh", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: refreshTokenCookieName, Value: token})
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+	return refreshTokenFromCookie(w)
+}
+
+func refreshTokenFromCookie(w *httptest.ResponseRecorder) string {
+	return strings.TrimPrefix(strings.Split(w.Header().Get("Set-Cookie"), ";")[0], refreshTokenCookieName+"=")
+}
+
+func getSessions(t *testing.T, s *testHTTPServer, token string) []sessionResponse {
+	req, _ := http.NewRequest("GET", v1SessionAPI, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	req.AddCookie(&http.Cookie{Name: refreshTokenCookieName, Value: token})
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response sessionsResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Items
+}
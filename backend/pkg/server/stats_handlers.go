@@ -0,0 +1,89 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"net/http"
+	"time"
+)
+
+// defaultStatsDays amount of the last days translations added statistics is returned for when the range is not passed
+const defaultStatsDays = 30
+
+func (s *HTTPServer) GetStats() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		statsQuery := query.DictionaryStats{
+			AuthorID: user.ID,
+			Period:   query.StatsPeriod(c.DefaultQuery("period", string(query.StatsPeriodDay))),
+		}
+
+		if statsQuery.AddedFrom, err = s.parseQueryDate(c.Query("addedFrom"), false); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse addedFrom - %v", err))
+			return
+		}
+
+		if statsQuery.AddedTo, err = s.parseQueryDate(c.Query("addedTo"), true); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse addedTo - %v", err))
+			return
+		}
+
+		if statsQuery.AddedTo.IsZero() {
+			statsQuery.AddedTo = time.Now()
+		}
+
+		if statsQuery.AddedFrom.IsZero() {
+			statsQuery.AddedFrom = statsQuery.AddedTo.AddDate(0, 0, -defaultStatsDays+1)
+		}
+
+		stats, err := s.app.Queries.DictionaryStats.Handle(statsQuery)
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not return dictionary statistics - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, s.statsViewToResponse(stats, statsQuery.Period))
+	}
+}
+
+func (s *HTTPServer) statsViewToResponse(view query.StatsView, period query.StatsPeriod) statsResponse {
+	response := statsResponse{
+		Total:    view.Total,
+		Untagged: view.Untagged,
+		Langs:    make([]langStatsResponse, 0, len(view.Langs)),
+		Tags:     make([]tagStatsResponse, 0, len(view.Tags)),
+		Period:   string(period),
+		Added:    make([]addedStatsResponse, 0, len(view.Added)),
+	}
+
+	for _, langStats := range view.Langs {
+		response.Langs = append(response.Langs, langStatsResponse{
+			Lang:  s.langViewToResponse(langStats.Lang),
+			Count: langStats.Count,
+		})
+	}
+
+	for _, tagStats := range view.Tags {
+		response.Tags = append(response.Tags, tagStatsResponse{
+			Tag:   tagResponse{ID: tagStats.Tag.ID, Name: tagStats.Tag.Name},
+			Count: tagStats.Count,
+		})
+	}
+
+	for _, added := range view.Added {
+		response.Added = append(response.Added, addedStatsResponse{
+			PeriodStart: added.PeriodStart.Format(dateLayout),
+			Count:       added.Count,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const v1StatsAPI = "/v1/api/stats"
+
+func TestServer_GetStats(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	deID := createLang(t, s, "DE")
+	createTag(t, s, "verb")
+	tagID := getExistingTags(t, s)[0].ID
+
+	requests := []translationRequest{
+		{Source: "go", Target: "gehen", TagIds: []string{tagID}, LangID: enID},
+		{Source: "run", Target: "laufen", TagIds: []string{tagID}, LangID: enID},
+		{Source: "table", Target: "Tisch", LangID: enID},
+		{Source: "Hund", Target: "dog", LangID: deID},
+	}
+	for _, request := range requests {
+		jsonValue, _ := json.Marshal(request)
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	today := time.Now().UTC()
+	req, _ := http.NewRequest("GET", v1StatsAPI+"?period=day&addedFrom="+today.AddDate(0, 0, -2).Format(dateLayout)+"&addedTo="+today.Format(dateLayout), http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response statsResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 4, response.Total)
+	assert.Equal(t, 2, response.Untagged)
+	assert.Equal(t, []langStatsResponse{
+		{Lang: langResponse{ID: enID, Name: "EN", Direction: "ltr", Fields: []langFieldResponse{}}, Count: 3},
+		{Lang: langResponse{ID: deID, Name: "DE", Direction: "ltr", Fields: []langFieldResponse{}}, Count: 1},
+	}, response.Langs)
+	assert.Equal(t, []tagStatsResponse{{Tag: tagResponse{ID: tagID, Name: "verb"}, Count: 2}}, response.Tags)
+	assert.Equal(t, []addedStatsResponse{
+		{PeriodStart: today.AddDate(0, 0, -2).Format(dateLayout), Count: 0},
+		{PeriodStart: today.AddDate(0, 0, -1).Format(dateLayout), Count: 0},
+		{PeriodStart: today.Format(dateLayout), Count: 4},
+	}, response.Added)
+
+	req, _ = http.NewRequest("GET", v1StatsAPI+"?period=week", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	response = statsResponse{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "week", response.Period)
+	assert.Equal(t, 4, response.Added[len(response.Added)-1].Count)
+}
+
+func TestServer_GetStatsInvalidParams(t *testing.T) {
+	s := initTestServer()
+
+	for _, params := range []string{"?period=month", "?addedFrom=yesterday", "?addedFrom=2023-03-10&addedTo=2023-03-01", "?addedFrom=2000-01-01&addedTo=2023-01-01"} {
+		req, _ := http.NewRequest("GET", v1StatsAPI+params, http.NoBody)
+		setAdminAuthToken(t, s, req)
+		w := httptest.NewRecorder()
+		s.engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, params)
+	}
+}
+
+func TestServer_GetStatsUnauthorised(t *testing.T) {
+	s := initTestServer()
+
+	req, _ := http.NewRequest("GET", v1StatsAPI, http.NoBody)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
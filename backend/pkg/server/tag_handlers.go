@@ -7,6 +7,7 @@ import (
 	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
 	"github.com/macyan13/webdict/backend/pkg/app/query"
 	"net/http"
+	"strconv"
 )
 
 const tagIDParam = "tagId"
@@ -29,6 +30,7 @@ func (s *HTTPServer) CreateTag() gin.HandlerFunc { //nolint:dupl // it's not ful
 
 		id, err := s.app.Commands.AddTag.Handle(command.AddTag{
 			Name:     request.Name,
+			ParentID: request.ParentID,
 			AuthorID: user.ID,
 		})
 
@@ -56,14 +58,14 @@ func (s *HTTPServer) GetTags() gin.HandlerFunc {
 			return
 		}
 
-		tags, err := s.app.Queries.AllTags.Handle(query.AllTags{AuthorID: user.ID})
+		tree, err := s.app.Queries.TagTree.Handle(query.TagTree{AuthorID: user.ID})
 
 		if err != nil {
 			s.badRequest(c, fmt.Errorf("can not get tags from DB - %v", err))
 			return
 		}
 
-		c.JSON(http.StatusOK, s.tagViewsToResponse(tags))
+		c.JSON(http.StatusOK, s.tagTreeToResponse(tree))
 	}
 }
 
@@ -86,6 +88,7 @@ func (s *HTTPServer) UpdateTag() gin.HandlerFunc { //nolint:dupl // it's not ful
 		if err = s.app.Commands.UpdateTag.Handle(command.UpdateTag{
 			TagID:    c.Param(tagIDParam),
 			Name:     request.Name,
+			ParentID: request.ParentID,
 			AuthorID: user.ID,
 		}); err != nil {
 			if err == tag.ErrTagAlreadyExists {
@@ -134,9 +137,12 @@ func (s *HTTPServer) DeleteTagByID() gin.HandlerFunc {
 			return
 		}
 
+		detach, _ := strconv.ParseBool(c.Query("detach"))
+
 		if err := s.app.Commands.DeleteTag.Handle(command.DeleteTag{
 			ID:       c.Param(tagIDParam),
 			AuthorID: user.ID,
+			Detach:   detach,
 		}); err != nil {
 			s.badRequest(c, fmt.Errorf("can not delete tag: %v", err))
 			return
@@ -146,18 +152,55 @@ func (s *HTTPServer) DeleteTagByID() gin.HandlerFunc {
 	}
 }
 
+// MergeTags merges the tag from the path into the target one from the request body
+func (s *HTTPServer) MergeTags() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		var request mergeTagsRequest
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse merge tags request: %v", err))
+			return
+		}
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		retagged, err := s.app.Commands.MergeTags.Handle(command.MergeTags{
+			SourceID: c.Param(tagIDParam),
+			TargetID: request.TargetID,
+			AuthorID: user.ID,
+		})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not merge tags: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, mergeTagsResponse{Retagged: retagged})
+	}
+}
+
 func (s *HTTPServer) tagViewToResponse(tg query.TagView) tagResponse {
 	return tagResponse{
-		ID:   tg.ID,
-		Name: tg.Name,
+		ID:       tg.ID,
+		Name:     tg.Name,
+		ParentID: tg.ParentID,
 	}
 }
 
-func (s *HTTPServer) tagViewsToResponse(tags []query.TagView) []tagResponse {
-	responses := make([]tagResponse, len(tags))
+// tagTreeToResponse converts tags tree to response keeping nested tags in children of their parents
+func (s *HTTPServer) tagTreeToResponse(tree []query.TagTreeView) []tagResponse {
+	responses := make([]tagResponse, len(tree))
 
-	for i, t := range tags {
-		responses[i] = s.tagViewToResponse(t)
+	for i := range tree {
+		responses[i] = s.tagViewToResponse(tree[i].Tag)
+		if len(tree[i].Children) != 0 {
+			responses[i].Children = s.tagTreeToResponse(tree[i].Children)
+		}
 	}
 
 	return responses
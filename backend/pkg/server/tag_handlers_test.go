@@ -185,6 +185,136 @@ func TestServer_GetTagsUnauthorised(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
+func TestServer_NestedTags(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+	createTag(t, s, "parent")
+	parentID := getExistingTags(t, s)[0].ID
+	childID := createNestedTag(t, s, "child", parentID, http.StatusCreated)
+	createNestedTag(t, s, "unknown", "missing", http.StatusBadRequest)
+
+	tags := getExistingTags(t, s)
+	assert.Equal(t, 1, len(tags))
+	assert.Equal(t, parentID, tags[0].ID)
+	assert.Equal(t, 1, len(tags[0].Children))
+	assert.Equal(t, childID, tags[0].Children[0].ID)
+	assert.Equal(t, parentID, tags[0].Children[0].ParentID)
+
+	jsonValue, _ := json.Marshal(tagRequest{Name: "parent", ParentID: childID})
+	req, _ := http.NewRequest("PUT", v1TagAPI+"/"+parentID, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	jsonValue, _ = json.Marshal(translationRequest{Source: "go", Target: "gehen", TagIds: []string{childID}, LangID: langID})
+	req, _ = http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	req, _ = http.NewRequest("GET", v1TranslationAPI+"?pageSize=10&page=1&tagId[]="+parentID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	var response lastTranslationsResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, len(response.Translations))
+
+	req, _ = http.NewRequest("DELETE", v1TagAPI+"/"+parentID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_MergeTags(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+	targetID := createNestedTag(t, s, "verb", "", http.StatusCreated)
+	sourceID := createNestedTag(t, s, "verbs", "", http.StatusCreated)
+	childID := createNestedTag(t, s, "irregular", sourceID, http.StatusCreated)
+	createTaggedTranslation(t, s, "go", langID, []string{sourceID, targetID})
+	createTaggedTranslation(t, s, "run", langID, []string{sourceID})
+
+	mergeTags(t, s, sourceID, sourceID, http.StatusBadRequest)
+	mergeTags(t, s, sourceID, childID, http.StatusBadRequest)
+	response := mergeTags(t, s, sourceID, targetID, http.StatusOK)
+	assert.Equal(t, 2, response.Retagged)
+
+	tags := getExistingTags(t, s)
+	assert.Equal(t, 1, len(tags))
+	assert.Equal(t, targetID, tags[0].ID)
+	assert.Equal(t, childID, tags[0].Children[0].ID)
+
+	for _, tr := range getExistingTranslations(t, s, langID) {
+		assert.Equal(t, 1, len(tr.Senses[0].Tags))
+		assert.Equal(t, targetID, tr.Senses[0].Tags[0].ID)
+	}
+}
+
+func TestServer_DeleteTagWithDetach(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+	tagID := createNestedTag(t, s, "verb", "", http.StatusCreated)
+	createTaggedTranslation(t, s, "go", langID, []string{tagID})
+
+	req, _ := http.NewRequest("DELETE", v1TagAPI+"/"+tagID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req, _ = http.NewRequest("DELETE", v1TagAPI+"/"+tagID+"?detach=true", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.Zero(t, len(getExistingTags(t, s)))
+	assert.Zero(t, len(getExistingTranslations(t, s, langID)[0].Senses[0].Tags))
+}
+
+func mergeTags(t *testing.T, s *testHTTPServer, sourceID, targetID string, code int) mergeTagsResponse {
+	jsonValue, _ := json.Marshal(mergeTagsRequest{TargetID: targetID})
+	req, _ := http.NewRequest("POST", v1TagAPI+"/"+sourceID+"/merge", bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+
+	var response mergeTagsResponse
+	if code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return response
+}
+
+func createTaggedTranslation(t *testing.T, s *testHTTPServer, source, langID string, tagIDs []string) {
+	jsonValue, _ := json.Marshal(translationRequest{Source: source, Target: "test", TagIds: tagIDs, LangID: langID})
+	req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func createNestedTag(t *testing.T, s *testHTTPServer, name, parentID string, code int) string {
+	jsonValue, _ := json.Marshal(tagRequest{Name: name, ParentID: parentID})
+	req, _ := http.NewRequest("POST", v1TagAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, code, w.Code)
+
+	var response idResponse
+	if code == http.StatusCreated {
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return response.ID
+}
+
 func getExistingTags(t *testing.T, s *testHTTPServer) []tagResponse {
 	req, _ := http.NewRequest("GET", v1TagAPI, http.NoBody)
 	setAdminAuthToken(t, s, req)
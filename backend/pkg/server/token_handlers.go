@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"net/http"
+	"time"
+)
+
+const tokenIDParam = "tokenId"
+
+func (s *HTTPServer) GetTokens() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		views, err := s.app.Queries.AllTokens.Handle(query.AllTokens{UserID: user.ID})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not get personal access tokens from DB - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, tokensResponse{Items: s.tokenViewsToResponse(views)})
+	}
+}
+
+// CreateToken creates personal access token, its secret is returned only in the response
+func (s *HTTPServer) CreateToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		var request tokenRequest
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse personal access token request: %v", err))
+			return
+		}
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		cmd := command.CreateToken{
+			UserID: user.ID,
+			Name:   request.Name,
+			Scopes: request.Scopes,
+		}
+		if request.ExpiresAt != nil {
+			cmd.ExpiresAt = *request.ExpiresAt
+		}
+
+		created, err := s.app.Commands.CreateToken.Handle(cmd)
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not create personal access token: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, createdTokenResponse{ID: created.ID, Token: created.Secret})
+	}
+}
+
+func (s *HTTPServer) RevokeToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		if err = s.app.Commands.RevokeToken.Handle(command.RevokeToken{
+			ID:     c.Param(tokenIDParam),
+			UserID: user.ID,
+		}); err != nil {
+			s.badRequest(c, fmt.Errorf("can not revoke personal access token: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, http.NoBody)
+	}
+}
+
+func (s *HTTPServer) tokenViewsToResponse(views []query.TokenView) []tokenResponse {
+	responses := make([]tokenResponse, len(views))
+	now := time.Now()
+
+	for i, view := range views {
+		responses[i] = tokenResponse{
+			ID:         view.ID,
+			Name:       view.Name,
+			Scopes:     view.Scopes,
+			CreatedAt:  view.CreatedAt,
+			ExpiresAt:  optionalTime(view.ExpiresAt),
+			LastUsedAt: optionalTime(view.LastUsedAt),
+			Expired:    !view.ExpiresAt.IsZero() && !view.ExpiresAt.After(now),
+		}
+	}
+
+	return responses
+}
+
+// optionalTime zero time is returned as null instead of year 1 date
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const v1TokenAPI = "/v1/api/tokens"
+
+func TestServer_PersonalAccessTokens(t *testing.T) {
+	s := initTestServer()
+	email := "john@test.com"
+	passwd := "testPassword"
+	createUser(t, s, "John Do", email, passwd)
+	authToken, err := s.authHandler.Authenticate(email, passwd, "")
+	assert.Nil(t, err)
+
+	w := apiRequest(s, "POST", v1TokenAPI, authToken.Token, tokenRequest{Name: "cron", Scopes: []string{"users:read"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = apiRequest(s, "POST", v1TokenAPI, authToken.Token, tokenRequest{Name: "cron", Scopes: []string{"read", "langs:write"}})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created createdTokenResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.True(t, strings.HasPrefix(created.Token, token.SecretPrefix))
+
+	w = apiRequest(s, "GET", v1TokenAPI, authToken.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var tokens tokensResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.Equal(t, 1, len(tokens.Items))
+	assert.Equal(t, created.ID, tokens.Items[0].ID)
+	assert.Equal(t, []string{"read", "langs:write"}, tokens.Items[0].Scopes)
+	assert.Nil(t, tokens.Items[0].ExpiresAt)
+	assert.Nil(t, tokens.Items[0].LastUsedAt)
+	assert.NotContains(t, w.Body.String(), created.Token, "the secret is shown only on creation")
+
+	assert.Equal(t, http.StatusOK, apiRequest(s, "GET", v1TagAPI, created.Token, nil).Code)
+	assert.Equal(t, http.StatusForbidden, apiRequest(s, "POST", v1TagAPI, created.Token, tagRequest{Name: "test"}).Code)
+	assert.Equal(t, http.StatusCreated, apiRequest(s, "POST", v1LangAPI, created.Token, langRequest{Name: "English", Code: "EN"}).Code)
+
+	// personal access tokens can not be used to manage the account
+	assert.Equal(t, http.StatusForbidden, apiRequest(s, "GET", v1ProfileAPI, created.Token, nil).Code)
+	assert.Equal(t, http.StatusForbidden, apiRequest(s, "GET", v1SessionAPI, created.Token, nil).Code)
+	assert.Equal(t, http.StatusForbidden, apiRequest(s, "POST", v1TokenAPI, created.Token, tokenRequest{Name: "cron", Scopes: []string{"write"}}).Code)
+
+	w = apiRequest(s, "GET", v1TokenAPI, authToken.Token, nil)
+	tokens = tokensResponse{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.NotNil(t, tokens.Items[0].LastUsedAt)
+
+	assert.Equal(t, http.StatusOK, apiRequest(s, "DELETE", v1TokenAPI+"/"+created.ID, authToken.Token, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, apiRequest(s, "GET", v1TagAPI, created.Token, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, apiRequest(s, "DELETE", v1TokenAPI+"/"+created.ID, authToken.Token, nil).Code)
+}
+
+func TestServer_PersonalAccessTokens_expiration(t *testing.T) {
+	s := initTestServer()
+	authToken, err := s.authHandler.Authenticate(s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd, "")
+	assert.Nil(t, err)
+
+	past := time.Now().Add(-time.Minute)
+	w := apiRequest(s, "POST", v1TokenAPI, authToken.Token, tokenRequest{Name: "cron", Scopes: []string{"read"}, ExpiresAt: &past})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	future := time.Now().Add(time.Hour)
+	w = apiRequest(s, "POST", v1TokenAPI, authToken.Token, tokenRequest{Name: "cron", Scopes: []string{"read"}, ExpiresAt: &future})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = apiRequest(s, "GET", v1TokenAPI, authToken.Token, nil)
+	var tokens tokensResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.WithinDuration(t, future, *tokens.Items[0].ExpiresAt, time.Second)
+	assert.False(t, tokens.Items[0].Expired)
+
+	// personal access tokens of the admin do not grant access to admin routes
+	var created createdTokenResponse
+	w = apiRequest(s, "POST", v1TokenAPI, authToken.Token, tokenRequest{Name: "admin", Scopes: []string{"write"}})
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, http.StatusForbidden, apiRequest(s, "GET", v1UserAPI, created.Token, nil).Code)
+}
+
+func TestServer_PersonalAccessTokens_importScopes(t *testing.T) {
+	s := initTestServer()
+	authToken, err := s.authHandler.Authenticate(s.opts.Admin.AdminEmail, s.opts.Admin.AdminPasswd, "")
+	assert.Nil(t, err)
+	langID := createLang(t, s, "EN")
+
+	var created, full createdTokenResponse
+	w := apiRequest(s, "POST", v1TokenAPI, authToken.Token, tokenRequest{Name: "import", Scopes: []string{"translations:write", "dictionary:write"}})
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &created))
+	w = apiRequest(s, "POST", v1TokenAPI, authToken.Token, tokenRequest{Name: "full", Scopes: []string{"write"}})
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &full))
+
+	importWords := func(secret, params string) int {
+		body := bytes.Buffer{}
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile(importFileField, "words.csv")
+		assert.Nil(t, err)
+		_, err = part.Write([]byte("go,gehen,,verb\n"))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+
+		req, _ := http.NewRequest("POST", v1TranslationAPI+"/import"+params, &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+secret)
+		w := httptest.NewRecorder()
+		s.engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, importWords(created.Token, "?createMissing=true&langId="+langID))
+	assert.Equal(t, http.StatusOK, importWords(created.Token, "?langId="+langID))
+	assert.Equal(t, http.StatusOK, importWords(full.Token, "?createMissing=true&langId="+langID))
+
+	document := dictionaryDocument{Version: dictionaryVersion, Langs: []langResponse{{ID: "newLang", Name: "German"}}}
+	assert.Equal(t, http.StatusForbidden, apiRequest(s, "POST", v1DictionaryAPI+"/import?mode=replace", created.Token, document).Code)
+	assert.Equal(t, http.StatusForbidden, apiRequest(s, "POST", v1DictionaryAPI+"/import", created.Token, document).Code)
+	assert.Equal(t, http.StatusOK, apiRequest(s, "POST", v1DictionaryAPI+"/import", full.Token, document).Code)
+	assert.Equal(t, http.StatusOK, apiRequest(s, "POST", v1DictionaryAPI+"/import", authToken.Token, document).Code)
+}
+
+func apiRequest(s *testHTTPServer, method, url, token string, body interface{}) *httptest.ResponseRecorder {
+	var payload io.Reader = http.NoBody
+	if body != nil {
+		jsonValue, _ := json.Marshal(body)
+		payload = bytes.NewBuffer(jsonValue)
+	}
+
+	req, _ := http.NewRequest(method, url, payload)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/macyan13/webdict/backend/pkg/app/command"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"net/http"
+)
+
+const trashItemIDParam = "trashItemId"
+
+func (s *HTTPServer) GetTrashItems() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		views, err := s.app.Queries.AllTrashItems.Handle(query.AllTrashItems{AuthorID: user.ID})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not get trash items from DB - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, trashItemsResponse{Items: s.trashItemViewsToResponse(views)})
+	}
+}
+
+func (s *HTTPServer) RestoreTrashItem() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		if err = s.app.Commands.RestoreTrashItem.Handle(command.RestoreTrashItem{
+			ID:       c.Param(trashItemIDParam),
+			AuthorID: user.ID,
+		}); err != nil {
+			switch err {
+			case translation.ErrSourceAlreadyExists:
+				s.badRequest(c, fmt.Errorf("can not restore translation as translation with the same source already exists"))
+			case tag.ErrTagAlreadyExists:
+				s.badRequest(c, fmt.Errorf("can not restore tag as tag with the same name already exists"))
+			case lang.ErrLangAlreadyExists:
+				s.badRequest(c, fmt.Errorf("can not restore lang as lang with the same name already exists"))
+			default:
+				s.badRequest(c, fmt.Errorf("can not restore trash item: %v", err))
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, http.NoBody)
+	}
+}
+
+func (s *HTTPServer) PurgeTrashItem() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		count, err := s.app.Commands.PurgeTrash.Handle(command.PurgeTrash{
+			ID:       c.Param(trashItemIDParam),
+			AuthorID: user.ID,
+		})
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not purge trash item: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, trashPurgeResponse{Count: count})
+	}
+}
+
+func (s *HTTPServer) PurgeTrash() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		count, err := s.app.Commands.PurgeTrash.Handle(command.PurgeTrash{AuthorID: user.ID})
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not purge trash: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, trashPurgeResponse{Count: count})
+	}
+}
+
+func (s *HTTPServer) trashItemViewsToResponse(views []query.TrashItemView) []trashItemResponse {
+	responses := make([]trashItemResponse, len(views))
+
+	for i, view := range views {
+		responses[i] = trashItemResponse{
+			ID:        view.ID,
+			Kind:      view.Kind,
+			EntityID:  view.EntityID,
+			Title:     view.Title,
+			DeletedAt: view.DeletedAt,
+		}
+	}
+
+	return responses
+}
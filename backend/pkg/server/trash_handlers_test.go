@@ -0,0 +1,146 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const v1TrashAPI = "/v1/api/trash"
+
+func TestServer_RestoreTrashItem(t *testing.T) {
+	s := initTestServer()
+	createTag(t, s, "test")
+	id := getExistingTags(t, s)[0].ID
+
+	req, _ := http.NewRequest("DELETE", v1TagAPI+"/"+id, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Zero(t, len(getExistingTags(t, s)))
+
+	items := getTrashItems(t, s)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "tag", items[0].Kind)
+	assert.Equal(t, id, items[0].EntityID)
+	assert.Equal(t, "test", items[0].Title)
+
+	req, _ = http.NewRequest("POST", v1TrashAPI+"/"+items[0].ID+"/restore", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	tags := getExistingTags(t, s)
+	assert.Equal(t, 1, len(tags))
+	assert.Equal(t, id, tags[0].ID)
+	assert.Zero(t, len(getTrashItems(t, s)))
+}
+
+func TestServer_RestoreTrashTranslationWithSameSource(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	jsonValue, _ := json.Marshal(translationRequest{Source: "test", Target: "test", LangID: langID})
+	req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	s.engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	id := getExistingTranslations(t, s, langID)[0].ID
+	req, _ = http.NewRequest("DELETE", v1TranslationAPI+"/"+id, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Zero(t, len(getExistingTranslations(t, s, langID)))
+
+	req, _ = http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	items := getTrashItems(t, s)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "translation", items[0].Kind)
+
+	req, _ = http.NewRequest("POST", v1TrashAPI+"/"+items[0].ID+"/restore", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 1, len(getTrashItems(t, s)))
+}
+
+func TestServer_RestoreTrashItemNotFound(t *testing.T) {
+	s := initTestServer()
+
+	req, _ := http.NewRequest("POST", v1TrashAPI+"/notExisting/restore", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_PurgeTrash(t *testing.T) {
+	s := initTestServer()
+	createTag(t, s, "test1")
+	createTag(t, s, "test2")
+
+	for _, tg := range getExistingTags(t, s) {
+		req, _ := http.NewRequest("DELETE", v1TagAPI+"/"+tg.ID, http.NoBody)
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	items := getTrashItems(t, s)
+	assert.Equal(t, 2, len(items))
+
+	req, _ := http.NewRequest("DELETE", v1TrashAPI+"/"+items[0].ID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, len(getTrashItems(t, s)))
+
+	req, _ = http.NewRequest("DELETE", v1TrashAPI, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response trashPurgeResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Zero(t, len(getTrashItems(t, s)))
+}
+
+func TestServer_GetTrashItemsUnauthorised(t *testing.T) {
+	s := initTestServer()
+
+	req, _ := http.NewRequest("GET", v1TrashAPI, http.NoBody)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func createTag(t *testing.T, s *testHTTPServer, name string) {
+	jsonValue, _ := json.Marshal(tagRequest{Name: name})
+	req, _ := http.NewRequest("POST", v1TagAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func getTrashItems(t *testing.T, s *testHTTPServer) []trashItemResponse {
+	req, _ := http.NewRequest("GET", v1TrashAPI, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+
+	var response trashItemsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Nil(t, err)
+	return response.Items
+}
@@ -8,9 +8,13 @@ import (
 	"github.com/macyan13/webdict/backend/pkg/app/query"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 const translationIDParam = "translationId"
+const revisionIDParam = "revisionId"
+const dateLayout = "2006-01-02"
+const defaultFuzzyDistance = 2
 
 func (s *HTTPServer) CreateTranslation() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -29,12 +33,11 @@ func (s *HTTPServer) CreateTranslation() gin.HandlerFunc {
 
 		id, err := s.app.Commands.AddTranslation.Handle(command.AddTranslation{
 			Transcription: request.Transcription,
-			Target:        request.Target,
 			Source:        request.Source,
-			Example:       request.Example,
-			TagIDs:        request.TagIds,
+			Senses:        s.translationRequestToSenses(request),
 			AuthorID:      user.ID,
 			LangID:        request.LangID,
+			Fields:        request.Fields,
 		})
 
 		if err != nil {
@@ -59,30 +62,99 @@ func (s *HTTPServer) SearchTranslations() gin.HandlerFunc {
 			s.unauthorized(c, err)
 		}
 
-		var pageSize, page int
-		pageSize, _ = strconv.Atoi(c.Query("pageSize"))
-		page, _ = strconv.Atoi(c.Query("page"))
+		searchQuery, err := s.searchQueryFromRequest(c, user.ID)
+		if err != nil {
+			s.badRequest(c, err)
+			return
+		}
 
-		lastViews, err := s.app.Queries.SearchTranslations.Handle(query.SearchTranslations{
-			AuthorID:   user.ID,
-			PageSize:   pageSize,
-			Page:       page,
-			TagIds:     c.QueryArray("tagId[]"),
-			LangID:     c.Query("langId"),
-			SourcePart: c.Query("sourcePart"),
-			TargetPart: c.Query("targetPart"),
-		})
+		searchQuery.PageSize, _ = strconv.Atoi(c.Query("pageSize"))
+		searchQuery.Page, _ = strconv.Atoi(c.Query("page"))
+		searchQuery.Cursor = c.Query("cursor")
+
+		lastViews, err := s.app.Queries.SearchTranslations.Handle(searchQuery)
 
 		if err != nil {
 			s.badRequest(c, fmt.Errorf("can not return last translations - %v", err))
 			return
 		}
 
-		c.JSON(http.StatusOK, lastTranslationsResponse{
+		response := lastTranslationsResponse{
 			Translations: s.translationViewsToResponse(lastViews.Views),
 			TotalRecords: lastViews.TotalRecords,
-		})
+		}
+
+		if lastViews.Next != nil {
+			response.NextCursor = lastViews.Next.Token()
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// searchQueryFromRequest returns translations search query with filter conditions and sort order passed in request params
+func (s *HTTPServer) searchQueryFromRequest(c *gin.Context, authorID string) (query.SearchTranslations, error) {
+	searchQuery := query.SearchTranslations{
+		AuthorID:   authorID,
+		TagIds:     c.QueryArray("tagId[]"),
+		LangIDs:    s.langIDsFromQuery(c),
+		SourcePart: c.Query("sourcePart"),
+		TargetPart: c.Query("targetPart"),
+		TextPart:   c.Query("textPart"),
+		Sort:       query.SortOrder(c.Query("sort")),
+	}
+
+	dates := []struct {
+		param    string
+		endOfDay bool
+		value    *time.Time
+	}{
+		{"createdFrom", false, &searchQuery.CreatedFrom},
+		{"createdTo", true, &searchQuery.CreatedTo},
+		{"updatedFrom", false, &searchQuery.UpdatedFrom},
+		{"updatedTo", true, &searchQuery.UpdatedTo},
+	}
+
+	var err error
+	for _, date := range dates {
+		if *date.value, err = s.parseQueryDate(c.Query(date.param), date.endOfDay); err != nil {
+			return query.SearchTranslations{}, fmt.Errorf("can not parse %s - %v", date.param, err)
+		}
+	}
+
+	return searchQuery, nil
+}
+
+// langIDsFromQuery returns langs requested by single langId or langId[] list params, no langs means any lang
+func (s *HTTPServer) langIDsFromQuery(c *gin.Context) []string {
+	langIDs := c.QueryArray("langId[]")
+	if langID := c.Query("langId"); langID != "" {
+		langIDs = append(langIDs, langID)
+	}
+
+	return langIDs
+}
+
+// parseQueryDate accepts RFC3339 or date only values, date only upper bound includes the whole day
+func (s *HTTPServer) parseQueryDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339 or %s format", dateLayout)
 	}
+
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return date, nil
 }
 
 func (s *HTTPServer) GetRandomTranslations() gin.HandlerFunc {
@@ -98,7 +170,7 @@ func (s *HTTPServer) GetRandomTranslations() gin.HandlerFunc {
 
 		lastViews, err := s.app.Queries.RandomTranslations.Handle(query.RandomTranslations{
 			AuthorID: user.ID,
-			LangID:   c.Query("langId"),
+			LangIDs:  s.langIDsFromQuery(c),
 			TagIds:   c.QueryArray("tagId[]"),
 			Limit:    limit,
 		})
@@ -114,6 +186,119 @@ func (s *HTTPServer) GetRandomTranslations() gin.HandlerFunc {
 	}
 }
 
+func (s *HTTPServer) GetDueTranslations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+		}
+
+		limit, _ := strconv.Atoi(c.Query("limit"))
+
+		dueViews, err := s.app.Queries.DueReviews.Handle(query.DueReviews{
+			AuthorID: user.ID,
+			LangID:   c.Query("langId"),
+			TagIds:   c.QueryArray("tagId[]"),
+			Limit:    limit,
+		})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not return translations due for review - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, dueTranslationsResponse{
+			Translations: s.translationViewsToResponse(dueViews.Views),
+		})
+	}
+}
+
+func (s *HTTPServer) GetFuzzyTranslations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+		}
+
+		limit, _ := strconv.Atoi(c.Query("limit"))
+
+		maxDistance := defaultFuzzyDistance
+		if param := c.Query("maxDistance"); param != "" {
+			if maxDistance, err = strconv.Atoi(param); err != nil {
+				s.badRequest(c, fmt.Errorf("can not parse maxDistance - %v", err))
+				return
+			}
+		}
+
+		fuzzyViews, err := s.app.Queries.FuzzyTranslations.Handle(query.FuzzyTranslations{
+			AuthorID:    user.ID,
+			LangID:      c.Query("langId"),
+			Text:        c.Query("text"),
+			MaxDistance: maxDistance,
+			Limit:       limit,
+		})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not return fuzzy translations - %v", err))
+			return
+		}
+
+		translations := make([]fuzzyTranslationResponse, 0, len(fuzzyViews.Views))
+		for _, view := range fuzzyViews.Views {
+			translations = append(translations, fuzzyTranslationResponse{
+				Translation: s.translationViewToResponse(view.Translation),
+				Distance:    view.Distance,
+			})
+		}
+
+		c.JSON(http.StatusOK, fuzzyTranslationsResponse{Translations: translations})
+	}
+}
+
+func (s *HTTPServer) ReviewTranslation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		var request reviewRequest
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			s.badRequest(c, fmt.Errorf("can not parse translation review request: %v", err))
+			return
+		}
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		if err = s.app.Commands.ReviewTranslation.Handle(command.ReviewTranslation{
+			ID:       c.Param(translationIDParam),
+			AuthorID: user.ID,
+			Grade:    request.Grade,
+		}); err != nil {
+			s.badRequest(c, fmt.Errorf("can not review translation: %v", err))
+			return
+		}
+
+		view, err := s.app.Queries.SingleTranslation.Handle(query.SingleTranslation{
+			ID:       c.Param(translationIDParam),
+			AuthorID: user.ID,
+		})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not get reviewed record - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, s.reviewViewToResponse(view.Review))
+	}
+}
+
 func (s *HTTPServer) UpdateTranslation() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
@@ -132,13 +317,12 @@ func (s *HTTPServer) UpdateTranslation() gin.HandlerFunc {
 
 		if err = s.app.Commands.UpdateTranslation.Handle(command.UpdateTranslation{
 			ID:            c.Param(translationIDParam),
-			Target:        request.Target,
 			Transcription: request.Transcription,
 			Source:        request.Source,
-			Example:       request.Example,
-			TagIDs:        request.TagIds,
+			Senses:        s.translationRequestToSenses(request),
 			AuthorID:      user.ID,
 			LangID:        request.LangID,
+			Fields:        request.Fields,
 		}); err != nil {
 			if err == translation.ErrSourceAlreadyExists {
 				s.badRequest(c, fmt.Errorf("translation with source %s already exists", request.Source))
@@ -204,6 +388,67 @@ func (s *HTTPServer) DeleteTranslationByID() gin.HandlerFunc {
 	}
 }
 
+func (s *HTTPServer) GetTranslationRevisions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		views, err := s.app.Queries.TranslationRevisions.Handle(query.TranslationRevisions{
+			ID:       c.Param(translationIDParam),
+			AuthorID: user.ID,
+		})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not return translation revisions - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, revisionsResponse{Revisions: s.revisionViewsToResponse(views)})
+	}
+}
+
+func (s *HTTPServer) RestoreTranslationRevision() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		user, err := s.authHandler.UserFromContext(c)
+		if err != nil {
+			s.unauthorized(c, err)
+			return
+		}
+
+		if err = s.app.Commands.RestoreTranslationRevision.Handle(command.RestoreTranslationRevision{
+			ID:         c.Param(translationIDParam),
+			RevisionID: c.Param(revisionIDParam),
+			AuthorID:   user.ID,
+		}); err != nil {
+			if err == translation.ErrSourceAlreadyExists {
+				s.badRequest(c, fmt.Errorf("can not restore revision as translation with the same source already exists"))
+				return
+			}
+			s.badRequest(c, fmt.Errorf("can not restore translation revision: %v", err))
+			return
+		}
+
+		view, err := s.app.Queries.SingleTranslation.Handle(query.SingleTranslation{
+			ID:       c.Param(translationIDParam),
+			AuthorID: user.ID,
+		})
+
+		if err != nil {
+			s.badRequest(c, fmt.Errorf("can not get restored record - %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, s.translationViewToResponse(view))
+	}
+}
+
 func (s *HTTPServer) translationViewsToResponse(translations []query.TranslationView) []translationResponse {
 	responses := make([]translationResponse, len(translations))
 
@@ -214,27 +459,102 @@ func (s *HTTPServer) translationViewsToResponse(translations []query.Translation
 	return responses
 }
 
+// translationRequestToSenses converts request senses to cmd senses, request with plain target, example and tags is treated as a single sense one
+func (s *HTTPServer) translationRequestToSenses(request translationRequest) []command.TranslationSense {
+	if len(request.Senses) == 0 {
+		return []command.TranslationSense{{Target: request.Target, Example: request.Example, TagIDs: request.TagIds}}
+	}
+
+	senses := make([]command.TranslationSense, len(request.Senses))
+
+	for i, sense := range request.Senses {
+		senses[i] = command.TranslationSense{
+			Target:  sense.Target,
+			Example: sense.Example,
+			TagIDs:  sense.TagIds,
+		}
+	}
+
+	return senses
+}
+
 func (s *HTTPServer) translationViewToResponse(view query.TranslationView) translationResponse {
-	tags := make([]tagResponse, len(view.Tags))
+	senses := make([]senseResponse, len(view.Senses))
+
+	for i, sense := range view.Senses {
+		tags := make([]tagResponse, len(sense.Tags))
+
+		for j, tag := range sense.Tags {
+			tags[j] = tagResponse{
+				ID:   tag.ID,
+				Name: tag.Name,
+			}
+		}
 
-	for i, tag := range view.Tags {
-		tags[i] = tagResponse{
-			ID:   tag.ID,
-			Name: tag.Name,
+		senses[i] = senseResponse{
+			Target:  sense.Target,
+			Example: sense.Example,
+			Tags:    tags,
 		}
 	}
 
-	return translationResponse{
+	response := translationResponse{
 		ID:            view.ID,
 		CreatedAt:     view.CreatedAd,
 		Transcription: view.Transcription,
-		Target:        view.Target,
 		Source:        view.Source,
-		Example:       view.Example,
-		Tags:          tags,
-		Lang: langResponse{
-			ID:   view.Lang.ID,
-			Name: view.Lang.Name,
-		},
+		Senses:        senses,
+		Tags:          []tagResponse{},
+		Lang:          s.langViewToResponse(view.Lang),
+		Review:        s.reviewViewToResponse(view.Review),
+		Fields:        map[string]string{},
+	}
+
+	for name, value := range view.Fields {
+		response.Fields[name] = value
+	}
+
+	// the primary sense is duplicated on the top level for clients which are not aware of senses
+	if len(senses) != 0 {
+		response.Target = senses[0].Target
+		response.Example = senses[0].Example
+		response.Tags = senses[0].Tags
+	}
+
+	return response
+}
+
+func (s *HTTPServer) reviewViewToResponse(view query.ReviewView) reviewResponse {
+	return reviewResponse{
+		Ease:        view.Ease,
+		Interval:    view.Interval,
+		Repetitions: view.Repetitions,
+		Lapses:      view.Lapses,
+		DueAt:       view.DueAt,
+		ReviewedAt:  view.ReviewedAt,
+	}
+}
+
+func (s *HTTPServer) revisionViewsToResponse(views []query.RevisionView) []revisionResponse {
+	responses := make([]revisionResponse, len(views))
+
+	for i, view := range views {
+		changes := make([]fieldChangeResponse, len(view.Changes))
+
+		for j, change := range view.Changes {
+			changes[j] = fieldChangeResponse{
+				Field:    change.Field,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			}
+		}
+
+		responses[i] = revisionResponse{
+			ID:        view.ID,
+			CreatedAt: view.CreatedAt,
+			Changes:   changes,
+		}
 	}
+
+	return responses
 }
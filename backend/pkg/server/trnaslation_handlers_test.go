@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 )
 
 const v1TranslationAPI = "/v1/api/translations"
@@ -61,6 +63,7 @@ func TestServer_CreateTranslation(t *testing.T) {
 	assert.Equal(t, transcription, created.Transcription)
 	assert.Equal(t, example, created.Example)
 	assert.Equal(t, tagID, created.Tags[0].ID)
+	assert.Equal(t, []senseResponse{{Target: tr, Example: example, Tags: created.Tags}}, created.Senses)
 	assert.Equal(t, ln, created.Lang.Name)
 }
 
@@ -146,6 +149,260 @@ func TestServer_SearchTranslationBySourcePart(t *testing.T) {
 	assert.Zero(t, len(getExistingTranslations(t, s, langID)))
 }
 
+func TestServer_SearchTranslationByCombinedFilter(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+	createTag(t, s, "searchTag")
+	tagID := getExistingTags(t, s)[0].ID
+
+	requests := []translationRequest{
+		{Source: "combinedSource", Target: "target", Example: "usage example", TagIds: []string{tagID}, LangID: langID},
+		{Source: "combinedOther", Target: "target", Example: "usage example", LangID: langID},
+		{Source: "combinedThird", Target: "target", Example: "another", TagIds: []string{tagID}, LangID: langID},
+	}
+	for _, request := range requests {
+		jsonValue, _ := json.Marshal(request)
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=10&page=1&langId="+langID+"&sourcePart=combined&textPart=usage&tagId[]="+tagID+"&createdFrom="+today+"&createdTo="+today, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response lastTranslationsResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.TotalRecords)
+	assert.Equal(t, "combinedSource", response.Translations[0].Source)
+}
+
+func TestServer_SearchTranslationIgnoresCaseAndDiacriticsRankingMatches(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "DE")
+
+	for _, source := range []string{"zuber", "Überall", "Über"} {
+		jsonValue, _ := json.Marshal(translationRequest{Source: source, Target: "test", LangID: langID})
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	records := getExistingTranslationsByPart(t, s, langID, "", "uber")
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, "Über", records[0].Source)
+	assert.Equal(t, "Überall", records[1].Source)
+	assert.Equal(t, "zuber", records[2].Source)
+}
+
+func TestServer_SearchTranslationTextPartIgnoresCaseAndDiacritics(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "DE")
+
+	requests := []translationRequest{
+		{Source: "Über", Target: "over", LangID: langID},
+		{Source: "Haus", Target: "Crème", LangID: langID},
+		{Source: "Katze", Target: "cat", Example: "Die Katze ist CRÈMEFARBEN", LangID: langID},
+		{Source: "Hund", Target: "dog", Transcription: "[Über]", LangID: langID},
+	}
+	for _, request := range requests {
+		jsonValue, _ := json.Marshal(request)
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	for textPart, want := range map[string]int{"uber": 2, "creme": 2, "CRÈME": 2, "Dog": 1} {
+		req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=10&page=1&langId="+langID+"&textPart="+url.QueryEscape(textPart), http.NoBody)
+		setAdminAuthToken(t, s, req)
+		w := httptest.NewRecorder()
+		s.engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response lastTranslationsResponse
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, want, response.TotalRecords, textPart)
+	}
+}
+
+func TestServer_GetFuzzyTranslations(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	for _, source := range []string{"receive", "recieve", "relieve", "deceive"} {
+		jsonValue, _ := json.Marshal(translationRequest{Source: source, Target: "test", LangID: langID})
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"/fuzzy?limit=10&maxDistance=1&text=RECEIVE&langId="+langID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response fuzzyTranslationsResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, len(response.Translations))
+	assert.Equal(t, "receive", response.Translations[0].Translation.Source)
+	assert.Equal(t, 0, response.Translations[0].Distance)
+	assert.Equal(t, "deceive", response.Translations[1].Translation.Source)
+	assert.Equal(t, 1, response.Translations[1].Distance)
+
+	req, _ = http.NewRequest("GET", v1TranslationAPI+"/fuzzy?limit=10&text=receive&langId="+langID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, len(response.Translations))
+	assert.Equal(t, "recieve", response.Translations[2].Translation.Source)
+	assert.Equal(t, 2, response.Translations[2].Distance)
+}
+
+func TestServer_GetFuzzyTranslationsInvalidDistance(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"/fuzzy?limit=10&maxDistance=10&text=word&langId="+langID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_SearchTranslationAcrossLangs(t *testing.T) {
+	s := initTestServer()
+	enID := createLang(t, s, "EN")
+	deID := createLang(t, s, "DE")
+	frID := createLang(t, s, "FR")
+
+	for _, langID := range []string{enID, deID, frID} {
+		jsonValue, _ := json.Marshal(translationRequest{Source: "word", Target: "test", LangID: langID})
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	search := func(params string) lastTranslationsResponse {
+		req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=10&page=1&sourcePart=word"+params, http.NoBody)
+		setAdminAuthToken(t, s, req)
+		w := httptest.NewRecorder()
+		s.engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response lastTranslationsResponse
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	assert.Equal(t, 3, search("").TotalRecords)
+
+	response := search("&langId[]=" + enID + "&langId[]=" + deID)
+	assert.Equal(t, 2, response.TotalRecords)
+	langs := []string{response.Translations[0].Lang.Name, response.Translations[1].Lang.Name}
+	assert.ElementsMatch(t, []string{"EN", "DE"}, langs)
+
+	response = search("&langId=" + frID)
+	assert.Equal(t, 1, response.TotalRecords)
+	assert.Equal(t, "FR", response.Translations[0].Lang.Name)
+
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"/random?limit=10", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var random randomTranslationsResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &random))
+	assert.Equal(t, 3, len(random.Translations))
+}
+
+func TestServer_SearchTranslationByCursor(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	for _, source := range []string{"delta", "alpha", "echo", "charlie", "bravo"} {
+		jsonValue, _ := json.Marshal(translationRequest{Source: source, Target: "test", LangID: langID})
+		req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+		setAdminAuthToken(t, s, req)
+		s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var sources []string
+	cursor := ""
+	for i := 0; i < 5; i++ {
+		req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=2&sort=source_asc&langId="+langID+"&cursor="+cursor, http.NoBody)
+		setAdminAuthToken(t, s, req)
+		w := httptest.NewRecorder()
+		s.engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response lastTranslationsResponse
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		for _, record := range response.Translations {
+			sources = append(sources, record.Source)
+		}
+
+		if cursor = response.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"alpha", "bravo", "charlie", "delta", "echo"}, sources)
+
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=2&sort=created_asc&langId="+langID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response lastTranslationsResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "delta", response.Translations[0].Source)
+	assert.Equal(t, "alpha", response.Translations[1].Source)
+	assert.NotEmpty(t, response.NextCursor)
+
+	req, _ = http.NewRequest("GET", v1TranslationAPI+"?pageSize=2&page=10&langId="+langID, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	response = lastTranslationsResponse{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 0, len(response.Translations))
+	assert.Equal(t, 5, response.TotalRecords)
+}
+
+func TestServer_SearchTranslationCursorOfAnotherSort(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	cursor := query.Cursor{Sort: query.SortSourceAsc, Text: "alpha", ID: "id"}
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=2&sort=updated_desc&langId="+langID+"&cursor="+cursor.Token(), http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_SearchTranslationInvalidDate(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=10&page=1&langId="+langID+"&createdFrom=yesterday", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestServer_DeleteTranslationByIdUnauthorised(t *testing.T) {
 	s := initTestServer()
 	langID := createLang(t, s, "EN")
@@ -181,10 +438,12 @@ func TestServer_UpdateTranslation(t *testing.T) {
 
 	request := translationRequest{
 		Transcription: transcription,
-		Target:        tr,
 		Source:        source,
-		Example:       example,
-		LangID:        langID,
+		Senses: []senseRequest{
+			{Target: tr, Example: example},
+			{Target: "secondTranslation"},
+		},
+		LangID: langID,
 	}
 	jsonValue, _ = json.Marshal(request)
 	req, _ = http.NewRequest("PUT", v1TranslationAPI+"/"+id, bytes.NewBuffer(jsonValue))
@@ -206,6 +465,10 @@ func TestServer_UpdateTranslation(t *testing.T) {
 	assert.Equal(t, source, record.Source)
 	assert.Equal(t, transcription, record.Transcription)
 	assert.Equal(t, example, record.Example)
+	assert.Equal(t, []senseResponse{
+		{Target: tr, Example: example, Tags: []tagResponse{}},
+		{Target: "secondTranslation", Tags: []tagResponse{}},
+	}, record.Senses)
 	assert.Equal(t, ln, record.Lang.Name)
 }
 
@@ -251,6 +514,135 @@ func TestServer_UpdateTranslationUnauthorised(t *testing.T) {
 	assert.Equal(t, originalTranslation, record.Target)
 }
 
+func TestServer_ReviewTranslation(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	jsonValue, _ := json.Marshal(translationRequest{Source: "test", Target: "test", LangID: langID})
+	req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	s.engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	due := getDueTranslations(t, s, langID)
+	assert.Equal(t, 1, len(due))
+	id := due[0].ID
+
+	jsonValue, _ = json.Marshal(reviewRequest{Grade: 5})
+	req, _ = http.NewRequest("POST", v1TranslationAPI+"/"+id+"/review", bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var review reviewResponse
+	err := json.Unmarshal(w.Body.Bytes(), &review)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, review.Repetitions)
+	assert.Equal(t, 1, review.Interval)
+	assert.True(t, review.DueAt.After(time.Now()))
+
+	assert.Zero(t, len(getDueTranslations(t, s, langID)))
+}
+
+func TestServer_ReviewTranslationInvalidGrade(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	jsonValue, _ := json.Marshal(translationRequest{Source: "test", Target: "test", LangID: langID})
+	req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	id := getExistingTranslations(t, s, langID)[0].ID
+
+	jsonValue, _ = json.Marshal(reviewRequest{Grade: 10})
+	req, _ = http.NewRequest("POST", v1TranslationAPI+"/"+id+"/review", bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 1, len(getDueTranslations(t, s, langID)))
+}
+
+func TestServer_TranslationRevisions(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	jsonValue, _ := json.Marshal(translationRequest{Source: "test", Target: "original", Example: "example", LangID: langID})
+	req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	id := getExistingTranslations(t, s, langID)[0].ID
+
+	jsonValue, _ = json.Marshal(translationRequest{Source: "test", Target: "changed", Example: "example", LangID: langID})
+	req, _ = http.NewRequest("PUT", v1TranslationAPI+"/"+id, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	revisions := getTranslationRevisions(t, s, id)
+	assert.Equal(t, 1, len(revisions))
+	assert.Equal(t, []fieldChangeResponse{{Field: "senses[0].target", OldValue: "original", NewValue: "changed"}}, revisions[0].Changes)
+
+	req, _ = http.NewRequest("POST", v1TranslationAPI+"/"+id+"/revisions/"+revisions[0].ID+"/restore", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w = httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var restored translationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &restored)
+	assert.Nil(t, err)
+	assert.Equal(t, "original", restored.Target)
+	assert.Equal(t, "example", restored.Example)
+
+	assert.Equal(t, 2, len(getTranslationRevisions(t, s, id)))
+}
+
+func TestServer_RestoreTranslationRevisionNotFound(t *testing.T) {
+	s := initTestServer()
+	langID := createLang(t, s, "EN")
+
+	jsonValue, _ := json.Marshal(translationRequest{Source: "test", Target: "test", LangID: langID})
+	req, _ := http.NewRequest("POST", v1TranslationAPI, bytes.NewBuffer(jsonValue))
+	setAdminAuthToken(t, s, req)
+	s.engine.ServeHTTP(httptest.NewRecorder(), req)
+	id := getExistingTranslations(t, s, langID)[0].ID
+
+	req, _ = http.NewRequest("POST", v1TranslationAPI+"/"+id+"/revisions/unknown/restore", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func getTranslationRevisions(t *testing.T, s *testHTTPServer, id string) []revisionResponse {
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"/"+id+"/revisions", http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response revisionsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Nil(t, err)
+
+	return response.Revisions
+}
+
+func getDueTranslations(t *testing.T, s *testHTTPServer, lang string) []translationResponse {
+	req, _ := http.NewRequest("GET", v1TranslationAPI+"/due?limit=10&langId="+lang, http.NoBody)
+	setAdminAuthToken(t, s, req)
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+
+	var response dueTranslationsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Nil(t, err)
+
+	return response.Translations
+}
+
 func getExistingTranslations(t *testing.T, s *testHTTPServer, lang string) []translationResponse {
 	req, _ := http.NewRequest("GET", v1TranslationAPI+"?pageSize=10&page=1&langId="+lang, http.NoBody)
 	setAdminAuthToken(t, s, req)
@@ -3,16 +3,40 @@ package server
 import "time"
 
 type translationRequest struct {
-	Source        string   `json:"source"`
-	Transcription string   `json:"transcription"`
-	Target        string   `json:"target"`
-	Example       string   `json:"example"`
-	TagIds        []string `json:"tag_ids"`
-	LangID        string   `json:"lang_id"`
+	Source        string            `json:"source"`
+	Transcription string            `json:"transcription"`
+	Senses        []senseRequest    `json:"senses"`
+	Target        string            `json:"target"`
+	Example       string            `json:"example"`
+	TagIds        []string          `json:"tag_ids"`
+	LangID        string            `json:"lang_id"`
+	Fields        map[string]string `json:"fields"` // Fields custom field values, boolean values are passed as "true" or "false"
+}
+
+type senseRequest struct {
+	Target  string   `json:"target"`
+	Example string   `json:"example"`
+	TagIds  []string `json:"tag_ids"`
+}
+
+type reviewRequest struct {
+	Grade int `json:"grade"`
+}
+
+type bulkTranslationsRequest struct {
+	IDs          []string `json:"ids"`
+	AddTagIDs    []string `json:"add_tag_ids"`
+	RemoveTagIDs []string `json:"remove_tag_ids"`
+	LangID       string   `json:"lang_id"`
 }
 
 type tagRequest struct {
-	Name string `json:"name"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+}
+
+type mergeTagsRequest struct {
+	TargetID string `json:"target_id"`
 }
 
 type userRequest struct {
@@ -36,7 +60,18 @@ type profileListOptions struct {
 }
 
 type langRequest struct {
-	Name string `json:"name"`
+	Name                string             `json:"name"`
+	Code                string             `json:"code"`
+	Direction           string             `json:"direction"` // Direction is detected by the code when it is not passed
+	TranscriptionScheme string             `json:"transcription_scheme"`
+	Fields              []langFieldRequest `json:"fields"`
+}
+
+// langFieldRequest custom field of the lang translations, kind is one of text, enum or boolean, options are set for enum only
+type langFieldRequest struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`
+	Options []string `json:"options"`
 }
 
 type signInRequest struct {
@@ -44,34 +79,237 @@ type signInRequest struct {
 	Password string `json:"password"`
 }
 
+type twoFactorSignInRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"` // Code TOTP or recovery code
+}
+
+type twoFactorEnrollRequest struct {
+	CurrentPassword string `json:"current_password"`
+}
+
+type twoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type tokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`     // Scopes `read`, `write` or `<resource>:<read|write>`, write access includes read one
+	ExpiresAt *time.Time `json:"expires_at"` // ExpiresAt RFC3339 expiration time, the token never expires when it is not set
+}
+
 type translationResponse struct {
-	ID            string        `json:"id"`
-	Source        string        `json:"source"`
-	Transcription string        `json:"transcription"`
-	Target        string        `json:"target"`
-	Example       string        `json:"example"`
-	Tags          []tagResponse `json:"tags"`
-	CreatedAt     time.Time     `json:"created_at"`
-	Lang          langResponse  `json:"lang"`
+	ID            string            `json:"id"`
+	Source        string            `json:"source"`
+	Transcription string            `json:"transcription"`
+	Senses        []senseResponse   `json:"senses"`
+	Target        string            `json:"target"`
+	Example       string            `json:"example"`
+	Tags          []tagResponse     `json:"tags"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Lang          langResponse      `json:"lang"`
+	Review        reviewResponse    `json:"review"`
+	Fields        map[string]string `json:"fields"`
+}
+
+type senseResponse struct {
+	Target  string        `json:"target"`
+	Example string        `json:"example"`
+	Tags    []tagResponse `json:"tags"`
+}
+
+type reviewResponse struct {
+	Ease        float64   `json:"ease"`
+	Interval    int       `json:"interval"`
+	Repetitions int       `json:"repetitions"`
+	Lapses      int       `json:"lapses"`
+	DueAt       time.Time `json:"due_at"`
+	ReviewedAt  time.Time `json:"reviewed_at"`
+}
+
+type importResponse struct {
+	DryRun       bool                     `json:"dry_run"`
+	Imported     int                      `json:"imported"`
+	CreatedTags  []string                 `json:"created_tags"`
+	CreatedLangs []string                 `json:"created_langs"`
+	Failed       []importRowErrorResponse `json:"failed"`
+}
+
+type importRowErrorResponse struct {
+	Line   int    `json:"line"`
+	Source string `json:"source"`
+	Error  string `json:"error"`
+}
+
+type bulkResponse struct {
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Items     []bulkItemResponse `json:"items"`
+}
+
+// deleteLangResponse result of lang deletion, lang is kept when some translations are not moved or deleted
+type deleteLangResponse struct {
+	Moved       int                `json:"moved"`
+	Deleted     int                `json:"deleted"`
+	LangDeleted bool               `json:"lang_deleted"`
+	Conflicts   []bulkItemResponse `json:"conflicts"`
+}
+
+// bulkItemResponse result of bulk operation for a single translation, empty error means success
+type bulkItemResponse struct {
+	ID    string `json:"id"`
+	Error string `json:"error,omitempty"`
+}
+
+// dictionaryDocument versioned document of the exported dictionary, translations reference langs and tags by IDs
+type dictionaryDocument struct {
+	Version      int                     `json:"version"`
+	ExportedAt   time.Time               `json:"exported_at"`
+	Langs        []langResponse          `json:"langs"`
+	Tags         []tagResponse           `json:"tags"`
+	Translations []dictionaryTranslation `json:"translations"`
+}
+
+type dictionaryTranslation struct {
+	ID            string            `json:"id"`
+	LangID        string            `json:"lang_id"`
+	Source        string            `json:"source"`
+	Transcription string            `json:"transcription"`
+	Senses        []dictionarySense `json:"senses"`
+	Fields        map[string]string `json:"fields,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Review        reviewResponse    `json:"review"`
+}
+
+type dictionarySense struct {
+	Target  string   `json:"target"`
+	Example string   `json:"example"`
+	TagIDs  []string `json:"tag_ids"`
+}
+
+type importDictionaryResponse struct {
+	Created      int                      `json:"created"`
+	Merged       int                      `json:"merged"`
+	Replaced     int                      `json:"replaced"`
+	Unchanged    int                      `json:"unchanged"`
+	CreatedTags  []string                 `json:"created_tags"`
+	CreatedLangs []string                 `json:"created_langs"`
+	Failed       []importRowErrorResponse `json:"failed"`
 }
 
 type lastTranslationsResponse struct {
 	Translations []translationResponse `json:"translations"`
 	TotalRecords int                   `json:"total_records"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
 }
 
 type randomTranslationsResponse struct {
 	Translations []translationResponse `json:"translations"`
 }
 
+type dueTranslationsResponse struct {
+	Translations []translationResponse `json:"translations"`
+}
+
+type fuzzyTranslationsResponse struct {
+	Translations []fuzzyTranslationResponse `json:"translations"`
+}
+
+type fuzzyTranslationResponse struct {
+	Translation translationResponse `json:"translation"`
+	Distance    int                 `json:"distance"`
+}
+
+type revisionsResponse struct {
+	Revisions []revisionResponse `json:"revisions"`
+}
+
+type revisionResponse struct {
+	ID        string                `json:"id"`
+	CreatedAt time.Time             `json:"created_at"`
+	Changes   []fieldChangeResponse `json:"changes"`
+}
+
+type fieldChangeResponse struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+type trashItemsResponse struct {
+	Items []trashItemResponse `json:"items"`
+}
+
+type trashItemResponse struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	EntityID  string    `json:"entity_id"`
+	Title     string    `json:"title"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type trashPurgeResponse struct {
+	Count int `json:"count"`
+}
+
+type sessionsResponse struct {
+	Items []sessionResponse `json:"items"`
+}
+
+type sessionResponse struct {
+	ID          string    `json:"id"`
+	UserAgent   string    `json:"user_agent"`
+	IP          string    `json:"ip"`
+	CreatedAt   time.Time `json:"created_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Current     bool      `json:"current"` // Current session of the refresh token cookie sent with the request
+}
+
+type sessionRevokeResponse struct {
+	Count int `json:"count"`
+}
+
+type tokensResponse struct {
+	Items []tokenResponse `json:"items"`
+}
+
+type tokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`   // ExpiresAt null for the token which never expires
+	LastUsedAt *time.Time `json:"last_used_at"` // LastUsedAt null for not used token
+	Expired    bool       `json:"expired"`
+}
+
+type createdTokenResponse struct {
+	ID    string `json:"id"`
+	Token string `json:"token"` // Token the secret is shown only once, it can not be received again
+}
+
 type tagResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	ParentID string        `json:"parent_id"`
+	Children []tagResponse `json:"children,omitempty"`
 }
 
 type langResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID                  string              `json:"id"`
+	Name                string              `json:"name"`
+	Code                string              `json:"code"`
+	Direction           string              `json:"direction"`
+	TranscriptionScheme string              `json:"transcription_scheme"`
+	Fields              []langFieldResponse `json:"fields"`
+}
+
+type langFieldResponse struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`
+	Options []string `json:"options"`
 }
 
 type userResponse struct {
@@ -81,6 +319,8 @@ type userResponse struct {
 	Role        roleResponse       `json:"role"`
 	DefaultLang langResponse       `json:"default_lang"`
 	ListOptions profileListOptions `json:"list_options"`
+
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
 }
 
 type roleResponse struct {
@@ -101,7 +341,50 @@ type idResponse struct {
 	ID string `json:"id"`
 }
 
+type mergeTagsResponse struct {
+	Retagged int `json:"retagged"`
+}
+
 type AuthTokenResponse struct {
 	AccessToken string `json:"accessToken"`
 	Type        string `json:"type"`
 }
+
+// twoFactorChallengeResponse is returned on sign-in instead of AuthTokenResponse for users with two-factor authentication
+type twoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+}
+
+type twoFactorEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type statsResponse struct {
+	Total    int                  `json:"total"`
+	Untagged int                  `json:"untagged"`
+	Langs    []langStatsResponse  `json:"langs"`
+	Tags     []tagStatsResponse   `json:"tags"`
+	Period   string               `json:"period"`
+	Added    []addedStatsResponse `json:"added"`
+}
+
+type langStatsResponse struct {
+	Lang  langResponse `json:"lang"`
+	Count int          `json:"count"`
+}
+
+type tagStatsResponse struct {
+	Tag   tagResponse `json:"tag"`
+	Count int         `json:"count"`
+}
+
+type addedStatsResponse struct {
+	PeriodStart string `json:"period_start"`
+	Count       int    `json:"count"`
+}
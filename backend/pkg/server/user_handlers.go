@@ -117,6 +117,34 @@ func (s *HTTPServer) DeleteUser() gin.HandlerFunc {
 	}
 }
 
+// UnlockUser resets failed sign-in attempts of the user, so the user can sign in without delay
+func (s *HTTPServer) UnlockUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		if err := s.app.Commands.UnlockUser.Handle(command.UnlockUser{ID: c.Param(userIDParam)}); err != nil {
+			s.badRequest(c, fmt.Errorf("can not unlock user: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, http.NoBody)
+	}
+}
+
+// ResetTwoFactor disables two-factor authentication of the user who lost access to authenticator app and recovery codes
+func (s *HTTPServer) ResetTwoFactor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+
+		if err := s.app.Commands.ResetTwoFactor.Handle(command.ResetTwoFactor{ID: c.Param(userIDParam)}); err != nil {
+			s.badRequest(c, fmt.Errorf("can not reset two-factor authentication: %v", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, http.NoBody)
+	}
+}
+
 func (s *HTTPServer) userViewsToResponses(users []query.UserView) []userResponse {
 	responses := make([]userResponse, len(users))
 
@@ -134,13 +162,12 @@ func (s *HTTPServer) userViewToResponse(usr query.UserView) userResponse {
 		Email:       usr.Email,
 		Role:        s.roleViewToResponse(usr.Role),
 		ListOptions: profileListOptions{HideTranscription: usr.ListOptions.HideTranscription},
+
+		TwoFactorEnabled: usr.TwoFactorEnabled,
 	}
 
 	if usr.DefaultLang.ID != "" {
-		response.DefaultLang = langResponse{
-			ID:   usr.DefaultLang.ID,
-			Name: usr.DefaultLang.Name,
-		}
+		response.DefaultLang = s.langViewToResponse(usr.DefaultLang)
 	}
 
 	return response
@@ -0,0 +1,27 @@
+package sqlite
+
+const (
+	interiorIndexPage = 0x02
+	interiorTablePage = 0x05
+	leafIndexPage     = 0x0a
+	leafTablePage     = 0x0d
+)
+
+// localSize returns amount of payload bytes stored on b-tree page of usable size, the rest goes to overflow pages
+func localSize(size, usable int, index bool) int {
+	maxLocal := usable - 35
+	if index {
+		maxLocal = (usable-12)*64/255 - 23
+	}
+
+	if size <= maxLocal {
+		return size
+	}
+
+	minLocal := (usable-12)*32/255 - 23
+	local := minLocal + (size-minLocal)%(usable-4)
+	if local > maxLocal {
+		return minLocal
+	}
+	return local
+}
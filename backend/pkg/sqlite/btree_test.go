@@ -0,0 +1,15 @@
+package sqlite
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLocalSize(t *testing.T) {
+	minLocal := (PageSize-12)*32/255 - 23
+	assert.Equal(t, 100, localSize(100, PageSize, false))
+	assert.Equal(t, PageSize-35, localSize(PageSize-35, PageSize, false))
+	assert.Equal(t, minLocal+(5000-minLocal)%(PageSize-4), localSize(5000, PageSize, false))
+	assert.Equal(t, minLocal, localSize(PageSize-34, PageSize, false))
+	assert.Equal(t, minLocal, localSize(2000, PageSize, true))
+}
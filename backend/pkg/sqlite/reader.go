@@ -0,0 +1,512 @@
+package sqlite
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+const (
+	encodingUTF8    = 1
+	encodingUTF16LE = 2
+	encodingUTF16BE = 3
+)
+
+// ErrTableNotFound is returned when the database schema has no requested table
+var ErrTableNotFound = errors.New("table is not found in database")
+
+// Database SQLite database file read to memory
+type Database struct {
+	data     []byte
+	pageSize int
+	usable   int
+	encoding uint32
+	schema   []schemaEntry
+}
+
+// schemaEntry table or index of the database schema with its b-tree root page
+type schemaEntry struct {
+	kind string
+	name string
+	sql  string
+	root int
+}
+
+// Read parses header and schema of SQLite database file
+func Read(data []byte) (*Database, error) {
+	if len(data) < 100 || string(data[:16]) != "SQLite format 3\x00" {
+		return nil, errors.New("not a SQLite database file")
+	}
+
+	pageSize := int(binary.BigEndian.Uint16(data[16:]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("invalid page size %d", pageSize)
+	}
+
+	db := &Database{
+		data:     data,
+		pageSize: pageSize,
+		usable:   pageSize - int(data[20]),
+		encoding: binary.BigEndian.Uint32(data[56:]),
+	}
+
+	if db.encoding == 0 {
+		db.encoding = encodingUTF8
+	}
+
+	if db.encoding > encodingUTF16BE {
+		return nil, fmt.Errorf("invalid text encoding %d", db.encoding)
+	}
+
+	rows, err := db.readTree(1)
+	if err != nil {
+		return nil, fmt.Errorf("can not read schema: %w", err)
+	}
+
+	for _, row := range rows {
+		if len(row.Values) < 5 {
+			continue
+		}
+
+		kind, _ := row.Values[0].(string)
+		name, _ := row.Values[1].(string)
+		sql, _ := row.Values[4].(string)
+		root, ok := row.Values[3].(int64)
+		if !ok || root == 0 {
+			// virtual tables and views have no pages
+			continue
+		}
+
+		db.schema = append(db.schema, schemaEntry{kind: kind, name: name, sql: sql, root: int(root)})
+	}
+
+	return db, nil
+}
+
+// Table returns all table rows in row ID order or in primary key order for WITHOUT ROWID table,
+// the table name is case-insensitive as in SQL
+func (db *Database) Table(name string) (Table, error) {
+	for _, entry := range db.schema {
+		if entry.kind != "table" || !strings.EqualFold(entry.name, name) {
+			continue
+		}
+
+		rows, err := db.readTree(entry.root)
+		if err != nil {
+			return Table{}, fmt.Errorf("can not read table %s: %w", entry.name, err)
+		}
+
+		_, _, withoutRowID := parseColumns(entry.sql)
+		return Table{Name: entry.name, SQL: entry.sql, Rows: rows, WithoutRowID: withoutRowID}, nil
+	}
+
+	return Table{}, ErrTableNotFound
+}
+
+// Records returns table rows as maps of column names to values, the INTEGER PRIMARY KEY column gets row IDs,
+// columns missing in records added before ALTER TABLE get nil values
+func (db *Database) Records(name string) ([]map[string]interface{}, error) {
+	table, err := db.Table(name)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, rowIDColumn, _ := parseColumns(table.SQL)
+	records := make([]map[string]interface{}, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		record := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if i < len(row.Values) {
+				record[column] = row.Values[i]
+			} else {
+				record[column] = nil
+			}
+		}
+
+		if rowIDColumn != "" {
+			record[rowIDColumn] = row.RowID
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (db *Database) page(number int) ([]byte, error) {
+	if number < 1 || number*db.pageSize > len(db.data) {
+		return nil, fmt.Errorf("page %d is out of file", number)
+	}
+	return db.data[(number-1)*db.pageSize : number*db.pageSize], nil
+}
+
+// readTree reads all rows of table b-tree or all entries of index b-tree with the root page, entries have zero row IDs
+func (db *Database) readTree(root int) ([]Row, error) {
+	var rows []Row
+	visited := map[int]struct{}{}
+
+	var walk func(number int) error
+	walk = func(number int) error {
+		if _, ok := visited[number]; ok {
+			return fmt.Errorf("page %d is referenced twice", number)
+		}
+		visited[number] = struct{}{}
+
+		page, err := db.page(number)
+		if err != nil {
+			return err
+		}
+
+		offset := 0
+		if number == 1 {
+			offset = 100
+		}
+
+		pageType := page[offset]
+		if pageType != leafTablePage && pageType != interiorTablePage && pageType != leafIndexPage && pageType != interiorIndexPage {
+			return fmt.Errorf("page %d has unexpected type %d", number, pageType)
+		}
+
+		interior := pageType == interiorTablePage || pageType == interiorIndexPage
+		headerSize := 8
+		if interior {
+			headerSize = 12
+		}
+
+		count := int(binary.BigEndian.Uint16(page[offset+3:]))
+		if offset+headerSize+2*count > len(page) {
+			return fmt.Errorf("page %d has invalid cells count", number)
+		}
+
+		for i := 0; i < count; i++ {
+			cellOffset := int(binary.BigEndian.Uint16(page[offset+headerSize+2*i:]))
+			if cellOffset >= len(page) {
+				return fmt.Errorf("page %d has invalid cell offset", number)
+			}
+			cell := page[cellOffset:]
+
+			if interior {
+				if len(cell) < 4 {
+					return fmt.Errorf("page %d has invalid cell", number)
+				}
+				if err = walk(int(binary.BigEndian.Uint32(cell))); err != nil {
+					return err
+				}
+				if pageType == interiorTablePage {
+					continue
+				}
+				// entries of interior index cells are not repeated in leaves
+				cell = cell[4:]
+			}
+
+			row, err := db.readCell(cell, pageType != leafTablePage)
+			if err != nil {
+				return fmt.Errorf("page %d: %w", number, err)
+			}
+			rows = append(rows, row)
+		}
+
+		if interior {
+			return walk(int(binary.BigEndian.Uint32(page[offset+8:])))
+		}
+
+		return nil
+	}
+
+	if err := walk(root); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// readCell reads table leaf cell or index cell without the left child pointer
+func (db *Database) readCell(cell []byte, index bool) (Row, error) {
+	size, n := readVarint(cell)
+	if n == 0 {
+		return Row{}, errors.New("invalid cell payload size")
+	}
+	cell = cell[n:]
+
+	var rowID uint64
+	if !index {
+		if rowID, n = readVarint(cell); n == 0 {
+			return Row{}, errors.New("invalid cell row ID")
+		}
+		cell = cell[n:]
+	}
+
+	if size > uint64(len(db.data)) {
+		return Row{}, errors.New("invalid cell payload size")
+	}
+
+	payload, err := db.readPayload(cell, int(size), index)
+	if err != nil {
+		return Row{}, err
+	}
+
+	values, err := db.decodeRecord(payload)
+	if err != nil {
+		return Row{}, err
+	}
+
+	return Row{RowID: int64(rowID), Values: values}, nil
+}
+
+// readPayload returns local part of the payload joined with its overflow pages
+func (db *Database) readPayload(cell []byte, size int, index bool) ([]byte, error) {
+	local := localSize(size, db.usable, index)
+	if local > len(cell) {
+		return nil, errors.New("cell exceeds page")
+	}
+
+	payload := append(make([]byte, 0, size), cell[:local]...)
+	if local == size {
+		return payload, nil
+	}
+
+	if len(cell) < local+4 {
+		return nil, errors.New("cell exceeds page")
+	}
+
+	next := int(binary.BigEndian.Uint32(cell[local:]))
+	for len(payload) < size {
+		page, err := db.page(next)
+		if err != nil {
+			return nil, fmt.Errorf("can not read overflow: %w", err)
+		}
+
+		chunk := page[4:db.usable]
+		if rest := size - len(payload); rest < len(chunk) {
+			chunk = chunk[:rest]
+		}
+		payload = append(payload, chunk...)
+		next = int(binary.BigEndian.Uint32(page))
+	}
+
+	return payload, nil
+}
+
+// decodeRecord decodes values of SQLite record, integers are returned as int64 and texts as string
+func (db *Database) decodeRecord(payload []byte) ([]interface{}, error) {
+	headerSize, n := readVarint(payload)
+	if n == 0 || headerSize < uint64(n) || headerSize > uint64(len(payload)) {
+		return nil, errors.New("invalid record header")
+	}
+
+	header := payload[n:headerSize]
+	body := payload[headerSize:]
+
+	var values []interface{}
+	for len(header) > 0 {
+		serialType, n := readVarint(header)
+		if n == 0 {
+			return nil, errors.New("invalid record serial type")
+		}
+		header = header[n:]
+
+		size := serialSize(serialType)
+		if size > uint64(len(body)) {
+			return nil, errors.New("record value exceeds payload")
+		}
+		data := body[:size]
+		body = body[size:]
+
+		value, err := db.decodeValue(serialType, data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func serialSize(serialType uint64) uint64 {
+	switch {
+	case serialType >= 12:
+		return (serialType - 12) / 2
+	case serialType == 5:
+		return 6
+	case serialType == 6, serialType == 7:
+		return 8
+	case serialType >= 1 && serialType <= 4:
+		return serialType
+	}
+	return 0
+}
+
+func (db *Database) decodeValue(serialType uint64, data []byte) (interface{}, error) {
+	switch {
+	case serialType == 0:
+		return nil, nil
+	case serialType >= 1 && serialType <= 6:
+		v := int64(int8(data[0]))
+		for _, b := range data[1:] {
+			v = v<<8 | int64(b)
+		}
+		return v, nil
+	case serialType == 7:
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+	case serialType == 8:
+		return int64(0), nil
+	case serialType == 9:
+		return int64(1), nil
+	case serialType >= 12 && serialType%2 == 0:
+		return append([]byte{}, data...), nil
+	case serialType >= 13:
+		return db.decodeText(data), nil
+	}
+
+	return nil, fmt.Errorf("invalid serial type %d", serialType)
+}
+
+func (db *Database) decodeText(data []byte) string {
+	if db.encoding == encodingUTF8 {
+		return string(data)
+	}
+
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		if db.encoding == encodingUTF16LE {
+			units = append(units, binary.LittleEndian.Uint16(data[i:]))
+		} else {
+			units = append(units, binary.BigEndian.Uint16(data[i:]))
+		}
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// parseColumns returns column names of CREATE TABLE statement in order of record values and the INTEGER PRIMARY KEY column
+// being an alias of row ID, records of WITHOUT ROWID table start with primary key columns followed by the rest ones
+func parseColumns(sql string) (columns []string, rowIDColumn string, withoutRowID bool) {
+	start, end := strings.Index(sql, "("), strings.LastIndex(sql, ")")
+	if start < 0 || end < start {
+		return nil, "", false
+	}
+
+	types := map[string]string{}
+	var primaryKey []string
+
+	for _, definition := range splitDefinitions(sql[start+1 : end]) {
+		tokens := strings.Fields(definition)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if strings.EqualFold(tokens[0], "CONSTRAINT") && len(tokens) > 2 {
+			// named table constraint
+			tokens = tokens[2:]
+			definition = strings.Join(tokens, " ")
+		}
+
+		upper := strings.ToUpper(definition)
+		switch strings.ToUpper(tokens[0]) {
+		case "UNIQUE", "CHECK", "FOREIGN":
+			continue
+		case "PRIMARY":
+			if open, closing := strings.Index(definition, "("), strings.LastIndex(definition, ")"); open >= 0 && closing > open {
+				for _, column := range strings.Split(definition[open+1:closing], ",") {
+					if fields := strings.Fields(column); len(fields) > 0 {
+						primaryKey = append(primaryKey, unquote(fields[0]))
+					}
+				}
+			}
+			continue
+		}
+
+		column, rest := splitName(definition)
+		columns = append(columns, column)
+		if fields := strings.Fields(rest); len(fields) > 0 {
+			types[column] = strings.ToUpper(fields[0])
+		}
+
+		if strings.Contains(upper, "PRIMARY KEY") {
+			primaryKey = []string{column}
+		}
+	}
+
+	if withoutRowID = strings.Contains(strings.ToUpper(sql[end:]), "WITHOUT ROWID"); withoutRowID {
+		ordered := append([]string{}, primaryKey...)
+		for _, column := range columns {
+			if !containsFold(primaryKey, column) {
+				ordered = append(ordered, column)
+			}
+		}
+		return ordered, "", true
+	}
+
+	if len(primaryKey) == 1 && types[primaryKey[0]] == "INTEGER" {
+		rowIDColumn = primaryKey[0]
+	}
+
+	return columns, rowIDColumn, false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitDefinitions splits columns and constraints definitions by commas outside of parentheses and quotes
+func splitDefinitions(definitions string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote rune
+
+	for i, r := range definitions {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '[':
+			quote = ']'
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(definitions[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(parts, strings.TrimSpace(definitions[start:]))
+}
+
+func unquote(name string) string {
+	if len(name) >= 2 && strings.ContainsRune("\"'`[", rune(name[0])) {
+		return name[1 : len(name)-1]
+	}
+	return name
+}
+
+// splitName returns unquoted name at the beginning of the definition and the rest of it
+func splitName(definition string) (name, rest string) {
+	closing := map[byte]byte{'"': '"', '\'': '\'', '`': '`', '[': ']'}
+	if end, ok := closing[definition[0]]; ok {
+		if i := strings.IndexByte(definition[1:], end); i >= 0 {
+			return definition[1 : i+1], definition[i+2:]
+		}
+	}
+
+	if i := strings.IndexFunc(definition, unicode.IsSpace); i >= 0 {
+		return definition[:i], definition[i:]
+	}
+	return definition, ""
+}
@@ -0,0 +1,198 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	var rows []Row
+	for i := 1; i <= 1000; i++ {
+		rows = append(rows, Row{RowID: int64(i), Values: []interface{}{nil, strings.Repeat("a", i%10*1000), int64(i % 3), float64(i) / 2}})
+	}
+
+	buf := bytes.Buffer{}
+	err := Write(&buf, []Table{
+		{
+			Name:    "words",
+			SQL:     "CREATE TABLE words (id integer primary key, word text, kind integer, weight real, extra text)",
+			Rows:    rows,
+			Indexes: []Index{{Name: "ix_words_kind", SQL: "CREATE INDEX ix_words_kind ON words (kind)", Columns: []int{2}}},
+		},
+		{Name: "BOOKS", SQL: "CREATE TABLE \"BOOKS\" (id TEXT PRIMARY KEY NOT NULL, title TEXT, data BLOB)", Rows: []Row{{RowID: 1, Values: []interface{}{"b1", "Title", []byte{1, 2}}}}},
+	})
+	assert.Nil(t, err)
+
+	db, err := Read(buf.Bytes())
+	assert.Nil(t, err)
+
+	records, err := db.Records("WORDS")
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, len(records))
+	assert.Equal(t, map[string]interface{}{"id": int64(999), "word": strings.Repeat("a", 9000), "kind": int64(0), "weight": 499.5, "extra": nil}, records[998])
+
+	table, err := db.Table("words")
+	assert.Nil(t, err)
+	assert.Equal(t, rows[0].Values[1:], table.Rows[0].Values[1:])
+
+	records, err = db.Records("books")
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{{"id": "b1", "title": "Title", "data": []byte{1, 2}}}, records)
+
+	_, err = db.Records("missing")
+	assert.ErrorIs(t, err, ErrTableNotFound)
+}
+
+func TestRead_WithoutRowID(t *testing.T) {
+	var rows []Row
+	for i := 1000; i > 0; i-- {
+		rows = append(rows, Row{Values: []interface{}{int64(i % 10), int64(i), strings.Repeat("a", i%7*300)}})
+	}
+
+	buf := bytes.Buffer{}
+	err := Write(&buf, []Table{{
+		Name:         "fields",
+		SQL:          "CREATE TABLE fields (name text NOT NULL, ntid integer NOT NULL, ord integer NOT NULL, PRIMARY KEY (ntid, ord)) without rowid",
+		Rows:         rows,
+		WithoutRowID: true,
+	}})
+	assert.Nil(t, err)
+
+	db, err := Read(buf.Bytes())
+	assert.Nil(t, err)
+
+	records, err := db.Records("fields")
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, len(records))
+	assert.Equal(t, map[string]interface{}{"ntid": int64(0), "ord": int64(10), "name": strings.Repeat("a", 900)}, records[0])
+	assert.Equal(t, map[string]interface{}{"ntid": int64(9), "ord": int64(999), "name": strings.Repeat("a", 1500)}, records[999])
+}
+
+func TestDatabase_decodeValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		encoding   uint32
+		serialType uint64
+		data       []byte
+		want       interface{}
+	}{
+		{"Null", encodingUTF8, 0, nil, nil},
+		{"Negative int", encodingUTF8, 2, []byte{0xff, 0xfe}, int64(-2)},
+		{"48-bit int", encodingUTF8, 5, []byte{0, 0, 0, 1, 0, 0}, int64(1 << 16)},
+		{"Zero", encodingUTF8, 8, nil, int64(0)},
+		{"One", encodingUTF8, 9, nil, int64(1)},
+		{"Blob", encodingUTF8, 16, []byte{1, 2}, []byte{1, 2}},
+		{"UTF-8 text", encodingUTF8, 17, []byte("ab"), "ab"},
+		{"UTF-16le text", encodingUTF16LE, 17, []byte{0x3f, 0x04}, "п"},
+		{"UTF-16be text", encodingUTF16BE, 17, []byte{0x04, 0x3f}, "п"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{encoding: tt.encoding}
+			got, err := db.decodeValue(tt.serialType, tt.data)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	buf := bytes.Buffer{}
+	assert.Nil(t, Write(&buf, []Table{{Name: "t", SQL: "CREATE TABLE t (a)", Rows: []Row{{RowID: 1, Values: []interface{}{"a"}}}}}))
+	valid := buf.Bytes()
+
+	tests := []struct {
+		name   string
+		change func(data []byte) []byte
+	}{
+		{"Not a database", func(data []byte) []byte { return []byte("text") }},
+		{"Invalid page size", func(data []byte) []byte { binary.BigEndian.PutUint16(data[16:], 1000); return data }},
+		{"Invalid encoding", func(data []byte) []byte { binary.BigEndian.PutUint32(data[56:], 4); return data }},
+		{"Truncated file", func(data []byte) []byte { return data[:PageSize/2] }},
+		{"Invalid page type", func(data []byte) []byte { data[100] = 1; return data }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.change(append([]byte{}, valid...)))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		sql          string
+		columns      []string
+		rowIDColumn  string
+		withoutRowID bool
+	}{
+		{"CREATE TABLE t (id integer primary key, a text, b)", []string{"id", "a", "b"}, "id", false},
+		{"CREATE TABLE t (id TEXT PRIMARY KEY NOT NULL UNIQUE, a TEXT)", []string{"id", "a"}, "", false},
+		{"CREATE TABLE t (\"id\" INTEGER, [a b] TEXT DEFAULT 'x,y', c NUMERIC(10, 2), PRIMARY KEY (\"id\"))", []string{"id", "a b", "c"}, "id", false},
+		{"CREATE TABLE t (a INTEGER, b INTEGER, PRIMARY KEY (a, b))", []string{"a", "b"}, "", false},
+		{"CREATE TABLE t (a INTEGER, b, CONSTRAINT pk PRIMARY KEY (a))", []string{"a", "b"}, "a", false},
+		{"CREATE TABLE t (a, id INTEGER PRIMARY KEY) WITHOUT ROWID", []string{"id", "a"}, "", true},
+		{"CREATE TABLE t (a, b, PRIMARY KEY( ))", []string{"a", "b"}, "", false},
+		{"CREATE TABLE t (a, b, PRIMARY KEY(a, ,b))", []string{"a", "b"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			columns, rowIDColumn, withoutRowID := parseColumns(tt.sql)
+			assert.Equal(t, tt.columns, columns)
+			assert.Equal(t, tt.rowIDColumn, rowIDColumn)
+			assert.Equal(t, tt.withoutRowID, withoutRowID)
+		})
+	}
+}
+
+func TestDatabase_decodeRecord_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{"Empty payload", nil},
+		{"Header size is smaller than its varint", []byte{0x80, 0x01, 0x01}},
+		{"Header size exceeds payload", []byte{0x05, 0x01}},
+		{"Truncated serial type", []byte{0x02, 0x81}},
+		{"Value exceeds payload", []byte{0x02, 0x19, 'a'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{encoding: encodingUTF8}
+			_, err := db.decodeRecord(tt.payload)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func FuzzRead(f *testing.F) {
+	buf := bytes.Buffer{}
+	assert.Nil(f, Write(&buf, []Table{
+		{
+			Name: "t",
+			SQL:  "CREATE TABLE t (id integer primary key, a text, b)",
+			Rows: []Row{{RowID: 1, Values: []interface{}{nil, strings.Repeat("a", 5000), int64(1)}}, {RowID: 2, Values: []interface{}{nil, "b", 0.5}}},
+		},
+		{
+			Name:         "p",
+			SQL:          "CREATE TABLE p (a, b, PRIMARY KEY (a, b)) WITHOUT ROWID",
+			Rows:         []Row{{Values: []interface{}{int64(1), "x"}}},
+			WithoutRowID: true,
+		},
+	}))
+	f.Add(buf.Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		db, err := Read(data)
+		if err != nil {
+			return
+		}
+
+		for _, entry := range db.schema {
+			_, _ = db.Records(entry.name)
+		}
+	})
+}
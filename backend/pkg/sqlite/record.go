@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// putVarint encodes v as SQLite big-endian variable length integer of 1-9 bytes
+func putVarint(v uint64) []byte {
+	if v <= 0x7f {
+		return []byte{byte(v)}
+	}
+
+	if v > 0x00ffffffffffffff {
+		buf := make([]byte, 9)
+		buf[8] = byte(v)
+		v >>= 8
+		for i := 7; i >= 0; i-- {
+			buf[i] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return buf
+	}
+
+	var buf []byte
+	for v > 0 {
+		buf = append([]byte{byte(v&0x7f) | 0x80}, buf...)
+		v >>= 7
+	}
+	buf[len(buf)-1] &= 0x7f
+	return buf
+}
+
+// readVarint decodes SQLite variable length integer, returns zero length if b is too short
+func readVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		if i >= len(b) {
+			return 0, 0
+		}
+
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+
+	if len(b) < 9 {
+		return 0, 0
+	}
+
+	return v<<8 | uint64(b[8]), 9
+}
+
+// encodeRecord encodes values in SQLite record format, supported values are nil, int64, int, float64, string and []byte
+func encodeRecord(values []interface{}) ([]byte, error) {
+	var header, body []byte
+
+	for _, value := range values {
+		serialType, data, err := serialize(value)
+		if err != nil {
+			return nil, err
+		}
+		header = append(header, putVarint(serialType)...)
+		body = append(body, data...)
+	}
+
+	// header size includes the size varint itself
+	size := len(header) + 1
+	for len(putVarint(uint64(size))) != size-len(header) {
+		size = len(header) + len(putVarint(uint64(size)))
+	}
+
+	record := append(putVarint(uint64(size)), header...)
+	return append(record, body...), nil
+}
+
+func serialize(value interface{}) (serialType uint64, data []byte, err error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil, nil
+	case int:
+		return serializeInt(int64(v))
+	case int64:
+		return serializeInt(v)
+	case float64:
+		data = make([]byte, 8)
+		binary.BigEndian.PutUint64(data, math.Float64bits(v))
+		return 7, data, nil
+	case string:
+		return uint64(13 + 2*len(v)), []byte(v), nil
+	case []byte:
+		return uint64(12 + 2*len(v)), v, nil
+	default:
+		return 0, nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+func serializeInt(v int64) (serialType uint64, data []byte, err error) {
+	switch {
+	case v == 0:
+		return 8, nil, nil
+	case v == 1:
+		return 9, nil, nil
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return 1, intBytes(v, 1), nil
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return 2, intBytes(v, 2), nil
+	case v >= -1<<23 && v < 1<<23:
+		return 3, intBytes(v, 3), nil
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return 4, intBytes(v, 4), nil
+	case v >= -1<<47 && v < 1<<47:
+		return 5, intBytes(v, 6), nil
+	default:
+		return 6, intBytes(v, 8), nil
+	}
+}
+
+// intBytes returns n lowest bytes of v in big-endian two's complement
+func intBytes(v int64, n int) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(v))
+	return data[8-n:]
+}
+
+// compareValues compares values in SQLite order: NULL, numbers, texts and blobs, texts use BINARY collation
+func compareValues(a, b interface{}) int {
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		return ra - rb
+	}
+
+	switch ra {
+	case 1:
+		fa, fb := toFloat(a), toFloat(b)
+		ia, aIsInt := toInt(a)
+		ib, bIsInt := toInt(b)
+		switch {
+		case aIsInt && bIsInt && ia < ib, !(aIsInt && bIsInt) && fa < fb:
+			return -1
+		case aIsInt && bIsInt && ia > ib, !(aIsInt && bIsInt) && fa > fb:
+			return 1
+		}
+		return 0
+	case 2:
+		return bytes.Compare([]byte(a.(string)), []byte(b.(string)))
+	case 3:
+		return bytes.Compare(a.([]byte), b.([]byte))
+	}
+
+	return 0
+}
+
+func typeRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case int, int64, float64:
+		return 1
+	case string:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestVarint(t *testing.T) {
+	tests := []struct {
+		value uint64
+		want  []byte
+	}{
+		{0, []byte{0x00}},
+		{0x7f, []byte{0x7f}},
+		{0x80, []byte{0x81, 0x00}},
+		{0x3fff, []byte{0xff, 0x7f}},
+		{0x4000, []byte{0x81, 0x80, 0x00}},
+		{math.MaxUint64, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, tt := range tests {
+		got := putVarint(tt.value)
+		assert.Equal(t, tt.want, got)
+
+		value, n := readVarint(got)
+		assert.Equal(t, tt.value, value)
+		assert.Equal(t, len(tt.want), n)
+	}
+
+	_, n := readVarint([]byte{0x81})
+	assert.Zero(t, n)
+}
+
+func TestEncodeRecord(t *testing.T) {
+	got, err := encodeRecord([]interface{}{nil, int64(0), 1, int64(-2), int64(300), 1.5, "ab", []byte{7}})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{
+		9, 0, 8, 9, 1, 2, 7, 17, 14, // header: size and serial types
+		0xfe, 0x01, 0x2c, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0, 'a', 'b', 7,
+	}, got)
+
+	_, err = encodeRecord([]interface{}{true})
+	assert.Error(t, err)
+}
+
+func TestSerializeInt(t *testing.T) {
+	tests := []struct {
+		value    int64
+		wantType uint64
+		wantLen  int
+	}{
+		{127, 1, 1},
+		{-32768, 2, 2},
+		{1 << 20, 3, 3},
+		{math.MaxInt32, 4, 4},
+		{1 << 40, 5, 6},
+		{math.MinInt64, 6, 8},
+	}
+	for _, tt := range tests {
+		serialType, data, err := serializeInt(tt.value)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.wantType, serialType)
+		assert.Equal(t, tt.wantLen, len(data))
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	assert.Negative(t, compareValues(nil, int64(-1)))
+	assert.Negative(t, compareValues(int64(5), "a"))
+	assert.Negative(t, compareValues("a", []byte{0}))
+	assert.Negative(t, compareValues(int64(2), int64(10)))
+	assert.Negative(t, compareValues(1.5, int64(2)))
+	assert.Negative(t, compareValues("B", "a"))
+	assert.Zero(t, compareValues(int64(2), 2.0))
+	assert.Positive(t, compareValues(int64(1<<62), int64(1<<62-1)))
+}
@@ -0,0 +1,402 @@
+package sqlite
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// PageSize size of pages in written database files
+const PageSize = 4096
+
+// sqliteVersion version number of SQLite library written to the file header
+const sqliteVersion = 3040001
+
+// Table table written to database file, SQL is its CREATE TABLE statement stored in the schema,
+// values of an INTEGER PRIMARY KEY column must be nil as the column is an alias of the row ID.
+// Rows of WITHOUT ROWID table are stored ordered by values without row IDs, so primary key columns must go first
+type Table struct {
+	Name         string
+	SQL          string
+	Rows         []Row
+	Indexes      []Index
+	WithoutRowID bool
+}
+
+// Row single table record, values must be in order of the table columns
+type Row struct {
+	RowID  int64
+	Values []interface{}
+}
+
+// Index table index written to database file, SQL is its CREATE INDEX statement stored in the schema,
+// Columns are positions of the indexed values in table rows
+type Index struct {
+	Name    string
+	SQL     string
+	Columns []int
+}
+
+// Write writes SQLite database file containing passed tables, the file is built in memory,
+// rows of every table must have unique row IDs
+func Write(w io.Writer, tables []Table) error {
+	b := &builder{pages: [][]byte{make([]byte, PageSize)}}
+
+	var schema []Row
+	for _, table := range tables {
+		writeTable := b.writeTable
+		if table.WithoutRowID {
+			writeTable = b.writeWithoutRowIDTable
+		}
+
+		root, err := writeTable(table.Rows, 0)
+		if err != nil {
+			return fmt.Errorf("can not write table %s: %w", table.Name, err)
+		}
+		schema = append(schema, Row{RowID: int64(len(schema) + 1), Values: []interface{}{"table", table.Name, table.Name, int64(root), table.SQL}})
+
+		for _, index := range table.Indexes {
+			root, err = b.writeIndex(table.Rows, index.Columns)
+			if err != nil {
+				return fmt.Errorf("can not write index %s: %w", index.Name, err)
+			}
+			schema = append(schema, Row{RowID: int64(len(schema) + 1), Values: []interface{}{"index", index.Name, table.Name, int64(root), index.SQL}})
+		}
+	}
+
+	if _, err := b.writeTable(schema, 1); err != nil {
+		return fmt.Errorf("can not write schema: %w", err)
+	}
+
+	b.writeHeader()
+
+	for _, page := range b.pages {
+		if _, err := w.Write(page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// builder keeps pages of the database file, page number is its position in pages plus one
+type builder struct {
+	pages [][]byte
+}
+
+// node b-tree page written to the file with the largest key of its subtree
+type node struct {
+	page   int
+	maxKey int64
+}
+
+func (b *builder) allocate() int {
+	b.pages = append(b.pages, make([]byte, PageSize))
+	return len(b.pages)
+}
+
+// space returns amount of bytes available for cells and their pointers on the page
+func (b *builder) space(page, headerSize int) int {
+	if page == 1 {
+		return PageSize - 100 - headerSize
+	}
+	return PageSize - headerSize
+}
+
+func (b *builder) writeHeader() {
+	page := b.pages[0]
+	copy(page, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(page[16:], PageSize)
+	page[18] = 1  // legacy write version
+	page[19] = 1  // legacy read version
+	page[21] = 64 // max embedded payload fraction
+	page[22] = 32 // min embedded payload fraction
+	page[23] = 32 // leaf payload fraction
+	binary.BigEndian.PutUint32(page[24:], 1)
+	binary.BigEndian.PutUint32(page[28:], uint32(len(b.pages)))
+	binary.BigEndian.PutUint32(page[40:], 1) // schema cookie
+	binary.BigEndian.PutUint32(page[44:], 4) // schema format
+	binary.BigEndian.PutUint32(page[56:], 1) // UTF-8 text encoding
+	binary.BigEndian.PutUint32(page[92:], 1)
+	binary.BigEndian.PutUint32(page[96:], sqliteVersion)
+}
+
+// writeTable writes table b-tree of rows and returns its root page, zero root means a new page
+func (b *builder) writeTable(rows []Row, root int) (int, error) {
+	rows = append([]Row{}, rows...)
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].RowID < rows[j].RowID
+	})
+
+	cells := make([][]byte, 0, len(rows))
+	for i, row := range rows {
+		if i > 0 && rows[i-1].RowID == row.RowID {
+			return 0, fmt.Errorf("duplicated row ID %d", row.RowID)
+		}
+
+		payload, err := encodeRecord(row.Values)
+		if err != nil {
+			return 0, err
+		}
+
+		cell := append(putVarint(uint64(len(payload))), putVarint(uint64(row.RowID))...)
+		cells = append(cells, append(cell, b.spill(payload, false)...))
+	}
+
+	if fits(cells, b.space(root, 8)) {
+		return b.writePage(root, leafTablePage, cells, 0), nil
+	}
+
+	var nodes []node
+	for start := 0; start < len(cells); {
+		end := start + 1
+		for end < len(cells) && fits(cells[start:end+1], b.space(0, 8)) {
+			end++
+		}
+		nodes = append(nodes, node{page: b.writePage(0, leafTablePage, cells[start:end], 0), maxKey: rows[end-1].RowID})
+		start = end
+	}
+
+	for {
+		if cells := tableInteriorCells(nodes[:len(nodes)-1]); fits(cells, b.space(root, 12)) {
+			return b.writePage(root, interiorTablePage, cells, nodes[len(nodes)-1].page), nil
+		}
+
+		var upper []node
+		for start := 0; start < len(nodes); {
+			end := start + 1
+			for end < len(nodes) && fits(tableInteriorCells(nodes[start:end]), b.space(0, 12)) {
+				end++
+			}
+			page := b.writePage(0, interiorTablePage, tableInteriorCells(nodes[start:end-1]), nodes[end-1].page)
+			upper = append(upper, node{page: page, maxKey: nodes[end-1].maxKey})
+			start = end
+		}
+		nodes = upper
+	}
+}
+
+// tableInteriorCells returns cells pointing to the left children with their largest keys
+func tableInteriorCells(children []node) [][]byte {
+	cells := make([][]byte, 0, len(children))
+	for _, child := range children {
+		cell := make([]byte, 4)
+		binary.BigEndian.PutUint32(cell, uint32(child.page))
+		cells = append(cells, append(cell, putVarint(uint64(child.maxKey))...))
+	}
+	return cells
+}
+
+// writeIndex writes index b-tree of rows values at columns positions and returns its root page
+func (b *builder) writeIndex(rows []Row, columns []int) (int, error) {
+	entries := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		entry := make([]interface{}, 0, len(columns)+1)
+		for _, column := range columns {
+			if column < 0 || column >= len(row.Values) {
+				return 0, fmt.Errorf("index column %d is out of row values", column)
+			}
+			entry = append(entry, row.Values[column])
+		}
+		entries = append(entries, append(entry, row.RowID))
+	}
+
+	return b.writeEntries(entries)
+}
+
+// writeWithoutRowIDTable writes WITHOUT ROWID table as index b-tree of rows values, zero root means a new page
+func (b *builder) writeWithoutRowIDTable(rows []Row, root int) (int, error) {
+	if root != 0 {
+		return 0, fmt.Errorf("WITHOUT ROWID table can not be written to page %d", root)
+	}
+
+	entries := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.Values)
+	}
+
+	return b.writeEntries(entries)
+}
+
+// writeEntries writes index b-tree of entries sorted by their values and returns its root page
+func (b *builder) writeEntries(entries [][]interface{}) (int, error) {
+	entries = append([][]interface{}{}, entries...)
+	sort.Slice(entries, func(i, j int) bool {
+		for k := 0; k < len(entries[i]) && k < len(entries[j]); k++ {
+			if c := compareValues(entries[i][k], entries[j][k]); c != 0 {
+				return c < 0
+			}
+		}
+		return len(entries[i]) < len(entries[j])
+	})
+
+	payloads := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		payload, err := encodeRecord(entry)
+		if err != nil {
+			return 0, err
+		}
+		payloads = append(payloads, payload)
+	}
+
+	return b.writeIndexLevel(payloads, nil), nil
+}
+
+// writeIndexLevel writes index b-tree level, children are nil for leaves or one more than payloads for interior pages,
+// payloads between pages go to the upper level as dividers
+func (b *builder) writeIndexLevel(payloads [][]byte, children []int) int {
+	pageType, headerSize := byte(leafIndexPage), 8
+	if children != nil {
+		pageType, headerSize = interiorIndexPage, 12
+	}
+
+	sizes := make([]int, 0, len(payloads))
+	for _, payload := range payloads {
+		sizes = append(sizes, indexCellSize(payload, children != nil))
+	}
+
+	if fitSizes(sizes, b.space(0, headerSize)) {
+		return b.writeIndexPage(pageType, payloads, children)
+	}
+
+	var dividers [][]byte
+	var pages []int
+	for start := 0; start < len(payloads); {
+		end := start + 1
+		for end < len(payloads) && fitSizes(sizes[start:end+1], b.space(0, headerSize)) {
+			end++
+		}
+
+		if end == len(payloads)-1 {
+			// the last payload can not be a divider without a page after it
+			end--
+		}
+
+		var pageChildren []int
+		if children != nil {
+			pageChildren = children[start : end+1]
+		}
+		pages = append(pages, b.writeIndexPage(pageType, payloads[start:end], pageChildren))
+
+		if end < len(payloads) {
+			dividers = append(dividers, payloads[end])
+		}
+		start = end + 1
+	}
+
+	return b.writeIndexLevel(dividers, pages)
+}
+
+// writeIndexPage writes index page of payloads, interior page children are the left ones of every payload and the right one
+func (b *builder) writeIndexPage(pageType byte, payloads [][]byte, children []int) int {
+	cells := make([][]byte, 0, len(payloads))
+	for i, payload := range payloads {
+		var cell []byte
+		if children != nil {
+			cell = make([]byte, 4)
+			binary.BigEndian.PutUint32(cell, uint32(children[i]))
+		}
+		cell = append(cell, putVarint(uint64(len(payload)))...)
+		cells = append(cells, append(cell, b.spill(payload, true)...))
+	}
+
+	right := 0
+	if children != nil {
+		right = children[len(children)-1]
+	}
+
+	return b.writePage(0, pageType, cells, right)
+}
+
+// indexCellSize returns size of index cell with the payload without writing its overflow pages
+func indexCellSize(payload []byte, interior bool) int {
+	local := localSize(len(payload), PageSize, true)
+	size := len(putVarint(uint64(len(payload)))) + local
+	if local < len(payload) {
+		size += 4
+	}
+	if interior {
+		size += 4
+	}
+	return size
+}
+
+// spill returns local part of the payload followed by the first overflow page number if the payload does not fit the page
+func (b *builder) spill(payload []byte, index bool) []byte {
+	local := localSize(len(payload), PageSize, index)
+	if local == len(payload) {
+		return payload
+	}
+
+	cell := append([]byte{}, payload[:local]...)
+	next := make([]byte, 4)
+	binary.BigEndian.PutUint32(next, uint32(b.writeOverflow(payload[local:])))
+	return append(cell, next...)
+}
+
+// writeOverflow writes data to the chain of overflow pages and returns the first page
+func (b *builder) writeOverflow(data []byte) int {
+	first := b.allocate()
+	page := first
+	for {
+		n := copy(b.pages[page-1][4:], data)
+		data = data[n:]
+		if len(data) == 0 {
+			return first
+		}
+
+		next := b.allocate()
+		binary.BigEndian.PutUint32(b.pages[page-1], uint32(next))
+		page = next
+	}
+}
+
+// writePage writes b-tree page with cells placed from the end of the page, zero page means a new page
+func (b *builder) writePage(page int, pageType byte, cells [][]byte, right int) int {
+	if page == 0 {
+		page = b.allocate()
+	}
+
+	data := b.pages[page-1]
+	offset := 0
+	if page == 1 {
+		offset = 100
+	}
+
+	headerSize := 8
+	if pageType == interiorIndexPage || pageType == interiorTablePage {
+		headerSize = 12
+		binary.BigEndian.PutUint32(data[offset+8:], uint32(right))
+	}
+
+	data[offset] = pageType
+	binary.BigEndian.PutUint16(data[offset+3:], uint16(len(cells)))
+
+	content := PageSize
+	for i, cell := range cells {
+		content -= len(cell)
+		copy(data[content:], cell)
+		binary.BigEndian.PutUint16(data[offset+headerSize+2*i:], uint16(content))
+	}
+	binary.BigEndian.PutUint16(data[offset+5:], uint16(content))
+
+	return page
+}
+
+// fits checks if cells with their pointers fit into space of a page
+func fits(cells [][]byte, space int) bool {
+	sizes := make([]int, 0, len(cells))
+	for _, cell := range cells {
+		sizes = append(sizes, len(cell))
+	}
+	return fitSizes(sizes, space)
+}
+
+func fitSizes(sizes []int, space int) bool {
+	total := 0
+	for _, size := range sizes {
+		total += size + 2
+	}
+	return total <= space
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	var rows []Row
+	for i := 1; i <= 1000; i++ {
+		rows = append(rows, Row{RowID: int64(i), Values: []interface{}{nil, strings.Repeat("a", i%10*1000), int64(i % 3)}})
+	}
+
+	buf := bytes.Buffer{}
+	err := Write(&buf, []Table{
+		{
+			Name:    "words",
+			SQL:     "CREATE TABLE words (id integer primary key, word text, kind integer)",
+			Rows:    rows,
+			Indexes: []Index{{Name: "ix_words_kind", SQL: "CREATE INDEX ix_words_kind ON words (kind)", Columns: []int{2}}},
+		},
+		{Name: "empty", SQL: "CREATE TABLE empty (id integer)"},
+	})
+	assert.Nil(t, err)
+
+	data := buf.Bytes()
+	assert.Equal(t, "SQLite format 3\x00", string(data[:16]))
+	assert.Equal(t, uint16(PageSize), binary.BigEndian.Uint16(data[16:]))
+	assert.Zero(t, len(data)%PageSize)
+	assert.Equal(t, uint32(len(data)/PageSize), binary.BigEndian.Uint32(data[28:]))
+	assert.Equal(t, byte(leafTablePage), data[100], "schema is expected to fit the first page")
+	assert.Equal(t, uint16(3), binary.BigEndian.Uint16(data[103:]), "schema is expected to contain 2 tables and index")
+}
+
+func TestWrite_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables []Table
+	}{
+		{
+			"Duplicated row ID",
+			[]Table{{Name: "t", SQL: "CREATE TABLE t (a)", Rows: []Row{{RowID: 1, Values: []interface{}{"a"}}, {RowID: 1, Values: []interface{}{"b"}}}}},
+		},
+		{
+			"Unsupported value",
+			[]Table{{Name: "t", SQL: "CREATE TABLE t (a)", Rows: []Row{{RowID: 1, Values: []interface{}{true}}}}},
+		},
+		{
+			"Index column out of values",
+			[]Table{{
+				Name:    "t",
+				SQL:     "CREATE TABLE t (a)",
+				Rows:    []Row{{RowID: 1, Values: []interface{}{"a"}}},
+				Indexes: []Index{{Name: "ix", SQL: "CREATE INDEX ix ON t (b)", Columns: []int{1}}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, Write(&bytes.Buffer{}, tt.tables))
+		})
+	}
+}
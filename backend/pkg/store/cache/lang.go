@@ -48,6 +48,10 @@ func (l LangRepo) Get(id, authorID string) (*lang.Lang, error) {
 	return l.domainProxy.Get(id, authorID)
 }
 
+func (l LangRepo) GetByName(name, authorID string) (*lang.Lang, error) {
+	return l.domainProxy.GetByName(name, authorID)
+}
+
 func (l LangRepo) Delete(id, authorID string) error {
 	if err := l.domainProxy.Delete(id, authorID); err != nil {
 		return err
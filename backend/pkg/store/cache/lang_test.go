@@ -39,7 +39,7 @@ func TestLangRepo_Create(t *testing.T) {
 				}
 			},
 			func() args {
-				ln, err := lang.NewLang("en", "testAuthor")
+				ln, err := lang.NewLang("en", "testAuthor", lang.Metadata{})
 				assert.Nil(t, err)
 				return args{lang: ln}
 			},
@@ -67,7 +67,7 @@ func TestLangRepo_Create(t *testing.T) {
 				}
 			},
 			func() args {
-				ln, err := lang.NewLang("en", "testAuthor")
+				ln, err := lang.NewLang("en", "testAuthor", lang.Metadata{})
 				assert.Nil(t, err)
 				return args{lang: ln}
 			},
@@ -126,7 +126,7 @@ func TestLangRepo_Update(t *testing.T) {
 				}
 			},
 			func() args {
-				ln, err := lang.NewLang("en", "testAuthor")
+				ln, err := lang.NewLang("en", "testAuthor", lang.Metadata{})
 				assert.Nil(t, err)
 				return args{lang: ln}
 			},
@@ -154,7 +154,7 @@ func TestLangRepo_Update(t *testing.T) {
 				}
 			},
 			func() args {
-				ln, err := lang.NewLang("en", "testAuthor")
+				ln, err := lang.NewLang("en", "testAuthor", lang.Metadata{})
 				assert.Nil(t, err)
 				return args{lang: ln}
 			},
@@ -3,8 +3,7 @@ package cache
 import "time"
 
 type Opts struct {
-	TagCacheTTL                time.Duration
-	TranslationCacheTTL        time.Duration
-	TranslationsSearchCacheTTL time.Duration
-	LangCacheTTL               time.Duration
+	TagCacheTTL         time.Duration
+	TranslationCacheTTL time.Duration
+	LangCacheTTL        time.Duration
 }
@@ -6,14 +6,13 @@ import (
 	"github.com/Code-Hex/go-generics-cache"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
 	"github.com/macyan13/webdict/backend/pkg/app/query"
-	"golang.org/x/exp/maps"
 	"time"
 )
 
 type TagRepo struct {
 	domainProxy tag.Repository
 	queryProxy  query.TagViewRepository
-	cache       *cache.Cache[string, map[string]query.TagView]
+	cache       *cache.Cache[string, query.TagHierarchy] // cache keeps hierarchy of all author tags by author ID
 	cacheTTL    time.Duration
 }
 
@@ -21,55 +20,50 @@ func NewTagRepo(ctx context.Context, domainProxy tag.Repository, queryProxy quer
 	return &TagRepo{
 		domainProxy: domainProxy,
 		queryProxy:  queryProxy,
-		cache:       cache.NewContext[string, map[string]query.TagView](ctx),
+		cache:       cache.NewContext[string, query.TagHierarchy](ctx),
 		cacheTTL:    opts.TagCacheTTL,
 	}
 }
 
 func (t TagRepo) GetAllViews(authorID string) ([]query.TagView, error) {
-	if cachedViews, ok := t.cache.Get(authorID); ok {
-		return maps.Values(cachedViews), nil
-	}
-
-	cachedViews, err := t.initCache(authorID)
+	hierarchy, err := t.GetHierarchy(authorID)
 	if err != nil {
 		return nil, err
 	}
-	return maps.Values(cachedViews), nil
+	return hierarchy.Views(), nil
+}
+
+// GetHierarchy returns cached hierarchy of author tags, the whole hierarchy is dropped on any author tag change
+func (t TagRepo) GetHierarchy(authorID string) (query.TagHierarchy, error) {
+	if hierarchy, ok := t.cache.Get(authorID); ok {
+		return hierarchy, nil
+	}
+
+	return t.initCache(authorID)
 }
 
 func (t TagRepo) GetView(id, authorID string) (query.TagView, error) {
-	cachedViews, ok := t.cache.Get(authorID)
-	if !ok {
-		refreshedViews, err := t.initCache(authorID)
-		if err != nil {
-			return query.TagView{}, err
-		}
-		cachedViews = refreshedViews
+	hierarchy, err := t.GetHierarchy(authorID)
+	if err != nil {
+		return query.TagView{}, err
 	}
 
-	if view, hit := cachedViews[id]; hit {
+	if view, hit := hierarchy.View(id); hit {
 		return view, nil
 	}
 	return query.TagView{}, fmt.Errorf("can not find tag, userID: %s, tagID: %s", authorID, id)
 }
 
 func (t TagRepo) GetViews(ids []string, authorID string) ([]query.TagView, error) {
-	cachedViews, ok := t.cache.Get(authorID)
-
-	if !ok {
-		refreshedViews, err := t.initCache(authorID)
-		if err != nil {
-			return nil, err
-		}
-
-		cachedViews = refreshedViews
+	hierarchy, err := t.GetHierarchy(authorID)
+	if err != nil {
+		return nil, err
 	}
 
 	views := make([]query.TagView, 0, len(ids))
 
 	for i := range ids {
-		if view, hit := cachedViews[ids[i]]; hit {
+		if view, hit := hierarchy.View(ids[i]); hit {
 			views = append(views, view)
 		} else {
 			return nil, fmt.Errorf("can not find tag, userID: %s, tagID: %s", authorID, ids[i])
@@ -101,6 +95,10 @@ func (t TagRepo) Get(id, authorID string) (*tag.Tag, error) {
 	return t.domainProxy.Get(id, authorID)
 }
 
+func (t TagRepo) GetByName(name, authorID string) (*tag.Tag, error) {
+	return t.domainProxy.GetByName(name, authorID)
+}
+
 func (t TagRepo) Delete(id, authorID string) error {
 	if err := t.domainProxy.Delete(id, authorID); err != nil {
 		return err
@@ -114,6 +112,19 @@ func (t TagRepo) AllExist(ids []string, authorID string) (bool, error) {
 	return t.domainProxy.AllExist(ids, authorID)
 }
 
+func (t TagRepo) ExistByParent(parentID, authorID string) (bool, error) {
+	return t.domainProxy.ExistByParent(parentID, authorID)
+}
+
+func (t TagRepo) MoveChildren(parentID, newParentID, authorID string) error {
+	if err := t.domainProxy.MoveChildren(parentID, newParentID, authorID); err != nil {
+		return err
+	}
+
+	t.cache.Delete(authorID)
+	return nil
+}
+
 func (t TagRepo) DeleteByAuthorID(authorID string) (int, error) {
 	count, err := t.domainProxy.DeleteByAuthorID(authorID)
 	if err == nil {
@@ -123,18 +134,13 @@ func (t TagRepo) DeleteByAuthorID(authorID string) (int, error) {
 	return count, err
 }
 
-func (t TagRepo) initCache(authorID string) (map[string]query.TagView, error) {
+func (t TagRepo) initCache(authorID string) (query.TagHierarchy, error) {
 	views, err := t.queryProxy.GetAllViews(authorID)
 	if err != nil {
-		return nil, err
-	}
-
-	cacheMap := make(map[string]query.TagView, len(views))
-
-	for i := range views {
-		cacheMap[views[i].ID] = views[i]
+		return query.TagHierarchy{}, err
 	}
 
-	t.cache.Set(authorID, cacheMap, cache.WithExpiration(t.cacheTTL))
-	return cacheMap, nil
+	hierarchy := query.NewTagHierarchy(views)
+	t.cache.Set(authorID, hierarchy, cache.WithExpiration(t.cacheTTL))
+	return hierarchy, nil
 }
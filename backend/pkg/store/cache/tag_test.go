@@ -14,7 +14,7 @@ import (
 func TestTagRepo_GetAllViews(t *testing.T) {
 	type fields struct {
 		queryProxy query.TagViewRepository
-		cache      *cache.Cache[string, map[string]query.TagView]
+		cache      *cache.Cache[string, query.TagHierarchy]
 	}
 	type args struct {
 		authorID string
@@ -33,7 +33,7 @@ func TestTagRepo_GetAllViews(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return([]query.TagView{{ID: "tag1"}, {ID: "tag2"}}, nil)
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{authorID: "testAuthor"},
@@ -42,11 +42,11 @@ func TestTagRepo_GetAllViews(t *testing.T) {
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
 				result := i.([]query.TagView)
-				cacheMap, _ := i2[0].(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
+				hierarchy, _ := i2[0].(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
 				assert.Equal(t, []query.TagView{{ID: "tag1"}, {ID: "tag2"}}, result)
 
 				for r := range result {
-					_, ok := cacheMap[result[r].ID]
+					_, ok := hierarchy.View(result[r].ID)
 					assert.True(t, ok)
 				}
 				return true
@@ -59,7 +59,7 @@ func TestTagRepo_GetAllViews(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return(nil, fmt.Errorf("testError"))
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{authorID: "testAuthor"},
@@ -72,8 +72,8 @@ func TestTagRepo_GetAllViews(t *testing.T) {
 		{
 			"Cache is present",
 			func() fields {
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}, "tag2": {ID: "tag2"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}, {ID: "tag2"}}))
 				return fields{
 					queryProxy: nil,
 					cache:      c,
@@ -111,7 +111,7 @@ func TestTagRepo_GetAllViews(t *testing.T) {
 func TestTagRepo_GetView(t *testing.T) {
 	type fields struct {
 		queryProxy query.TagViewRepository
-		cache      *cache.Cache[string, map[string]query.TagView]
+		cache      *cache.Cache[string, query.TagHierarchy]
 	}
 	type args struct {
 		id       string
@@ -131,7 +131,7 @@ func TestTagRepo_GetView(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return([]query.TagView{{ID: "tag1"}, {ID: "tag2"}}, nil)
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{
@@ -144,9 +144,10 @@ func TestTagRepo_GetView(t *testing.T) {
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
 				result := i.(query.TagView)
-				cacheMap, _ := i2[0].(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
+				hierarchy, _ := i2[0].(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
 				assert.Equal(t, query.TagView{ID: "tag1"}, result, i2[1])
-				assert.Equal(t, result, cacheMap[result.ID], i2[1])
+				cached, _ := hierarchy.View(result.ID)
+				assert.Equal(t, result, cached, i2[1])
 				return true
 			},
 		},
@@ -157,7 +158,7 @@ func TestTagRepo_GetView(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return(nil, fmt.Errorf("testErr"))
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{
@@ -177,7 +178,7 @@ func TestTagRepo_GetView(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return([]query.TagView{{ID: "tag1"}, {ID: "tag2"}}, nil)
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{
@@ -193,8 +194,8 @@ func TestTagRepo_GetView(t *testing.T) {
 		{
 			"Cache is present",
 			func() fields {
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					cache: c,
 				}
@@ -233,7 +234,7 @@ func TestTagRepo_GetView(t *testing.T) {
 func TestTagRepo_GetViews(t *testing.T) {
 	type fields struct {
 		queryProxy query.TagViewRepository
-		cache      *cache.Cache[string, map[string]query.TagView]
+		cache      *cache.Cache[string, query.TagHierarchy]
 	}
 	type args struct {
 		ids      []string
@@ -253,7 +254,7 @@ func TestTagRepo_GetViews(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return([]query.TagView{{ID: "tag1"}, {ID: "tag2"}}, nil)
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{
@@ -265,11 +266,11 @@ func TestTagRepo_GetViews(t *testing.T) {
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
 				result := i.([]query.TagView)
-				cacheMap, _ := i2[0].(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
+				hierarchy, _ := i2[0].(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
 				assert.Equal(t, []query.TagView{{ID: "tag1"}, {ID: "tag2"}}, result)
 
 				for r := range result {
-					_, ok := cacheMap[result[r].ID]
+					_, ok := hierarchy.View(result[r].ID)
 					assert.True(t, ok)
 				}
 				return true
@@ -282,7 +283,7 @@ func TestTagRepo_GetViews(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return(nil, fmt.Errorf("testErr"))
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{
@@ -302,7 +303,7 @@ func TestTagRepo_GetViews(t *testing.T) {
 				queryProxy.On("GetAllViews", "testAuthor").Return([]query.TagView{{ID: "tag1"}, {ID: "tag2"}}, nil)
 				return fields{
 					queryProxy: queryProxy,
-					cache:      cache.New[string, map[string]query.TagView](),
+					cache:      cache.New[string, query.TagHierarchy](),
 				}
 			},
 			args{
@@ -318,8 +319,8 @@ func TestTagRepo_GetViews(t *testing.T) {
 		{
 			"Cache is present",
 			func() fields {
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}, "tag2": {ID: "tag2"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}, {ID: "tag2"}}))
 				return fields{
 					cache: c,
 				}
@@ -368,7 +369,7 @@ func TestTagRepo_GetViews(t *testing.T) {
 func TestTagRepo_Update(t *testing.T) {
 	type fields struct {
 		domainProxy tag.Repository
-		cache       *cache.Cache[string, map[string]query.TagView]
+		cache       *cache.Cache[string, query.TagHierarchy]
 	}
 	type args struct {
 		tag *tag.Tag
@@ -385,8 +386,8 @@ func TestTagRepo_Update(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("Update", mock.AnythingOfType("*tag.Tag")).Return(fmt.Errorf("testErr"))
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -402,8 +403,8 @@ func TestTagRepo_Update(t *testing.T) {
 				return false
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				cacheMap, _ := i2[0].(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
-				_, ok := cacheMap["tag1"]
+				hierarchy, _ := i2[0].(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
+				_, ok := hierarchy.View("tag1")
 				assert.True(t, ok, i2)
 				return true
 			},
@@ -413,8 +414,8 @@ func TestTagRepo_Update(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("Update", mock.AnythingOfType("*tag.Tag")).Return(nil)
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -430,7 +431,7 @@ func TestTagRepo_Update(t *testing.T) {
 				return false
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				_, ok := i2[0].(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
+				_, ok := i2[0].(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
 				assert.False(t, ok, i2)
 				return true
 			},
@@ -455,7 +456,7 @@ func TestTagRepo_Update(t *testing.T) {
 func TestTagRepo_Delete(t *testing.T) {
 	type fields struct {
 		domainProxy tag.Repository
-		cache       *cache.Cache[string, map[string]query.TagView]
+		cache       *cache.Cache[string, query.TagHierarchy]
 	}
 	type args struct {
 		id       string
@@ -473,8 +474,8 @@ func TestTagRepo_Delete(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("Delete", "tag1", "testAuthor").Return(fmt.Errorf("testErr"))
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -489,8 +490,8 @@ func TestTagRepo_Delete(t *testing.T) {
 				return false
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				cacheMap, _ := i.(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
-				_, ok := cacheMap["tag1"]
+				hierarchy, _ := i.(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
+				_, ok := hierarchy.View("tag1")
 				assert.True(t, ok, i2)
 				return true
 			},
@@ -500,8 +501,8 @@ func TestTagRepo_Delete(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("Delete", "tag1", "testAuthor").Return(nil)
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -516,7 +517,7 @@ func TestTagRepo_Delete(t *testing.T) {
 				return false
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				_, ok := i.(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
+				_, ok := i.(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
 				assert.False(t, ok, i2)
 				return true
 			},
@@ -541,7 +542,7 @@ func TestTagRepo_Delete(t *testing.T) {
 func TestTagRepo_Create(t *testing.T) {
 	type fields struct {
 		domainProxy tag.Repository
-		cache       *cache.Cache[string, map[string]query.TagView]
+		cache       *cache.Cache[string, query.TagHierarchy]
 	}
 	type args struct {
 		tag *tag.Tag
@@ -558,8 +559,8 @@ func TestTagRepo_Create(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("Create", mock.AnythingOfType("*tag.Tag")).Return(fmt.Errorf("testErr"))
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -575,8 +576,8 @@ func TestTagRepo_Create(t *testing.T) {
 				return false
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				cacheMap, _ := i2[0].(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
-				_, ok := cacheMap["tag1"]
+				hierarchy, _ := i2[0].(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
+				_, ok := hierarchy.View("tag1")
 				assert.True(t, ok, i2)
 				return true
 			},
@@ -586,8 +587,8 @@ func TestTagRepo_Create(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("Create", mock.AnythingOfType("*tag.Tag")).Return(nil)
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -603,7 +604,7 @@ func TestTagRepo_Create(t *testing.T) {
 				return false
 			},
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				_, ok := i2[0].(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
+				_, ok := i2[0].(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
 				assert.False(t, ok, i2)
 				return true
 			},
@@ -628,7 +629,7 @@ func TestTagRepo_Create(t *testing.T) {
 func TestTagRepo_DeleteByAuthorID(t *testing.T) {
 	type fields struct {
 		domainProxy tag.Repository
-		cache       *cache.Cache[string, map[string]query.TagView]
+		cache       *cache.Cache[string, query.TagHierarchy]
 	}
 	type args struct {
 		authorID string
@@ -646,8 +647,8 @@ func TestTagRepo_DeleteByAuthorID(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("DeleteByAuthorID", "testAuthor").Return(0, fmt.Errorf("testErr"))
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -657,8 +658,8 @@ func TestTagRepo_DeleteByAuthorID(t *testing.T) {
 			0,
 			assert.Error,
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				cacheMap, _ := i.(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
-				_, ok := cacheMap["tag1"]
+				hierarchy, _ := i.(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
+				_, ok := hierarchy.View("tag1")
 				assert.True(t, ok, i2)
 				return true
 			},
@@ -668,8 +669,8 @@ func TestTagRepo_DeleteByAuthorID(t *testing.T) {
 			func() fields {
 				domainProxy := tag.NewMockRepository(t)
 				domainProxy.On("DeleteByAuthorID", "testAuthor").Return(5, nil)
-				c := cache.New[string, map[string]query.TagView]()
-				c.Set("testAuthor", map[string]query.TagView{"tag1": {ID: "tag1"}})
+				c := cache.New[string, query.TagHierarchy]()
+				c.Set("testAuthor", query.NewTagHierarchy([]query.TagView{{ID: "tag1"}}))
 				return fields{
 					domainProxy: domainProxy,
 					cache:       c,
@@ -679,7 +680,7 @@ func TestTagRepo_DeleteByAuthorID(t *testing.T) {
 			5,
 			assert.NoError,
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
-				_, ok := i.(*cache.Cache[string, map[string]query.TagView]).Get("testAuthor")
+				_, ok := i.(*cache.Cache[string, query.TagHierarchy]).Get("testAuthor")
 				assert.False(t, ok)
 				return true
 			},
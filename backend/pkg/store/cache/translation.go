@@ -11,31 +11,31 @@ import (
 	"time"
 )
 
+// multiLangCacheKey is used instead of lang in the pages key of filters by multiple or any lang
+const multiLangCacheKey = "*"
+
 type TranslationRepo struct {
-	domainProxy                 translation.Repository
-	queryProxy                  query.TranslationViewRepository
-	cacheTTL                    time.Duration
-	searchCacheTTL              time.Duration
-	singleRecordCache           *cache.Cache[string, query.TranslationView]
-	lastTranslationsPageCache   *cache.Cache[string, map[string]query.LastTranslationViews]
-	translationsSearchPageCache *cache.Cache[string, map[string]query.LastTranslationViews]
+	domainProxy               translation.Repository
+	queryProxy                query.TranslationViewRepository
+	cacheTTL                  time.Duration
+	singleRecordCache         *cache.Cache[string, query.TranslationView]
+	lastTranslationsPageCache *cache.Cache[string, map[string]query.LastTranslationViews]
 }
 
 func NewTranslationRepo(ctx context.Context, domainProxy translation.Repository, queryProxy query.TranslationViewRepository, cacheTTL time.Duration) *TranslationRepo {
 	return &TranslationRepo{
-		domainProxy:                 domainProxy,
-		queryProxy:                  queryProxy,
-		cacheTTL:                    cacheTTL,
-		singleRecordCache:           cache.NewContext[string, query.TranslationView](ctx),
-		lastTranslationsPageCache:   cache.NewContext[string, map[string]query.LastTranslationViews](ctx),
-		translationsSearchPageCache: cache.NewContext[string, map[string]query.LastTranslationViews](ctx),
+		domainProxy:               domainProxy,
+		queryProxy:                queryProxy,
+		cacheTTL:                  cacheTTL,
+		singleRecordCache:         cache.NewContext[string, query.TranslationView](ctx),
+		lastTranslationsPageCache: cache.NewContext[string, map[string]query.LastTranslationViews](ctx),
 	}
 }
 
 func (t *TranslationRepo) Create(record *translation.Translation) error {
 	err := t.domainProxy.Create(record)
 	if err == nil {
-		t.lastTranslationsPageCache.Delete(t.authorLangCacheKey(record.AuthorID(), record.LangID()))
+		t.deleteAuthorLangPages(record.AuthorID(), record.LangID())
 	}
 
 	return err
@@ -45,7 +45,7 @@ func (t *TranslationRepo) Update(record *translation.Translation) error {
 	err := t.domainProxy.Update(record)
 	if err == nil {
 		t.singleRecordCache.Delete(record.ID())
-		t.lastTranslationsPageCache.Delete(t.authorLangCacheKey(record.AuthorID(), record.LangID()))
+		t.deleteAuthorLangPages(record.AuthorID(), record.LangID())
 	}
 
 	return err
@@ -63,6 +63,18 @@ func (t *TranslationRepo) ExistByLang(langID, authorID string) (bool, error) {
 	return t.domainProxy.ExistByLang(langID, authorID)
 }
 
+func (t *TranslationRepo) ExistBySource(source, langID, authorID string) (bool, error) {
+	return t.domainProxy.ExistBySource(source, langID, authorID)
+}
+
+func (t *TranslationRepo) GetBySource(source, langID, authorID string) (*translation.Translation, error) {
+	return t.domainProxy.GetBySource(source, langID, authorID)
+}
+
+func (t *TranslationRepo) GetByLang(langID, authorID string) ([]*translation.Translation, error) {
+	return t.domainProxy.GetByLang(langID, authorID)
+}
+
 func (t *TranslationRepo) Delete(id, authorID string) error {
 	record, err := t.domainProxy.Get(id, authorID)
 
@@ -73,12 +85,57 @@ func (t *TranslationRepo) Delete(id, authorID string) error {
 	err = t.domainProxy.Delete(id, authorID)
 	if err == nil {
 		t.singleRecordCache.Delete(id)
-		t.lastTranslationsPageCache.Delete(t.authorLangCacheKey(record.AuthorID(), record.LangID()))
+		t.deleteAuthorLangPages(record.AuthorID(), record.LangID())
+	}
+
+	return err
+}
+
+// UpdateMany saves translations by single store request and invalidates pages of their authors once,
+// all author pages are dropped as translations could be moved from any lang
+func (t *TranslationRepo) UpdateMany(records []*translation.Translation) []error {
+	errs := t.domainProxy.UpdateMany(records)
+
+	var saved []*translation.Translation
+	for i, record := range records {
+		if i >= len(errs) || errs[i] == nil {
+			saved = append(saved, record)
+		}
 	}
+	t.deleteRecordsPages(saved)
+
+	return errs
+}
+
+// DeleteMany deletes translations by single store request and invalidates pages of their authors once,
+// pages are invalidated on failure as well as a part of translations could be deleted before it
+func (t *TranslationRepo) DeleteMany(records []*translation.Translation) error {
+	err := t.domainProxy.DeleteMany(records)
+	t.deleteRecordsPages(records)
 
 	return err
 }
 
+// ReplaceTag retags translations by single store request and invalidates cached views of the retagged translations
+func (t *TranslationRepo) ReplaceTag(tagID, newTagID, authorID string) (int, error) {
+	count, err := t.domainProxy.ReplaceTag(tagID, newTagID, authorID)
+	if err == nil {
+		t.deleteTaggedViews(tagID, authorID)
+	}
+
+	return count, err
+}
+
+// RemoveTag detaches the tag by single store request and invalidates cached views of the detached translations
+func (t *TranslationRepo) RemoveTag(tagID, authorID string) (int, error) {
+	count, err := t.domainProxy.RemoveTag(tagID, authorID)
+	if err == nil {
+		t.deleteTaggedViews(tagID, authorID)
+	}
+
+	return count, err
+}
+
 func (t *TranslationRepo) DeleteByAuthorID(authorID string) (int, error) {
 	count, err := t.domainProxy.DeleteByAuthorID(authorID)
 	if err == nil {
@@ -102,16 +159,33 @@ func (t *TranslationRepo) GetView(id, authorID string) (query.TranslationView, e
 	return view, err
 }
 
-func (t *TranslationRepo) GetLastViewsByTags(authorID, langID string, pageSize, page int, tagIds []string) (query.LastTranslationViews, error) {
-	pageKey := fmt.Sprintf("%d-%d-%v", pageSize, page, strings.Join(t.sortTagsAlphabetically(tagIds), "-"))
-	authorPagesKey := t.authorLangCacheKey(authorID, langID)
+func (t *TranslationRepo) GetLastViews(filter query.TranslationFilter, pageSize, page int) (query.LastTranslationViews, error) {
+	return t.getCachedViews(filter, t.filterPageKey(filter, pageSize, page), func() (query.LastTranslationViews, error) {
+		return t.queryProxy.GetLastViews(filter, pageSize, page)
+	})
+}
+
+func (t *TranslationRepo) GetViewsAfter(filter query.TranslationFilter, cursor *query.Cursor, limit int) (query.LastTranslationViews, error) {
+	cursorKey := ""
+	if cursor != nil {
+		cursorKey = cursor.Token()
+	}
+
+	return t.getCachedViews(filter, fmt.Sprintf("%s-cursor-%s", t.filterPageKey(filter, limit, 0), cursorKey), func() (query.LastTranslationViews, error) {
+		return t.queryProxy.GetViewsAfter(filter, cursor, limit)
+	})
+}
+
+// getCachedViews returns views stored under the page key in the filter author lang pages or fetches and stores them
+func (t *TranslationRepo) getCachedViews(filter query.TranslationFilter, pageKey string, fetch func() (query.LastTranslationViews, error)) (query.LastTranslationViews, error) {
+	authorPagesKey := t.authorLangsCacheKey(filter.AuthorID, filter.LangIDs)
 
 	if authorLangPages, ok := t.lastTranslationsPageCache.Get(authorPagesKey); ok {
 		if cachedViews, ok := authorLangPages[pageKey]; ok {
 			return cachedViews, nil
 		}
 
-		views, err := t.queryProxy.GetLastViewsByTags(authorID, langID, pageSize, page, tagIds)
+		views, err := fetch()
 
 		if err == nil {
 			authorLangPages[pageKey] = views
@@ -120,7 +194,7 @@ func (t *TranslationRepo) GetLastViewsByTags(authorID, langID string, pageSize,
 		return views, err
 	}
 
-	views, err := t.queryProxy.GetLastViewsByTags(authorID, langID, pageSize, page, tagIds)
+	views, err := fetch()
 
 	if err == nil {
 		cacheMap := map[string]query.LastTranslationViews{pageKey: views}
@@ -130,74 +204,129 @@ func (t *TranslationRepo) GetLastViewsByTags(authorID, langID string, pageSize,
 	return views, err
 }
 
-func (t *TranslationRepo) GetLastViewsBySourcePart(authorID, langID, sourcePart string, pageSize, page int) (query.LastTranslationViews, error) {
-	pageKey := fmt.Sprintf("%d-%d-source-%s", pageSize, page, sourcePart)
-	authorPagesKey := t.authorLangCacheKey(authorID, langID)
+func (t *TranslationRepo) GetRandomViews(authorID string, langIDs []string, tags query.TagGroups, limit int) (query.RandomViews, error) {
+	return t.queryProxy.GetRandomViews(authorID, langIDs, tags, limit)
+}
 
-	if authorLangPages, ok := t.translationsSearchPageCache.Get(authorPagesKey); ok {
-		if cachedViews, ok := authorLangPages[pageKey]; ok {
-			return cachedViews, nil
-		}
+func (t *TranslationRepo) GetDueViews(authorID, langID string, tags query.TagGroups, dueAt time.Time, limit int) (query.DueViews, error) {
+	return t.queryProxy.GetDueViews(authorID, langID, tags, dueAt, limit)
+}
 
-		views, err := t.queryProxy.GetLastViewsBySourcePart(authorID, langID, sourcePart, pageSize, page)
+func (t *TranslationRepo) GetFuzzyViews(authorID, langID, text string, maxDistance, limit int) (query.FuzzyViews, error) {
+	return t.queryProxy.GetFuzzyViews(authorID, langID, text, maxDistance, limit)
+}
 
-		if err == nil {
-			authorLangPages[pageKey] = views
-		}
+// filterPageKey builds page key from all filter conditions, author is part of the pages key
+func (t *TranslationRepo) filterPageKey(filter query.TranslationFilter, pageSize, page int) string {
+	return fmt.Sprintf(
+		"%d-%d-sort-%s-langs-%s-source-%s-target-%s-text-%s-tags-%s-created-%s-updated-%s",
+		pageSize,
+		page,
+		filter.Sort,
+		strings.Join(t.sortTagsAlphabetically(filter.LangIDs), "-"),
+		filter.SourcePart,
+		filter.TargetPart,
+		filter.TextPart,
+		t.tagGroupsKey(filter.Tags),
+		t.dateRangeKey(filter.Created),
+		t.dateRangeKey(filter.Updated),
+	)
+}
 
-		return views, err
+func (t *TranslationRepo) dateRangeKey(r query.DateRange) string {
+	return fmt.Sprintf("%s-%s", r.From.Format(time.RFC3339Nano), r.To.Format(time.RFC3339Nano))
+}
+
+// tagGroupsKey builds key of tag groups which does not depend on order of groups and tags in them
+func (t *TranslationRepo) tagGroupsKey(groups query.TagGroups) string {
+	keys := make([]string, 0, len(groups))
+	for _, group := range groups {
+		keys = append(keys, strings.Join(t.sortTagsAlphabetically(group), "+"))
 	}
 
-	views, err := t.queryProxy.GetLastViewsBySourcePart(authorID, langID, sourcePart, pageSize, page)
+	return strings.Join(t.sortTagsAlphabetically(keys), "-")
+}
 
-	if err == nil {
-		cacheMap := map[string]query.LastTranslationViews{pageKey: views}
-		t.translationsSearchPageCache.Set(authorPagesKey, cacheMap, cache.WithExpiration(t.searchCacheTTL))
-	}
+func (t *TranslationRepo) sortTagsAlphabetically(tagIds []string) []string {
+	tagIds = append([]string(nil), tagIds...)
+	sort.Slice(tagIds, func(i, j int) bool {
+		return tagIds[i] < tagIds[j]
+	})
 
-	return views, err
+	return tagIds
 }
 
-func (t *TranslationRepo) GetLastViewsByTargetPart(authorID, langID, targetPart string, pageSize, page int) (query.LastTranslationViews, error) {
-	pageKey := fmt.Sprintf("%d-%d-target-%s", pageSize, page, targetPart)
-	authorPagesKey := t.authorLangCacheKey(authorID, langID)
+func (t *TranslationRepo) authorLangCacheKey(authorID, lang string) string {
+	return fmt.Sprintf("%s-%s", authorID, lang)
+}
 
-	if authorLangPages, ok := t.translationsSearchPageCache.Get(authorPagesKey); ok {
-		if cachedViews, ok := authorLangPages[pageKey]; ok {
-			return cachedViews, nil
-		}
+// authorLangsCacheKey returns author lang pages key for single lang filters,
+// pages of multiple or any lang filters are stored together under author multi lang key
+func (t *TranslationRepo) authorLangsCacheKey(authorID string, langIDs []string) string {
+	if len(langIDs) == 1 {
+		return t.authorLangCacheKey(authorID, langIDs[0])
+	}
 
-		views, err := t.queryProxy.GetLastViewsByTargetPart(authorID, langID, targetPart, pageSize, page)
+	return t.authorLangCacheKey(authorID, multiLangCacheKey)
+}
 
-		if err == nil {
-			authorLangPages[pageKey] = views
-		}
+// deleteAuthorLangPages invalidates cached pages which can contain author translations of the lang
+func (t *TranslationRepo) deleteAuthorLangPages(authorID, langID string) {
+	t.lastTranslationsPageCache.Delete(t.authorLangCacheKey(authorID, langID))
+	t.lastTranslationsPageCache.Delete(t.authorLangCacheKey(authorID, multiLangCacheKey))
+}
 
-		return views, err
+// deleteRecordsPages invalidates cached views of the records and all pages of their authors
+func (t *TranslationRepo) deleteRecordsPages(records []*translation.Translation) {
+	authors := map[string]struct{}{}
+	for _, record := range records {
+		t.singleRecordCache.Delete(record.ID())
+		authors[record.AuthorID()] = struct{}{}
 	}
 
-	views, err := t.queryProxy.GetLastViewsByTargetPart(authorID, langID, targetPart, pageSize, page)
+	t.deleteAuthorsPages(authors)
+}
 
-	if err == nil {
-		cacheMap := map[string]query.LastTranslationViews{pageKey: views}
-		t.translationsSearchPageCache.Set(authorPagesKey, cacheMap, cache.WithExpiration(t.searchCacheTTL))
+// deleteAuthorsPages invalidates all cached pages of the authors
+func (t *TranslationRepo) deleteAuthorsPages(authors map[string]struct{}) {
+	if len(authors) == 0 {
+		return
 	}
 
-	return views, err
+	for _, key := range t.lastTranslationsPageCache.Keys() {
+		for authorID := range authors {
+			if strings.HasPrefix(key, t.authorLangCacheKey(authorID, "")) {
+				t.lastTranslationsPageCache.Delete(key)
+				break
+			}
+		}
+	}
 }
 
-func (t *TranslationRepo) GetRandomViews(authorID, langID string, tagIds []string, limit int) (query.RandomViews, error) {
-	return t.queryProxy.GetRandomViews(authorID, langID, tagIds, limit)
-}
+// deleteTaggedViews invalidates cached views of translations tagged by the tag and all pages of the tag author
+func (t *TranslationRepo) deleteTaggedViews(tagID, authorID string) {
+	for _, id := range t.singleRecordCache.Keys() {
+		view, ok := t.singleRecordCache.Get(id)
+		if !ok {
+			continue
+		}
 
-func (t *TranslationRepo) sortTagsAlphabetically(tagIds []string) []string {
-	sort.Slice(tagIds, func(i, j int) bool {
-		return tagIds[i] < tagIds[j]
-	})
+		for _, sense := range view.Senses {
+			if t.hasTag(sense.Tags, tagID) {
+				t.singleRecordCache.Delete(id)
+				break
+			}
+		}
+	}
 
-	return tagIds
+	t.deleteAuthorsPages(map[string]struct{}{authorID: {}})
 }
 
-func (t *TranslationRepo) authorLangCacheKey(authorID, lang string) string {
-	return fmt.Sprintf("%s-%s", authorID, lang)
+func (t *TranslationRepo) hasTag(tags []query.TagView, tagID string) bool {
+	for i := range tags {
+		if tags[i].ID == tagID {
+			return true
+		}
+	}
+	return false
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 )
@@ -60,6 +61,7 @@ func TestTranslationRepo_Create(t *testing.T) {
 				repo.On("Create", mock.AnythingOfType("*translation.Translation")).Return(nil)
 				pageCache := cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO())
 				pageCache.Set("authorID-EN", map[string]query.LastTranslationViews{"key": {}})
+				pageCache.Set("authorID-*", map[string]query.LastTranslationViews{"key": {}})
 				pageCache.Set("AuthorID-DE", map[string]query.LastTranslationViews{"key": {}})
 				return fields{
 					domainProxy: &repo,
@@ -76,6 +78,8 @@ func TestTranslationRepo_Create(t *testing.T) {
 				pageCache := i.(*cache.Cache[string, map[string]query.LastTranslationViews])
 				_, ok := pageCache.Get("AuthorID-DE")
 				assert.True(t, ok, i2...)
+				_, ok = pageCache.Get("authorID-*")
+				assert.False(t, ok, i2...)
 				_, ok = pageCache.Get("authorID-EN")
 				return assert.False(t, ok, i2...)
 			},
@@ -187,6 +191,114 @@ func TestTranslationRepo_Update(t *testing.T) {
 	}
 }
 
+func TestTranslationRepo_UpdateMany(t *testing.T) {
+	records := []*translation.Translation{
+		createTranslationByAuthorIDAndIDAndLangID("author-1", "firstID", "EN"),
+		createTranslationByAuthorIDAndIDAndLangID("author-1", "secondID", "DE"),
+	}
+
+	repo := translation.MockRepository{}
+	repo.On("UpdateMany", records).Return([]error{nil, translation.ErrSourceAlreadyExists})
+
+	pageCache := cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO())
+	for _, key := range []string{"author-1-EN", "author-1-FR", "author-1-*", "author-10-EN"} {
+		pageCache.Set(key, map[string]query.LastTranslationViews{"key": {}})
+	}
+	singleCache := cache.NewContext[string, query.TranslationView](context.TODO())
+	singleCache.Set("firstID", query.TranslationView{})
+	singleCache.Set("secondID", query.TranslationView{})
+
+	cachedRepo := TranslationRepo{domainProxy: &repo, cacheTTL: time.Minute, singleRecordCache: singleCache, lastTranslationsPageCache: pageCache}
+	assert.Equal(t, []error{nil, translation.ErrSourceAlreadyExists}, cachedRepo.UpdateMany(records))
+
+	assert.Equal(t, []string{"author-10-EN"}, pageCache.Keys())
+	_, ok := singleCache.Get("firstID")
+	assert.False(t, ok)
+	_, ok = singleCache.Get("secondID")
+	assert.True(t, ok)
+	repo.AssertNumberOfCalls(t, "UpdateMany", 1)
+}
+
+func TestTranslationRepo_DeleteMany(t *testing.T) {
+	records := []*translation.Translation{createTranslationByAuthorIDAndIDAndLangID("authorID", "testID", "EN")}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantKeys []string
+	}{
+		{"Error on DB delete, cache is cleared as a part could be deleted", errors.New("error"), []string{"otherID-EN"}},
+		{"Translations are deleted and cache is cleared", nil, []string{"otherID-EN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := translation.MockRepository{}
+			repo.On("DeleteMany", records).Return(tt.err)
+
+			pageCache := cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO())
+			pageCache.Set("authorID-EN", map[string]query.LastTranslationViews{"key": {}})
+			pageCache.Set("otherID-EN", map[string]query.LastTranslationViews{"key": {}})
+
+			cachedRepo := TranslationRepo{
+				domainProxy:               &repo,
+				cacheTTL:                  time.Minute,
+				singleRecordCache:         cache.NewContext[string, query.TranslationView](context.TODO()),
+				lastTranslationsPageCache: pageCache,
+			}
+			assert.Equal(t, tt.err, cachedRepo.DeleteMany(records))
+
+			keys := pageCache.Keys()
+			sort.Strings(keys)
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+}
+
+func TestTranslationRepo_ReplaceTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantKeys  []string
+		wantViews []string
+	}{
+		{"Error on DB update", errors.New("error"), []string{"authorID-*", "authorID-EN", "otherID-EN"}, []string{"tagged", "untagged"}},
+		{"Tagged views and author pages are cleared", nil, []string{"otherID-EN"}, []string{"untagged"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := translation.MockRepository{}
+			repo.On("ReplaceTag", "tag1", "tag2", "authorID").Return(1, tt.err)
+
+			pageCache := cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO())
+			pageCache.Set("authorID-EN", map[string]query.LastTranslationViews{"key": {}})
+			pageCache.Set("authorID-*", map[string]query.LastTranslationViews{"key": {}})
+			pageCache.Set("otherID-EN", map[string]query.LastTranslationViews{"key": {}})
+
+			singleRecordCache := cache.NewContext[string, query.TranslationView](context.TODO())
+			singleRecordCache.Set("tagged", query.TranslationView{Senses: []query.SenseView{{}, {Tags: []query.TagView{{ID: "tag1"}}}}})
+			singleRecordCache.Set("untagged", query.TranslationView{Senses: []query.SenseView{{Tags: []query.TagView{{ID: "tag2"}}}}})
+
+			cachedRepo := TranslationRepo{
+				domainProxy:               &repo,
+				cacheTTL:                  time.Minute,
+				singleRecordCache:         singleRecordCache,
+				lastTranslationsPageCache: pageCache,
+			}
+			count, err := cachedRepo.ReplaceTag("tag1", "tag2", "authorID")
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, 1, count)
+
+			keys := pageCache.Keys()
+			sort.Strings(keys)
+			assert.Equal(t, tt.wantKeys, keys)
+
+			views := singleRecordCache.Keys()
+			sort.Strings(views)
+			assert.Equal(t, tt.wantViews, views)
+		})
+	}
+}
+
 func TestTranslationRepo_Delete(t *testing.T) {
 	type fields struct {
 		domainProxy       translation.Repository
@@ -448,12 +560,12 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 		pageCache  *cache.Cache[string, map[string]query.LastTranslationViews]
 	}
 	type args struct {
-		authorID string
-		lang     string
+		filter   query.TranslationFilter
 		pageSize int
 		page     int
-		tagIds   []string
 	}
+	filter := query.TranslationFilter{AuthorID: "authorID", LangIDs: []string{"EN"}, SourcePart: "sour", Tags: query.TagGroups{{"tag1"}, {"tag2"}}}
+	pageKey := (&TranslationRepo{}).filterPageKey(filter, 10, 2)
 	tests := []struct {
 		name     string
 		fieldsFn func() fields
@@ -466,13 +578,13 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 			"Cache is not set, error on DB query",
 			func() fields {
 				repo := query.MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTags", "authorID", "EN", 10, 1, []string{"tag1", "tag2"}).Return(query.LastTranslationViews{}, errors.New("error"))
+				repo.On("GetLastViews", filter, 10, 1).Return(query.LastTranslationViews{}, errors.New("error"))
 				return fields{
 					queryProxy: &repo,
 					pageCache:  cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO()),
 				}
 			},
-			args{authorID: "authorID", lang: "EN", pageSize: 10, page: 1, tagIds: []string{"tag1", "tag2"}},
+			args{filter: filter, pageSize: 10, page: 1},
 			query.LastTranslationViews{},
 			assert.Error,
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
@@ -485,7 +597,7 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 			"Author cache is set, requested page is missed",
 			func() fields {
 				repo := query.MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTags", "authorID", "EN", 10, 2, []string{"tag1", "tag2"}).Return(query.LastTranslationViews{TotalRecords: 5}, nil)
+				repo.On("GetLastViews", filter, 10, 2).Return(query.LastTranslationViews{TotalRecords: 5}, nil)
 				pageCache := cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO())
 				pageCache.Set("authorID-EN", map[string]query.LastTranslationViews{"1": {}})
 				return fields{
@@ -493,7 +605,7 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 					pageCache:  pageCache,
 				}
 			},
-			args{authorID: "authorID", lang: "EN", pageSize: 10, page: 2, tagIds: []string{"tag1", "tag2"}},
+			args{filter: filter, pageSize: 10, page: 2},
 			query.LastTranslationViews{TotalRecords: 5},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Nil(t, err, i...)
@@ -503,7 +615,7 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 				pageCache := i.(*cache.Cache[string, map[string]query.LastTranslationViews])
 				authorCache, ok := pageCache.Get("authorID-EN")
 				assert.True(t, ok, i2...)
-				_, ok = authorCache["10-2-tag1-tag2"]
+				_, ok = authorCache[pageKey]
 				return assert.True(t, ok, i2...)
 			},
 		},
@@ -511,13 +623,13 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 			"Cache is not set",
 			func() fields {
 				repo := query.MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTags", "authorID", "EN", 10, 2, []string{"tag1", "tag2"}).Return(query.LastTranslationViews{TotalRecords: 5}, nil)
+				repo.On("GetLastViews", filter, 10, 2).Return(query.LastTranslationViews{TotalRecords: 5}, nil)
 				return fields{
 					queryProxy: &repo,
 					pageCache:  cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO()),
 				}
 			},
-			args{authorID: "authorID", lang: "EN", pageSize: 10, page: 2, tagIds: []string{"tag1", "tag2"}},
+			args{filter: filter, pageSize: 10, page: 2},
 			query.LastTranslationViews{TotalRecords: 5},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Nil(t, err, i...)
@@ -527,7 +639,7 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 				pageCache := i.(*cache.Cache[string, map[string]query.LastTranslationViews])
 				authorCache, ok := pageCache.Get("authorID-EN")
 				assert.True(t, ok, i2...)
-				_, ok = authorCache["10-2-tag1-tag2"]
+				_, ok = authorCache[pageKey]
 				return assert.True(t, ok, i2...)
 			},
 		},
@@ -535,7 +647,7 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 			"Author cache is set, page cache is not set, error on getting requested page",
 			func() fields {
 				repo := query.MockTranslationViewRepository{}
-				repo.On("GetLastViewsByTags", "authorID", "EN", 10, 2, []string{"tag1", "tag2"}).Return(query.LastTranslationViews{}, errors.New("error"))
+				repo.On("GetLastViews", filter, 10, 2).Return(query.LastTranslationViews{}, errors.New("error"))
 				pageCache := cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO())
 				pageCache.Set("authorID-EN", map[string]query.LastTranslationViews{"1": {}})
 				return fields{
@@ -543,14 +655,14 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 					pageCache:  pageCache,
 				}
 			},
-			args{authorID: "authorID", lang: "EN", pageSize: 10, page: 2, tagIds: []string{"tag1", "tag2"}},
+			args{filter: filter, pageSize: 10, page: 2},
 			query.LastTranslationViews{},
 			assert.Error,
 			func(t assert.TestingT, i interface{}, i2 ...interface{}) bool {
 				pageCache := i.(*cache.Cache[string, map[string]query.LastTranslationViews])
 				authorCache, ok := pageCache.Get("authorID-EN")
 				assert.True(t, ok, i2...)
-				_, ok = authorCache["10-2-tag1-tag2"]
+				_, ok = authorCache[pageKey]
 				return assert.False(t, ok, i2...)
 			},
 		},
@@ -558,12 +670,16 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 			"Page is set",
 			func() fields {
 				pageCache := cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO())
-				pageCache.Set("authorID-EN", map[string]query.LastTranslationViews{"10-2-tag1-tag2": {TotalRecords: 5}})
+				pageCache.Set("authorID-EN", map[string]query.LastTranslationViews{pageKey: {TotalRecords: 5}})
 				return fields{
 					pageCache: pageCache,
 				}
 			},
-			args{authorID: "authorID", lang: "EN", pageSize: 10, page: 2, tagIds: []string{"tag2", "tag1"}},
+			args{
+				filter:   query.TranslationFilter{AuthorID: "authorID", LangIDs: []string{"EN"}, SourcePart: "sour", Tags: query.TagGroups{{"tag2"}, {"tag1"}}},
+				pageSize: 10,
+				page:     2,
+			},
 			query.LastTranslationViews{TotalRecords: 5},
 			func(t assert.TestingT, err error, i ...interface{}) bool {
 				assert.Nil(t, err, i...)
@@ -573,7 +689,7 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 				pageCache := i.(*cache.Cache[string, map[string]query.LastTranslationViews])
 				authorCache, ok := pageCache.Get("authorID-EN")
 				assert.True(t, ok, i2...)
-				_, ok = authorCache["10-2-tag1-tag2"]
+				_, ok = authorCache[pageKey]
 				return assert.True(t, ok, i2...)
 			},
 		},
@@ -586,27 +702,76 @@ func TestTranslationRepo_GetLastViews(t *testing.T) {
 				lastTranslationsPageCache: f.pageCache,
 				cacheTTL:                  time.Minute,
 			}
-			got, err := repo.GetLastViewsByTags(tt.args.authorID, tt.args.lang, tt.args.pageSize, tt.args.page, tt.args.tagIds)
-			if !tt.wantErr(t, err, fmt.Sprintf("GetLastViewsByTags(%v, %v, %v, %v, %v)", tt.args.authorID, tt.args.lang, tt.args.pageSize, tt.args.page, tt.args.tagIds)) {
-				assert.Equalf(t, tt.want, got, "GetLastViewsByTags(%v, %v, %v, %v, %v)", tt.args.authorID, tt.args.lang, tt.args.pageSize, tt.args.page, tt.args.tagIds)
+			got, err := repo.GetLastViews(tt.args.filter, tt.args.pageSize, tt.args.page)
+			if !tt.wantErr(t, err, fmt.Sprintf("GetLastViews(%v, %v, %v)", tt.args.filter, tt.args.pageSize, tt.args.page)) {
+				assert.Equalf(t, tt.want, got, "GetLastViews(%v, %v, %v)", tt.args.filter, tt.args.pageSize, tt.args.page)
 			}
-			tt.wantFn(t, repo.lastTranslationsPageCache, fmt.Sprintf("GetLastViewsByTags(%v, %v, %v, %v, %v)", tt.args.authorID, tt.args.lang, tt.args.pageSize, tt.args.page, tt.args.tagIds))
+			tt.wantFn(t, repo.lastTranslationsPageCache, fmt.Sprintf("GetLastViews(%v, %v, %v)", tt.args.filter, tt.args.pageSize, tt.args.page))
 		})
 	}
 }
 
+func TestTranslationRepo_GetViewsAfter(t *testing.T) {
+	filter := query.TranslationFilter{AuthorID: "authorID", LangIDs: []string{"EN"}, Sort: query.SortCreatedAsc}
+	cursor := query.Cursor{Sort: query.SortCreatedAsc, ID: "id1"}
+	next := query.Cursor{Sort: query.SortCreatedAsc, ID: "id2"}
+
+	queryRepo := query.MockTranslationViewRepository{}
+	queryRepo.On("GetViewsAfter", filter, &cursor, 10).Return(query.LastTranslationViews{Next: &next}, nil).Once()
+	queryRepo.On("GetViewsAfter", filter, (*query.Cursor)(nil), 10).Return(query.LastTranslationViews{}, nil).Once()
+	repo := TranslationRepo{
+		queryProxy:                &queryRepo,
+		lastTranslationsPageCache: cache.NewContext[string, map[string]query.LastTranslationViews](context.TODO()),
+		cacheTTL:                  time.Minute,
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.GetViewsAfter(filter, &cursor, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, query.LastTranslationViews{Next: &next}, got)
+	}
+
+	got, err := repo.GetViewsAfter(filter, nil, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, query.LastTranslationViews{}, got)
+
+	authorCache, ok := repo.lastTranslationsPageCache.Get("authorID-EN")
+	assert.True(t, ok)
+	assert.Len(t, authorCache, 2)
+	queryRepo.AssertExpectations(t)
+}
+
+func TestTranslationRepo_filterPageKey(t *testing.T) {
+	repo := TranslationRepo{}
+	from := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	tags := query.TagGroups{{"tag21", "tag2"}, {"tag1"}}
+
+	key := repo.filterPageKey(query.TranslationFilter{
+		SourcePart: "sour",
+		TargetPart: "targ",
+		TextPart:   "exam",
+		Tags:       tags,
+		Created:    query.DateRange{From: from},
+		Sort:       query.SortSourceAsc,
+	}, 10, 2)
+
+	assert.Equal(t, "10-2-sort-source_asc-langs--source-sour-target-targ-text-exam-tags-tag1-tag2+tag21-created-2023-01-02T00:00:00Z-0001-01-01T00:00:00Z-updated-0001-01-01T00:00:00Z-0001-01-01T00:00:00Z", key)
+	assert.Equal(t, query.TagGroups{{"tag21", "tag2"}, {"tag1"}}, tags)
+	assert.NotEqual(t, key, repo.filterPageKey(query.TranslationFilter{SourcePart: "sour", Tags: tags}, 10, 2))
+}
+
 func createTranslationByAuthorIDAndIDAndLangID(authorID, id, langID string) *translation.Translation {
 	return translation.UnmarshalFromDB(
 		id,
 		"test",
 		"test",
-		"test",
+		[]translation.Sense{translation.NewSense("test", "test", []string{})},
 		authorID,
-		"test",
-		[]string{},
 		time.Now(),
 		time.Now(),
 		langID,
+		translation.Review{},
+		nil,
 	)
 }
 
@@ -706,3 +871,10 @@ func TestTranslationRepo_DeleteByAuthorID(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslationRepo_authorLangsCacheKey(t *testing.T) {
+	repo := TranslationRepo{}
+	assert.Equal(t, "authorID-EN", repo.authorLangsCacheKey("authorID", []string{"EN"}))
+	assert.Equal(t, "authorID-*", repo.authorLangsCacheKey("authorID", []string{"EN", "DE"}))
+	assert.Equal(t, "authorID-*", repo.authorLangsCacheKey("authorID", nil))
+}
@@ -36,6 +36,16 @@ func (l LangRepo) Get(id, authorID string) (*lang.Lang, error) {
 	return nil, lang.ErrNotFound
 }
 
+func (l LangRepo) GetByName(name, authorID string) (*lang.Lang, error) {
+	for _, ln := range l.storage {
+		if ln.AuthorID() == authorID && ln.Name() == name {
+			return ln, nil
+		}
+	}
+
+	return nil, lang.ErrNotFound
+}
+
 func (l LangRepo) Delete(id, authorID string) error {
 	ln, ok := l.storage[id]
 
@@ -76,11 +86,7 @@ func (l LangRepo) GetAllViews(authorID string) ([]query.LangView, error) {
 			continue
 		}
 
-		langData := ln.ToMap()
-		langs = append(langs, query.LangView{
-			ID:   ln.ID(),
-			Name: langData["name"].(string),
-		})
+		langs = append(langs, l.toView(ln))
 	}
 
 	return langs, nil
@@ -90,12 +96,30 @@ func (l LangRepo) GetView(id, authorID string) (query.LangView, error) {
 	ln, ok := l.storage[id]
 
 	if ok && ln.AuthorID() == authorID {
-		langData := ln.ToMap()
-		return query.LangView{
-			ID:   ln.ID(),
-			Name: langData["name"].(string),
-		}, nil
+		return l.toView(ln), nil
 	}
 
 	return query.LangView{}, fmt.Errorf("not found")
 }
+
+func (l LangRepo) toView(ln *lang.Lang) query.LangView {
+	langData := ln.ToMap()
+	view := query.LangView{
+		ID:                  ln.ID(),
+		Name:                langData["name"].(string),
+		Code:                langData["code"].(string),
+		Direction:           langData["direction"].(string),
+		TranscriptionScheme: langData["transcriptionScheme"].(string),
+		Fields:              make([]query.LangFieldView, 0),
+	}
+
+	for _, field := range langData["fields"].([]map[string]interface{}) {
+		view.Fields = append(view.Fields, query.LangFieldView{
+			Name:    field["name"].(string),
+			Kind:    field["kind"].(string),
+			Options: field["options"].([]string),
+		})
+	}
+
+	return view
+}
@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"sort"
+	"time"
+)
+
+type RevisionRepo struct {
+	storage map[string]*translation.Revision
+}
+
+func NewRevisionRepository() *RevisionRepo {
+	return &RevisionRepo{
+		storage: map[string]*translation.Revision{},
+	}
+}
+
+func (r *RevisionRepo) Create(revision *translation.Revision) error {
+	r.storage[revision.ID()] = revision
+	return nil
+}
+
+func (r *RevisionRepo) Get(id, translationID, authorID string) (*translation.Revision, error) {
+	revision, ok := r.storage[id]
+
+	if ok && revision.TranslationID() == translationID && revision.AuthorID() == authorID {
+		return revision, nil
+	}
+
+	return nil, translation.ErrRevisionNotFound
+}
+
+func (r *RevisionRepo) DeleteByTranslationID(translationID, authorID string) error {
+	for key, revision := range r.storage {
+		if revision.TranslationID() == translationID && revision.AuthorID() == authorID {
+			delete(r.storage, key)
+		}
+	}
+
+	return nil
+}
+
+func (r *RevisionRepo) DeleteByAuthorID(authorID string) (int, error) {
+	counter := 0
+	for key, revision := range r.storage {
+		if revision.AuthorID() == authorID {
+			delete(r.storage, key)
+			counter++
+		}
+	}
+
+	return counter, nil
+}
+
+func (r *RevisionRepo) GetViews(translationID, authorID string) ([]query.RevisionView, error) {
+	views := make([]query.RevisionView, 0)
+
+	for _, revision := range r.storage {
+		if revision.TranslationID() != translationID || revision.AuthorID() != authorID {
+			continue
+		}
+
+		changes := make([]query.FieldChangeView, 0, len(revision.Changes()))
+		for _, change := range revision.Changes() {
+			changes = append(changes, query.FieldChangeView{
+				Field:    change.Field(),
+				OldValue: change.OldValue(),
+				NewValue: change.NewValue(),
+			})
+		}
+
+		views = append(views, query.RevisionView{
+			ID:        revision.ID(),
+			CreatedAt: revision.ToMap()["createdAt"].(time.Time),
+			Changes:   changes,
+		})
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].CreatedAt.After(views[j].CreatedAt)
+	})
+
+	return views, nil
+}
@@ -0,0 +1,116 @@
+package inmemory
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"sort"
+	"sync"
+)
+
+// SessionRepo keeps copies of sessions, so rotation of the read session is not visible until it is saved with Update
+type SessionRepo struct {
+	storage map[string]*session.Session
+	mu      sync.Mutex
+}
+
+func NewSessionRepository() *SessionRepo {
+	return &SessionRepo{
+		storage: map[string]*session.Session{},
+	}
+}
+
+func (r *SessionRepo) Create(s *session.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.storage[s.ID()] = copySession(s)
+	return nil
+}
+
+func (r *SessionRepo) Get(id string) (*session.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.storage[id]
+
+	if ok && !s.Expired() {
+		return copySession(s), nil
+	}
+
+	return nil, session.ErrNotFound
+}
+
+func (r *SessionRepo) Update(s *session.Session, presentedTokenID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.storage[s.ID()]
+	if !ok || stored.TokenID() != presentedTokenID {
+		return session.ErrTokenReused
+	}
+
+	r.storage[s.ID()] = copySession(s)
+	return nil
+}
+
+func (r *SessionRepo) Delete(id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.storage[id]
+
+	if ok && s.UserID() == userID {
+		delete(r.storage, id)
+		return nil
+	}
+
+	return fmt.Errorf("not found")
+}
+
+func (r *SessionRepo) DeleteByUserID(userID, exceptID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counter := 0
+	for key, s := range r.storage {
+		if s.UserID() == userID && key != exceptID {
+			delete(r.storage, key)
+			counter++
+		}
+	}
+
+	return counter, nil
+}
+
+func (r *SessionRepo) GetAllViews(userID string) ([]query.SessionView, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	views := make([]query.SessionView, 0)
+
+	for _, s := range r.storage {
+		if s.UserID() != userID || s.Expired() {
+			continue
+		}
+
+		views = append(views, query.SessionView{
+			ID:          s.ID(),
+			UserAgent:   s.UserAgent(),
+			IP:          s.IP(),
+			CreatedAt:   s.CreatedAt(),
+			RefreshedAt: s.RefreshedAt(),
+			ExpiresAt:   s.ExpiresAt(),
+		})
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].RefreshedAt.After(views[j].RefreshedAt)
+	})
+
+	return views, nil
+}
+
+func copySession(s *session.Session) *session.Session {
+	return session.UnmarshalFromDB(s.ID(), s.UserID(), s.TokenID(), s.UserAgent(), s.IP(), s.CreatedAt(), s.RefreshedAt(), s.ExpiresAt())
+}
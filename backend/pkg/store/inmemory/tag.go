@@ -26,6 +26,16 @@ func (r *TagRepo) Get(id, authorID string) (*tag.Tag, error) {
 	return nil, tag.ErrNotFound
 }
 
+func (r *TagRepo) GetByName(name, authorID string) (*tag.Tag, error) {
+	for _, t := range r.storage {
+		if t.AuthorID() == authorID && t.ToMap()["name"] == name {
+			return t, nil
+		}
+	}
+
+	return nil, tag.ErrNotFound
+}
+
 func (r *TagRepo) Delete(id, authorID string) error {
 	t, ok := r.storage[id]
 
@@ -64,6 +74,26 @@ func (r *TagRepo) DeleteByAuthorID(authorID string) (int, error) {
 	return counter, nil
 }
 
+func (r *TagRepo) ExistByParent(parentID, authorID string) (bool, error) {
+	for _, t := range r.storage {
+		if t.AuthorID() == authorID && t.ParentID() == parentID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *TagRepo) MoveChildren(parentID, newParentID, authorID string) error {
+	for id, t := range r.storage {
+		if t.AuthorID() == authorID && t.ParentID() == parentID {
+			r.storage[id] = tag.UnmarshalFromDB(t.ID(), t.ToMap()["name"].(string), authorID, newParentID)
+		}
+	}
+
+	return nil
+}
+
 func (r *TagRepo) GetAllViews(authorID string) ([]query.TagView, error) {
 	tags := make([]query.TagView, 0)
 	for _, t := range r.storage {
@@ -71,25 +101,26 @@ func (r *TagRepo) GetAllViews(authorID string) ([]query.TagView, error) {
 			continue
 		}
 
-		tagData := t.ToMap()
-		tags = append(tags, query.TagView{
-			ID:   t.ID(),
-			Name: tagData["name"].(string),
-		})
+		tags = append(tags, r.toView(t))
 	}
 
 	return tags, nil
 }
 
+func (r *TagRepo) GetHierarchy(authorID string) (query.TagHierarchy, error) {
+	views, err := r.GetAllViews(authorID)
+	if err != nil {
+		return query.TagHierarchy{}, err
+	}
+
+	return query.NewTagHierarchy(views), nil
+}
+
 func (r *TagRepo) GetView(id, authorID string) (query.TagView, error) {
 	t, ok := r.storage[id]
 
 	if ok && t.AuthorID() == authorID {
-		tagData := t.ToMap()
-		return query.TagView{
-			ID:   t.ID(),
-			Name: tagData["name"].(string),
-		}, nil
+		return r.toView(t), nil
 	}
 
 	return query.TagView{}, fmt.Errorf("not found")
@@ -101,11 +132,7 @@ func (r *TagRepo) GetViews(ids []string, authorID string) ([]query.TagView, erro
 	for _, id := range ids {
 		for _, t := range r.storage {
 			if t.AuthorID() == authorID && t.ID() == id {
-				tagData := t.ToMap()
-				views = append(views, query.TagView{
-					ID:   t.ID(),
-					Name: tagData["name"].(string),
-				})
+				views = append(views, r.toView(t))
 			}
 		}
 	}
@@ -117,3 +144,11 @@ func (r *TagRepo) Update(t *tag.Tag) error {
 	r.storage[t.ID()] = t
 	return nil
 }
+
+func (r *TagRepo) toView(t *tag.Tag) query.TagView {
+	return query.TagView{
+		ID:       t.ID(),
+		Name:     t.ToMap()["name"].(string),
+		ParentID: t.ParentID(),
+	}
+}
@@ -0,0 +1,95 @@
+package inmemory
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"sort"
+)
+
+type TokenRepo struct {
+	storage map[string]*token.Token
+}
+
+func NewTokenRepository() *TokenRepo {
+	return &TokenRepo{
+		storage: map[string]*token.Token{},
+	}
+}
+
+func (r *TokenRepo) Create(t *token.Token) error {
+	r.storage[t.ID()] = t
+	return nil
+}
+
+func (r *TokenRepo) GetByHash(hash string) (*token.Token, error) {
+	for _, t := range r.storage {
+		if t.Hash() == hash && !t.Expired() {
+			return t, nil
+		}
+	}
+
+	return nil, token.ErrNotFound
+}
+
+func (r *TokenRepo) Update(t *token.Token) error {
+	if _, ok := r.storage[t.ID()]; !ok {
+		return token.ErrNotFound
+	}
+
+	r.storage[t.ID()] = t
+	return nil
+}
+
+func (r *TokenRepo) Delete(id, userID string) error {
+	t, ok := r.storage[id]
+
+	if ok && t.UserID() == userID {
+		delete(r.storage, id)
+		return nil
+	}
+
+	return fmt.Errorf("not found")
+}
+
+func (r *TokenRepo) DeleteByUserID(userID string) (int, error) {
+	counter := 0
+	for key, t := range r.storage {
+		if t.UserID() == userID {
+			delete(r.storage, key)
+			counter++
+		}
+	}
+
+	return counter, nil
+}
+
+func (r *TokenRepo) GetAllViews(userID string) ([]query.TokenView, error) {
+	views := make([]query.TokenView, 0)
+
+	for _, t := range r.storage {
+		if t.UserID() != userID {
+			continue
+		}
+
+		scopes := make([]string, 0, len(t.Scopes()))
+		for _, s := range t.Scopes() {
+			scopes = append(scopes, string(s))
+		}
+
+		views = append(views, query.TokenView{
+			ID:         t.ID(),
+			Name:       t.Name(),
+			Scopes:     scopes,
+			CreatedAt:  t.CreatedAt(),
+			ExpiresAt:  t.ExpiresAt(),
+			LastUsedAt: t.LastUsedAt(),
+		})
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].CreatedAt.After(views[j].CreatedAt)
+	})
+
+	return views, nil
+}
@@ -32,7 +32,9 @@ func (r *TranslationRepo) Get(id, authorID string) (*translation.Translation, er
 	t, ok := r.storage[id]
 
 	if ok && t.AuthorID() == authorID {
-		return t, nil
+		// copy keeps stored translation unchanged until it is saved like in real stores
+		record := *t
+		return &record, nil
 	}
 
 	return nil, translation.ErrNotFound
@@ -49,7 +51,46 @@ func (r *TranslationRepo) Delete(id, authorID string) error {
 	return fmt.Errorf("not found")
 }
 
+func (r *TranslationRepo) UpdateMany(translations []*translation.Translation) []error {
+	errs := make([]error, len(translations))
+	for i, t := range translations {
+		if r.sourceExists(t) {
+			errs[i] = translation.ErrSourceAlreadyExists
+			continue
+		}
+		r.storage[t.ID()] = t
+	}
+
+	return errs
+}
+
+func (r *TranslationRepo) DeleteMany(translations []*translation.Translation) error {
+	for _, t := range translations {
+		if err := r.Delete(t.ID(), t.AuthorID()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sourceExists checks if other translation with the same source exists in the translation lang
+func (r *TranslationRepo) sourceExists(t *translation.Translation) bool {
+	source := t.ToMap()["source"]
+	for _, existing := range r.storage {
+		if existing.ID() != t.ID() && existing.AuthorID() == t.AuthorID() && existing.LangID() == t.LangID() && existing.ToMap()["source"] == source {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r *TranslationRepo) Create(t *translation.Translation) error {
+	if r.sourceExists(t) {
+		return translation.ErrSourceAlreadyExists
+	}
+
 	r.storage[t.ID()] = t
 	return nil
 }
@@ -66,13 +107,45 @@ func (r *TranslationRepo) ExistByLang(langID, authorID string) (bool, error) {
 	return false, nil
 }
 
+func (r *TranslationRepo) ExistBySource(source, langID, authorID string) (bool, error) {
+	for _, t := range r.storage {
+		if t.AuthorID() == authorID && t.LangID() == langID && t.ToMap()["source"] == source {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *TranslationRepo) GetBySource(source, langID, authorID string) (*translation.Translation, error) {
+	for _, t := range r.storage {
+		if t.AuthorID() == authorID && t.LangID() == langID && t.ToMap()["source"] == source {
+			return t, nil
+		}
+	}
+
+	return nil, translation.ErrNotFound
+}
+
+func (r *TranslationRepo) GetByLang(langID, authorID string) ([]*translation.Translation, error) {
+	var records []*translation.Translation
+	for _, t := range r.storage {
+		if t.AuthorID() == authorID && t.LangID() == langID {
+			record := *t
+			records = append(records, &record)
+		}
+	}
+
+	return records, nil
+}
+
 func (r *TranslationRepo) ExistByTag(tagID, authorID string) (bool, error) {
 	for _, t := range r.storage {
 		if t.AuthorID() != authorID {
 			continue
 		}
 
-		for _, tag := range t.ToMap()["tagIDs"].([]string) {
+		for _, tag := range t.TagIDs() {
 			if tag == tagID {
 				return true, nil
 			}
@@ -82,6 +155,37 @@ func (r *TranslationRepo) ExistByTag(tagID, authorID string) (bool, error) {
 	return false, nil
 }
 
+func (r *TranslationRepo) ReplaceTag(tagID, newTagID, authorID string) (int, error) {
+	return r.updateTagged(tagID, authorID, func(t *translation.Translation) error {
+		return t.ReplaceTag(tagID, newTagID)
+	})
+}
+
+func (r *TranslationRepo) RemoveTag(tagID, authorID string) (int, error) {
+	return r.updateTagged(tagID, authorID, func(t *translation.Translation) error {
+		return t.RemoveTags([]string{tagID})
+	})
+}
+
+// updateTagged applies the change to copies of all author translations tagged by tagID and saves them
+func (r *TranslationRepo) updateTagged(tagID, authorID string, change func(t *translation.Translation) error) (int, error) {
+	counter := 0
+	for id, t := range r.storage {
+		if t.AuthorID() != authorID || !containsString(t.TagIDs(), tagID) {
+			continue
+		}
+
+		record := *t
+		if err := change(&record); err != nil {
+			return counter, err
+		}
+		r.storage[id] = &record
+		counter++
+	}
+
+	return counter, nil
+}
+
 func (r *TranslationRepo) DeleteByAuthorID(authorID string) (int, error) {
 	counter := 0
 	for key, tr := range r.storage {
@@ -94,141 +198,309 @@ func (r *TranslationRepo) DeleteByAuthorID(authorID string) (int, error) {
 	return counter, nil
 }
 
-func (r *TranslationRepo) GetLastViewsByTags(authorID, langID string, pageSize, page int, tagIds []string) (query.LastTranslationViews, error) {
-	type mapItem struct {
-		t         *translation.Translation
-		createdAt time.Time
+// sortItem keeps translation with all keys it can be sorted by
+type sortItem struct {
+	t          *translation.Translation
+	id         string
+	createdAt  time.Time
+	updatedAt  time.Time
+	source     string
+	target     string
+	sourceRank query.MatchRank
+	targetRank query.MatchRank
+}
+
+func (r *TranslationRepo) GetLastViews(filter query.TranslationFilter, pageSize, page int) (query.LastTranslationViews, error) {
+	items := r.sortedItems(filter)
+
+	views := make([]query.TranslationView, 0, pageSize)
+	for i := pageSize * (page - 1); i < len(items) && i < pageSize*page; i++ {
+		view, err := r.translationToView(items[i].t)
+		if err != nil {
+			return query.LastTranslationViews{}, err
+		}
+		views = append(views, view)
 	}
 
-	items := make([]mapItem, 0, len(r.storage))
+	return query.LastTranslationViews{
+		Views:        views,
+		TotalRecords: len(items),
+	}, nil
+}
+
+func (r *TranslationRepo) GetViewsAfter(filter query.TranslationFilter, cursor *query.Cursor, limit int) (query.LastTranslationViews, error) {
+	items := r.sortedItems(filter)
+
+	start := 0
+	if cursor != nil {
+		after := r.cursorToItem(*cursor)
+		start = sort.Search(len(items), func(i int) bool {
+			return r.less(after, items[i], filter.Sort)
+		})
+	}
+
+	views := make([]query.TranslationView, 0, limit)
+	for i := start; i < len(items) && i < start+limit; i++ {
+		view, err := r.translationToView(items[i].t)
+		if err != nil {
+			return query.LastTranslationViews{}, err
+		}
+		views = append(views, view)
+	}
+
+	result := query.LastTranslationViews{Views: views}
+	if last := start + limit - 1; last < len(items)-1 {
+		next := r.itemToCursor(items[last], filter.Sort)
+		result.Next = &next
+	}
+
+	return result, nil
+}
+
+// sortedItems returns author translations matched the filter in the filter sort order
+func (r *TranslationRepo) sortedItems(filter query.TranslationFilter) []sortItem {
+	items := make([]sortItem, 0, len(r.storage))
 
 	for _, v := range r.storage {
-		if v.AuthorID() != authorID || v.LangID() != langID {
+		if v.AuthorID() != filter.AuthorID || !r.inLangs(v.LangID(), filter.LangIDs) {
 			continue
 		}
 
 		data := v.ToMap()
 
-		if !r.containsAll(data["tagIDs"].([]string), tagIds) {
+		if !r.matchFilter(v, data, filter) {
 			continue
 		}
 
-		items = append(items, mapItem{
-			t:         v,
-			createdAt: data["createdAt"].(time.Time),
-		})
+		sourceRank, targetRank := r.rankMatch(v, data, filter)
+		if sourceRank == query.NoMatch || targetRank == query.NoMatch {
+			continue
+		}
+
+		item := sortItem{
+			t:          v,
+			id:         v.ID(),
+			createdAt:  data["createdAt"].(time.Time),
+			updatedAt:  data["updatedAt"].(time.Time),
+			source:     query.NormalizeSearchText(data["source"].(string)),
+			sourceRank: sourceRank,
+			targetRank: targetRank,
+		}
+
+		if senses := v.Senses(); len(senses) != 0 {
+			item.target = query.NormalizeSearchText(senses[0].Target())
+		}
+
+		items = append(items, item)
 	}
 
 	sort.Slice(items, func(i, j int) bool {
-		return items[i].createdAt.After(items[j].createdAt)
+		return r.less(items[i], items[j], filter.Sort)
 	})
 
-	var offset int
-	if page > 1 {
-		offset = pageSize * page
+	return items
+}
+
+// less reports whether a goes before b in the sort order, translation ID breaks ties
+func (r *TranslationRepo) less(a, b sortItem, order query.SortOrder) bool {
+	switch order {
+	case query.SortCreatedAsc:
+		if !a.createdAt.Equal(b.createdAt) {
+			return a.createdAt.Before(b.createdAt)
+		}
+		return a.id < b.id
+	case query.SortUpdatedDesc:
+		if !a.updatedAt.Equal(b.updatedAt) {
+			return a.updatedAt.After(b.updatedAt)
+		}
+		return a.id > b.id
+	case query.SortSourceAsc:
+		if a.source != b.source {
+			return a.source < b.source
+		}
+		return a.id < b.id
+	case query.SortTargetAsc:
+		if a.target != b.target {
+			return a.target < b.target
+		}
+		return a.id < b.id
+	case query.SortRelevance:
+		if a.sourceRank != b.sourceRank {
+			return a.sourceRank < b.sourceRank
+		}
+		if a.targetRank != b.targetRank {
+			return a.targetRank < b.targetRank
+		}
+	}
+
+	if !a.createdAt.Equal(b.createdAt) {
+		return a.createdAt.After(b.createdAt)
 	}
+	return a.id > b.id
+}
 
-	if len(items) < offset && page != 1 {
-		return query.LastTranslationViews{}, fmt.Errorf("can not get translations from DB")
+func (r *TranslationRepo) itemToCursor(item sortItem, order query.SortOrder) query.Cursor {
+	cursor := query.Cursor{Sort: order, ID: item.id}
+
+	switch order {
+	case query.SortUpdatedDesc:
+		cursor.Time = item.updatedAt
+	case query.SortSourceAsc:
+		cursor.Text = item.source
+	case query.SortTargetAsc:
+		cursor.Text = item.target
+	case query.SortRelevance:
+		cursor.SourceRank, cursor.TargetRank = item.sourceRank, item.targetRank
+		cursor.Time = item.createdAt
+	default:
+		cursor.Time = item.createdAt
 	}
 
-	views := make([]query.TranslationView, 0, len(items))
+	return cursor
+}
 
-	i := 0
-	limit := offset + pageSize
-	for _, v := range items {
-		if i >= offset && i < limit {
-			view, err := r.translationToView(v.t)
+func (r *TranslationRepo) cursorToItem(cursor query.Cursor) sortItem {
+	return sortItem{
+		id:         cursor.ID,
+		createdAt:  cursor.Time,
+		updatedAt:  cursor.Time,
+		source:     cursor.Text,
+		target:     cursor.Text,
+		sourceRank: cursor.SourceRank,
+		targetRank: cursor.TargetRank,
+	}
+}
 
-			if err != nil {
-				return query.LastTranslationViews{}, err
-			}
-			views = append(views, view)
-		} else if i >= offset+limit {
-			break
+// matchFilter checks that translation matches all set filter conditions except author, lang, source and target parts
+func (r *TranslationRepo) matchFilter(t *translation.Translation, data map[string]interface{}, filter query.TranslationFilter) bool {
+	if !r.matchesTags(t.TagIDs(), filter.Tags) {
+		return false
+	}
+
+	if !filter.Created.Contains(data["createdAt"].(time.Time)) || !filter.Updated.Contains(data["updatedAt"].(time.Time)) {
+		return false
+	}
+
+	if filter.TextPart == "" {
+		return true
+	}
+
+	textPart := query.NormalizeSearchText(filter.TextPart)
+	if strings.Contains(query.NormalizeSearchText(data["source"].(string)), textPart) ||
+		strings.Contains(query.NormalizeSearchText(data["transcription"].(string)), textPart) {
+		return true
+	}
+
+	for _, sense := range t.Senses() {
+		if strings.Contains(query.NormalizeSearchText(sense.Target()), textPart) || strings.Contains(query.NormalizeSearchText(sense.Example()), textPart) {
+			return true
 		}
-		i++
 	}
 
-	return query.LastTranslationViews{
-		Views:        views,
-		TotalRecords: len(items),
-	}, nil
+	return false
 }
 
-func (r *TranslationRepo) GetLastViewsBySourcePart(authorID, langID, sourcePart string, pageSize, page int) (query.LastTranslationViews, error) {
-	type mapItem struct {
-		t         *translation.Translation
-		createdAt time.Time
+// rankMatch returns source and target parts match ranks ignoring case and diacritics, not set part is an exact match
+func (r *TranslationRepo) rankMatch(t *translation.Translation, data map[string]interface{}, filter query.TranslationFilter) (sourceRank, targetRank query.MatchRank) {
+	if filter.SourcePart != "" {
+		sourceRank = query.RankMatch(query.NormalizeSearchText(data["source"].(string)), query.NormalizeSearchText(filter.SourcePart))
 	}
 
-	items := make([]mapItem, 0, len(r.storage))
+	if filter.TargetPart == "" {
+		return sourceRank, query.ExactMatch
+	}
+
+	targetPart := query.NormalizeSearchText(filter.TargetPart)
+	targetRank = query.NoMatch
+	for _, sense := range t.Senses() {
+		if rank := query.RankMatch(query.NormalizeSearchText(sense.Target()), targetPart); rank < targetRank {
+			targetRank = rank
+		}
+	}
+
+	return sourceRank, targetRank
+}
+
+func (r *TranslationRepo) GetRandomViews(authorID string, langIDs []string, tags query.TagGroups, limit int) (query.RandomViews, error) {
+	views := make([]query.TranslationView, 0, limit)
+	found := 0
 
 	for _, v := range r.storage {
-		if v.AuthorID() != authorID || v.LangID() != langID {
+		if found >= limit {
+			return query.RandomViews{Views: views}, nil
+		}
+		if v.AuthorID() != authorID || !r.inLangs(v.LangID(), langIDs) {
 			continue
 		}
 
-		data := v.ToMap()
+		if !r.matchesTags(v.TagIDs(), tags) {
+			continue
+		}
+
+		view, err := r.translationToView(v)
 
-		source, err := data["source"].(string)
-		if !err {
-			return query.LastTranslationViews{}, fmt.Errorf("can not get translations from DB")
+		if err != nil {
+			return query.RandomViews{}, err
 		}
 
-		if !strings.Contains(source, sourcePart) {
+		views = append(views, view)
+		found++
+	}
+
+	return query.RandomViews{Views: views}, nil
+}
+
+func (r *TranslationRepo) GetDueViews(authorID, langID string, tags query.TagGroups, dueAt time.Time, limit int) (query.DueViews, error) {
+	items := make([]*translation.Translation, 0, len(r.storage))
+
+	for _, v := range r.storage {
+		if v.AuthorID() != authorID || v.LangID() != langID {
 			continue
 		}
 
-		items = append(items, mapItem{
-			t:         v,
-			createdAt: data["createdAt"].(time.Time),
-		})
+		if !r.matchesTags(v.TagIDs(), tags) {
+			continue
+		}
+
+		review := v.Review()
+		if review.DueAt().After(dueAt) {
+			continue
+		}
+
+		items = append(items, v)
 	}
 
 	sort.Slice(items, func(i, j int) bool {
-		return items[i].createdAt.After(items[j].createdAt)
+		left, right := items[i].Review(), items[j].Review()
+		return left.DueAt().Before(right.DueAt())
 	})
 
-	var offset int
-	if page > 1 {
-		offset = pageSize * page
-	}
-
-	if len(items) < offset && page != 1 {
-		return query.LastTranslationViews{}, fmt.Errorf("can not get translations from DB")
+	if len(items) > limit {
+		items = items[:limit]
 	}
 
 	views := make([]query.TranslationView, 0, len(items))
-
-	i := 0
-	limit := offset + pageSize
 	for _, v := range items {
-		if i >= offset && i < limit {
-			view, err := r.translationToView(v.t)
+		view, err := r.translationToView(v)
 
-			if err != nil {
-				return query.LastTranslationViews{}, err
-			}
-			views = append(views, view)
-		} else if i >= offset+limit {
-			break
+		if err != nil {
+			return query.DueViews{}, err
 		}
-		i++
+
+		views = append(views, view)
 	}
 
-	return query.LastTranslationViews{
-		Views:        views,
-		TotalRecords: len(items),
-	}, nil
+	return query.DueViews{Views: views}, nil
 }
 
-func (r *TranslationRepo) GetLastViewsByTargetPart(authorID, langID, targetPart string, pageSize, page int) (query.LastTranslationViews, error) {
+func (r *TranslationRepo) GetFuzzyViews(authorID, langID, text string, maxDistance, limit int) (query.FuzzyViews, error) {
 	type mapItem struct {
 		t         *translation.Translation
 		createdAt time.Time
+		distance  int
 	}
 
+	text = query.NormalizeSearchText(text)
 	items := make([]mapItem, 0, len(r.storage))
 
 	for _, v := range r.storage {
@@ -237,95 +509,176 @@ func (r *TranslationRepo) GetLastViewsByTargetPart(authorID, langID, targetPart
 		}
 
 		data := v.ToMap()
-
-		target, err := data["target"].(string)
-		if !err {
-			return query.LastTranslationViews{}, fmt.Errorf("can not get translations from DB")
+		distance := query.EditDistance(query.NormalizeSearchText(data["source"].(string)), text)
+		for _, sense := range v.Senses() {
+			if d := query.EditDistance(query.NormalizeSearchText(sense.Target()), text); d < distance {
+				distance = d
+			}
 		}
 
-		if !strings.Contains(target, targetPart) {
+		if distance > maxDistance {
 			continue
 		}
 
-		items = append(items, mapItem{
-			t:         v,
-			createdAt: data["createdAt"].(time.Time),
-		})
+		items = append(items, mapItem{t: v, createdAt: data["createdAt"].(time.Time), distance: distance})
 	}
 
 	sort.Slice(items, func(i, j int) bool {
+		if items[i].distance != items[j].distance {
+			return items[i].distance < items[j].distance
+		}
 		return items[i].createdAt.After(items[j].createdAt)
 	})
 
-	var offset int
-	if page > 1 {
-		offset = pageSize * page
+	if len(items) > limit {
+		items = items[:limit]
 	}
 
-	if len(items) < offset && page != 1 {
-		return query.LastTranslationViews{}, fmt.Errorf("can not get translations from DB")
-	}
-
-	views := make([]query.TranslationView, 0, len(items))
-
-	i := 0
-	limit := offset + pageSize
-	for _, v := range items {
-		if i >= offset && i < limit {
-			view, err := r.translationToView(v.t)
+	views := make([]query.FuzzyView, 0, len(items))
+	for _, item := range items {
+		view, err := r.translationToView(item.t)
 
-			if err != nil {
-				return query.LastTranslationViews{}, err
-			}
-			views = append(views, view)
-		} else if i >= offset+limit {
-			break
+		if err != nil {
+			return query.FuzzyViews{}, err
 		}
-		i++
+
+		views = append(views, query.FuzzyView{Translation: view, Distance: item.distance})
 	}
 
-	return query.LastTranslationViews{
-		Views:        views,
-		TotalRecords: len(items),
-	}, nil
+	return query.FuzzyViews{Views: views}, nil
 }
 
-func (r *TranslationRepo) GetRandomViews(authorID, langID string, tagIds []string, limit int) (query.RandomViews, error) {
-	views := make([]query.TranslationView, 0, limit)
-	found := 0
+func (r *TranslationRepo) GetStats(authorID string, added query.DateRange, period query.StatsPeriod) (query.StatsView, error) {
+	stats := query.StatsView{}
+	langCounts := map[string]int{}
+	tagCounts := map[string]int{}
+	addedCounts := map[time.Time]int{}
 
-	for _, v := range r.storage {
-		if found >= limit {
-			return query.RandomViews{Views: views}, nil
-		}
-		if v.AuthorID() != authorID || v.LangID() != langID {
+	for _, t := range r.storage {
+		if t.AuthorID() != authorID {
 			continue
 		}
 
-		data := v.ToMap()
+		stats.Total++
+		langCounts[t.LangID()]++
 
-		if !r.containsAll(data["tagIDs"].([]string), tagIds) {
-			continue
+		tagIDs := t.TagIDs()
+		if len(tagIDs) == 0 {
+			stats.Untagged++
 		}
 
-		view, err := r.translationToView(v)
+		for _, tagID := range tagIDs {
+			tagCounts[tagID]++
+		}
 
+		if createdAt := t.ToMap()["createdAt"].(time.Time); added.Contains(createdAt) {
+			addedCounts[period.Start(createdAt)]++
+		}
+	}
+
+	for langID, count := range langCounts {
+		langView, err := r.langRepo.GetView(langID, authorID)
 		if err != nil {
-			return query.RandomViews{}, err
+			return query.StatsView{}, err
 		}
+		stats.Langs = append(stats.Langs, query.LangStatsView{Lang: langView, Count: count})
+	}
 
-		views = append(views, view)
-		found++
+	for tagID, count := range tagCounts {
+		tagView, err := r.tagRepo.GetView(tagID, authorID)
+		if err != nil {
+			return query.StatsView{}, err
+		}
+		stats.Tags = append(stats.Tags, query.TagStatsView{Tag: tagView, Count: count})
 	}
 
-	return query.RandomViews{Views: views}, nil
+	for start, count := range addedCounts {
+		stats.Added = append(stats.Added, query.AddedStatsView{PeriodStart: start, Count: count})
+	}
+
+	sort.Slice(stats.Added, func(i, j int) bool {
+		return stats.Added[i].PeriodStart.Before(stats.Added[j].PeriodStart)
+	})
+
+	return stats, nil
 }
 
-func (r *TranslationRepo) containsAll(tags, searchTags []string) bool {
-	for _, searchTag := range searchTags {
+func (r *TranslationRepo) ForEachTranslation(authorID string, fn func(query.ExportTranslationView) error) error {
+	items := make([]*translation.Translation, 0)
+	for _, t := range r.storage {
+		if t.AuthorID() == authorID {
+			items = append(items, t)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ToMap()["createdAt"].(time.Time).Before(items[j].ToMap()["createdAt"].(time.Time))
+	})
+
+	for _, t := range items {
+		if err := fn(r.translationToExportView(t)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TranslationRepo) translationToExportView(t *translation.Translation) query.ExportTranslationView {
+	translationData := t.ToMap()
+	senses := translationData["senses"].([]map[string]interface{})
+	senseViews := make([]query.ExportSenseView, 0, len(senses))
+	for _, sense := range senses {
+		senseViews = append(senseViews, query.ExportSenseView{
+			Target:  sense["target"].(string),
+			Example: sense["example"].(string),
+			TagIDs:  sense["tagIDs"].([]string),
+		})
+	}
+
+	reviewData := translationData["review"].(map[string]interface{})
+
+	return query.ExportTranslationView{
+		ID:            t.ID(),
+		LangID:        translationData["langID"].(string),
+		Source:        translationData["source"].(string),
+		Transcription: translationData["transcription"].(string),
+		Senses:        senseViews,
+		Fields:        translationData["fields"].(map[string]string),
+		CreatedAt:     translationData["createdAt"].(time.Time),
+		UpdatedAt:     translationData["updatedAt"].(time.Time),
+		Review: query.ReviewView{
+			Ease:        reviewData["ease"].(float64),
+			Interval:    reviewData["interval"].(int),
+			Repetitions: reviewData["repetitions"].(int),
+			Lapses:      reviewData["lapses"].(int),
+			DueAt:       reviewData["dueAt"].(time.Time),
+			ReviewedAt:  reviewData["reviewedAt"].(time.Time),
+		},
+	}
+}
+
+// inLangs checks if lang is one of passed langs, empty langs means any lang
+func (r *TranslationRepo) inLangs(langID string, langIDs []string) bool {
+	if len(langIDs) == 0 {
+		return true
+	}
+
+	for _, id := range langIDs {
+		if id == langID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesTags checks that tags contain any tag of every group
+func (r *TranslationRepo) matchesTags(tags []string, groups query.TagGroups) bool {
+	for _, group := range groups {
 		found := false
 		for _, tag := range tags {
-			if searchTag == tag {
+			if containsString(group, tag) {
 				found = true
 				break
 			}
@@ -350,9 +703,19 @@ func (r *TranslationRepo) GetView(id, authorID string) (query.TranslationView, e
 
 func (r *TranslationRepo) translationToView(t *translation.Translation) (query.TranslationView, error) {
 	translationData := t.ToMap()
-	tagViews, err := r.tagRepo.GetViews(translationData["tagIDs"].([]string), translationData["authorID"].(string))
-	if err != nil {
-		return query.TranslationView{}, err
+	senses := translationData["senses"].([]map[string]interface{})
+	senseViews := make([]query.SenseView, 0, len(senses))
+	for _, sense := range senses {
+		tagViews, err := r.tagRepo.GetViews(sense["tagIDs"].([]string), translationData["authorID"].(string))
+		if err != nil {
+			return query.TranslationView{}, err
+		}
+
+		senseViews = append(senseViews, query.SenseView{
+			Target:  sense["target"].(string),
+			Example: sense["example"].(string),
+			Tags:    tagViews,
+		})
 	}
 
 	langView, err := r.langRepo.GetView(translationData["langID"].(string), translationData["authorID"].(string))
@@ -360,14 +723,32 @@ func (r *TranslationRepo) translationToView(t *translation.Translation) (query.T
 		return query.TranslationView{}, err
 	}
 
+	reviewData := translationData["review"].(map[string]interface{})
+
 	return query.TranslationView{
 		ID:            t.ID(),
 		CreatedAd:     translationData["createdAt"].(time.Time),
 		Transcription: translationData["transcription"].(string),
-		Target:        translationData["target"].(string),
 		Source:        translationData["source"].(string),
-		Example:       translationData["example"].(string),
-		Tags:          tagViews,
+		Senses:        senseViews,
 		Lang:          langView,
+		Fields:        translationData["fields"].(map[string]string),
+		Review: query.ReviewView{
+			Ease:        reviewData["ease"].(float64),
+			Interval:    reviewData["interval"].(int),
+			Repetitions: reviewData["repetitions"].(int),
+			Lapses:      reviewData["lapses"].(int),
+			DueAt:       reviewData["dueAt"].(time.Time),
+			ReviewedAt:  reviewData["reviewedAt"].(time.Time),
+		},
 	}, nil
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
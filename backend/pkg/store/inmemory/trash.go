@@ -0,0 +1,113 @@
+package inmemory
+
+import (
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"sort"
+	"time"
+)
+
+type TrashRepo struct {
+	storage map[string]*trash.Item
+}
+
+func NewTrashRepository() *TrashRepo {
+	return &TrashRepo{
+		storage: map[string]*trash.Item{},
+	}
+}
+
+func (r *TrashRepo) Create(item *trash.Item) error {
+	r.storage[item.ID()] = item
+	return nil
+}
+
+func (r *TrashRepo) Get(id, authorID string) (*trash.Item, error) {
+	item, ok := r.storage[id]
+
+	if ok && item.AuthorID() == authorID {
+		return item, nil
+	}
+
+	return nil, trash.ErrNotFound
+}
+
+func (r *TrashRepo) GetAll(authorID string) ([]*trash.Item, error) {
+	items := make([]*trash.Item, 0)
+	for _, item := range r.storage {
+		if item.AuthorID() == authorID {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+func (r *TrashRepo) GetDeletedBefore(deletedAt time.Time) ([]*trash.Item, error) {
+	items := make([]*trash.Item, 0)
+	for _, item := range r.storage {
+		if item.DeletedAt().Before(deletedAt) {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+func (r *TrashRepo) Delete(id, authorID string) error {
+	item, ok := r.storage[id]
+
+	if ok && item.AuthorID() == authorID {
+		delete(r.storage, id)
+		return nil
+	}
+
+	return fmt.Errorf("not found")
+}
+
+func (r *TrashRepo) DeleteByAuthorID(authorID string) (int, error) {
+	counter := 0
+	for key, item := range r.storage {
+		if item.AuthorID() == authorID {
+			delete(r.storage, key)
+			counter++
+		}
+	}
+
+	return counter, nil
+}
+
+func (r *TrashRepo) GetAllViews(authorID string) ([]query.TrashItemView, error) {
+	views := make([]query.TrashItemView, 0)
+
+	for _, item := range r.storage {
+		if item.AuthorID() != authorID {
+			continue
+		}
+
+		view := query.TrashItemView{
+			ID:        item.ID(),
+			Kind:      string(item.Kind()),
+			EntityID:  item.EntityID(),
+			DeletedAt: item.DeletedAt(),
+		}
+
+		switch item.Kind() {
+		case trash.TranslationKind:
+			view.Title = item.Translation().ToMap()["source"].(string)
+		case trash.TagKind:
+			view.Title = item.Tag().ToMap()["name"].(string)
+		case trash.LangKind:
+			view.Title = item.Lang().Name()
+		}
+
+		views = append(views, view)
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].DeletedAt.After(views[j].DeletedAt)
+	})
+
+	return views, nil
+}
@@ -4,16 +4,26 @@ import (
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/user"
 	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"sync"
+	"time"
 )
 
 type UserRepo struct {
 	storage       map[string]*user.User
+	attempts      map[string]*user.SignInAttempts
+	attemptsMu    sync.Mutex          // attemptsMu sign-in requests are handled in parallel, so failures have to be counted atomically
+	usedSteps     map[string]int64    // usedSteps last used TOTP steps by user IDs, users are shared with callers, so they can not keep it
+	usedCodes     map[string]struct{} // usedCodes used recovery code hashes
+	twoFactorMu   sync.Mutex
 	roleConverter *query.RoleConverter
 }
 
 func NewUserRepository(roleMapper *query.RoleConverter) *UserRepo {
 	return &UserRepo{
 		storage:       map[string]*user.User{},
+		attempts:      map[string]*user.SignInAttempts{},
+		usedSteps:     map[string]int64{},
+		usedCodes:     map[string]struct{}{},
 		roleConverter: roleMapper,
 	}
 }
@@ -48,6 +58,25 @@ func (u *UserRepo) Update(usr *user.User) error {
 	return nil
 }
 
+func (u *UserRepo) UseTwoFactorCode(userID string, use user.TwoFactorUse) error {
+	u.twoFactorMu.Lock()
+	defer u.twoFactorMu.Unlock()
+
+	if use.RecoveryCodeHash != "" {
+		if _, ok := u.usedCodes[use.RecoveryCodeHash]; ok {
+			return user.ErrTwoFactorCodeUsed
+		}
+		u.usedCodes[use.RecoveryCodeHash] = struct{}{}
+		return nil
+	}
+
+	if use.Step <= u.usedSteps[userID] {
+		return user.ErrTwoFactorCodeUsed
+	}
+	u.usedSteps[userID] = use.Step
+	return nil
+}
+
 func (u *UserRepo) Delete(id string) (int, error) {
 	_, ok := u.storage[id]
 
@@ -59,6 +88,46 @@ func (u *UserRepo) Delete(id string) (int, error) {
 	return 0, fmt.Errorf("not found")
 }
 
+func (u *UserRepo) GetAttempts(key string) (*user.SignInAttempts, error) {
+	u.attemptsMu.Lock()
+	defer u.attemptsMu.Unlock()
+
+	return copyAttempts(u.notExpiredAttempts(key)), nil
+}
+
+func (u *UserRepo) RegisterFailure(key string, policy user.LockoutPolicy) (*user.SignInAttempts, error) {
+	u.attemptsMu.Lock()
+	defer u.attemptsMu.Unlock()
+
+	attempts := copyAttempts(u.notExpiredAttempts(key))
+	attempts.RegisterFailure(policy)
+	u.attempts[key] = attempts
+	return copyAttempts(attempts), nil
+}
+
+func (u *UserRepo) notExpiredAttempts(key string) *user.SignInAttempts {
+	attempts, ok := u.attempts[key]
+
+	if ok && attempts.ExpiresAt().After(time.Now()) {
+		return attempts
+	}
+
+	return user.NewSignInAttempts(key)
+}
+
+// copyAttempts stored attempts are not shared with callers, so they are changed only under the lock
+func copyAttempts(a *user.SignInAttempts) *user.SignInAttempts {
+	return user.UnmarshalAttemptsFromDB(a.Key(), a.Failures(), a.LastFailedAt(), a.BlockedUntil(), a.ExpiresAt())
+}
+
+func (u *UserRepo) DeleteAttempts(key string) error {
+	u.attemptsMu.Lock()
+	defer u.attemptsMu.Unlock()
+
+	delete(u.attempts, key)
+	return nil
+}
+
 func (u *UserRepo) GetAllViews() ([]query.UserView, error) {
 	results := make([]query.UserView, 0, len(u.storage))
 
@@ -93,11 +162,12 @@ func (u *UserRepo) GetView(id string) (query.UserView, error) {
 		listOptions := userData["listOptions"].(map[string]interface{})
 
 		return query.UserView{
-			ID:          userData["id"].(string),
-			Name:        userData["name"].(string),
-			Email:       userData["email"].(string),
-			Role:        role,
-			ListOptions: query.UserListOptionsView{HideTranscription: listOptions["hideTranscription"].(bool)},
+			ID:               userData["id"].(string),
+			Name:             userData["name"].(string),
+			Email:            userData["email"].(string),
+			Role:             role,
+			ListOptions:      query.UserListOptionsView{HideTranscription: listOptions["hideTranscription"].(bool)},
+			TwoFactorEnabled: t.TwoFactorEnabled(),
 		}, nil
 	}
 
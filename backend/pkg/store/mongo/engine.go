@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -19,6 +20,14 @@ type Opts struct {
 
 const queryDefaultTimeoutInSec = 3
 
+// exportTimeoutInSec limits time of reading all author documents for export
+const exportTimeoutInSec = 60
+
+const (
+	namespaceNotFoundErrorCode = 26
+	indexNotFoundErrorCode     = 27
+)
+
 func InitDatabase(ctx context.Context, opts Opts) (*mongo.Database, error) {
 	clientOpts := options.Client()
 
@@ -48,3 +57,12 @@ func replaceOnDuplicateKeyError(original, replace error) error {
 
 	return original
 }
+
+// isIndexNotFoundError checks if index can not be dropped as it or the whole collection does not exist
+func isIndexNotFoundError(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == indexNotFoundErrorCode || cmdErr.Code == namespaceNotFoundErrorCode
+	}
+	return false
+}
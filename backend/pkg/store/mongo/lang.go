@@ -17,9 +17,20 @@ type LangRepo struct {
 }
 
 type LangModel struct {
-	ID       string `bson:"_id"`
-	Name     string `bson:"name"`
-	AuthorID string `bson:"author_id"`
+	ID                  string           `bson:"_id"`
+	Name                string           `bson:"name"`
+	AuthorID            string           `bson:"author_id"`
+	Code                string           `bson:"code"`
+	Direction           string           `bson:"direction"`
+	TranscriptionScheme string           `bson:"transcription_scheme"`
+	Fields              []LangFieldModel `bson:"fields"`
+}
+
+// LangFieldModel represents the nested custom field schema in the mongo lang document
+type LangFieldModel struct {
+	Name    string   `bson:"name"`
+	Kind    string   `bson:"kind"`
+	Options []string `bson:"options"`
 }
 
 func NewLangRepo(db *mongo.Database) (*LangRepo, error) {
@@ -116,11 +127,25 @@ func (r *LangRepo) Get(id, authorID string) (*lang.Lang, error) {
 		return nil, err
 	}
 
-	return lang.UnmarshalFromDB(
-		record.ID,
-		record.Name,
-		record.AuthorID,
-	), nil
+	return fromLangModelToDomain(record), nil
+}
+
+// GetByName searches for lang with name and authorId
+func (r *LangRepo) GetByName(name, authorID string) (*lang.Lang, error) {
+	var record LangModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if err := r.collection.FindOne(ctx, bson.D{{Key: "name", Value: name}, {Key: "author_id", Value: authorID}}).Decode(&record); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, lang.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return fromLangModelToDomain(record), nil
 }
 
 func (r *LangRepo) Delete(id, authorID string) error {
@@ -210,8 +235,42 @@ func (r *LangRepo) fromDomainToModel(l *lang.Lang) (LangModel, error) {
 }
 
 func (r *LangRepo) fromModelToView(model LangModel) query.LangView {
-	return query.LangView{
-		ID:   model.ID,
-		Name: model.Name,
+	metadata := fromLangModelToMetadata(model)
+	view := query.LangView{
+		ID:                  model.ID,
+		Name:                model.Name,
+		Code:                metadata.Code(),
+		Direction:           string(metadata.Direction()),
+		TranscriptionScheme: string(metadata.TranscriptionScheme()),
+		Fields:              make([]query.LangFieldView, 0, len(model.Fields)),
+	}
+
+	for _, field := range model.Fields {
+		view.Fields = append(view.Fields, query.LangFieldView{Name: field.Name, Kind: field.Kind, Options: field.Options})
+	}
+
+	return view
+}
+
+func fromLangModelToDomain(model LangModel) *lang.Lang {
+	return lang.UnmarshalFromDB(
+		model.ID,
+		model.Name,
+		model.AuthorID,
+		fromLangModelToMetadata(model),
+		fromLangFieldModelsToDomain(model.Fields),
+	)
+}
+
+func fromLangFieldModelsToDomain(models []LangFieldModel) []lang.Field {
+	fields := make([]lang.Field, 0, len(models))
+	for _, model := range models {
+		fields = append(fields, lang.NewField(model.Name, lang.FieldKind(model.Kind), model.Options))
 	}
+	return fields
+}
+
+// fromLangModelToMetadata detects direction of the langs stored without it
+func fromLangModelToMetadata(model LangModel) lang.Metadata {
+	return lang.NewMetadata(model.Code, lang.Direction(model.Direction), lang.TranscriptionScheme(model.TranscriptionScheme))
 }
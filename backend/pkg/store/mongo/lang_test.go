@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -11,18 +12,24 @@ func TestLangRepo_fromModelToView(t *testing.T) {
 		ID:       "id",
 		Name:     "en",
 		AuthorID: "author",
+		Code:     "he",
+		Fields:   []LangFieldModel{{Name: "binyan", Kind: "enum", Options: []string{"paal", "piel"}}},
 	}
 
 	repo := LangRepo{}
 	view := repo.fromModelToView(model)
 	assert.Equal(t, model.ID, view.ID)
 	assert.Equal(t, model.Name, view.Name)
+	assert.Equal(t, "he", view.Code)
+	assert.Equal(t, "rtl", view.Direction)
+	assert.Equal(t, []query.LangFieldView{{Name: "binyan", Kind: "enum", Options: []string{"paal", "piel"}}}, view.Fields)
 }
 
 func TestLangRepo_fromDomainToModel(t *testing.T) {
 	ln := "en"
-	entity, err := lang.NewLang(ln, "testAuthor")
+	entity, err := lang.NewLang(ln, "testAuthor", lang.NewMetadata("en", "", lang.IPA))
 	assert.Nil(t, err)
+	assert.Nil(t, entity.ApplyFields([]lang.Field{lang.NewField("plural", lang.TextField, nil)}))
 	repo := LangRepo{}
 
 	model, err := repo.fromDomainToModel(entity)
@@ -30,4 +37,8 @@ func TestLangRepo_fromDomainToModel(t *testing.T) {
 	assert.Equal(t, entity.ID(), model.ID)
 	assert.Equal(t, entity.AuthorID(), model.AuthorID)
 	assert.Equal(t, ln, model.Name)
+	assert.Equal(t, "en", model.Code)
+	assert.Equal(t, "ltr", model.Direction)
+	assert.Equal(t, "ipa", model.TranscriptionScheme)
+	assert.Equal(t, []LangFieldModel{{Name: "plural", Kind: "text", Options: []string{}}}, model.Fields)
 }
@@ -0,0 +1,196 @@
+package mongo
+
+import (
+	"context"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// RevisionRepo Mongo DB implementation for domain translation revision entity
+type RevisionRepo struct {
+	collection *mongo.Collection
+}
+
+// RevisionModel represents mongo translation revision document
+type RevisionModel struct {
+	ID            string             `bson:"_id"`
+	TranslationID string             `bson:"translation_id"`
+	AuthorID      string             `bson:"author_id"`
+	Source        string             `bson:"source"`
+	Transcription string             `bson:"transcription"`
+	Senses        []SenseModel       `bson:"senses"`
+	LangID        string             `bson:"lang_id"`
+	Fields        map[string]string  `bson:"fields"`
+	Changes       []FieldChangeModel `bson:"changes"`
+	CreatedAt     time.Time          `bson:"created_at"`
+}
+
+// FieldChangeModel represents the nested changed field in the mongo translation revision document
+type FieldChangeModel struct {
+	Field    string `bson:"field"`
+	OldValue string `bson:"old_value"`
+	NewValue string `bson:"new_value"`
+}
+
+// NewRevisionRepo creates RevisionRepo
+func NewRevisionRepo(db *mongo.Database) (*RevisionRepo, error) {
+	r := RevisionRepo{collection: db.Collection("translation_revisions")}
+
+	if err := r.initIndexes(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// initIndexes creates required for current queries indexes in translation revisions collection
+func (r *RevisionRepo) initIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "translation_id", Value: 1},
+				{Key: "author_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Create saves new translation revision to DB
+func (r *RevisionRepo) Create(revision *translation.Revision) error {
+	model, err := r.fromDomainToModel(revision)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err = r.collection.InsertOne(ctx, model)
+	return err
+}
+
+// Get searches for revision with id of the translation with translationID and authorID
+func (r *RevisionRepo) Get(id, translationID, authorID string) (*translation.Revision, error) {
+	var record RevisionModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "translation_id", Value: translationID}, {Key: "author_id", Value: authorID}}
+	if err := r.collection.FindOne(ctx, filter).Decode(&record); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, translation.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return r.fromModelToDomain(record), nil
+}
+
+// DeleteByTranslationID removes all revisions of the translation
+func (r *RevisionRepo) DeleteByTranslationID(translationID, authorID string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.D{{Key: "translation_id", Value: translationID}, {Key: "author_id", Value: authorID}})
+	return err
+}
+
+func (r *RevisionRepo) DeleteByAuthorID(authorID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+	result, err := r.collection.DeleteMany(ctx, bson.D{{Key: "author_id", Value: authorID}})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
+
+// GetViews returns revisions of the translation, the latest go first
+func (r *RevisionRepo) GetViews(translationID, authorID string) ([]query.RevisionView, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	filter := bson.D{{Key: "translation_id", Value: translationID}, {Key: "author_id", Value: authorID}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var models []RevisionModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	views := make([]query.RevisionView, 0, len(models))
+	for i := range models {
+		views = append(views, r.fromModelToView(models[i]))
+	}
+
+	return views, nil
+}
+
+// fromDomainToModel converts domain translation revision to mongo model
+func (r *RevisionRepo) fromDomainToModel(revision *translation.Revision) (RevisionModel, error) {
+	model := RevisionModel{}
+	err := mapstructure.Decode(revision.ToMap(), &model)
+	return model, err
+}
+
+// fromModelToDomain converts mongo model to domain translation revision
+func (r *RevisionRepo) fromModelToDomain(model RevisionModel) *translation.Revision {
+	changes := make([]translation.FieldChange, 0, len(model.Changes))
+	for _, change := range model.Changes {
+		changes = append(changes, translation.NewFieldChange(change.Field, change.OldValue, change.NewValue))
+	}
+
+	return translation.UnmarshalRevisionFromDB(
+		model.ID,
+		model.TranslationID,
+		model.AuthorID,
+		model.Source,
+		model.Transcription,
+		fromSenseModelsToDomain(model.Senses),
+		model.LangID,
+		model.Fields,
+		changes,
+		model.CreatedAt,
+	)
+}
+
+// fromModelToView converts mongo model to translation revision View
+func (r *RevisionRepo) fromModelToView(model RevisionModel) query.RevisionView {
+	changes := make([]query.FieldChangeView, 0, len(model.Changes))
+	for _, change := range model.Changes {
+		changes = append(changes, query.FieldChangeView{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return query.RevisionView{
+		ID:        model.ID,
+		CreatedAt: model.CreatedAt,
+		Changes:   changes,
+	}
+}
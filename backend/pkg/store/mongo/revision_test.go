@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRevisionRepo_fromDomainToModel(t *testing.T) {
+	tr, err := translation.NewTranslation("source", "", []translation.Sense{translation.NewSense("target", "example", []string{"tag1"})}, "testAuthor", "EN")
+	assert.Nil(t, err)
+
+	assert.Nil(t, tr.ApplyFields(map[string]string{"gender": "m"}))
+
+	before := *tr
+	assert.Nil(t, tr.ApplyChanges("source", "", []translation.Sense{translation.NewSense("changed", "example", []string{"tag1"})}, "EN"))
+
+	revision, changed := translation.NewRevision(&before, tr)
+	assert.True(t, changed)
+
+	repo := RevisionRepo{}
+	model, err := repo.fromDomainToModel(revision)
+
+	assert.Nil(t, err)
+	assert.Equal(t, revision.ID(), model.ID)
+	assert.Equal(t, tr.ID(), model.TranslationID)
+	assert.Equal(t, "testAuthor", model.AuthorID)
+	assert.Equal(t, "source", model.Source)
+	assert.Equal(t, "EN", model.LangID)
+	assert.Equal(t, []SenseModel{{Target: "target", Example: "example", TagIDs: []string{"tag1"}}}, model.Senses)
+	assert.Equal(t, map[string]string{"gender": "m"}, model.Fields)
+	assert.Equal(t, []FieldChangeModel{{Field: "senses[0].target", OldValue: "target", NewValue: "changed"}}, model.Changes)
+	assert.Equal(t, revision.ToMap()["createdAt"], model.CreatedAt)
+}
+
+func TestRevisionRepo_fromModelToDomain(t *testing.T) {
+	model := RevisionModel{
+		ID:            "revID",
+		TranslationID: "trID",
+		AuthorID:      "testAuthor",
+		Source:        "source",
+		Senses:        []SenseModel{{Target: "target", TagIDs: []string{"tag1"}}},
+		LangID:        "EN",
+		Fields:        map[string]string{"gender": "m"},
+		Changes:       []FieldChangeModel{{Field: "source", OldValue: "source", NewValue: "changed"}},
+		CreatedAt:     time.Now(),
+	}
+
+	repo := RevisionRepo{}
+	revision := repo.fromModelToDomain(model)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":            "revID",
+		"translationID": "trID",
+		"authorID":      "testAuthor",
+		"source":        "source",
+		"transcription": "",
+		"senses":        []map[string]interface{}{{"target": "target", "example": "", "tagIDs": []string{"tag1"}}},
+		"langID":        "EN",
+		"fields":        map[string]string{"gender": "m"},
+		"changes":       []map[string]interface{}{{"field": "source", "oldValue": "source", "newValue": "changed"}},
+		"createdAt":     model.CreatedAt,
+	}, revision.ToMap())
+}
+
+func TestRevisionRepo_fromModelToView(t *testing.T) {
+	model := RevisionModel{
+		ID:        "revID",
+		Changes:   []FieldChangeModel{{Field: "source", OldValue: "source", NewValue: "changed"}},
+		CreatedAt: time.Now(),
+	}
+
+	repo := RevisionRepo{}
+	assert.Equal(t, query.RevisionView{
+		ID:        "revID",
+		CreatedAt: model.CreatedAt,
+		Changes:   []query.FieldChangeView{{Field: "source", OldValue: "source", NewValue: "changed"}},
+	}, repo.fromModelToView(model))
+}
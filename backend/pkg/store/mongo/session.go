@@ -0,0 +1,204 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// SessionRepo Mongo DB implementation for domain session entity
+type SessionRepo struct {
+	collection *mongo.Collection
+}
+
+// SessionModel represents mongo session document
+type SessionModel struct {
+	ID          string    `bson:"_id"`
+	UserID      string    `bson:"user_id"`
+	TokenID     string    `bson:"token_id"`
+	UserAgent   string    `bson:"user_agent"`
+	IP          string    `bson:"ip"`
+	CreatedAt   time.Time `bson:"created_at"`
+	RefreshedAt time.Time `bson:"refreshed_at"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+}
+
+// NewSessionRepo creates SessionRepo
+func NewSessionRepo(db *mongo.Database) (*SessionRepo, error) {
+	r := SessionRepo{collection: db.Collection("sessions")}
+
+	if err := r.initIndexes(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// initIndexes creates required for current queries indexes in session collection, expired sessions are removed by mongo TTL monitor
+func (r *SessionRepo) initIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "refreshed_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "expires_at", Value: 1},
+			},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Create saves new session to DB
+func (r *SessionRepo) Create(s *session.Session) error {
+	model, err := r.fromDomainToModel(s)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err = r.collection.InsertOne(ctx, model)
+	return err
+}
+
+// Get searches for not expired session with id, TTL monitor runs periodically, so expired sessions can still be kept in DB
+func (r *SessionRepo) Get(id string) (*session.Session, error) {
+	var model SessionModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "expires_at", Value: bson.D{{Key: "$gt", Value: time.Now()}}}}
+	if err := r.collection.FindOne(ctx, filter).Decode(&model); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, session.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.fromModelToDomain(model), nil
+}
+
+// Update saves rotated session to DB when the stored one still keeps presentedTokenID, the check and the update are atomic
+func (r *SessionRepo) Update(s *session.Session, presentedTokenID string) error {
+	model, err := r.fromDomainToModel(s)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	filter := bson.D{{Key: "_id", Value: model.ID}, {Key: "token_id", Value: presentedTokenID}}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": model})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount != 1 {
+		return session.ErrTokenReused
+	}
+
+	return nil
+}
+
+// Delete removes session with id and userID
+func (r *SessionRepo) Delete(id, userID string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}, {Key: "user_id", Value: userID}})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount != 1 {
+		return fmt.Errorf("1 record was supposed to be deleted, %d removed", result.DeletedCount)
+	}
+
+	return nil
+}
+
+// DeleteByUserID removes all user sessions except the one with exceptID
+func (r *SessionRepo) DeleteByUserID(userID, exceptID string) (int, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}}
+	if exceptID != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$ne", Value: exceptID}}})
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
+
+// GetAllViews returns not expired user sessions, the latest refreshed go first
+func (r *SessionRepo) GetAllViews(userID string) ([]query.SessionView, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "expires_at", Value: bson.D{{Key: "$gt", Value: time.Now()}}}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "refreshed_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var models []SessionModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	views := make([]query.SessionView, 0, len(models))
+	for i := range models {
+		views = append(views, r.fromModelToView(models[i]))
+	}
+
+	return views, nil
+}
+
+// fromDomainToModel converts domain session to mongo model
+func (r *SessionRepo) fromDomainToModel(s *session.Session) (SessionModel, error) {
+	model := SessionModel{}
+	err := mapstructure.Decode(s.ToMap(), &model)
+	return model, err
+}
+
+// fromModelToDomain converts mongo model to domain session
+func (r *SessionRepo) fromModelToDomain(model SessionModel) *session.Session {
+	return session.UnmarshalFromDB(model.ID, model.UserID, model.TokenID, model.UserAgent, model.IP, model.CreatedAt, model.RefreshedAt, model.ExpiresAt)
+}
+
+// fromModelToView converts mongo model to session View
+func (r *SessionRepo) fromModelToView(model SessionModel) query.SessionView {
+	return query.SessionView{
+		ID:          model.ID,
+		UserAgent:   model.UserAgent,
+		IP:          model.IP,
+		CreatedAt:   model.CreatedAt,
+		RefreshedAt: model.RefreshedAt,
+		ExpiresAt:   model.ExpiresAt,
+	}
+}
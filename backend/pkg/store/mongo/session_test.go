@@ -0,0 +1,38 @@
+package mongo
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/session"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSessionRepo_fromDomainToModel(t *testing.T) {
+	s, err := session.NewSession("testUser", "Mozilla/5.0", "127.0.0.1", time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+
+	repo := SessionRepo{}
+	model, err := repo.fromDomainToModel(s)
+
+	assert.Nil(t, err)
+	assert.Equal(t, SessionModel{
+		ID:          s.ID(),
+		UserID:      "testUser",
+		TokenID:     s.TokenID(),
+		UserAgent:   "Mozilla/5.0",
+		IP:          "127.0.0.1",
+		CreatedAt:   s.CreatedAt(),
+		RefreshedAt: s.RefreshedAt(),
+		ExpiresAt:   s.ExpiresAt(),
+	}, model)
+}
+
+func TestSessionRepo_fromModelToDomain(t *testing.T) {
+	now := time.Now()
+	model := SessionModel{ID: "sessionID", UserID: "testUser", TokenID: "tokenID", UserAgent: "Mozilla/5.0", IP: "127.0.0.1", CreatedAt: now, RefreshedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	repo := SessionRepo{}
+	assert.Equal(t, session.UnmarshalFromDB("sessionID", "testUser", "tokenID", "Mozilla/5.0", "127.0.0.1", now, now, now.Add(time.Hour)), repo.fromModelToDomain(model))
+	assert.Equal(t, query.SessionView{ID: "sessionID", UserAgent: "Mozilla/5.0", IP: "127.0.0.1", CreatedAt: now, RefreshedAt: now, ExpiresAt: now.Add(time.Hour)}, repo.fromModelToView(model))
+}
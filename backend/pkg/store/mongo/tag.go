@@ -22,6 +22,7 @@ type TagModel struct {
 	ID       string `bson:"_id"`
 	Name     string `bson:"name"`
 	AuthorID string `bson:"author_id"`
+	ParentID string `bson:"parent_id"`
 }
 
 // NewTagRepo creates TagRepo
@@ -47,6 +48,9 @@ func (r *TagRepo) initIndexes() error {
 				{Key: "created_at", Value: -1},
 			},
 		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "parent_id", Value: 1}},
+		},
 	}
 
 	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
@@ -119,6 +123,30 @@ func (r *TagRepo) Get(id, authorID string) (*tag.Tag, error) {
 		record.ID,
 		record.Name,
 		record.AuthorID,
+		record.ParentID,
+	), nil
+}
+
+// GetByName searches for tag with name and authorId
+func (r *TagRepo) GetByName(name, authorID string) (*tag.Tag, error) {
+	var record TagModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if err := r.collection.FindOne(ctx, bson.D{{Key: "name", Value: name}, {Key: "author_id", Value: authorID}}).Decode(&record); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, tag.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return tag.UnmarshalFromDB(
+		record.ID,
+		record.Name,
+		record.AuthorID,
+		record.ParentID,
 	), nil
 }
 
@@ -192,6 +220,40 @@ func (r *TagRepo) GetAllViews(authorID string) ([]query.TagView, error) {
 	return views, nil
 }
 
+// GetHierarchy returns all author tags with their nesting
+func (r *TagRepo) GetHierarchy(authorID string) (query.TagHierarchy, error) {
+	views, err := r.GetAllViews(authorID)
+	if err != nil {
+		return query.TagHierarchy{}, err
+	}
+
+	return query.NewTagHierarchy(views), nil
+}
+
+// ExistByParent checks if any author tag is nested into the parent
+func (r *TagRepo) ExistByParent(parentID, authorID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "author_id", Value: authorID}, {Key: "parent_id", Value: parentID}})
+
+	return count > 0, err
+}
+
+// MoveChildren nests all tags nested into the parent into the new parent by single updateMany request
+func (r *TagRepo) MoveChildren(parentID, newParentID, authorID string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.D{{Key: "author_id", Value: authorID}, {Key: "parent_id", Value: parentID}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "parent_id", Value: newParentID}}}},
+	)
+
+	return err
+}
+
 // GetView searches for tag with id and authorId
 func (r *TagRepo) GetView(id, authorID string) (query.TagView, error) {
 	var record TagModel
@@ -246,7 +308,8 @@ func (r *TagRepo) fromDomainToModel(t *tag.Tag) (TagModel, error) {
 // fromModelToView converts mongo model to tag View
 func (r *TagRepo) fromModelToView(model TagModel) query.TagView {
 	return query.TagView{
-		ID:   model.ID,
-		Name: model.Name,
+		ID:       model.ID,
+		Name:     model.Name,
+		ParentID: model.ParentID,
 	}
 }
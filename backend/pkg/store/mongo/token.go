@@ -0,0 +1,202 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// TokenRepo Mongo DB implementation for domain personal access token entity
+type TokenRepo struct {
+	collection *mongo.Collection
+}
+
+// TokenModel represents mongo personal access token document
+type TokenModel struct {
+	ID         string    `bson:"_id"`
+	UserID     string    `bson:"user_id"`
+	Name       string    `bson:"name"`
+	Hash       string    `bson:"hash"`
+	Scopes     []string  `bson:"scopes"`
+	CreatedAt  time.Time `bson:"created_at"`
+	ExpiresAt  time.Time `bson:"expires_at"`
+	LastUsedAt time.Time `bson:"last_used_at"`
+}
+
+// NewTokenRepo creates TokenRepo
+func NewTokenRepo(db *mongo.Database) (*TokenRepo, error) {
+	r := TokenRepo{collection: db.Collection("tokens")}
+
+	if err := r.initIndexes(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// initIndexes creates required for current queries indexes in token collection,
+// expired tokens are kept to be shown in the token list until the user revokes them
+func (r *TokenRepo) initIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "hash", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Create saves new token to DB
+func (r *TokenRepo) Create(t *token.Token) error {
+	model, err := r.fromDomainToModel(t)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err = r.collection.InsertOne(ctx, model)
+	return err
+}
+
+// GetByHash searches for not expired token with the secret hash
+func (r *TokenRepo) GetByHash(hash string) (*token.Token, error) {
+	var model TokenModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if err := r.collection.FindOne(ctx, bson.D{{Key: "hash", Value: hash}}).Decode(&model); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, token.ErrNotFound
+		}
+		return nil, err
+	}
+
+	t := r.fromModelToDomain(model)
+	if t.Expired() {
+		return nil, token.ErrNotFound
+	}
+
+	return t, nil
+}
+
+// Update saves token usage to DB
+func (r *TokenRepo) Update(t *token.Token) error {
+	model, err := r.fromDomainToModel(t)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: model.ID}}, bson.M{"$set": model})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount != 1 {
+		return token.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes token with id and userID
+func (r *TokenRepo) Delete(id, userID string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}, {Key: "user_id", Value: userID}})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount != 1 {
+		return fmt.Errorf("1 record was supposed to be deleted, %d removed", result.DeletedCount)
+	}
+
+	return nil
+}
+
+// DeleteByUserID removes all user tokens
+func (r *TokenRepo) DeleteByUserID(userID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.D{{Key: "user_id", Value: userID}})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
+
+// GetAllViews returns all user tokens including expired ones, the latest created go first
+func (r *TokenRepo) GetAllViews(userID string) ([]query.TokenView, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: "user_id", Value: userID}}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var models []TokenModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	views := make([]query.TokenView, 0, len(models))
+	for i := range models {
+		views = append(views, r.fromModelToView(models[i]))
+	}
+
+	return views, nil
+}
+
+// fromDomainToModel converts domain token to mongo model
+func (r *TokenRepo) fromDomainToModel(t *token.Token) (TokenModel, error) {
+	model := TokenModel{}
+	err := mapstructure.Decode(t.ToMap(), &model)
+	return model, err
+}
+
+// fromModelToDomain converts mongo model to domain token
+func (r *TokenRepo) fromModelToDomain(model TokenModel) *token.Token {
+	return token.UnmarshalFromDB(model.ID, model.UserID, model.Name, model.Hash, model.Scopes, model.CreatedAt, model.ExpiresAt, model.LastUsedAt)
+}
+
+// fromModelToView converts mongo model to token View
+func (r *TokenRepo) fromModelToView(model TokenModel) query.TokenView {
+	return query.TokenView{
+		ID:         model.ID,
+		Name:       model.Name,
+		Scopes:     model.Scopes,
+		CreatedAt:  model.CreatedAt,
+		ExpiresAt:  model.ExpiresAt,
+		LastUsedAt: model.LastUsedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/token"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTokenRepo_fromDomainToModel(t *testing.T) {
+	tok, _, err := token.NewToken("testUser", "cron", []token.Scope{"translations:read", "tags:write"}, time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+
+	repo := TokenRepo{}
+	model, err := repo.fromDomainToModel(tok)
+
+	assert.Nil(t, err)
+	assert.Equal(t, TokenModel{
+		ID:        tok.ID(),
+		UserID:    "testUser",
+		Name:      "cron",
+		Hash:      tok.Hash(),
+		Scopes:    []string{"translations:read", "tags:write"},
+		CreatedAt: tok.CreatedAt(),
+		ExpiresAt: tok.ExpiresAt(),
+	}, model)
+}
+
+func TestTokenRepo_fromModelToDomain(t *testing.T) {
+	now := time.Now()
+	model := TokenModel{ID: "tokenID", UserID: "testUser", Name: "cron", Hash: "hash", Scopes: []string{"read"}, CreatedAt: now, LastUsedAt: now}
+
+	repo := TokenRepo{}
+	assert.Equal(t, token.UnmarshalFromDB("tokenID", "testUser", "cron", "hash", []string{"read"}, now, time.Time{}, now), repo.fromModelToDomain(model))
+	assert.Equal(t, query.TokenView{ID: "tokenID", Name: "cron", Scopes: []string{"read"}, CreatedAt: now, LastUsedAt: now}, repo.fromModelToView(model))
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
 	"github.com/macyan13/webdict/backend/pkg/app/query"
@@ -11,9 +12,14 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"regexp"
+	"sort"
 	"time"
+	"unicode/utf8"
 )
 
+// fuzzyCandidatesLimit caps amount of fuzzy search candidates loaded to memory for edit distance calculation, the newest go first
+const fuzzyCandidatesLimit = 1000
+
 // TranslationRepo Mongo DB implementation for domain translation entity
 type TranslationRepo struct {
 	collection *mongo.Collection
@@ -23,28 +29,142 @@ type TranslationRepo struct {
 
 // TranslationModel represents mongo translation document
 type TranslationModel struct {
-	ID            string    `bson:"_id"`
-	AuthorID      string    `bson:"author_id"`
-	CreatedAt     time.Time `bson:"created_at"`
-	UpdatedAt     time.Time `bson:"updatedAt"`
-	Transcription string    `bson:"transcription"`
-	Target        string    `bson:"target"`
-	Source        string    `bson:"source"`
-	Example       string    `bson:"example"`
-	TagIDs        []string  `bson:"tag_ids"`
-	LangID        string    `bson:"lang_id"`
+	ID                string            `bson:"_id"`
+	AuthorID          string            `bson:"author_id"`
+	CreatedAt         time.Time         `bson:"created_at"`
+	UpdatedAt         time.Time         `bson:"updatedAt"`
+	Transcription     string            `bson:"transcription"`
+	TranscriptionNorm string            `bson:"transcription_norm"` // TranscriptionNorm is stored for empty transcription too, not migrated documents are found by its absence
+	Source            string            `bson:"source"`
+	SourceNorm        string            `bson:"source_norm,omitempty"`
+	Trigrams          []string          `bson:"trigrams,omitempty"`
+	SortTarget        string            `bson:"sort_target"`
+	Senses            []SenseModel      `bson:"senses"`
+	LangID            string            `bson:"lang_id"`
+	Review            ReviewModel       `bson:"review"`
+	Fields            map[string]string `bson:"fields"`
+}
+
+// SenseModel represents the nested translation sense in the mongo translation document
+type SenseModel struct {
+	Target      string   `bson:"target"`
+	TargetNorm  string   `bson:"target_norm,omitempty"`
+	Example     string   `bson:"example"`
+	ExampleNorm string   `bson:"example_norm,omitempty"`
+	TagIDs      []string `bson:"tag_ids"`
+}
+
+// ReviewModel represents the nested spaced repetition state in the mongo translation document
+type ReviewModel struct {
+	Ease        float64   `bson:"ease"`
+	Interval    int       `bson:"interval"`
+	Repetitions int       `bson:"repetitions"`
+	Lapses      int       `bson:"lapses"`
+	DueAt       time.Time `bson:"due_at"`
+	ReviewedAt  time.Time `bson:"reviewed_at"`
 }
 
 // NewTranslationRepo creates new TranslationRepo
 func NewTranslationRepo(db *mongo.Database, tagRepo query.TagViewRepository, langRepo query.LangViewRepository) (*TranslationRepo, error) {
 	t := TranslationRepo{collection: db.Collection("translations"), tagRepo: tagRepo, langRepo: langRepo}
 
+	if err := t.migrateToSenses(); err != nil {
+		return nil, err
+	}
+
+	if err := t.migrateToNormalizedFields(); err != nil {
+		return nil, err
+	}
+
 	if err := t.initIndexes(); err != nil {
 		return nil, err
 	}
 	return &t, nil
 }
 
+// migrateToSenses moves target, example and tag_ids of documents created before senses support to the single sense
+// and drops indexes built on the removed fields
+func (r *TranslationRepo) migrateToSenses() error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "senses", Value: bson.A{bson.D{
+			{Key: "target", Value: "$target"},
+			{Key: "example", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$example", ""}}}},
+			{Key: "tag_ids", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$tag_ids", bson.A{}}}}},
+		}}}}}},
+		{{Key: "$unset", Value: bson.A{"target", "example", "tag_ids"}}},
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, bson.D{{Key: "senses", Value: bson.D{{Key: "$exists", Value: false}}}}, pipeline); err != nil {
+		return err
+	}
+
+	obsoleteIndexes := []string{
+		"author_id_1_tag_ids_1",
+		"author_id_1_lang_id_1_tag_ids_1_created_at_-1",
+		"author_id_1_lang_id_1_target_1_created_at_-1",
+	}
+
+	for _, name := range obsoleteIndexes {
+		if _, err := r.collection.Indexes().DropOne(ctx, name); err != nil && !isIndexNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateToNormalizedFields sets normalized for search texts and trigrams to documents created before it was supported
+// and drops indexes replaced by the normalized fields ones
+func (r *TranslationRepo) migrateToNormalizedFields() error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "source_norm", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "trigrams", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "sort_target", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "transcription_norm", Value: bson.D{{Key: "$exists", Value: false}}}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	var models []TranslationModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return err
+	}
+
+	for i := range models {
+		r.normalize(&models[i])
+		update := bson.M{"$set": bson.M{
+			"source_norm":        models[i].SourceNorm,
+			"transcription_norm": models[i].TranscriptionNorm,
+			"senses":             models[i].Senses,
+			"trigrams":           models[i].Trigrams,
+			"sort_target":        models[i].SortTarget,
+		}}
+		if _, err = r.collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: models[i].ID}}, update); err != nil {
+			return err
+		}
+	}
+
+	obsoleteIndexes := []string{
+		"author_id_1_lang_id_1_source_1_created_at_-1",
+		"author_id_1_lang_id_1_senses.target_1_created_at_-1",
+	}
+
+	for _, name := range obsoleteIndexes {
+		if _, err = r.collection.Indexes().DropOne(ctx, name); err != nil && !isIndexNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // initIndexes creates required for current queries indexes in translation collection
 func (r *TranslationRepo) initIndexes() error {
 	indexes := []mongo.IndexModel{
@@ -60,7 +180,13 @@ func (r *TranslationRepo) initIndexes() error {
 		{
 			Keys: bson.D{
 				{Key: "author_id", Value: 1},
-				{Key: "tag_ids", Value: 1},
+				{Key: "senses.tag_ids", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "created_at", Value: -1},
 			},
 		},
 		{
@@ -73,7 +199,6 @@ func (r *TranslationRepo) initIndexes() error {
 			Keys: bson.D{
 				{Key: "author_id", Value: 1},
 				{Key: "lang_id", Value: 1},
-				{Key: "tag_ids", Value: 1},
 				{Key: "created_at", Value: -1},
 			},
 		},
@@ -81,7 +206,7 @@ func (r *TranslationRepo) initIndexes() error {
 			Keys: bson.D{
 				{Key: "author_id", Value: 1},
 				{Key: "lang_id", Value: 1},
-				{Key: "source", Value: 1},
+				{Key: "senses.tag_ids", Value: 1},
 				{Key: "created_at", Value: -1},
 			},
 		},
@@ -89,8 +214,42 @@ func (r *TranslationRepo) initIndexes() error {
 			Keys: bson.D{
 				{Key: "author_id", Value: 1},
 				{Key: "lang_id", Value: 1},
-				{Key: "target", Value: 1},
-				{Key: "created_at", Value: -1},
+				{Key: "source_norm", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "lang_id", Value: 1},
+				{Key: "senses.target_norm", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "lang_id", Value: 1},
+				{Key: "trigrams", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "lang_id", Value: 1},
+				{Key: "updatedAt", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "lang_id", Value: 1},
+				{Key: "sort_target", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "lang_id", Value: 1},
+				{Key: "review.due_at", Value: 1},
 			},
 		},
 	}
@@ -160,18 +319,30 @@ func (r *TranslationRepo) Get(id, authorID string) (*translation.Translation, er
 		return nil, err
 	}
 
-	return translation.UnmarshalFromDB(
-		record.ID,
-		record.Source,
-		record.Transcription,
-		record.Target,
-		record.AuthorID,
-		record.Example,
-		record.TagIDs,
-		record.CreatedAt,
-		record.UpdatedAt,
-		record.LangID,
-	), nil
+	return fromTranslationModelToDomain(record), nil
+}
+
+// GetByLang provides all author translations of the lang
+func (r *TranslationRepo) GetByLang(langID, authorID string) ([]*translation.Translation, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: "author_id", Value: authorID}, {Key: "lang_id", Value: langID}})
+	if err != nil {
+		return nil, err
+	}
+
+	var models []TranslationModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	records := make([]*translation.Translation, 0, len(models))
+	for i := range models {
+		records = append(records, fromTranslationModelToDomain(models[i]))
+	}
+
+	return records, nil
 }
 
 func (r *TranslationRepo) Delete(id, authorID string) error {
@@ -191,6 +362,88 @@ func (r *TranslationRepo) Delete(id, authorID string) error {
 	return nil
 }
 
+// UpdateMany saves updated translations by single unordered bulk write, so a failed translation does not stop saving of the others
+func (r *TranslationRepo) UpdateMany(translations []*translation.Translation) []error {
+	errs := make([]error, len(translations))
+	writes := make([]mongo.WriteModel, 0, len(translations))
+	positions := make([]int, 0, len(translations)) // positions of translations of the writes
+
+	for i, t := range translations {
+		model, err := r.fromDomainToModel(t)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: model.ID}, {Key: "author_id", Value: model.AuthorID}}).
+			SetUpdate(bson.M{"$set": model}))
+		positions = append(positions, i)
+	}
+
+	if len(writes) == 0 {
+		return errs
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err == nil {
+		return errs
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		for _, position := range positions {
+			errs[position] = err
+		}
+		return errs
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Index >= 0 && writeErr.Index < len(positions) {
+			errs[positions[writeErr.Index]] = replaceOnDuplicateKeyError(writeErr, translation.ErrSourceAlreadyExists)
+		}
+	}
+
+	return errs
+}
+
+// DeleteMany deletes all passed translations by single request
+func (r *TranslationRepo) DeleteMany(translations []*translation.Translation) error {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(translations))
+	authorIDs := make([]string, 0, 1)
+	for _, t := range translations {
+		ids = append(ids, t.ID())
+		if len(authorIDs) == 0 || authorIDs[len(authorIDs)-1] != t.AuthorID() {
+			authorIDs = append(authorIDs, t.AuthorID())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.D{
+		{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: "author_id", Value: bson.D{{Key: "$in", Value: authorIDs}}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount != int64(len(ids)) {
+		return fmt.Errorf("%d records were supposed to be deleted, %d removed", len(ids), result.DeletedCount)
+	}
+
+	return nil
+}
+
 func (r *TranslationRepo) ExistByLang(langID, authorID string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
 	defer cancel()
@@ -204,11 +457,87 @@ func (r *TranslationRepo) ExistByTag(tagID, authorID string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
 	defer cancel()
 
-	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "tag_ids", Value: tagID}, {Key: "author_id", Value: authorID}})
+	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "senses.tag_ids", Value: tagID}, {Key: "author_id", Value: authorID}})
 
 	return count > 0, err
 }
 
+// ReplaceTag retags senses tagged by tagID with newTagID by single updateMany request,
+// newTagID is appended to the sense tags once even if the sense is already tagged by it
+func (r *TranslationRepo) ReplaceTag(tagID, newTagID, authorID string) (int, error) {
+	return r.updateSensesTags(tagID, authorID, bson.D{{Key: "$cond", Value: bson.D{
+		{Key: "if", Value: bson.D{{Key: "$in", Value: bson.A{tagID, "$$sense.tag_ids"}}}},
+		{Key: "then", Value: bson.D{{Key: "$concatArrays", Value: bson.A{
+			r.withoutTags("$$sense.tag_ids", tagID, newTagID),
+			bson.A{newTagID},
+		}}}},
+		{Key: "else", Value: "$$sense.tag_ids"},
+	}}})
+}
+
+// RemoveTag removes the tag from all senses by single updateMany request
+func (r *TranslationRepo) RemoveTag(tagID, authorID string) (int, error) {
+	return r.updateSensesTags(tagID, authorID, r.withoutTags("$$sense.tag_ids", tagID))
+}
+
+// updateSensesTags sets tag_ids of every sense of author translations tagged by tagID to the result of the tags expression
+func (r *TranslationRepo) updateSensesTags(tagID, authorID string, tags bson.D) (int, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{{{Key: "$set", Value: bson.D{
+		{Key: "senses", Value: bson.D{{Key: "$map", Value: bson.D{
+			{Key: "input", Value: "$senses"},
+			{Key: "as", Value: "sense"},
+			{Key: "in", Value: bson.D{{Key: "$mergeObjects", Value: bson.A{"$$sense", bson.D{{Key: "tag_ids", Value: tags}}}}}},
+		}}}},
+		{Key: "updatedAt", Value: time.Now()},
+	}}}}
+
+	result, err := r.collection.UpdateMany(ctx, bson.D{{Key: "senses.tag_ids", Value: tagID}, {Key: "author_id", Value: authorID}}, pipeline)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
+
+// withoutTags builds expression filtering out the tags from the tags array
+func (r *TranslationRepo) withoutTags(input string, tagIDs ...string) bson.D {
+	return bson.D{{Key: "$filter", Value: bson.D{
+		{Key: "input", Value: input},
+		{Key: "as", Value: "tag"},
+		{Key: "cond", Value: bson.D{{Key: "$not", Value: bson.A{bson.D{{Key: "$in", Value: bson.A{"$$tag", tagIDs}}}}}}},
+	}}}
+}
+
+func (r *TranslationRepo) ExistBySource(source, langID, authorID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "source", Value: source}, {Key: "lang_id", Value: langID}, {Key: "author_id", Value: authorID}})
+
+	return count > 0, err
+}
+
+func (r *TranslationRepo) GetBySource(source, langID, authorID string) (*translation.Translation, error) {
+	var record TranslationModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	err := r.collection.FindOne(ctx, bson.D{{Key: "source", Value: source}, {Key: "lang_id", Value: langID}, {Key: "author_id", Value: authorID}}).Decode(&record)
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, translation.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return fromTranslationModelToDomain(record), nil
+}
+
 func (r *TranslationRepo) DeleteByAuthorID(authorID string) (int, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
 	defer cancel()
@@ -220,27 +549,188 @@ func (r *TranslationRepo) DeleteByAuthorID(authorID string) (int, error) {
 	return int(result.DeletedCount), nil
 }
 
-func (r *TranslationRepo) GetLastViewsByTags(authorID, langID string, pageSize, page int, tagIds []string) (query.LastTranslationViews, error) {
-	filter := bson.D{{Key: "author_id", Value: authorID}, {Key: "lang_id", Value: langID}}
-	if len(tagIds) != 0 {
-		filter = append(filter, bson.E{Key: "tag_ids", Value: bson.D{{Key: "$all", Value: tagIds}}})
+// GetLastViews returns page of translations matched all set filter conditions in the filter sort order,
+// by default exact source and target matches go first, then prefix and substring ones, the latest created go first within the same rank
+func (r *TranslationRepo) GetLastViews(filter query.TranslationFilter, pageSize, page int) (query.LastTranslationViews, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	match := r.lastViewsFilter(filter)
+	totalDocuments, err := r.collection.CountDocuments(ctx, match)
+	if err != nil {
+		return query.LastTranslationViews{}, err
+	}
+
+	skip := (page - 1) * pageSize
+	if int(totalDocuments) <= skip {
+		return query.LastTranslationViews{TotalRecords: int(totalDocuments)}, nil
+	}
+
+	models, err := r.getRankedModels(ctx, r.viewsPipeline(filter, match, nil, skip, pageSize))
+	if err != nil {
+		return query.LastTranslationViews{}, err
+	}
+
+	views, err := r.fromRankedModelsToViews(models)
+	if err != nil {
+		return query.LastTranslationViews{}, err
 	}
 
-	return r.getLastViewsByFilter(filter, pageSize, page)
+	return query.LastTranslationViews{
+		Views:        views,
+		TotalRecords: int(totalDocuments),
+	}, nil
 }
 
-func (r *TranslationRepo) GetLastViewsBySourcePart(authorID, langID, sourcePart string, pageSize, page int) (query.LastTranslationViews, error) {
-	patter := primitive.Regex{Pattern: fmt.Sprintf(".*%s.*", regexp.QuoteMeta(sourcePart))}
-	filter := bson.D{{Key: "author_id", Value: authorID}, {Key: "lang_id", Value: langID}, {Key: "source", Value: bson.M{"$regex": patter}}}
+// GetViewsAfter returns translations matched all set filter conditions following the cursor in the filter sort order,
+// one extra document is requested to find out if there is the next page
+func (r *TranslationRepo) GetViewsAfter(filter query.TranslationFilter, cursor *query.Cursor, limit int) (query.LastTranslationViews, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
 
-	return r.getLastViewsByFilter(filter, pageSize, page)
+	models, err := r.getRankedModels(ctx, r.viewsPipeline(filter, r.lastViewsFilter(filter), cursor, 0, limit+1))
+	if err != nil {
+		return query.LastTranslationViews{}, err
+	}
+
+	var next *query.Cursor
+	if len(models) > limit {
+		models = models[:limit]
+		c := r.modelToCursor(models[limit-1], filter.Sort)
+		next = &c
+	}
+
+	views, err := r.fromRankedModelsToViews(models)
+	if err != nil {
+		return query.LastTranslationViews{}, err
+	}
+
+	return query.LastTranslationViews{Views: views, Next: next}, nil
 }
 
-func (r *TranslationRepo) GetLastViewsByTargetPart(authorID, langID, targetPart string, pageSize, page int) (query.LastTranslationViews, error) {
-	patter := primitive.Regex{Pattern: fmt.Sprintf(".*%s.*", regexp.QuoteMeta(targetPart))}
-	filter := bson.D{{Key: "author_id", Value: authorID}, {Key: "lang_id", Value: langID}, {Key: "target", Value: bson.M{"$regex": patter}}}
+// lastViewsFilter builds mongo filter from all set conditions of translation filter
+func (r *TranslationRepo) lastViewsFilter(filter query.TranslationFilter) bson.D {
+	result := r.langsCondition(bson.D{{Key: "author_id", Value: filter.AuthorID}}, filter.LangIDs)
 
-	return r.getLastViewsByFilter(filter, pageSize, page)
+	if filter.SourcePart != "" {
+		result = append(result, bson.E{Key: "source_norm", Value: bson.M{"$regex": r.partRegex(query.NormalizeSearchText(filter.SourcePart))}})
+	}
+
+	if filter.TargetPart != "" {
+		result = append(result, bson.E{Key: "senses.target_norm", Value: bson.M{"$regex": r.partRegex(query.NormalizeSearchText(filter.TargetPart))}})
+	}
+
+	if filter.TextPart != "" {
+		textPart := r.partRegex(query.NormalizeSearchText(filter.TextPart))
+		result = append(result, bson.E{Key: "$or", Value: bson.A{
+			bson.M{"source_norm": bson.M{"$regex": textPart}},
+			bson.M{"senses.target_norm": bson.M{"$regex": textPart}},
+			bson.M{"transcription_norm": bson.M{"$regex": textPart}},
+			bson.M{"senses.example_norm": bson.M{"$regex": textPart}},
+		}})
+	}
+
+	result = r.tagsCondition(result, filter.Tags)
+
+	if !filter.Created.IsZero() {
+		result = append(result, bson.E{Key: "created_at", Value: r.dateRangeCondition(filter.Created)})
+	}
+
+	if !filter.Updated.IsZero() {
+		result = append(result, bson.E{Key: "updatedAt", Value: r.dateRangeCondition(filter.Updated)})
+	}
+
+	return result
+}
+
+// tagsCondition adds tags condition to the filter, translation should be tagged by any tag of every group,
+// single tag groups are matched together to keep the condition as simple as for flat tags
+func (r *TranslationRepo) tagsCondition(filter bson.D, tags query.TagGroups) bson.D {
+	var all []string
+	var groups bson.A
+
+	for _, group := range tags {
+		if len(group) == 1 {
+			all = append(all, group[0])
+			continue
+		}
+		groups = append(groups, bson.D{{Key: "senses.tag_ids", Value: bson.D{{Key: "$in", Value: group}}}})
+	}
+
+	if len(all) != 0 {
+		filter = append(filter, bson.E{Key: "senses.tag_ids", Value: bson.D{{Key: "$all", Value: all}}})
+	}
+
+	if len(groups) != 0 {
+		filter = append(filter, bson.E{Key: "$and", Value: groups})
+	}
+
+	return filter
+}
+
+// langsCondition adds lang condition to the filter, empty langs means any lang
+func (r *TranslationRepo) langsCondition(filter bson.D, langIDs []string) bson.D {
+	switch len(langIDs) {
+	case 0:
+		return filter
+	case 1:
+		return append(filter, bson.E{Key: "lang_id", Value: langIDs[0]})
+	default:
+		return append(filter, bson.E{Key: "lang_id", Value: bson.D{{Key: "$in", Value: langIDs}}})
+	}
+}
+
+// lastViewsRanks builds rank fields for set source and target parts, the fields order defines sorting priority
+func (r *TranslationRepo) lastViewsRanks(filter query.TranslationFilter) bson.D {
+	ranks := bson.D{}
+
+	if filter.SourcePart != "" {
+		ranks = append(ranks, bson.E{Key: "source_rank", Value: r.rankExpression("$source_norm", query.NormalizeSearchText(filter.SourcePart))})
+	}
+
+	if filter.TargetPart != "" {
+		ranks = append(ranks, bson.E{Key: "target_rank", Value: bson.D{{Key: "$min", Value: bson.D{{Key: "$map", Value: bson.D{
+			{Key: "input", Value: "$senses.target_norm"},
+			{Key: "as", Value: "target"},
+			{Key: "in", Value: r.rankExpression("$$target", query.NormalizeSearchText(filter.TargetPart))},
+		}}}}}})
+	}
+
+	return ranks
+}
+
+// rankExpression builds aggregation expression calculating query.MatchRank of the part in the field, the field must contain the part
+func (r *TranslationRepo) rankExpression(field, part string) bson.D {
+	return bson.D{{Key: "$switch", Value: bson.D{
+		{Key: "branches", Value: bson.A{
+			bson.D{
+				{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{field, part}}}},
+				{Key: "then", Value: int(query.ExactMatch)},
+			},
+			bson.D{
+				{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{bson.D{{Key: "$indexOfCP", Value: bson.A{field, part}}}, 0}}}},
+				{Key: "then", Value: int(query.PrefixMatch)},
+			},
+		}},
+		{Key: "default", Value: int(query.SubstringMatch)},
+	}}}
+}
+
+func (r *TranslationRepo) partRegex(part string) primitive.Regex {
+	return primitive.Regex{Pattern: fmt.Sprintf(".*%s.*", regexp.QuoteMeta(part))}
+}
+
+func (r *TranslationRepo) dateRangeCondition(dateRange query.DateRange) bson.D {
+	condition := bson.D{}
+	if !dateRange.From.IsZero() {
+		condition = append(condition, bson.E{Key: "$gte", Value: dateRange.From})
+	}
+
+	if !dateRange.To.IsZero() {
+		condition = append(condition, bson.E{Key: "$lte", Value: dateRange.To})
+	}
+
+	return condition
 }
 
 func (r *TranslationRepo) GetView(id, authorID string) (query.TranslationView, error) {
@@ -257,14 +747,13 @@ func (r *TranslationRepo) GetView(id, authorID string) (query.TranslationView, e
 	return r.fromModelToView(record)
 }
 
-func (r *TranslationRepo) GetRandomViews(authorID, langID string, tagIds []string, limit int) (query.RandomViews, error) {
+// GetRandomViews returns random translations of passed langs, empty langs means any lang
+func (r *TranslationRepo) GetRandomViews(authorID string, langIDs []string, tags query.TagGroups, limit int) (query.RandomViews, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
 	defer cancel()
 
-	filter := bson.D{{Key: "author_id", Value: authorID}, {Key: "lang_id", Value: langID}}
-	if len(tagIds) != 0 {
-		filter = append(filter, bson.E{Key: "tag_ids", Value: bson.D{{Key: "$all", Value: tagIds}}})
-	}
+	filter := r.langsCondition(bson.D{{Key: "author_id", Value: authorID}}, langIDs)
+	filter = r.tagsCondition(filter, tags)
 
 	pipeline := []bson.D{
 		{{Key: "$match", Value: filter}},
@@ -301,69 +790,583 @@ func (r *TranslationRepo) GetRandomViews(authorID, langID string, tagIds []strin
 	return query.RandomViews{Views: views}, nil
 }
 
-func (r *TranslationRepo) getLastViewsByFilter(filter bson.D, pageSize, page int) (query.LastTranslationViews, error) {
+// GetDueViews returns translations which review is scheduled before dueAt, translations without review state are treated as due
+func (r *TranslationRepo) GetDueViews(authorID, langID string, tags query.TagGroups, dueAt time.Time, limit int) (query.DueViews, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
 	defer cancel()
 
-	totalDocuments, err := r.collection.CountDocuments(ctx, filter)
+	filter := bson.D{
+		{Key: "author_id", Value: authorID},
+		{Key: "lang_id", Value: langID},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "review.due_at", Value: bson.D{{Key: "$lte", Value: dueAt}}}},
+			bson.D{{Key: "review.due_at", Value: bson.D{{Key: "$exists", Value: false}}}},
+		}},
+	}
+	filter = r.tagsCondition(filter, tags)
+
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{Key: "review.due_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return query.LastTranslationViews{}, err
+		return query.DueViews{}, err
 	}
 
-	if totalDocuments == 0 && page == 1 {
-		return query.LastTranslationViews{}, nil
+	var models []TranslationModel
+
+	if err = cursor.All(ctx, &models); err != nil {
+		return query.DueViews{}, err
 	}
 
-	totalPages := int(totalDocuments) / pageSize
-	if int(totalDocuments)%pageSize != 0 {
-		totalPages++
+	err = cursor.Close(ctx)
+	if err != nil {
+		return query.DueViews{}, err
 	}
 
-	if totalPages < page {
-		return query.LastTranslationViews{}, fmt.Errorf("can not get %d translations page from DB as max page is %d", page, totalPages)
+	views := make([]query.TranslationView, 0, len(models))
+
+	for i := range models {
+		view, err := r.fromModelToView(models[i])
+
+		if err != nil {
+			return query.DueViews{}, err
+		}
+
+		views = append(views, view)
 	}
 
-	skip := (page - 1) * pageSize
-	sort := bson.M{"created_at": -1}
-	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSkip(int64(skip)).SetLimit(int64(pageSize)).SetSort(sort))
+	return query.DueViews{Views: views}, nil
+}
+
+// GetFuzzyViews returns translations which source or any target is within max edit distance of the text, the closest go first.
+// Candidates are preselected by shared trigrams: a text within distance k shares at least trigrams count - 3k of them
+func (r *TranslationRepo) GetFuzzyViews(authorID, langID, text string, maxDistance, limit int) (query.FuzzyViews, error) {
+	text = query.NormalizeSearchText(text)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Aggregate(ctx, r.fuzzyCandidatesPipeline(authorID, langID, text, maxDistance))
 	if err != nil {
-		return query.LastTranslationViews{}, err
+		return query.FuzzyViews{}, err
 	}
 
 	var models []TranslationModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return query.FuzzyViews{}, err
+	}
+
+	type candidate struct {
+		model    TranslationModel
+		distance int
+	}
+
+	candidates := make([]candidate, 0, len(models))
+	for _, model := range models {
+		distance := query.EditDistance(model.SourceNorm, text)
+		for _, sense := range model.Senses {
+			if d := query.EditDistance(sense.TargetNorm, text); d < distance {
+				distance = d
+			}
+		}
+
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{model: model, distance: distance})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	views := make([]query.FuzzyView, 0, len(candidates))
+	for _, c := range candidates {
+		view, err := r.fromModelToView(c.model)
+		if err != nil {
+			return query.FuzzyViews{}, err
+		}
+
+		views = append(views, query.FuzzyView{Translation: view, Distance: c.distance})
+	}
+
+	return query.FuzzyViews{Views: views}, nil
+}
+
+// fuzzyCandidatesPipeline builds aggregation selecting documents sharing enough trigrams with the normalized text,
+// too short text can not be filtered by trigrams, so documents with source or any target length within max distance are selected
+func (r *TranslationRepo) fuzzyCandidatesPipeline(authorID, langID, text string, maxDistance int) mongo.Pipeline {
+	match := bson.D{{Key: "author_id", Value: authorID}, {Key: "lang_id", Value: langID}}
+	sortStage := bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}}}}
+	limitStage := bson.D{{Key: "$limit", Value: fuzzyCandidatesLimit}}
+
+	trigrams := r.trigrams(text)
+	minShared := len(trigrams) - 3*maxDistance
+	if minShared <= 0 {
+		return mongo.Pipeline{
+			{{Key: "$match", Value: match}},
+			{{Key: "$match", Value: bson.D{{Key: "$expr", Value: r.lengthWindowExpression(utf8.RuneCountInString(text), maxDistance)}}}},
+			sortStage,
+			limitStage,
+		}
+	}
+
+	match = append(match, bson.E{Key: "trigrams", Value: bson.D{{Key: "$in", Value: trigrams}}})
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$gte", Value: bson.A{
+			bson.D{{Key: "$size", Value: bson.D{{Key: "$setIntersection", Value: bson.A{"$trigrams", trigrams}}}}},
+			minShared,
+		}}}}}}},
+		sortStage,
+		limitStage,
+	}
+}
+
+// lengthWindowExpression checks that source or any target differs from the length by at most max distance,
+// edit distance of texts is never less than difference of their lengths
+func (r *TranslationRepo) lengthWindowExpression(length, maxDistance int) bson.D {
+	withinWindow := func(field string) bson.D {
+		return bson.D{{Key: "$lte", Value: bson.A{
+			bson.D{{Key: "$abs", Value: bson.D{{Key: "$subtract", Value: bson.A{
+				bson.D{{Key: "$strLenCP", Value: bson.D{{Key: "$ifNull", Value: bson.A{field, ""}}}}},
+				length,
+			}}}}},
+			maxDistance,
+		}}}
+	}
+
+	return bson.D{{Key: "$or", Value: bson.A{
+		withinWindow("$source_norm"),
+		bson.D{{Key: "$anyElementTrue", Value: bson.A{bson.D{{Key: "$map", Value: bson.D{
+			{Key: "input", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$senses", bson.A{}}}}},
+			{Key: "as", Value: "sense"},
+			{Key: "in", Value: withinWindow("$$sense.target_norm")},
+		}}}}}},
+	}}}
+}
+
+// ForEachTranslation passes all author translations to fn in creation order reading them from DB cursor one by one
+func (r *TranslationRepo) ForEachTranslation(authorID string, fn func(query.ExportTranslationView) error) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), exportTimeoutInSec*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: "author_id", Value: authorID}}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var model TranslationModel
+		if err = cursor.Decode(&model); err != nil {
+			return err
+		}
+
+		if err = fn(r.fromModelToExportView(model)); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
 
+func (r *TranslationRepo) fromModelToExportView(model TranslationModel) query.ExportTranslationView {
+	view := query.ExportTranslationView{
+		ID:            model.ID,
+		LangID:        model.LangID,
+		Source:        model.Source,
+		Transcription: model.Transcription,
+		Senses:        make([]query.ExportSenseView, 0, len(model.Senses)),
+		Fields:        model.Fields,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+		Review: query.ReviewView{
+			Ease:        model.Review.Ease,
+			Interval:    model.Review.Interval,
+			Repetitions: model.Review.Repetitions,
+			Lapses:      model.Review.Lapses,
+			DueAt:       model.Review.DueAt,
+			ReviewedAt:  model.Review.ReviewedAt,
+		},
+	}
+
+	for _, sense := range model.Senses {
+		view.Senses = append(view.Senses, query.ExportSenseView{Target: sense.Target, Example: sense.Example, TagIDs: sense.TagIDs})
+	}
+
+	return view
+}
+
+// statsModel represents result of the statistics aggregation, every field is a separate facet
+type statsModel struct {
+	Total    []countModel      `bson:"total"`
+	Untagged []countModel      `bson:"untagged"`
+	Langs    []groupCountModel `bson:"langs"`
+	Tags     []groupCountModel `bson:"tags"`
+	Added    []groupCountModel `bson:"added"`
+}
+
+type countModel struct {
+	Count int `bson:"count"`
+}
+
+type groupCountModel struct {
+	ID    string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// GetStats returns author dictionary statistics calculated by the single aggregation
+func (r *TranslationRepo) GetStats(authorID string, added query.DateRange, period query.StatsPeriod) (query.StatsView, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Aggregate(ctx, r.statsPipeline(authorID, added, period))
+	if err != nil {
+		return query.StatsView{}, err
+	}
+
+	var models []statsModel
 	if err = cursor.All(ctx, &models); err != nil {
-		return query.LastTranslationViews{}, err
+		return query.StatsView{}, err
 	}
 
-	err = cursor.Close(ctx)
+	if len(models) == 0 {
+		return query.StatsView{}, nil
+	}
+
+	return r.fromStatsModelToView(models[0], authorID)
+}
+
+// statsPipeline builds aggregation counting author translations in facets,
+// translations added are grouped by the period start formatted as date in UTC
+func (r *TranslationRepo) statsPipeline(authorID string, added query.DateRange, period query.StatsPeriod) mongo.Pipeline {
+	var periodStart interface{} = "$created_at"
+	if period == query.StatsPeriodWeek {
+		periodStart = bson.D{{Key: "$subtract", Value: bson.A{
+			"$created_at",
+			bson.D{{Key: "$multiply", Value: bson.A{
+				bson.D{{Key: "$subtract", Value: bson.A{bson.D{{Key: "$isoDayOfWeek", Value: "$created_at"}}, 1}}},
+				int64(24 * time.Hour / time.Millisecond),
+			}}},
+		}}}
+	}
+
+	addedMatch := bson.D{}
+	if condition := r.dateRangeCondition(added); len(condition) != 0 {
+		addedMatch = bson.D{{Key: "created_at", Value: condition}}
+	}
+
+	countStage := bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: nil}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "author_id", Value: authorID}}}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "total", Value: bson.A{countStage}},
+			{Key: "untagged", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "senses.tag_ids.0", Value: bson.D{{Key: "$exists", Value: false}}}}}},
+				countStage,
+			}},
+			{Key: "langs", Value: bson.A{
+				bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$lang_id"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+			}},
+			{Key: "tags", Value: bson.A{
+				bson.D{{Key: "$project", Value: bson.D{{Key: "tag_ids", Value: bson.D{{Key: "$reduce", Value: bson.D{
+					{Key: "input", Value: "$senses.tag_ids"},
+					{Key: "initialValue", Value: bson.A{}},
+					{Key: "in", Value: bson.D{{Key: "$setUnion", Value: bson.A{"$$value", bson.D{{Key: "$ifNull", Value: bson.A{"$$this", bson.A{}}}}}}}},
+				}}}}}}},
+				bson.D{{Key: "$unwind", Value: "$tag_ids"}},
+				bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$tag_ids"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+			}},
+			{Key: "added", Value: bson.A{
+				bson.D{{Key: "$match", Value: addedMatch}},
+				bson.D{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: bson.D{{Key: "$dateToString", Value: bson.D{{Key: "format", Value: "%Y-%m-%d"}, {Key: "date", Value: periodStart}}}}},
+					{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+				}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+			}},
+		}}},
+	}
+}
+
+// fromStatsModelToView converts statistics aggregation result to view performing requests for related lang and tag views
+func (r *TranslationRepo) fromStatsModelToView(model statsModel, authorID string) (query.StatsView, error) {
+	stats := query.StatsView{}
+
+	if len(model.Total) != 0 {
+		stats.Total = model.Total[0].Count
+	}
+
+	if len(model.Untagged) != 0 {
+		stats.Untagged = model.Untagged[0].Count
+	}
+
+	for _, langCount := range model.Langs {
+		langView, err := r.langRepo.GetView(langCount.ID, authorID)
+		if err != nil {
+			return query.StatsView{}, err
+		}
+		stats.Langs = append(stats.Langs, query.LangStatsView{Lang: langView, Count: langCount.Count})
+	}
+
+	if len(model.Tags) != 0 {
+		tagIDs := make([]string, 0, len(model.Tags))
+		for _, tagCount := range model.Tags {
+			tagIDs = append(tagIDs, tagCount.ID)
+		}
+
+		tagViews, err := r.tagRepo.GetViews(tagIDs, authorID)
+		if err != nil {
+			return query.StatsView{}, err
+		}
+
+		if len(tagViews) != len(tagIDs) {
+			return query.StatsView{}, fmt.Errorf("can not find all translation tags")
+		}
+
+		counts := make(map[string]int, len(model.Tags))
+		for _, tagCount := range model.Tags {
+			counts[tagCount.ID] = tagCount.Count
+		}
+
+		for _, tagView := range tagViews {
+			stats.Tags = append(stats.Tags, query.TagStatsView{Tag: tagView, Count: counts[tagView.ID]})
+		}
+	}
+
+	for _, addedCount := range model.Added {
+		start, err := time.Parse("2006-01-02", addedCount.ID)
+		if err != nil {
+			return query.StatsView{}, err
+		}
+		stats.Added = append(stats.Added, query.AddedStatsView{PeriodStart: start, Count: addedCount.Count})
+	}
+
+	return stats, nil
+}
+
+// rankedTranslationModel represents translation document with calculated relevance ranks
+type rankedTranslationModel struct {
+	TranslationModel `bson:",inline"`
+	SourceRank       int `bson:"source_rank"`
+	TargetRank       int `bson:"target_rank"`
+}
+
+func (r *TranslationRepo) getRankedModels(ctx context.Context, pipeline mongo.Pipeline) ([]rankedTranslationModel, error) {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return query.LastTranslationViews{}, err
+		return nil, err
 	}
 
-	views := make([]query.TranslationView, 0, pageSize)
+	var models []rankedTranslationModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
 
-	for i := range models {
-		view, err := r.fromModelToView(models[i])
+	return models, nil
+}
+
+func (r *TranslationRepo) fromRankedModelsToViews(models []rankedTranslationModel) ([]query.TranslationView, error) {
+	views := make([]query.TranslationView, 0, len(models))
 
+	for i := range models {
+		view, err := r.fromModelToView(models[i].TranslationModel)
 		if err != nil {
-			return query.LastTranslationViews{}, err
+			return nil, err
 		}
 
 		views = append(views, view)
 	}
 
-	return query.LastTranslationViews{
-		Views:        views,
-		TotalRecords: int(totalDocuments),
-	}, nil
+	return views, nil
+}
+
+// viewsPipeline builds aggregation returning filtered documents sorted in the filter sort order starting after the cursor
+func (r *TranslationRepo) viewsPipeline(filter query.TranslationFilter, match bson.D, cursor *query.Cursor, skip, limit int) mongo.Pipeline {
+	keys := r.sortKeys(filter)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+
+	if ranks := r.lastViewsRanks(filter); filter.Sort == query.SortRelevance && len(ranks) != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: ranks}})
+	}
+
+	if cursor != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: r.afterCursorCondition(keys, r.cursorValues(*cursor))}})
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: keys}})
+
+	if skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	}
+
+	return append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+}
+
+// sortKeys returns document fields with directions defining the filter sort order, document ID breaks ties
+func (r *TranslationRepo) sortKeys(filter query.TranslationFilter) bson.D {
+	switch filter.Sort {
+	case query.SortCreatedAsc:
+		return bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	case query.SortUpdatedDesc:
+		return bson.D{{Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}}
+	case query.SortSourceAsc:
+		return bson.D{{Key: "source_norm", Value: 1}, {Key: "_id", Value: 1}}
+	case query.SortTargetAsc:
+		return bson.D{{Key: "sort_target", Value: 1}, {Key: "_id", Value: 1}}
+	case query.SortRelevance:
+		keys := bson.D{}
+		for _, rank := range r.lastViewsRanks(filter) {
+			keys = append(keys, bson.E{Key: rank.Key, Value: 1})
+		}
+		return append(keys, bson.E{Key: "created_at", Value: -1}, bson.E{Key: "_id", Value: -1})
+	default:
+		return bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+	}
+}
+
+// cursorValues returns cursor value of every field which can be a sort key
+func (r *TranslationRepo) cursorValues(cursor query.Cursor) map[string]interface{} {
+	return map[string]interface{}{
+		"source_rank": int(cursor.SourceRank),
+		"target_rank": int(cursor.TargetRank),
+		"created_at":  cursor.Time,
+		"updatedAt":   cursor.Time,
+		"source_norm": cursor.Text,
+		"sort_target": cursor.Text,
+		"_id":         cursor.ID,
+	}
+}
+
+// afterCursorCondition builds condition matching documents which go after the cursor values in the sort keys order:
+// the first key goes after the cursor one or it is equal and the rest keys go after the cursor ones
+func (r *TranslationRepo) afterCursorCondition(keys bson.D, values map[string]interface{}) bson.D {
+	conditions := bson.A{}
+
+	for i, key := range keys {
+		condition := bson.D{}
+		for _, equalKey := range keys[:i] {
+			condition = append(condition, bson.E{Key: equalKey.Key, Value: values[equalKey.Key]})
+		}
+
+		operator := "$gt"
+		if key.Value == -1 {
+			operator = "$lt"
+		}
+
+		conditions = append(conditions, append(condition, bson.E{Key: key.Key, Value: bson.D{{Key: operator, Value: values[key.Key]}}}))
+	}
+
+	return bson.D{{Key: "$or", Value: conditions}}
+}
+
+func (r *TranslationRepo) modelToCursor(model rankedTranslationModel, order query.SortOrder) query.Cursor {
+	cursor := query.Cursor{Sort: order, ID: model.ID}
+
+	switch order {
+	case query.SortUpdatedDesc:
+		cursor.Time = model.UpdatedAt
+	case query.SortSourceAsc:
+		cursor.Text = model.SourceNorm
+	case query.SortTargetAsc:
+		cursor.Text = model.SortTarget
+	case query.SortRelevance:
+		cursor.SourceRank, cursor.TargetRank = query.MatchRank(model.SourceRank), query.MatchRank(model.TargetRank)
+		cursor.Time = model.CreatedAt
+	default:
+		cursor.Time = model.CreatedAt
+	}
+
+	return cursor
 }
 
 // fromDomainToModel converts domain translation to mongo model
 func (r *TranslationRepo) fromDomainToModel(t *translation.Translation) (TranslationModel, error) {
 	model := TranslationModel{}
-	err := mapstructure.Decode(t.ToMap(), &model)
-	return model, err
+	if err := mapstructure.Decode(t.ToMap(), &model); err != nil {
+		return model, err
+	}
+
+	r.normalize(&model)
+	return model, nil
+}
+
+// normalize sets source, transcription and sense texts normalized for case and diacritics insensitive search,
+// trigrams for fuzzy search are built from the source and targets
+func (r *TranslationRepo) normalize(model *TranslationModel) {
+	model.SourceNorm = query.NormalizeSearchText(model.Source)
+	model.TranscriptionNorm = query.NormalizeSearchText(model.Transcription)
+	texts := []string{model.SourceNorm}
+	for i := range model.Senses {
+		model.Senses[i].TargetNorm = query.NormalizeSearchText(model.Senses[i].Target)
+		model.Senses[i].ExampleNorm = query.NormalizeSearchText(model.Senses[i].Example)
+		texts = append(texts, model.Senses[i].TargetNorm)
+	}
+	model.Trigrams = r.trigrams(texts...)
+
+	model.SortTarget = ""
+	if len(model.Senses) != 0 {
+		model.SortTarget = model.Senses[0].TargetNorm
+	}
+}
+
+// trigrams returns unique trigrams of all passed texts padded with two spaces from both sides,
+// so a text of n runes gives n+2 trigrams and each edit changes at most 3 of them
+func (r *TranslationRepo) trigrams(texts ...string) []string {
+	var result []string
+	seen := map[string]struct{}{}
+
+	for _, text := range texts {
+		runes := []rune("  " + text + "  ")
+		for i := 0; i+3 <= len(runes); i++ {
+			trigram := string(runes[i : i+3])
+			if _, ok := seen[trigram]; ok {
+				continue
+			}
+			seen[trigram] = struct{}{}
+			result = append(result, trigram)
+		}
+	}
+
+	return result
+}
+
+// fromTranslationModelToDomain converts mongo model to domain translation
+func fromTranslationModelToDomain(model TranslationModel) *translation.Translation {
+	return translation.UnmarshalFromDB(
+		model.ID,
+		model.Source,
+		model.Transcription,
+		fromSenseModelsToDomain(model.Senses),
+		model.AuthorID,
+		model.CreatedAt,
+		model.UpdatedAt,
+		model.LangID,
+		translation.NewReview(
+			model.Review.Ease,
+			model.Review.Interval,
+			model.Review.Repetitions,
+			model.Review.Lapses,
+			model.Review.DueAt,
+			model.Review.ReviewedAt,
+		),
+		model.Fields,
+	)
+}
+
+// fromSenseModelsToDomain converts mongo sense models to domain translation senses
+func fromSenseModelsToDomain(models []SenseModel) []translation.Sense {
+	senses := make([]translation.Sense, 0, len(models))
+	for _, model := range models {
+		senses = append(senses, translation.NewSense(model.Target, model.Example, model.TagIDs))
+	}
+	return senses
 }
 
 // fromModelToView converts mongo model to translation View performing request for receiving related tag views
@@ -372,9 +1375,16 @@ func (r *TranslationRepo) fromModelToView(model TranslationModel) (query.Transla
 		ID:            model.ID,
 		CreatedAd:     model.CreatedAt,
 		Transcription: model.Transcription,
-		Target:        model.Target,
 		Source:        model.Source,
-		Example:       model.Example,
+		Fields:        model.Fields,
+		Review: query.ReviewView{
+			Ease:        model.Review.Ease,
+			Interval:    model.Review.Interval,
+			Repetitions: model.Review.Repetitions,
+			Lapses:      model.Review.Lapses,
+			DueAt:       model.Review.DueAt,
+			ReviewedAt:  model.Review.ReviewedAt,
+		},
 	}
 
 	langView, err := r.langRepo.GetView(model.LangID, model.AuthorID)
@@ -384,20 +1394,55 @@ func (r *TranslationRepo) fromModelToView(model TranslationModel) (query.Transla
 
 	view.Lang = langView
 
-	if model.TagIDs == nil {
-		return view, nil
-	}
-	tagViews, err := r.tagRepo.GetViews(model.TagIDs, model.AuthorID)
+	tagIDs := r.sensesTagIDs(model.Senses)
+	tagViews := map[string]query.TagView{}
 
-	if err != nil {
-		return query.TranslationView{}, err
-	}
+	if len(tagIDs) != 0 {
+		views, err := r.tagRepo.GetViews(tagIDs, model.AuthorID)
+		if err != nil {
+			return query.TranslationView{}, err
+		}
+
+		if len(tagIDs) != len(views) {
+			return query.TranslationView{}, fmt.Errorf("can not find all translation tags")
+		}
 
-	if len(model.TagIDs) != len(tagViews) {
-		return query.TranslationView{}, fmt.Errorf("can not find all translation tags")
+		for _, tagView := range views {
+			tagViews[tagView.ID] = tagView
+		}
 	}
 
-	view.Tags = tagViews
+	view.Senses = make([]query.SenseView, 0, len(model.Senses))
+	for _, sense := range model.Senses {
+		senseView := query.SenseView{
+			Target:  sense.Target,
+			Example: sense.Example,
+		}
+
+		for _, tagID := range sense.TagIDs {
+			senseView.Tags = append(senseView.Tags, tagViews[tagID])
+		}
+
+		view.Senses = append(view.Senses, senseView)
+	}
 
 	return view, nil
 }
+
+// sensesTagIDs returns unique tag IDs of all passed senses
+func (r *TranslationRepo) sensesTagIDs(senses []SenseModel) []string {
+	var tagIDs []string
+	seen := map[string]struct{}{}
+
+	for _, sense := range senses {
+		for _, tagID := range sense.TagIDs {
+			if _, ok := seen[tagID]; ok {
+				continue
+			}
+			seen[tagID] = struct{}{}
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+
+	return tagIDs
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
 	"github.com/macyan13/webdict/backend/pkg/app/query"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"testing"
 	"time"
 )
@@ -18,7 +21,7 @@ func TestTranslationRepo_fromDomainToModel(t *testing.T) {
 	example := "testExample"
 	tags := []string{"test1", "test2"}
 	langID := "EN"
-	domain, err := translation.NewTranslation(source, transcription, meaning, authorID, example, tags, langID)
+	domain, err := translation.NewTranslation(source, transcription, []translation.Sense{translation.NewSense(meaning, example, tags)}, authorID, langID)
 	assert.Nil(t, err)
 	domainMap := domain.ToMap()
 
@@ -26,15 +29,19 @@ func TestTranslationRepo_fromDomainToModel(t *testing.T) {
 	model, err := repo.fromDomainToModel(domain)
 
 	assert.Nil(t, err)
-	assert.Equal(t, meaning, model.Target)
+	assert.Equal(t, []SenseModel{{Target: meaning, TargetNorm: "testtranslation", Example: example, ExampleNorm: "testexample", TagIDs: tags}}, model.Senses)
 	assert.Equal(t, authorID, model.AuthorID)
 	assert.Equal(t, transcription, model.Transcription)
 	assert.Equal(t, source, model.Source)
-	assert.Equal(t, example, model.Example)
-	assert.Equal(t, tags, model.TagIDs)
+	assert.Equal(t, "testtext", model.SourceNorm)
 	assert.Equal(t, langID, model.LangID)
 	assert.Equal(t, domainMap["createdAt"], model.CreatedAt)
 	assert.Equal(t, domainMap["updatedAt"], model.UpdatedAt)
+
+	reviewMap := domainMap["review"].(map[string]interface{})
+	assert.Equal(t, reviewMap["ease"], model.Review.Ease)
+	assert.Equal(t, reviewMap["interval"], model.Review.Interval)
+	assert.Equal(t, reviewMap["dueAt"], model.Review.DueAt)
 }
 
 func TestTranslationRepo_fromModelToView_positiveCase(t *testing.T) {
@@ -44,14 +51,23 @@ func TestTranslationRepo_fromModelToView_positiveCase(t *testing.T) {
 		CreatedAt:     time.Now().Add(5 * time.Second),
 		UpdatedAt:     time.Now().Add(10 * time.Second),
 		Transcription: "transcription",
-		Target:        "translation",
 		Source:        "text",
-		Example:       "example",
-		TagIDs:        []string{"tag1", "tag2"},
-		LangID:        "EN",
+		Senses: []SenseModel{
+			{Target: "translation", Example: "example", TagIDs: []string{"tag1", "tag2"}},
+			{Target: "translation2", TagIDs: []string{"tag2"}},
+		},
+		LangID: "EN",
+		Review: ReviewModel{
+			Ease:        2.5,
+			Interval:    6,
+			Repetitions: 2,
+			Lapses:      1,
+			DueAt:       time.Now().Add(24 * time.Hour),
+			ReviewedAt:  time.Now(),
+		},
 	}
 
-	tagViews := []query.TagView{{Name: "tag1"}, {Name: "tag2"}}
+	tagViews := []query.TagView{{ID: "tag1", Name: "tag1"}, {ID: "tag2", Name: "tag2"}}
 	tagRepo := query.MockTagViewRepository{}
 	tagRepo.On("GetViews", []string{"tag1", "tag2"}, "testAuthor").Return(tagViews, nil)
 
@@ -68,12 +84,21 @@ func TestTranslationRepo_fromModelToView_positiveCase(t *testing.T) {
 
 	assert.Equal(t, model.ID, view.ID)
 	assert.Equal(t, model.CreatedAt, view.CreatedAd)
-	assert.Equal(t, model.Target, view.Target)
 	assert.Equal(t, model.Transcription, view.Transcription)
 	assert.Equal(t, model.Source, view.Source)
-	assert.Equal(t, model.Example, view.Example)
-	assert.Equal(t, tagViews, view.Tags)
+	assert.Equal(t, []query.SenseView{
+		{Target: "translation", Example: "example", Tags: tagViews},
+		{Target: "translation2", Tags: []query.TagView{tagViews[1]}},
+	}, view.Senses)
 	assert.Equal(t, model.LangID, view.Lang.ID)
+	assert.Equal(t, query.ReviewView{
+		Ease:        model.Review.Ease,
+		Interval:    model.Review.Interval,
+		Repetitions: model.Review.Repetitions,
+		Lapses:      model.Review.Lapses,
+		DueAt:       model.Review.DueAt,
+		ReviewedAt:  model.Review.ReviewedAt,
+	}, view.Review)
 }
 
 func TestTranslationRepo_fromModelToView_tagsNotSet(t *testing.T) {
@@ -83,9 +108,8 @@ func TestTranslationRepo_fromModelToView_tagsNotSet(t *testing.T) {
 		CreatedAt:     time.Now().Add(5 * time.Second),
 		UpdatedAt:     time.Now().Add(10 * time.Second),
 		Transcription: "transcription",
-		Target:        "translation",
 		Source:        "text",
-		Example:       "example",
+		Senses:        []SenseModel{{Target: "translation", Example: "example"}},
 		LangID:        "EN",
 	}
 
@@ -98,17 +122,16 @@ func TestTranslationRepo_fromModelToView_tagsNotSet(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, model.ID, view.ID)
 	assert.Equal(t, model.CreatedAt, view.CreatedAd)
-	assert.Equal(t, model.Target, view.Target)
+	assert.Equal(t, []query.SenseView{{Target: "translation", Example: "example"}}, view.Senses)
 	assert.Equal(t, model.Transcription, view.Transcription)
 	assert.Equal(t, model.Source, view.Source)
-	assert.Equal(t, model.Example, view.Example)
 	assert.Equal(t, model.LangID, view.Lang.ID)
 }
 
 func TestTranslationRepo_fromModelToView_errorOnGetViews(t *testing.T) {
 	model := TranslationModel{
 		AuthorID: "testAuthor",
-		TagIDs:   []string{"tag1", "tag2"},
+		Senses:   []SenseModel{{TagIDs: []string{"tag1", "tag2"}}},
 		LangID:   "EN",
 	}
 
@@ -130,7 +153,7 @@ func TestTranslationRepo_fromModelToView_errorOnGetViews(t *testing.T) {
 func TestTranslationRepo_fromModelToView_errorOnTagViewsMiscount(t *testing.T) {
 	model := TranslationModel{
 		AuthorID: "testAuthor",
-		TagIDs:   []string{"tag1", "tag2"},
+		Senses:   []SenseModel{{TagIDs: []string{"tag1", "tag2"}}},
 		LangID:   "EN",
 	}
 
@@ -152,7 +175,7 @@ func TestTranslationRepo_fromModelToView_errorOnTagViewsMiscount(t *testing.T) {
 func TestTranslationRepo_fromModelToView_errorOnGetLangView(t *testing.T) {
 	model := TranslationModel{
 		AuthorID: "testAuthor",
-		TagIDs:   []string{"tag1"},
+		Senses:   []SenseModel{{TagIDs: []string{"tag1"}}},
 		LangID:   "EN",
 	}
 
@@ -170,3 +193,317 @@ func TestTranslationRepo_fromModelToView_errorOnGetLangView(t *testing.T) {
 	_, err := translationRepo.fromModelToView(model)
 	assert.Equal(t, "testError", err.Error())
 }
+
+func TestTranslationRepo_lastViewsFilter(t *testing.T) {
+	createdFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedTo := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	repo := TranslationRepo{}
+
+	assert.Equal(t, bson.D{{Key: "author_id", Value: "testAuthor"}, {Key: "lang_id", Value: "EN"}}, repo.lastViewsFilter(query.TranslationFilter{
+		AuthorID: "testAuthor",
+		LangIDs:  []string{"EN"},
+	}))
+
+	assert.Equal(t, bson.D{
+		{Key: "author_id", Value: "testAuthor"},
+		{Key: "lang_id", Value: "EN"},
+		{Key: "source_norm", Value: bson.M{"$regex": primitive.Regex{Pattern: ".*so\\.urce.*"}}},
+		{Key: "senses.target_norm", Value: bson.M{"$regex": primitive.Regex{Pattern: ".*uber.*"}}},
+		{Key: "$or", Value: bson.A{
+			bson.M{"source_norm": bson.M{"$regex": primitive.Regex{Pattern: ".*creme.*"}}},
+			bson.M{"senses.target_norm": bson.M{"$regex": primitive.Regex{Pattern: ".*creme.*"}}},
+			bson.M{"transcription_norm": bson.M{"$regex": primitive.Regex{Pattern: ".*creme.*"}}},
+			bson.M{"senses.example_norm": bson.M{"$regex": primitive.Regex{Pattern: ".*creme.*"}}},
+		}},
+		{Key: "senses.tag_ids", Value: bson.D{{Key: "$all", Value: []string{"tag1", "tag2"}}}},
+		{Key: "created_at", Value: bson.D{{Key: "$gte", Value: createdFrom}}},
+		{Key: "updatedAt", Value: bson.D{{Key: "$lte", Value: updatedTo}}},
+	}, repo.lastViewsFilter(query.TranslationFilter{
+		AuthorID:   "testAuthor",
+		LangIDs:    []string{"EN"},
+		SourcePart: "So.urce",
+		TargetPart: "Über",
+		TextPart:   "Crème",
+		Tags:       query.TagGroups{{"tag1"}, {"tag2"}},
+		Created:    query.DateRange{From: createdFrom},
+		Updated:    query.DateRange{To: updatedTo},
+	}))
+}
+
+func TestTranslationRepo_tagsCondition(t *testing.T) {
+	repo := TranslationRepo{}
+	filter := bson.D{{Key: "author_id", Value: "testAuthor"}}
+
+	assert.Equal(t, filter, repo.tagsCondition(filter, nil))
+	assert.Equal(t, bson.D{
+		{Key: "author_id", Value: "testAuthor"},
+		{Key: "senses.tag_ids", Value: bson.D{{Key: "$all", Value: []string{"tag2"}}}},
+		{Key: "$and", Value: bson.A{
+			bson.D{{Key: "senses.tag_ids", Value: bson.D{{Key: "$in", Value: []string{"tag1", "child1", "child2"}}}}},
+		}},
+	}, repo.tagsCondition(filter, query.TagGroups{{"tag1", "child1", "child2"}, {"tag2"}}))
+}
+
+func TestTranslationRepo_withoutTags(t *testing.T) {
+	repo := TranslationRepo{}
+
+	assert.Equal(t, bson.D{{Key: "$filter", Value: bson.D{
+		{Key: "input", Value: "$$sense.tag_ids"},
+		{Key: "as", Value: "tag"},
+		{Key: "cond", Value: bson.D{{Key: "$not", Value: bson.A{bson.D{{Key: "$in", Value: bson.A{"$$tag", []string{"tag1", "tag2"}}}}}}}},
+	}}}, repo.withoutTags("$$sense.tag_ids", "tag1", "tag2"))
+}
+
+func TestTranslationRepo_lastViewsRanks(t *testing.T) {
+	repo := TranslationRepo{}
+
+	assert.Empty(t, repo.lastViewsRanks(query.TranslationFilter{AuthorID: "testAuthor", LangIDs: []string{"EN"}, TextPart: "text"}))
+
+	ranks := repo.lastViewsRanks(query.TranslationFilter{SourcePart: "Über", TargetPart: "Target"})
+	assert.Equal(t, bson.D{
+		{Key: "source_rank", Value: repo.rankExpression("$source_norm", "uber")},
+		{Key: "target_rank", Value: bson.D{{Key: "$min", Value: bson.D{{Key: "$map", Value: bson.D{
+			{Key: "input", Value: "$senses.target_norm"},
+			{Key: "as", Value: "target"},
+			{Key: "in", Value: repo.rankExpression("$$target", "target")},
+		}}}}}},
+	}, ranks)
+}
+
+func TestTranslationRepo_trigrams(t *testing.T) {
+	repo := TranslationRepo{}
+
+	assert.Equal(t, []string{"  w", " wo", "wor", "ord", "rd ", "d  "}, repo.trigrams("word"))
+	assert.Equal(t, []string{"  a", " a ", "a  ", "  b", " b ", "b  "}, repo.trigrams("a", "b", "a"))
+	assert.Equal(t, []string{"  ü", " üb", "übe", "ber", "er ", "r  "}, repo.trigrams("über"))
+}
+
+func TestTranslationRepo_fuzzyCandidatesPipeline(t *testing.T) {
+	repo := TranslationRepo{}
+	match := bson.D{{Key: "author_id", Value: "testAuthor"}, {Key: "lang_id", Value: "EN"}}
+	sortStage := bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}}}}
+	limitStage := bson.D{{Key: "$limit", Value: fuzzyCandidatesLimit}}
+
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$match", Value: bson.D{{Key: "$expr", Value: repo.lengthWindowExpression(2, 2)}}}},
+		sortStage,
+		limitStage,
+	}, repo.fuzzyCandidatesPipeline("testAuthor", "EN", "wö", 2))
+
+	trigrams := repo.trigrams("word")
+	pipeline := repo.fuzzyCandidatesPipeline("testAuthor", "EN", "word", 1)
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: append(match, bson.E{Key: "trigrams", Value: bson.D{{Key: "$in", Value: trigrams}}})}},
+		{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$gte", Value: bson.A{
+			bson.D{{Key: "$size", Value: bson.D{{Key: "$setIntersection", Value: bson.A{"$trigrams", trigrams}}}}},
+			3,
+		}}}}}}},
+		sortStage,
+		limitStage,
+	}, pipeline)
+}
+
+func TestTranslationRepo_lengthWindowExpression(t *testing.T) {
+	repo := TranslationRepo{}
+	sourceWindow := bson.D{{Key: "$lte", Value: bson.A{
+		bson.D{{Key: "$abs", Value: bson.D{{Key: "$subtract", Value: bson.A{
+			bson.D{{Key: "$strLenCP", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$source_norm", ""}}}}},
+			5,
+		}}}}},
+		3,
+	}}}
+
+	expression := repo.lengthWindowExpression(5, 3)
+	or := expression[0].Value.(bson.A)
+	assert.Equal(t, "$or", expression[0].Key)
+	assert.Equal(t, sourceWindow, or[0])
+	assert.Contains(t, fmt.Sprint(or[1]), "$$sense.target_norm")
+}
+
+func TestTranslationRepo_normalize(t *testing.T) {
+	repo := TranslationRepo{}
+	model := TranslationModel{Source: "Über", Transcription: "[ˈyːbɐ]", Senses: []SenseModel{{Target: "Over", Example: "Über ALLES"}}}
+
+	repo.normalize(&model)
+
+	assert.Equal(t, "uber", model.SourceNorm)
+	assert.Equal(t, "[ˈyːbɐ]", model.TranscriptionNorm)
+	assert.Equal(t, "over", model.Senses[0].TargetNorm)
+	assert.Equal(t, "uber alles", model.Senses[0].ExampleNorm)
+	assert.Equal(t, repo.trigrams("uber", "over"), model.Trigrams)
+	assert.Equal(t, "over", model.SortTarget)
+}
+
+func TestTranslationRepo_langsCondition(t *testing.T) {
+	repo := TranslationRepo{}
+	filter := bson.D{{Key: "author_id", Value: "testAuthor"}}
+
+	assert.Equal(t, filter, repo.langsCondition(filter, nil))
+	assert.Equal(t, append(filter, bson.E{Key: "lang_id", Value: "EN"}), repo.langsCondition(filter, []string{"EN"}))
+	assert.Equal(t,
+		append(filter, bson.E{Key: "lang_id", Value: bson.D{{Key: "$in", Value: []string{"EN", "DE"}}}}),
+		repo.langsCondition(filter, []string{"EN", "DE"}),
+	)
+}
+
+func TestTranslationRepo_sortKeys(t *testing.T) {
+	repo := TranslationRepo{}
+
+	assert.Equal(t, bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, repo.sortKeys(query.TranslationFilter{}))
+	assert.Equal(t, bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, repo.sortKeys(query.TranslationFilter{Sort: query.SortCreatedDesc, SourcePart: "a"}))
+	assert.Equal(t, bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}, repo.sortKeys(query.TranslationFilter{Sort: query.SortCreatedAsc}))
+	assert.Equal(t, bson.D{{Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}}, repo.sortKeys(query.TranslationFilter{Sort: query.SortUpdatedDesc}))
+	assert.Equal(t, bson.D{{Key: "source_norm", Value: 1}, {Key: "_id", Value: 1}}, repo.sortKeys(query.TranslationFilter{Sort: query.SortSourceAsc}))
+	assert.Equal(t, bson.D{{Key: "sort_target", Value: 1}, {Key: "_id", Value: 1}}, repo.sortKeys(query.TranslationFilter{Sort: query.SortTargetAsc}))
+	assert.Equal(t, bson.D{
+		{Key: "source_rank", Value: 1},
+		{Key: "target_rank", Value: 1},
+		{Key: "created_at", Value: -1},
+		{Key: "_id", Value: -1},
+	}, repo.sortKeys(query.TranslationFilter{SourcePart: "a", TargetPart: "b"}))
+}
+
+func TestTranslationRepo_afterCursorCondition(t *testing.T) {
+	repo := TranslationRepo{}
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	values := repo.cursorValues(query.Cursor{SourceRank: query.PrefixMatch, Time: createdAt, ID: "testID"})
+
+	assert.Equal(t, bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "source_rank", Value: bson.D{{Key: "$gt", Value: 1}}}},
+		bson.D{{Key: "source_rank", Value: 1}, {Key: "created_at", Value: bson.D{{Key: "$lt", Value: createdAt}}}},
+		bson.D{{Key: "source_rank", Value: 1}, {Key: "created_at", Value: createdAt}, {Key: "_id", Value: bson.D{{Key: "$lt", Value: "testID"}}}},
+	}}}, repo.afterCursorCondition(bson.D{{Key: "source_rank", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, values))
+}
+
+func TestTranslationRepo_viewsPipeline(t *testing.T) {
+	repo := TranslationRepo{}
+	match := bson.D{{Key: "author_id", Value: "testAuthor"}}
+
+	filter := query.TranslationFilter{AuthorID: "testAuthor", SourcePart: "a"}
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: repo.lastViewsRanks(filter)}},
+		{{Key: "$sort", Value: repo.sortKeys(filter)}},
+		{{Key: "$skip", Value: 10}},
+		{{Key: "$limit", Value: 5}},
+	}, repo.viewsPipeline(filter, match, nil, 10, 5))
+
+	filter = query.TranslationFilter{AuthorID: "testAuthor", SourcePart: "a", Sort: query.SortSourceAsc}
+	cursor := query.Cursor{Sort: query.SortSourceAsc, Text: "abc", ID: "testID"}
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$match", Value: repo.afterCursorCondition(repo.sortKeys(filter), repo.cursorValues(cursor))}},
+		{{Key: "$sort", Value: repo.sortKeys(filter)}},
+		{{Key: "$limit", Value: 6}},
+	}, repo.viewsPipeline(filter, match, &cursor, 0, 6))
+}
+
+func TestTranslationRepo_modelToCursor(t *testing.T) {
+	repo := TranslationRepo{}
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	model := rankedTranslationModel{
+		TranslationModel: TranslationModel{ID: "testID", CreatedAt: createdAt, UpdatedAt: updatedAt, SourceNorm: "source", SortTarget: "target"},
+		SourceRank:       1,
+		TargetRank:       2,
+	}
+
+	assert.Equal(t, query.Cursor{Sort: query.SortRelevance, SourceRank: 1, TargetRank: 2, Time: createdAt, ID: "testID"}, repo.modelToCursor(model, query.SortRelevance))
+	assert.Equal(t, query.Cursor{Sort: query.SortCreatedAsc, Time: createdAt, ID: "testID"}, repo.modelToCursor(model, query.SortCreatedAsc))
+	assert.Equal(t, query.Cursor{Sort: query.SortUpdatedDesc, Time: updatedAt, ID: "testID"}, repo.modelToCursor(model, query.SortUpdatedDesc))
+	assert.Equal(t, query.Cursor{Sort: query.SortSourceAsc, Text: "source", ID: "testID"}, repo.modelToCursor(model, query.SortSourceAsc))
+	assert.Equal(t, query.Cursor{Sort: query.SortTargetAsc, Text: "target", ID: "testID"}, repo.modelToCursor(model, query.SortTargetAsc))
+}
+
+func TestTranslationRepo_statsPipeline(t *testing.T) {
+	repo := TranslationRepo{}
+	from := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	pipeline := repo.statsPipeline("testAuthor", query.DateRange{From: from, To: to}, query.StatsPeriodDay)
+	assert.Equal(t, 2, len(pipeline))
+	assert.Equal(t, bson.D{{Key: "$match", Value: bson.D{{Key: "author_id", Value: "testAuthor"}}}}, pipeline[0])
+
+	facets := pipeline[1][0].Value.(bson.D)
+	assert.Equal(t, []string{"total", "untagged", "langs", "tags", "added"}, []string{facets[0].Key, facets[1].Key, facets[2].Key, facets[3].Key, facets[4].Key})
+
+	added := facets[4].Value.(bson.A)
+	assert.Equal(t, bson.D{{Key: "$match", Value: bson.D{{Key: "created_at", Value: repo.dateRangeCondition(query.DateRange{From: from, To: to})}}}}, added[0])
+	group := added[1].(bson.D)[0].Value.(bson.D)
+	assert.Equal(t, bson.D{{Key: "$dateToString", Value: bson.D{{Key: "format", Value: "%Y-%m-%d"}, {Key: "date", Value: "$created_at"}}}}, group[0].Value)
+
+	pipeline = repo.statsPipeline("testAuthor", query.DateRange{From: from, To: to}, query.StatsPeriodWeek)
+	group = pipeline[1][0].Value.(bson.D)[4].Value.(bson.A)[1].(bson.D)[0].Value.(bson.D)
+	periodStart := group[0].Value.(bson.D)[0].Value.(bson.D)[1].Value
+	assert.Equal(t, "$subtract", periodStart.(bson.D)[0].Key)
+}
+
+func TestTranslationRepo_fromStatsModelToView(t *testing.T) {
+	langRepo := query.MockLangViewRepository{}
+	langRepo.On("GetView", "lang1", "testAuthor").Return(query.LangView{ID: "lang1", Name: "EN"}, nil)
+	tagRepo := query.MockTagViewRepository{}
+	tagRepo.On("GetViews", []string{"tag1", "tag2"}, "testAuthor").Return([]query.TagView{{ID: "tag2", Name: "noun"}, {ID: "tag1", Name: "verb"}}, nil)
+	repo := TranslationRepo{langRepo: &langRepo, tagRepo: &tagRepo}
+
+	got, err := repo.fromStatsModelToView(statsModel{
+		Total:    []countModel{{Count: 3}},
+		Untagged: []countModel{{Count: 1}},
+		Langs:    []groupCountModel{{ID: "lang1", Count: 3}},
+		Tags:     []groupCountModel{{ID: "tag1", Count: 2}, {ID: "tag2", Count: 1}},
+		Added:    []groupCountModel{{ID: "2023-03-06", Count: 3}},
+	}, "testAuthor")
+
+	assert.Nil(t, err)
+	assert.Equal(t, query.StatsView{
+		Total:    3,
+		Untagged: 1,
+		Langs:    []query.LangStatsView{{Lang: query.LangView{ID: "lang1", Name: "EN"}, Count: 3}},
+		Tags:     []query.TagStatsView{{Tag: query.TagView{ID: "tag2", Name: "noun"}, Count: 1}, {Tag: query.TagView{ID: "tag1", Name: "verb"}, Count: 2}},
+		Added:    []query.AddedStatsView{{PeriodStart: time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC), Count: 3}},
+	}, got)
+
+	got, err = repo.fromStatsModelToView(statsModel{}, "testAuthor")
+	assert.Nil(t, err)
+	assert.Equal(t, query.StatsView{}, got)
+}
+
+func TestTranslationRepo_fromModelToExportView(t *testing.T) {
+	model := TranslationModel{
+		ID:            "id",
+		AuthorID:      "testAuthor",
+		CreatedAt:     time.Now().Add(5 * time.Second),
+		UpdatedAt:     time.Now().Add(10 * time.Second),
+		Transcription: "transcription",
+		Source:        "text",
+		Senses: []SenseModel{
+			{Target: "translation", Example: "example", TagIDs: []string{"tag1", "tag2"}},
+			{Target: "translation2"},
+		},
+		LangID: "EN",
+		Review: ReviewModel{Ease: 2.5, Interval: 6, Repetitions: 2, Lapses: 1, DueAt: time.Now(), ReviewedAt: time.Now()},
+	}
+
+	view := (&TranslationRepo{}).fromModelToExportView(model)
+
+	assert.Equal(t, query.ExportTranslationView{
+		ID:            "id",
+		LangID:        "EN",
+		Source:        "text",
+		Transcription: "transcription",
+		Senses: []query.ExportSenseView{
+			{Target: "translation", Example: "example", TagIDs: []string{"tag1", "tag2"}},
+			{Target: "translation2"},
+		},
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+		Review: query.ReviewView{
+			Ease:        2.5,
+			Interval:    6,
+			Repetitions: 2,
+			Lapses:      1,
+			DueAt:       model.Review.DueAt,
+			ReviewedAt:  model.Review.ReviewedAt,
+		},
+	}, view)
+}
@@ -0,0 +1,232 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/lang"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// TrashRepo Mongo DB implementation for domain trash item entity
+type TrashRepo struct {
+	collection *mongo.Collection
+}
+
+// TrashItemModel represents mongo trash item document, only the field of the deleted entity kind is set
+type TrashItemModel struct {
+	ID          string            `bson:"_id"`
+	Kind        string            `bson:"kind"`
+	AuthorID    string            `bson:"author_id"`
+	DeletedAt   time.Time         `bson:"deleted_at"`
+	Translation *TranslationModel `bson:"translation,omitempty"`
+	Tag         *TagModel         `bson:"tag,omitempty"`
+	Lang        *LangModel        `bson:"lang,omitempty"`
+}
+
+// NewTrashRepo creates TrashRepo
+func NewTrashRepo(db *mongo.Database) (*TrashRepo, error) {
+	r := TrashRepo{collection: db.Collection("trash")}
+
+	if err := r.initIndexes(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// initIndexes creates required for current queries indexes in trash collection
+func (r *TrashRepo) initIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "deleted_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "deleted_at", Value: 1},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Create saves new trash item to DB
+func (r *TrashRepo) Create(item *trash.Item) error {
+	model, err := r.fromDomainToModel(item)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err = r.collection.InsertOne(ctx, model)
+	return err
+}
+
+// Get searches for trash item with id and authorID
+func (r *TrashRepo) Get(id, authorID string) (*trash.Item, error) {
+	var record TrashItemModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	if err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: id}, {Key: "author_id", Value: authorID}}).Decode(&record); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, trash.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.fromModelToDomain(record), nil
+}
+
+// GetAll returns all trash items of the author
+func (r *TrashRepo) GetAll(authorID string) ([]*trash.Item, error) {
+	return r.getByFilter(bson.D{{Key: "author_id", Value: authorID}})
+}
+
+// GetDeletedBefore returns trash items of all authors deleted before passed time
+func (r *TrashRepo) GetDeletedBefore(deletedAt time.Time) ([]*trash.Item, error) {
+	return r.getByFilter(bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$lt", Value: deletedAt}}}})
+}
+
+// Delete removes trash item with id and authorID
+func (r *TrashRepo) Delete(id, authorID string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}, {Key: "author_id", Value: authorID}})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount != 1 {
+		return fmt.Errorf("1 record was supposed to be deleted, %d removed", result.DeletedCount)
+	}
+
+	return nil
+}
+
+func (r *TrashRepo) DeleteByAuthorID(authorID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+	result, err := r.collection.DeleteMany(ctx, bson.D{{Key: "author_id", Value: authorID}})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
+
+// GetAllViews returns all trash item views of the author, the latest deleted go first
+func (r *TrashRepo) GetAllViews(authorID string) ([]query.TrashItemView, error) {
+	models, err := r.getModelsByFilter(bson.D{{Key: "author_id", Value: authorID}})
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]query.TrashItemView, 0, len(models))
+	for i := range models {
+		views = append(views, r.fromModelToView(models[i]))
+	}
+
+	return views, nil
+}
+
+func (r *TrashRepo) getByFilter(filter bson.D) ([]*trash.Item, error) {
+	models, err := r.getModelsByFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*trash.Item, 0, len(models))
+	for i := range models {
+		items = append(items, r.fromModelToDomain(models[i]))
+	}
+
+	return items, nil
+}
+
+func (r *TrashRepo) getModelsByFilter(filter bson.D) ([]TrashItemModel, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var models []TrashItemModel
+	if err = cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// fromDomainToModel converts domain trash item to mongo model
+func (r *TrashRepo) fromDomainToModel(item *trash.Item) (TrashItemModel, error) {
+	model := TrashItemModel{}
+	err := mapstructure.Decode(item.ToMap(), &model)
+	return model, err
+}
+
+// fromModelToDomain converts mongo model to domain trash item
+func (r *TrashRepo) fromModelToDomain(model TrashItemModel) *trash.Item {
+	var tr *translation.Translation
+	if model.Translation != nil {
+		tr = fromTranslationModelToDomain(*model.Translation)
+	}
+
+	var tg *tag.Tag
+	if model.Tag != nil {
+		tg = tag.UnmarshalFromDB(model.Tag.ID, model.Tag.Name, model.Tag.AuthorID, model.Tag.ParentID)
+	}
+
+	var ln *lang.Lang
+	if model.Lang != nil {
+		ln = fromLangModelToDomain(*model.Lang)
+	}
+
+	return trash.UnmarshalFromDB(model.ID, trash.Kind(model.Kind), model.AuthorID, model.DeletedAt, tr, tg, ln)
+}
+
+// fromModelToView converts mongo model to trash item View
+func (r *TrashRepo) fromModelToView(model TrashItemModel) query.TrashItemView {
+	view := query.TrashItemView{
+		ID:        model.ID,
+		Kind:      model.Kind,
+		DeletedAt: model.DeletedAt,
+	}
+
+	switch {
+	case model.Translation != nil:
+		view.EntityID = model.Translation.ID
+		view.Title = model.Translation.Source
+	case model.Tag != nil:
+		view.EntityID = model.Tag.ID
+		view.Title = model.Tag.Name
+	case model.Lang != nil:
+		view.EntityID = model.Lang.ID
+		view.Title = model.Lang.Name
+	}
+
+	return view
+}
@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"github.com/macyan13/webdict/backend/pkg/app/domain/tag"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/translation"
+	"github.com/macyan13/webdict/backend/pkg/app/domain/trash"
+	"github.com/macyan13/webdict/backend/pkg/app/query"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTrashRepo_fromDomainToModel(t *testing.T) {
+	tr, err := translation.NewTranslation("source", "", []translation.Sense{translation.NewSense("target", "example", []string{"tag1"})}, "testAuthor", "EN")
+	assert.Nil(t, err)
+
+	item, err := trash.NewTranslationItem(tr)
+	assert.Nil(t, err)
+
+	repo := TrashRepo{}
+	model, err := repo.fromDomainToModel(item)
+
+	assert.Nil(t, err)
+	assert.Equal(t, item.ID(), model.ID)
+	assert.Equal(t, "translation", model.Kind)
+	assert.Equal(t, "testAuthor", model.AuthorID)
+	assert.Equal(t, item.DeletedAt(), model.DeletedAt)
+	assert.Nil(t, model.Tag)
+	assert.Nil(t, model.Lang)
+	assert.Equal(t, tr.ID(), model.Translation.ID)
+	assert.Equal(t, "source", model.Translation.Source)
+	assert.Equal(t, []SenseModel{{Target: "target", Example: "example", TagIDs: []string{"tag1"}}}, model.Translation.Senses)
+}
+
+func TestTrashRepo_fromModelToDomain(t *testing.T) {
+	model := TrashItemModel{
+		ID:        "itemID",
+		Kind:      "tag",
+		AuthorID:  "testAuthor",
+		DeletedAt: time.Now(),
+		Tag:       &TagModel{ID: "tagID", Name: "tag", AuthorID: "testAuthor"},
+	}
+
+	repo := TrashRepo{}
+	item := repo.fromModelToDomain(model)
+
+	assert.Equal(t, trash.UnmarshalFromDB("itemID", trash.TagKind, "testAuthor", model.DeletedAt, nil, tag.UnmarshalFromDB("tagID", "tag", "testAuthor", ""), nil), item)
+}
+
+func TestTrashRepo_fromModelToView(t *testing.T) {
+	model := TrashItemModel{
+		ID:          "itemID",
+		Kind:        "translation",
+		AuthorID:    "testAuthor",
+		DeletedAt:   time.Now(),
+		Translation: &TranslationModel{ID: "trID", Source: "source"},
+	}
+
+	repo := TrashRepo{}
+	assert.Equal(t, query.TrashItemView{
+		ID:        "itemID",
+		Kind:      "translation",
+		EntityID:  "trID",
+		Title:     "source",
+		DeletedAt: model.DeletedAt,
+	}, repo.fromModelToView(model))
+}
@@ -12,11 +12,15 @@ import (
 	"time"
 )
 
+// registerFailureRetries parallel first failures of the same key insert the record at the same time, so one of them is retried
+const registerFailureRetries = 3
+
 // UserRepo Mongo DB implementation for domain user entity
 type UserRepo struct {
-	collection    *mongo.Collection
-	langViewRepo  query.LangViewRepository
-	roleConverter *query.RoleConverter
+	collection        *mongo.Collection
+	attemptCollection *mongo.Collection
+	langViewRepo      query.LangViewRepository
+	roleConverter     *query.RoleConverter
 }
 
 // UserModel represents mongo user document
@@ -28,6 +32,25 @@ type UserModel struct {
 	Role          int              `bson:"role"`
 	DefaultLangID string           `bson:"default_lang_id"`
 	ListOptions   ListOptionsModel `bson:"list_options"`
+	TokenVersion  int              `bson:"token_version"`
+	TwoFactor     TwoFactorModel   `bson:"two_factor"`
+}
+
+// TwoFactorModel represents the nested two-factor authentication settings in the mongo user document
+type TwoFactorModel struct {
+	Secret        string   `bson:"secret"`
+	Enabled       bool     `bson:"enabled"`
+	LastUsedStep  int64    `bson:"last_used_step"`
+	RecoveryCodes []string `bson:"recovery_codes"`
+}
+
+// SignInAttemptsModel represents mongo document of failed sign-in attempts
+type SignInAttemptsModel struct {
+	Key          string    `bson:"_id"`
+	Failures     int       `bson:"failures"`
+	LastFailedAt time.Time `bson:"last_failed_at"`
+	BlockedUntil time.Time `bson:"blocked_until"`
+	ExpiresAt    time.Time `bson:"expires_at"`
 }
 
 // ListOptionsModel represents the nested list options in the mongo user document
@@ -37,7 +60,12 @@ type ListOptionsModel struct {
 
 // NewUserRepo creates new UserRepo
 func NewUserRepo(db *mongo.Database, langRepo query.LangViewRepository, roleMapper *query.RoleConverter) (*UserRepo, error) {
-	u := UserRepo{collection: db.Collection("users"), langViewRepo: langRepo, roleConverter: roleMapper}
+	u := UserRepo{
+		collection:        db.Collection("users"),
+		attemptCollection: db.Collection("sign_in_attempts"),
+		langViewRepo:      langRepo,
+		roleConverter:     roleMapper,
+	}
 
 	if err := u.initIndexes(); err != nil {
 		return nil, err
@@ -45,7 +73,8 @@ func NewUserRepo(db *mongo.Database, langRepo query.LangViewRepository, roleMapp
 	return &u, nil
 }
 
-// initIndexes creates required for current queries indexes in user collection
+// initIndexes creates required for current queries indexes in user and sign-in attempts collections,
+// expired attempts are removed by mongo TTL monitor
 func (r *UserRepo) initIndexes() error {
 	indexes := []mongo.IndexModel{
 		{
@@ -62,6 +91,15 @@ func (r *UserRepo) initIndexes() error {
 	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
 		return err
 	}
+
+	attemptIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	if _, err := r.attemptCollection.Indexes().CreateOne(ctx, attemptIndex); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -142,6 +180,33 @@ func (r *UserRepo) Update(usr *user.User) error {
 	return nil
 }
 
+// UseTwoFactorCode saves accepted code by conditional update, so only one of parallel sign-ins with the same code passes
+func (r *UserRepo) UseTwoFactorCode(userID string, use user.TwoFactorUse) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	filter := bson.D{{Key: "_id", Value: userID}, {Key: "two_factor.enabled", Value: true}}
+	var update bson.M
+	if use.RecoveryCodeHash != "" {
+		filter = append(filter, bson.E{Key: "two_factor.recovery_codes", Value: use.RecoveryCodeHash})
+		update = bson.M{"$pull": bson.M{"two_factor.recovery_codes": use.RecoveryCodeHash}}
+	} else {
+		filter = append(filter, bson.E{Key: "two_factor.last_used_step", Value: bson.D{{Key: "$lt", Value: use.Step}}})
+		update = bson.M{"$set": bson.M{"two_factor.last_used_step": use.Step}}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount != 1 {
+		return user.ErrTwoFactorCodeUsed
+	}
+
+	return nil
+}
+
 func (r *UserRepo) Delete(id string) (int, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
 	defer cancel()
@@ -158,6 +223,89 @@ func (r *UserRepo) Delete(id string) (int, error) {
 	return 1, nil
 }
 
+// GetAttempts returns not expired sign-in attempts by key, TTL monitor runs periodically so expiration is checked in the query too
+func (r *UserRepo) GetAttempts(key string) (*user.SignInAttempts, error) {
+	var record SignInAttemptsModel
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	filter := bson.D{{Key: "_id", Value: key}, {Key: "expires_at", Value: bson.M{"$gt": time.Now()}}}
+	err := r.attemptCollection.FindOne(ctx, filter).Decode(&record)
+
+	if err == mongo.ErrNoDocuments {
+		return user.NewSignInAttempts(key), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.fromModelToAttempts(record), nil
+}
+
+// RegisterFailure atomically increments failures of not expired attempts or starts them over, then saves the block
+// calculated from the returned amount of failures. Parallel inserts of the same key fail with duplicate key error, so they are retried
+func (r *UserRepo) RegisterFailure(key string, policy user.LockoutPolicy) (*user.SignInAttempts, error) {
+	var record SignInAttemptsModel
+	var err error
+	for i := 0; i < registerFailureRetries; i++ {
+		if record, err = r.incrementFailures(key, policy); !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := r.fromModelToAttempts(record)
+	attempts.Block(policy)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	// $max keeps the longest block when blocks of parallel failures are saved in different order
+	update := bson.M{"$max": bson.M{"blocked_until": attempts.BlockedUntil(), "expires_at": attempts.ExpiresAt()}}
+	if _, err = r.attemptCollection.UpdateOne(ctx, bson.D{{Key: "_id", Value: key}}, update); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// incrementFailures increments failures with upsert, expired record is removed first as upsert can not replace it because of the same id
+func (r *UserRepo) incrementFailures(key string, policy user.LockoutPolicy) (SignInAttemptsModel, error) {
+	var record SignInAttemptsModel
+	now := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	expired := bson.D{{Key: "_id", Value: key}, {Key: "expires_at", Value: bson.M{"$lte": now}}}
+	if _, err := r.attemptCollection.DeleteOne(ctx, expired); err != nil {
+		return record, err
+	}
+
+	filter := bson.D{{Key: "_id", Value: key}, {Key: "expires_at", Value: bson.M{"$gt": now}}}
+	update := bson.M{
+		"$inc":         bson.M{"failures": 1},
+		"$set":         bson.M{"last_failed_at": now},
+		"$setOnInsert": bson.M{"blocked_until": now, "expires_at": now.Add(policy.ResetAfter)},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := r.attemptCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&record)
+	return record, err
+}
+
+func (r *UserRepo) DeleteAttempts(key string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), queryDefaultTimeoutInSec*time.Second)
+	defer cancel()
+
+	_, err := r.attemptCollection.DeleteOne(ctx, bson.D{{Key: "_id", Value: key}})
+	return err
+}
+
 func (r *UserRepo) GetAllViews() ([]query.UserView, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
 	defer cancel()
@@ -206,6 +354,11 @@ func (r *UserRepo) fromDomainToModel(usr *user.User) (UserModel, error) {
 	return model, err
 }
 
+// fromModelToAttempts converts mongo model to domain sign-in attempts
+func (r *UserRepo) fromModelToAttempts(model SignInAttemptsModel) *user.SignInAttempts {
+	return user.UnmarshalAttemptsFromDB(model.Key, model.Failures, model.LastFailedAt, model.BlockedUntil, model.ExpiresAt)
+}
+
 // fromModelToView converts mongo model to user View
 func (r *UserRepo) fromModelToView(model UserModel) (query.UserView, error) {
 	role, err := r.roleConverter.RoleToView(user.Role(model.Role))
@@ -222,6 +375,7 @@ func (r *UserRepo) fromModelToView(model UserModel) (query.UserView, error) {
 		ListOptions: query.UserListOptionsView{
 			HideTranscription: model.ListOptions.HideTranscription,
 		},
+		TwoFactorEnabled: model.TwoFactor.Enabled,
 	}
 
 	if model.DefaultLangID != "" {
@@ -246,5 +400,12 @@ func (r *UserRepo) fromModelToDomain(model UserModel) *user.User {
 		user.Role(model.Role),
 		model.DefaultLangID,
 		user.NewListOptions(model.ListOptions.HideTranscription),
+		model.TokenVersion,
+		user.UnmarshalTwoFactorFromDB(
+			model.TwoFactor.Secret,
+			model.TwoFactor.Enabled,
+			model.TwoFactor.LastUsedStep,
+			model.TwoFactor.RecoveryCodes,
+		),
 	)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/macyan13/webdict/backend/pkg/app/query"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestUserRepo_fromDomainToModel(t *testing.T) {
@@ -19,6 +20,9 @@ func TestUserRepo_fromDomainToModel(t *testing.T) {
 	err = usr.ApplyChanges(name, email, password, role, usr.DefaultLangID(), user.NewListOptions(true))
 	assert.Nil(t, err)
 
+	secret, err := usr.EnrollTwoFactor()
+	assert.Nil(t, err)
+
 	repo := UserRepo{}
 
 	model, err := repo.fromDomainToModel(usr)
@@ -29,6 +33,9 @@ func TestUserRepo_fromDomainToModel(t *testing.T) {
 	assert.Equal(t, password, model.Password)
 	assert.Equal(t, int(role), model.Role)
 	assert.Equal(t, true, model.ListOptions.HideTranscription)
+	assert.Equal(t, usr.TokenVersion(), model.TokenVersion)
+	assert.Equal(t, secret, model.TwoFactor.Secret)
+	assert.False(t, model.TwoFactor.Enabled)
 }
 
 func TestUserRepo_fromModelToView(t *testing.T) {
@@ -113,11 +120,12 @@ func TestUserRepo_fromModelToView(t *testing.T) {
 
 func TestUserRepo_fromModelToDomain(t *testing.T) {
 	model := UserModel{
-		ID:       "authorID",
-		Name:     "John",
-		Email:    "John@do.com",
-		Password: "testPassword",
-		Role:     1,
+		ID:           "authorID",
+		Name:         "John",
+		Email:        "John@do.com",
+		Password:     "testPassword",
+		Role:         1,
+		TokenVersion: 2,
 	}
 
 	repo := UserRepo{}
@@ -130,4 +138,15 @@ func TestUserRepo_fromModelToDomain(t *testing.T) {
 	assert.Equal(t, user.Role(model.Role), usr.Role())
 	assert.Equal(t, model.DefaultLangID, usr.DefaultLangID())
 	assert.Equal(t, false, listOptions.ToMap()["hideTranscription"])
+	assert.Equal(t, 2, usr.TokenVersion())
+}
+
+func TestUserRepo_fromModelToAttempts(t *testing.T) {
+	now := time.Now()
+	model := SignInAttemptsModel{Key: user.AccountAttemptsKey("testUser"), Failures: 2, LastFailedAt: now, BlockedUntil: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)}
+
+	repo := UserRepo{}
+	attempts := repo.fromModelToAttempts(model)
+	assert.Equal(t, user.UnmarshalAttemptsFromDB(user.AccountAttemptsKey("testUser"), 2, now, now.Add(time.Minute), now.Add(time.Hour)), attempts)
+	assert.True(t, attempts.Blocked())
 }